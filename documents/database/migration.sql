@@ -0,0 +1,157 @@
+-- APIが前提とするスキーマの変更
+-- NOTE: テーブルはLaravel側のマイグレーションで管理しているため、APIの起動前にLaravel側へ同じ変更を加えること。
+-- 上から順に適用する。既存の行は各カラムの既定値になり、search_text・sort_nameはAPIの起動時に埋め戻す。
+-- 文字列の長さはドメインのValidateの上限に合わせている。文字コードはutf8mb4を前提とする
+
+-- ---------------------------------------------------------------------------
+-- game_masters
+-- ---------------------------------------------------------------------------
+
+ALTER TABLE game_masters
+    -- 発売日(user-003)。未登録はNULL。精度の期間の初日を保存する
+    ADD COLUMN release_date DATE NULL AFTER developer,
+    -- 発売日の精度(user-004)。0: 日, 1: 年月, 2: 年
+    ADD COLUMN release_date_precision TINYINT UNSIGNED NOT NULL DEFAULT 0 AFTER release_date,
+    -- キーワード検索用の正規化した文字列(user-001)。名前・別名・説明などを含む
+    -- NOTE: TEXTは既定値を持てないため、既存の行は空文字になる。APIは登録・更新のたびに保存する
+    ADD COLUMN search_text TEXT NOT NULL AFTER release_date_precision,
+    -- 読みを正規化した並び替え用の名前(user-011)
+    ADD COLUMN sort_name VARCHAR(255) NOT NULL DEFAULT '' AFTER search_text,
+    -- シリーズ(user-014)。シリーズに属さないときはNULL
+    ADD COLUMN series_master_id BIGINT UNSIGNED NULL AFTER sort_name,
+    ADD COLUMN series_number SMALLINT UNSIGNED NOT NULL DEFAULT 0 AFTER series_master_id,
+    -- 論理削除(user-010)
+    ADD COLUMN deleted_at DATETIME(3) NULL AFTER updated_at,
+    ADD INDEX idx_game_masters_sort_name (sort_name),
+    ADD INDEX idx_game_masters_release_date (release_date),
+    ADD INDEX idx_game_masters_series_master_id (series_master_id),
+    ADD INDEX idx_game_masters_deleted_at (deleted_at);
+
+-- ---------------------------------------------------------------------------
+-- game_reference_urls
+-- ---------------------------------------------------------------------------
+
+ALTER TABLE game_reference_urls
+    -- 表示順(user-005)。既存のリンクは0になり、ID順に並ぶ
+    ADD COLUMN display_order INT UNSIGNED NOT NULL DEFAULT 0 AFTER description,
+    -- 種別(user-006)。0: その他, 1: 公式, 2: ストア, 3: Wiki, 4: リーダーボード, 5: 動画
+    ADD COLUMN kind TINYINT UNSIGNED NOT NULL DEFAULT 0 AFTER display_order,
+    ADD COLUMN store VARCHAR(255) NOT NULL DEFAULT '' AFTER kind,
+    ADD COLUMN region VARCHAR(16) NOT NULL DEFAULT '' AFTER store,
+    ADD INDEX idx_game_reference_urls_game_master_id (game_master_id, display_order);
+
+-- ---------------------------------------------------------------------------
+-- tag_masters
+-- ---------------------------------------------------------------------------
+
+ALTER TABLE tag_masters
+    -- 分類(user-023)。0: 未分類, 1: ジャンル, 2: システム, 3: 難易度
+    ADD COLUMN category TINYINT UNSIGNED NOT NULL DEFAULT 0 AFTER description,
+    -- 親タグ(user-023)。親タグがないときはNULL
+    ADD COLUMN parent_id BIGINT UNSIGNED NULL AFTER category,
+    -- 論理削除(user-010)
+    ADD COLUMN deleted_at DATETIME(3) NULL AFTER updated_at,
+    ADD INDEX idx_tag_masters_parent_id (parent_id),
+    ADD INDEX idx_tag_masters_deleted_at (deleted_at);
+
+-- ---------------------------------------------------------------------------
+-- platform_masters
+-- ---------------------------------------------------------------------------
+
+ALTER TABLE platform_masters
+    -- メーカー・種類・世代・発売年(user-025)。未登録のときは空文字・0
+    ADD COLUMN manufacturer VARCHAR(255) NOT NULL DEFAULT '' AFTER description,
+    -- 種類 0: 未分類, 1: 据え置き, 2: 携帯, 3: ハイブリッド, 4: PC, 5: モバイル, 6: アーケード
+    ADD COLUMN kind TINYINT UNSIGNED NOT NULL DEFAULT 0 AFTER manufacturer,
+    ADD COLUMN generation TINYINT UNSIGNED NOT NULL DEFAULT 0 AFTER kind,
+    ADD COLUMN release_year SMALLINT UNSIGNED NOT NULL DEFAULT 0 AFTER generation,
+    -- 論理削除(user-010)
+    ADD COLUMN deleted_at DATETIME(3) NULL AFTER updated_at,
+    ADD INDEX idx_platform_masters_deleted_at (deleted_at);
+
+-- ---------------------------------------------------------------------------
+-- 追加するテーブル
+-- ---------------------------------------------------------------------------
+
+-- 別名・読み(user-011)
+CREATE TABLE game_aliases (
+    id BIGINT UNSIGNED NOT NULL AUTO_INCREMENT,
+    game_master_id BIGINT UNSIGNED NOT NULL,
+    name VARCHAR(255) NOT NULL,
+    language VARCHAR(35) NOT NULL DEFAULT '',
+    -- 0: 正式名称, 1: 略称, 2: 読み, 3: 旧題
+    kind TINYINT UNSIGNED NOT NULL DEFAULT 0,
+    created_at DATETIME(3) NULL,
+    updated_at DATETIME(3) NULL,
+    PRIMARY KEY (id),
+    INDEX idx_game_aliases_game_master_id (game_master_id)
+);
+
+-- シリーズ(user-014)
+CREATE TABLE series_masters (
+    id BIGINT UNSIGNED NOT NULL AUTO_INCREMENT,
+    name VARCHAR(255) NOT NULL,
+    description VARCHAR(2048) NOT NULL DEFAULT '',
+    created_at DATETIME(3) NULL,
+    updated_at DATETIME(3) NULL,
+    PRIMARY KEY (id)
+);
+
+-- ゲーム間の関連(user-015)
+-- NOTE: 同じ組み合わせ・種類の重複はAPIで確認する
+CREATE TABLE game_relations (
+    id BIGINT UNSIGNED NOT NULL AUTO_INCREMENT,
+    source_game_master_id BIGINT UNSIGNED NOT NULL,
+    target_game_master_id BIGINT UNSIGNED NOT NULL,
+    -- 関連元から関連先への向き。0: remake_of, 1: port_of, 2: expansion_of, 3: sequel_of
+    kind TINYINT UNSIGNED NOT NULL,
+    created_at DATETIME(3) NULL,
+    updated_at DATETIME(3) NULL,
+    PRIMARY KEY (id),
+    INDEX idx_game_relations_source_game_master_id (source_game_master_id),
+    INDEX idx_game_relations_target_game_master_id (target_game_master_id)
+);
+
+-- 統合したゲームの転送(user-016)。統合元のIDで統合先を引く
+CREATE TABLE game_redirects (
+    game_master_id BIGINT UNSIGNED NOT NULL,
+    target_game_master_id BIGINT UNSIGNED NOT NULL,
+    created_at DATETIME(3) NULL,
+    PRIMARY KEY (game_master_id),
+    INDEX idx_game_redirects_target_game_master_id (target_game_master_id)
+);
+
+-- カバー画像(user-017)。1ゲームにつき1件
+CREATE TABLE game_covers (
+    game_master_id BIGINT UNSIGNED NOT NULL,
+    `key` VARCHAR(255) NOT NULL,
+    url VARCHAR(2048) NOT NULL,
+    content_type VARCHAR(255) NOT NULL,
+    size BIGINT NOT NULL,
+    width INT NOT NULL,
+    height INT NOT NULL,
+    thumbnail_key VARCHAR(255) NOT NULL,
+    thumbnail_url VARCHAR(2048) NOT NULL,
+    created_at DATETIME(3) NULL,
+    updated_at DATETIME(3) NULL,
+    PRIMARY KEY (game_master_id)
+);
+
+-- 統合したタグ・プラットフォームの旧名(user-024)
+CREATE TABLE tag_synonyms (
+    id BIGINT UNSIGNED NOT NULL AUTO_INCREMENT,
+    tag_master_id BIGINT UNSIGNED NOT NULL,
+    name VARCHAR(255) NOT NULL,
+    created_at DATETIME(3) NULL,
+    PRIMARY KEY (id),
+    INDEX idx_tag_synonyms_tag_master_id (tag_master_id)
+);
+
+CREATE TABLE platform_synonyms (
+    id BIGINT UNSIGNED NOT NULL AUTO_INCREMENT,
+    platform_master_id BIGINT UNSIGNED NOT NULL,
+    name VARCHAR(255) NOT NULL,
+    created_at DATETIME(3) NULL,
+    PRIMARY KEY (id),
+    INDEX idx_platform_synonyms_platform_master_id (platform_master_id)
+);
@@ -4,11 +4,14 @@ import (
 	"context"
 	"fmt"
 	handle "mysrtafes-backend/handle/http"
+	"mysrtafes-backend/handle/http/v1/cursor"
 	"mysrtafes-backend/pkg/challenge"
 	"mysrtafes-backend/pkg/game"
 	"mysrtafes-backend/pkg/game/platform"
+	"mysrtafes-backend/pkg/game/series"
 	"mysrtafes-backend/pkg/game/tag"
 	"mysrtafes-backend/repository"
+	"mysrtafes-backend/repository/storage"
 	"os"
 	"os/signal"
 	"syscall"
@@ -20,6 +23,13 @@ type osEnv struct {
 	Env      Env
 	Addr     string
 	DBConfig DBConfig
+	// NOTE: シーク法のカーソルの署名用の鍵。未設定のときは起動ごとに生成する
+	CursorSecret string
+	// NOTE: カバー画像の保存先のディレクトリと公開URL。公開URLは/coversで配信する前提
+	CoverDir string
+	CoverURL string
+	// NOTE: 取得系のCache-Control。ルートグループごとに指定する
+	CacheControl handle.CacheControl
 }
 
 var env = osEnv{
@@ -32,6 +42,14 @@ var env = osEnv{
 		Port: os.Getenv("MYS_RTA_FES_DB_PORT"),
 		Name: os.Getenv("MYS_RTA_FES_DB_NAME"),
 	},
+	CursorSecret: os.Getenv("MYS_RTA_FES_CURSOR_SECRET"),
+	CoverDir:     os.Getenv("MYS_RTA_FES_COVER_DIR"),
+	CoverURL:     os.Getenv("MYS_RTA_FES_COVER_URL"),
+	CacheControl: handle.CacheControl{
+		Games:     os.Getenv("MYS_RTA_FES_CACHE_CONTROL_GAMES"),
+		Tags:      os.Getenv("MYS_RTA_FES_CACHE_CONTROL_TAGS"),
+		Platforms: os.Getenv("MYS_RTA_FES_CACHE_CONTROL_PLATFORMS"),
+	},
 }
 
 // 動作環境
@@ -50,6 +68,25 @@ func init() {
 	if env.Env == "" {
 		env.Env = Env_Dev
 	}
+	if env.CoverDir == "" {
+		env.CoverDir = "./covers"
+	}
+	if env.CoverURL == "" {
+		env.CoverURL = "/covers"
+	}
+	// NOTE: マスタデータは変更が少ないため短時間キャッシュさせ、以降はETagで再検証させる
+	if env.CacheControl.Games == "" {
+		env.CacheControl.Games = "public, max-age=60"
+	}
+	if env.CacheControl.Tags == "" {
+		env.CacheControl.Tags = "public, max-age=300"
+	}
+	if env.CacheControl.Platforms == "" {
+		env.CacheControl.Platforms = "public, max-age=300"
+	}
+	if env.CursorSecret != "" {
+		cursor.SetSecret([]byte(env.CursorSecret))
+	}
 }
 
 func main() {
@@ -59,6 +96,11 @@ func main() {
 		panic(err)
 	}
 	dbRepository := repository.New(db)
+	// 追加したカラムの埋め戻し
+	if err := dbRepository.Backfill(); err != nil {
+		panic(err)
+	}
+	coverStorage := storage.NewLocal(env.CoverDir, env.CoverURL)
 	// Serviceの生成
 	services := handle.NewServices(
 		env.Addr,
@@ -66,6 +108,12 @@ func main() {
 		challenge.NewServer(dbRepository),
 		tag.NewServer(dbRepository),
 		platform.NewServer(dbRepository),
+		series.NewServer(dbRepository),
+		game.NewLinkServer(dbRepository),
+		game.NewRelationServer(dbRepository),
+		game.NewCoverServer(dbRepository, coverStorage),
+		coverStorage.Handler(),
+		env.CacheControl,
 	)
 
 	// 終了シグナル受け取りContextの定義
@@ -1,13 +1,19 @@
 package handle
 
 import (
+	"mysrtafes-backend/handle/http/v1/cache"
 	v1Game "mysrtafes-backend/handle/http/v1/game"
+	v1Cover "mysrtafes-backend/handle/http/v1/game/cover"
+	v1Link "mysrtafes-backend/handle/http/v1/game/link"
 	v1Platform "mysrtafes-backend/handle/http/v1/game/platform"
+	v1Relation "mysrtafes-backend/handle/http/v1/game/relation"
+	v1Series "mysrtafes-backend/handle/http/v1/game/series"
 	v1Tag "mysrtafes-backend/handle/http/v1/game/tag"
 	v1Challenge "mysrtafes-backend/handle/http/v1/mystery-challenge2/challenge"
 	"mysrtafes-backend/pkg/challenge"
 	"mysrtafes-backend/pkg/game"
 	"mysrtafes-backend/pkg/game/platform"
+	"mysrtafes-backend/pkg/game/series"
 	"mysrtafes-backend/pkg/game/tag"
 	"net/http"
 
@@ -21,17 +27,36 @@ type services struct {
 	Challenge challenge.Server
 	Tag       tag.Server
 	Platform  platform.Server
+	Series    series.Server
+	Link      game.LinkServer
+	Relation  game.RelationServer
+	Cover     game.CoverServer
+	// NOTE: 保存したカバー画像の配信。ストレージが外部で配信するときはnil
+	CoverFiles   http.Handler
+	CacheControl CacheControl
 	// TODO: HandleをもつServiceの追加
 }
 
-func NewServices(addr string, game game.Server, challenge challenge.Server, tag tag.Server, platform platform.Server) services {
-	return services{addr, game, challenge, tag, platform}
+// ルートグループごとの取得系のCache-Control
+// NOTE: 空のときはCache-Controlを設定しない。ETag・Last-Modifiedによる304はいずれも行う
+type CacheControl struct {
+	Games     string
+	Tags      string
+	Platforms string
+}
+
+func NewServices(addr string, game game.Server, challenge challenge.Server, tag tag.Server, platform platform.Server, series series.Server, link game.LinkServer, relation game.RelationServer, cover game.CoverServer, coverFiles http.Handler, cacheControl CacheControl) services {
+	return services{addr, game, challenge, tag, platform, series, link, relation, cover, coverFiles, cacheControl}
 }
 
 func (s services) Server() *http.Server {
 	r := chi.NewRouter()
 	r.Use(middleware.Logger)
 	r.Mount("/api/v1", s.apiV1Router())
+	// /covers
+	if s.CoverFiles != nil {
+		r.Mount("/covers", http.StripPrefix("/covers", s.CoverFiles))
+	}
 	return &http.Server{
 		Addr:    s.addr,
 		Handler: r,
@@ -60,40 +85,133 @@ func (s services) gameRouter() http.Handler {
 	r.Mount("/tags", s.tagRouter())
 	// /api/v1/games/platforms
 	r.Mount("/platforms", s.platformRouter())
+	// /api/v1/games/series
+	r.Mount("/series", s.seriesRouter())
+	// /api/v1/games/{gameID}/links
+	r.Mount("/{gameID}/links", s.linkRouter())
+	// /api/v1/games/{gameID}/relations
+	r.Mount("/{gameID}/relations", s.relationRouter())
+	// /api/v1/games/{gameID}/cover
+	r.Mount("/{gameID}/cover", s.coverRouter())
 
 	gameHandler := v1Game.NewGameHandler(s.Game)
 	// 複数操作
-	r.Get("/", gameHandler.HandleGameForMultiple)
+	// NOTE: POSTは一括登録と単体登録をリクエストの形式で振り分ける
+	r.With(cache.Handler(s.CacheControl.Games)).Get("/", gameHandler.HandleGameForMultiple)
+	r.Post("/", gameHandler.HandleGameForMultiple)
+	r.Put("/", gameHandler.HandleGameForMultiple)
+	r.Delete("/", gameHandler.HandleGameForMultiple)
+	// ゴミ箱
+	// NOTE: 削除は論理削除のため、復元・完全削除はゴミ箱から行う
+	r.Get("/trash", gameHandler.HandleGameTrashForMultiple)
+	r.Post("/trash/{gameID}/restore", gameHandler.HandleGameTrash)
+	r.Delete("/trash/{gameID}", gameHandler.HandleGameTrash)
+	// 統合
+	r.Post("/{gameID}/merge", gameHandler.HandleGameMerge)
 	// 単体操作
+	// NOTE: 単体のETagは更新の前提条件に使う版のため、タグなどの変更で変わらない。古い内容を返さないようキャッシュしない
 	r.Get("/{gameID}", gameHandler.HandleGame)
-	r.Post("/", gameHandler.HandleGame)
 	r.Put("/{gameID}", gameHandler.HandleGame)
+	r.Patch("/{gameID}", gameHandler.HandleGame)
 	r.Delete("/{gameID}", gameHandler.HandleGame)
 	return r
 }
 
 func (s services) tagRouter() http.Handler {
 	r := chi.NewRouter()
+	gameHandler := v1Game.NewGameHandler(s.Game)
 	tagHandler := v1Tag.NewTagHandler(s.Tag)
 	// 複数操作
-	r.Get("/", tagHandler.HandleTagForMultiple)
+	r.With(cache.Handler(s.CacheControl.Tags)).Get("/", tagHandler.HandleTagForMultiple)
+	// タグを持つゲーム
+	// NOTE: 返却するのはゲームのため、ゲームのCache-Controlを使う
+	r.With(cache.Handler(s.CacheControl.Games)).Get("/{tagID}/games", gameHandler.HandleGameForTag)
+	// ゴミ箱
+	r.Get("/trash", tagHandler.HandleTagTrashForMultiple)
+	r.Post("/trash/{tagID}/restore", tagHandler.HandleTagTrash)
+	r.Delete("/trash/{tagID}", tagHandler.HandleTagTrash)
+	// 統合
+	r.Post("/{tagID}/merge", tagHandler.HandleTagMerge)
 	// 単体操作
-	r.Get("/{tagID}", tagHandler.HandleTag)
+	r.With(cache.Handler(s.CacheControl.Tags)).Get("/{tagID}", tagHandler.HandleTag)
 	r.Post("/", tagHandler.HandleTag)
 	r.Put("/{tagID}", tagHandler.HandleTag)
+	r.Patch("/{tagID}", tagHandler.HandleTag)
 	r.Delete("/{tagID}", tagHandler.HandleTag)
 	return r
 }
 
 func (s services) platformRouter() http.Handler {
 	r := chi.NewRouter()
+	gameHandler := v1Game.NewGameHandler(s.Game)
 	platformHandler := v1Platform.NewPlatformHandler(s.Platform)
 	// 複数操作
-	r.Get("/", platformHandler.HandlePlatformForMultiple)
+	r.With(cache.Handler(s.CacheControl.Platforms)).Get("/", platformHandler.HandlePlatformForMultiple)
+	// プラットフォームを持つゲーム
+	// NOTE: 返却するのはゲームのため、ゲームのCache-Controlを使う
+	r.With(cache.Handler(s.CacheControl.Games)).Get("/{platformID}/games", gameHandler.HandleGameForPlatform)
+	// ゴミ箱
+	r.Get("/trash", platformHandler.HandlePlatformTrashForMultiple)
+	r.Post("/trash/{platformID}/restore", platformHandler.HandlePlatformTrash)
+	r.Delete("/trash/{platformID}", platformHandler.HandlePlatformTrash)
+	// 統合
+	r.Post("/{platformID}/merge", platformHandler.HandlePlatformMerge)
 	// 単体操作
-	r.Get("/{platformID}", platformHandler.HandlePlatform)
+	r.With(cache.Handler(s.CacheControl.Platforms)).Get("/{platformID}", platformHandler.HandlePlatform)
 	r.Post("/", platformHandler.HandlePlatform)
 	r.Put("/{platformID}", platformHandler.HandlePlatform)
+	r.Patch("/{platformID}", platformHandler.HandlePlatform)
 	r.Delete("/{platformID}", platformHandler.HandlePlatform)
 	return r
 }
+
+func (s services) seriesRouter() http.Handler {
+	r := chi.NewRouter()
+	seriesHandler := v1Series.NewSeriesHandler(s.Series)
+	// 複数操作
+	r.Get("/", seriesHandler.HandleSeriesForMultiple)
+	// 単体操作
+	r.Get("/{seriesID}", seriesHandler.HandleSeries)
+	r.Post("/", seriesHandler.HandleSeries)
+	r.Put("/{seriesID}", seriesHandler.HandleSeries)
+	r.Patch("/{seriesID}", seriesHandler.HandleSeries)
+	r.Delete("/{seriesID}", seriesHandler.HandleSeries)
+	return r
+}
+
+func (s services) linkRouter() http.Handler {
+	r := chi.NewRouter()
+	linkHandler := v1Link.NewLinkHandler(s.Link)
+	// 複数操作
+	r.Get("/", linkHandler.HandleLinkForMultiple)
+	r.Put("/", linkHandler.HandleLinkForMultiple)
+	// 単体操作
+	r.Get("/{linkID}", linkHandler.HandleLink)
+	r.Post("/", linkHandler.HandleLink)
+	r.Put("/{linkID}", linkHandler.HandleLink)
+	r.Delete("/{linkID}", linkHandler.HandleLink)
+	return r
+}
+
+func (s services) relationRouter() http.Handler {
+	r := chi.NewRouter()
+	relationHandler := v1Relation.NewRelationHandler(s.Relation)
+	// 複数操作
+	r.Get("/", relationHandler.HandleRelationForMultiple)
+	// 単体操作
+	r.Get("/{relationID}", relationHandler.HandleRelation)
+	r.Post("/", relationHandler.HandleRelation)
+	r.Delete("/{relationID}", relationHandler.HandleRelation)
+	return r
+}
+
+func (s services) coverRouter() http.Handler {
+	r := chi.NewRouter()
+	coverHandler := v1Cover.NewCoverHandler(s.Cover)
+	// 単体操作
+	// NOTE: カバー画像はゲームに1件のため、登録・差し替えともにPUTで行う
+	r.Get("/", coverHandler.HandleCover)
+	r.Put("/", coverHandler.HandleCover)
+	r.Delete("/", coverHandler.HandleCover)
+	return r
+}
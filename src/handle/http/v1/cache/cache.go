@@ -0,0 +1,139 @@
+package cache
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/base64"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// 取得系のレスポンスのキャッシュ
+// NOTE: GETのみ対象。レスポンスを一旦バッファし、検証子(ETag・Last-Modified)とリクエストの条件を比べて304を返却する
+// ETagはハンドラが設定したもの(単体の版)を優先し、未設定のとき(一覧など)は本文から作る。
+// 本文から作るため、一覧のいずれかの要素やメタ情報が変われば一覧のETagも変わる
+// cacheControlが空のときはCache-Controlを設定しない
+func Handler(cacheControl string) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.Method != http.MethodGet {
+				next.ServeHTTP(w, r)
+				return
+			}
+			rec := &recorder{
+				header: w.Header(),
+				status: http.StatusOK,
+			}
+			next.ServeHTTP(rec, r)
+
+			// NOTE: エラーやリダイレクトはキャッシュの対象外
+			if rec.status != http.StatusOK {
+				w.WriteHeader(rec.status)
+				w.Write(rec.body.Bytes())
+				return
+			}
+			if cacheControl != "" {
+				w.Header().Set("Cache-Control", cacheControl)
+			}
+			etag := w.Header().Get("ETag")
+			if etag == "" {
+				etag = NewETag(rec.body.Bytes())
+				w.Header().Set("ETag", etag)
+			}
+			if notModified(r, etag, w.Header().Get("Last-Modified")) {
+				// NOTE: 304は本文を持たないため、本文に関するヘッダは返却しない
+				w.Header().Del("Content-Type")
+				w.Header().Del("Content-Length")
+				w.WriteHeader(http.StatusNotModified)
+				return
+			}
+			w.WriteHeader(rec.status)
+			w.Write(rec.body.Bytes())
+		})
+	}
+}
+
+// 本文から作る強いETag
+func NewETag(body []byte) string {
+	sum := sha256.Sum256(body)
+	return `"` + base64.RawURLEncoding.EncodeToString(sum[:16]) + `"`
+}
+
+// Last-Modifiedの設定
+// NOTE: 最も新しい日時を設定する。ゼロ値のみのときは設定しない
+func SetLastModified(w http.ResponseWriter, times ...time.Time) {
+	var last time.Time
+	for _, t := range times {
+		if t.After(last) {
+			last = t
+		}
+	}
+	if last.IsZero() {
+		return
+	}
+	w.Header().Set("Last-Modified", last.UTC().Format(http.TimeFormat))
+}
+
+// 条件付きリクエストの判定
+// NOTE: If-None-Matchを優先し、指定がないときのみIf-Modified-Sinceを見る
+func notModified(r *http.Request, etag string, lastModified string) bool {
+	if ifNoneMatch := r.Header.Get("If-None-Match"); ifNoneMatch != "" {
+		return matchWeak(ifNoneMatch, etag)
+	}
+	ifModifiedSince := r.Header.Get("If-Modified-Since")
+	if ifModifiedSince == "" || lastModified == "" {
+		return false
+	}
+	since, err := http.ParseTime(ifModifiedSince)
+	if err != nil {
+		return false
+	}
+	modified, err := http.ParseTime(lastModified)
+	if err != nil {
+		return false
+	}
+	return !modified.After(since)
+}
+
+// 弱い比較
+// NOTE: If-None-MatchはW/の有無を無視して比べる
+func matchWeak(ifNoneMatch string, etag string) bool {
+	if strings.TrimSpace(ifNoneMatch) == "*" {
+		return true
+	}
+	etag = strings.TrimPrefix(etag, "W/")
+	for _, tag := range strings.Split(ifNoneMatch, ",") {
+		if strings.TrimPrefix(strings.TrimSpace(tag), "W/") == etag {
+			return true
+		}
+	}
+	return false
+}
+
+// レスポンスのバッファ
+// NOTE: ヘッダは元のResponseWriterのものをそのまま使う
+type recorder struct {
+	header      http.Header
+	status      int
+	wroteHeader bool
+	body        bytes.Buffer
+}
+
+func (r *recorder) Header() http.Header {
+	return r.header
+}
+
+func (r *recorder) Write(b []byte) (int, error) {
+	r.wroteHeader = true
+	return r.body.Write(b)
+}
+
+// NOTE: http.ResponseWriterと同じく最初の呼び出しのみ有効
+func (r *recorder) WriteHeader(status int) {
+	if r.wroteHeader {
+		return
+	}
+	r.status = status
+	r.wroteHeader = true
+}
@@ -0,0 +1,227 @@
+package cache
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHandler(t *testing.T) {
+	lastModified := time.Date(2023, 4, 1, 12, 0, 0, 0, time.UTC)
+	body := `{"code":200}`
+	next := func(status int, etag string) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "application/json")
+			if etag != "" {
+				w.Header().Set("ETag", etag)
+			}
+			SetLastModified(w, lastModified)
+			w.WriteHeader(status)
+			w.Write([]byte(body))
+		})
+	}
+	type args struct {
+		cacheControl string
+		method       string
+		header       map[string]string
+	}
+	tests := []struct {
+		name             string
+		next             http.Handler
+		args             args
+		wantStatusCode   int
+		wantBody         string
+		wantETag         string
+		wantCacheControl string
+	}{
+		{
+			name: "OK",
+			next: next(http.StatusOK, ""),
+			args: args{
+				cacheControl: "public, max-age=60",
+				method:       http.MethodGet,
+			},
+			wantStatusCode:   http.StatusOK,
+			wantBody:         body,
+			wantETag:         NewETag([]byte(body)),
+			wantCacheControl: "public, max-age=60",
+		},
+		{
+			name: "設定済みのETagを優先",
+			next: next(http.StatusOK, `"v1"`),
+			args: args{
+				method: http.MethodGet,
+			},
+			wantStatusCode: http.StatusOK,
+			wantBody:       body,
+			wantETag:       `"v1"`,
+		},
+		{
+			name: "If-None-Matchが一致",
+			next: next(http.StatusOK, ""),
+			args: args{
+				cacheControl: "public, max-age=60",
+				method:       http.MethodGet,
+				header: map[string]string{
+					"If-None-Match": `"other", ` + NewETag([]byte(body)),
+				},
+			},
+			wantStatusCode:   http.StatusNotModified,
+			wantETag:         NewETag([]byte(body)),
+			wantCacheControl: "public, max-age=60",
+		},
+		{
+			name: "If-None-Matchは弱い比較",
+			next: next(http.StatusOK, `"v1"`),
+			args: args{
+				method: http.MethodGet,
+				header: map[string]string{
+					"If-None-Match": `W/"v1"`,
+				},
+			},
+			wantStatusCode: http.StatusNotModified,
+			wantETag:       `"v1"`,
+		},
+		{
+			name: "If-None-Matchが不一致",
+			next: next(http.StatusOK, `"v1"`),
+			args: args{
+				method: http.MethodGet,
+				header: map[string]string{
+					"If-None-Match": `"v0"`,
+				},
+			},
+			wantStatusCode: http.StatusOK,
+			wantBody:       body,
+			wantETag:       `"v1"`,
+		},
+		{
+			name: "If-Modified-Sinceより更新なし",
+			next: next(http.StatusOK, `"v1"`),
+			args: args{
+				method: http.MethodGet,
+				header: map[string]string{
+					"If-Modified-Since": lastModified.Format(http.TimeFormat),
+				},
+			},
+			wantStatusCode: http.StatusNotModified,
+			wantETag:       `"v1"`,
+		},
+		{
+			name: "If-Modified-Sinceより更新あり",
+			next: next(http.StatusOK, `"v1"`),
+			args: args{
+				method: http.MethodGet,
+				header: map[string]string{
+					"If-Modified-Since": lastModified.Add(-time.Second).Format(http.TimeFormat),
+				},
+			},
+			wantStatusCode: http.StatusOK,
+			wantBody:       body,
+			wantETag:       `"v1"`,
+		},
+		{
+			name: "If-None-MatchをIf-Modified-Sinceより優先",
+			next: next(http.StatusOK, `"v1"`),
+			args: args{
+				method: http.MethodGet,
+				header: map[string]string{
+					"If-None-Match":     `"v0"`,
+					"If-Modified-Since": lastModified.Format(http.TimeFormat),
+				},
+			},
+			wantStatusCode: http.StatusOK,
+			wantBody:       body,
+			wantETag:       `"v1"`,
+		},
+		{
+			name: "エラーはキャッシュしない",
+			next: next(http.StatusNotFound, ""),
+			args: args{
+				cacheControl: "public, max-age=60",
+				method:       http.MethodGet,
+				header: map[string]string{
+					"If-None-Match": "*",
+				},
+			},
+			wantStatusCode: http.StatusNotFound,
+			wantBody:       body,
+		},
+		{
+			name: "GET以外は対象外",
+			next: next(http.StatusOK, ""),
+			args: args{
+				cacheControl: "public, max-age=60",
+				method:       http.MethodPost,
+				header: map[string]string{
+					"If-None-Match": "*",
+				},
+			},
+			wantStatusCode: http.StatusOK,
+			wantBody:       body,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			r := httptest.NewRequest(tt.args.method, "http://example.com/", nil)
+			for key, val := range tt.args.header {
+				r.Header.Set(key, val)
+			}
+			Handler(tt.args.cacheControl)(tt.next).ServeHTTP(w, r)
+			if !assert.Equal(t, tt.wantStatusCode, w.Code) {
+				return
+			}
+			assert.Equal(t, tt.wantBody, w.Body.String())
+			assert.Equal(t, tt.wantETag, w.Header().Get("ETag"))
+			assert.Equal(t, tt.wantCacheControl, w.Header().Get("Cache-Control"))
+			if tt.wantStatusCode == http.StatusNotModified {
+				assert.Empty(t, w.Header().Get("Content-Type"))
+			}
+		})
+	}
+}
+
+func TestNewETag(t *testing.T) {
+	// NOTE: 本文が変われば変わる
+	assert.Equal(t, NewETag([]byte(`{"data":[1]}`)), NewETag([]byte(`{"data":[1]}`)))
+	assert.NotEqual(t, NewETag([]byte(`{"data":[1]}`)), NewETag([]byte(`{"data":[2]}`)))
+}
+
+func TestSetLastModified(t *testing.T) {
+	tests := []struct {
+		name  string
+		times []time.Time
+		want  string
+	}{
+		{
+			name: "最も新しい日時",
+			times: []time.Time{
+				time.Date(2023, 4, 1, 12, 0, 0, 0, time.UTC),
+				time.Date(2023, 4, 2, 12, 0, 0, 0, time.UTC),
+				time.Time{},
+			},
+			want: "Sun, 02 Apr 2023 12:00:00 GMT",
+		},
+		{
+			name:  "ゼロ値のみは設定しない",
+			times: []time.Time{{}},
+			want:  "",
+		},
+		{
+			name:  "空は設定しない",
+			times: nil,
+			want:  "",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			SetLastModified(w, tt.times...)
+			assert.Equal(t, tt.want, w.Header().Get("Last-Modified"))
+		})
+	}
+}
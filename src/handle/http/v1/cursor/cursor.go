@@ -0,0 +1,80 @@
+package cursor
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	stdErrors "errors"
+	"mysrtafes-backend/pkg/errors"
+	"strings"
+)
+
+// 署名用の鍵
+// NOTE: 未設定のときは起動ごとに生成するため、再起動前のカーソルは使えなくなる
+var secret = func() []byte {
+	key := make([]byte, 32)
+	if _, err := rand.Read(key); err != nil {
+		panic(err)
+	}
+	return key
+}()
+
+// 署名用の鍵の設定
+func SetSecret(key []byte) {
+	secret = key
+}
+
+// カーソルのトークン化
+// NOTE: 位置をJSONにしてbase64urlにし、リソース名を含めたHMAC-SHA256の署名を付ける
+// JSONにできない値を渡したときは空文字を返す
+func Encode(resource string, v interface{}) string {
+	payload, err := json.Marshal(v)
+	if err != nil {
+		return ""
+	}
+	encoded := base64.RawURLEncoding.EncodeToString(payload)
+	return encoded + "." + sign(resource, encoded)
+}
+
+// トークンからカーソルを復元
+// NOTE: 署名が一致しないもの(改ざん・他リソースのカーソル)はエラー
+func Decode(resource string, token string, v interface{}) error {
+	err := decode(resource, token, v)
+	if err != nil {
+		return errors.NewInvalidRequest(
+			errors.Layer_Request,
+			errors.NewInformation(
+				errors.ID_InvalidParams,
+				err.Error(),
+				[]errors.InvalidParams{
+					errors.NewInvalidParams("cursor", token),
+				},
+			),
+			"cursor decode error",
+		)
+	}
+	return nil
+}
+
+func decode(resource string, token string, v interface{}) error {
+	encoded, signature, ok := strings.Cut(token, ".")
+	if !ok {
+		return stdErrors.New("cursor format error")
+	}
+	if !hmac.Equal([]byte(signature), []byte(sign(resource, encoded))) {
+		return stdErrors.New("cursor signature error")
+	}
+	payload, err := base64.RawURLEncoding.DecodeString(encoded)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(payload, v)
+}
+
+func sign(resource string, encoded string) string {
+	mac := hmac.New(sha256.New, secret)
+	mac.Write([]byte(resource + "\n" + encoded))
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
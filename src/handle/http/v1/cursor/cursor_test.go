@@ -0,0 +1,82 @@
+package cursor
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type position struct {
+	ID   uint64
+	Name string
+}
+
+func TestEncodeDecode(t *testing.T) {
+	SetSecret([]byte("test secret"))
+	token := Encode("games", position{ID: 10, Name: "シレン"})
+
+	tests := []struct {
+		name     string
+		resource string
+		token    string
+		want     position
+		wantErr  bool
+	}{
+		{
+			name:     "OK",
+			resource: "games",
+			token:    token,
+			want:     position{ID: 10, Name: "シレン"},
+		},
+		{
+			name:     "他リソースのカーソル",
+			resource: "tags",
+			token:    token,
+			wantErr:  true,
+		},
+		{
+			name:     "位置の改ざん",
+			resource: "games",
+			token: func() string {
+				tampered := Encode("games", position{ID: 11, Name: "シレン"})
+				_, signature, _ := strings.Cut(token, ".")
+				payload, _, _ := strings.Cut(tampered, ".")
+				return payload + "." + signature
+			}(),
+			wantErr: true,
+		},
+		{
+			name:     "署名なし",
+			resource: "games",
+			token:    strings.Split(token, ".")[0],
+			wantErr:  true,
+		},
+		{
+			name:     "空文字",
+			resource: "games",
+			token:    "",
+			wantErr:  true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := position{}
+			err := Decode(tt.resource, tt.token, &got)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Decode() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !tt.wantErr {
+				assert.Equal(t, tt.want, got)
+			}
+		})
+	}
+}
+
+func TestDecode_鍵の変更(t *testing.T) {
+	SetSecret([]byte("old secret"))
+	token := Encode("games", position{ID: 1})
+	SetSecret([]byte("new secret"))
+	assert.Error(t, Decode("games", token, &position{}))
+}
@@ -28,27 +28,39 @@ type errorInformation struct {
 }
 
 func WriteError(w http.ResponseWriter, err error) {
+	writeError(w, StatusCode(err), err)
+}
+
+// エラーに対応するHTTPステータスコード
+func StatusCode(err error) int {
 	switch err.(type) {
 	case errors.InvalidRequestError,
 		errors.InvalidValidateError:
-		writeError(w, http.StatusBadRequest, err)
+		return http.StatusBadRequest
 	case errors.NotFoundError:
-		writeError(w, http.StatusNotFound, err)
+		return http.StatusNotFound
 	case errors.ForbiddenError:
-		writeError(w, http.StatusForbidden, err)
+		return http.StatusForbidden
 	case errors.UnauthorizedError:
-		writeError(w, http.StatusUnauthorized, err)
+		return http.StatusUnauthorized
 	case errors.UnsupportedMediaTypeError:
-		writeError(w, http.StatusUnsupportedMediaType, err)
+		return http.StatusUnsupportedMediaType
+	case errors.PreconditionFailedError:
+		return http.StatusPreconditionFailed
 	case errors.InternalServerErrorError:
-		writeError(w, http.StatusInternalServerError, err)
+		return http.StatusInternalServerError
 	default:
-		writeError(w, http.StatusInternalServerError, err)
+		return http.StatusInternalServerError
 	}
 }
 
-func writeError(w http.ResponseWriter, statusCode int, err error) {
-	body := response{}
+// エラーレスポンスのerror部分
+// NOTE: 一括操作の行ごとの結果など、レスポンスの一部としてエラーを返すときに使う
+func NewErrorBody(err error) interface{} {
+	return newErrorBody(StatusCode(err), err)
+}
+
+func newErrorBody(statusCode int, err error) interface{} {
 	switch err := err.(type) {
 	case errors.Informator:
 		var code errors.Code
@@ -58,7 +70,7 @@ func writeError(w http.ResponseWriter, statusCode int, err error) {
 			code, message = err.Information().Code.Detail()
 			invalidParams = err.Information().Problem
 		}
-		body.Error = errorWithInformation{
+		return errorWithInformation{
 			errorBase: errorBase{
 				Title:  http.StatusText(statusCode),
 				Status: statusCode,
@@ -71,12 +83,18 @@ func writeError(w http.ResponseWriter, statusCode int, err error) {
 			InvalidParams: invalidParams,
 		}
 	default:
-		body.Error = errorBase{
+		return errorBase{
 			Title:  http.StatusText(statusCode),
 			Status: statusCode,
 			Detail: err.Error(),
 		}
 	}
+}
+
+func writeError(w http.ResponseWriter, statusCode int, err error) {
+	body := response{
+		Error: newErrorBody(statusCode, err),
+	}
 
 	w.Header().Set("Content-Type", "application/problem+json")
 	w.WriteHeader(statusCode)
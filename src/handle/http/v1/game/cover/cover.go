@@ -0,0 +1,83 @@
+package cover
+
+import (
+	"log"
+	"mysrtafes-backend/handle/http/v1/errors"
+	"mysrtafes-backend/pkg/game"
+	"net/http"
+)
+
+type coverHandler struct {
+	server game.CoverServer
+}
+
+func NewCoverHandler(s game.CoverServer) *coverHandler {
+	return &coverHandler{s}
+}
+
+func (h *coverHandler) HandleCover(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+		h.read(w, r)
+	case http.MethodPut:
+		h.upload(w, r)
+	case http.MethodDelete:
+		h.delete(w, r)
+	default:
+		http.NotFound(w, r)
+	}
+}
+
+func (h *coverHandler) upload(w http.ResponseWriter, r *http.Request) {
+	gameID, data, err := NewCoverUpload(r)
+	if err != nil {
+		log.Println(err)
+		errors.WriteError(w, err)
+		return
+	}
+
+	cover, err := h.server.Upload(gameID, data)
+	if err != nil {
+		log.Println(err)
+		errors.WriteError(w, err)
+		return
+	}
+
+	WriteUploadCover(w, cover)
+}
+
+func (h *coverHandler) read(w http.ResponseWriter, r *http.Request) {
+	gameID, err := NewGameID(r)
+	if err != nil {
+		log.Println(err)
+		errors.WriteError(w, err)
+		return
+	}
+
+	cover, err := h.server.Read(gameID)
+	if err != nil {
+		log.Println(err)
+		errors.WriteError(w, err)
+		return
+	}
+
+	WriteReadCover(w, cover)
+}
+
+func (h *coverHandler) delete(w http.ResponseWriter, r *http.Request) {
+	gameID, err := NewGameID(r)
+	if err != nil {
+		log.Println(err)
+		errors.WriteError(w, err)
+		return
+	}
+
+	err = h.server.Delete(gameID)
+	if err != nil {
+		log.Println(err)
+		errors.WriteError(w, err)
+		return
+	}
+
+	WriteDeleteCover(w, gameID)
+}
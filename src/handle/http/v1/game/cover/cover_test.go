@@ -0,0 +1,295 @@
+package cover
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"mysrtafes-backend/pkg/errors"
+	"mysrtafes-backend/pkg/game"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/assert"
+)
+
+type server struct {
+	Cover *game.Cover
+	err   error
+	// flags
+	upload, read, delete bool
+}
+
+func (s *server) Upload(game.ID, []byte) (*game.Cover, error) {
+	if s.upload {
+		return s.Cover, s.err
+	}
+	return nil, fmt.Errorf("failed upload")
+}
+func (s *server) Read(game.ID) (*game.Cover, error) {
+	if s.read {
+		return s.Cover, s.err
+	}
+	return nil, fmt.Errorf("failed read")
+}
+func (s *server) Delete(game.ID) error {
+	if s.delete {
+		return s.err
+	}
+	return fmt.Errorf("failed delete")
+}
+
+func newTestCover() *game.Cover {
+	return &game.Cover{
+		Key:          "games/1/cover-1.png",
+		URL:          "/covers/games/1/cover-1.png",
+		ContentType:  game.CoverContentType_PNG,
+		Size:         1024,
+		Width:        600,
+		Height:       800,
+		ThumbnailKey: "games/1/thumbnail-1.jpg",
+		ThumbnailURL: "/covers/games/1/thumbnail-1.jpg",
+	}
+}
+
+func TestNewCoverHandler(t *testing.T) {
+	type args struct {
+		s game.CoverServer
+	}
+	tests := []struct {
+		name string
+		args args
+		want *coverHandler
+	}{
+		{
+			name: "ok",
+			args: args{
+				s: &server{},
+			},
+			want: &coverHandler{
+				server: &server{},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, NewCoverHandler(tt.args.s), tt.want)
+		})
+	}
+}
+
+func Test_coverHandler_HandleCover(t *testing.T) {
+	type fields struct {
+		server game.CoverServer
+	}
+	type args struct {
+		w           *httptest.ResponseRecorder
+		method      string
+		url         string
+		contentType string
+		body        io.Reader
+		pathParam   map[string]string
+	}
+	tests := []struct {
+		name           string
+		fields         fields
+		args           args
+		wantStatusCode int
+		wantBody       string
+	}{
+		{
+			name: "Get OK",
+			fields: fields{
+				server: &server{
+					Cover: newTestCover(),
+					read:  true,
+				},
+			},
+			args: args{
+				w:      httptest.NewRecorder(),
+				method: http.MethodGet,
+				url:    "http://example.com",
+				body:   strings.NewReader(``),
+				pathParam: map[string]string{
+					"gameID": "1",
+				},
+			},
+			wantStatusCode: http.StatusOK,
+			wantBody: func() string {
+				body := coverResponse(http.StatusOK, "success read cover", newTestCover())
+				str, _ := json.Marshal(body)
+				return string(str)
+			}(),
+		},
+		{
+			name: "Get NotFound NG",
+			fields: fields{
+				server: &server{
+					err:  errors.NewNotFound(errors.Layer_Model, nil, "not found"),
+					read: true,
+				},
+			},
+			args: args{
+				w:      httptest.NewRecorder(),
+				method: http.MethodGet,
+				url:    "http://example.com",
+				body:   strings.NewReader(``),
+				pathParam: map[string]string{
+					"gameID": "1",
+				},
+			},
+			wantStatusCode: http.StatusNotFound,
+			wantBody:       "",
+		},
+		{
+			name: "Put OK",
+			fields: fields{
+				server: &server{
+					Cover:  newTestCover(),
+					upload: true,
+				},
+			},
+			args: args{
+				w:           httptest.NewRecorder(),
+				method:      http.MethodPut,
+				url:         "http://example.com",
+				contentType: "multipart/form-data; boundary=" + testBoundary,
+				body:        newTestMultipart(FormKey, "cover.png", "png"),
+				pathParam: map[string]string{
+					"gameID": "1",
+				},
+			},
+			wantStatusCode: http.StatusOK,
+			wantBody: func() string {
+				body := coverResponse(http.StatusOK, "success upload cover", newTestCover())
+				str, _ := json.Marshal(body)
+				return string(str)
+			}(),
+		},
+		{
+			name: "Put UnsupportedMediaType NG",
+			fields: fields{
+				server: &server{
+					err:    errors.NewUnsupportedMediaType(errors.Layer_Domain, nil, "content_type"),
+					upload: true,
+				},
+			},
+			args: args{
+				w:           httptest.NewRecorder(),
+				method:      http.MethodPut,
+				url:         "http://example.com",
+				contentType: "multipart/form-data; boundary=" + testBoundary,
+				body:        newTestMultipart(FormKey, "cover.txt", "text"),
+				pathParam: map[string]string{
+					"gameID": "1",
+				},
+			},
+			wantStatusCode: http.StatusUnsupportedMediaType,
+			wantBody:       "",
+		},
+		{
+			name: "Put not multipart NG",
+			fields: fields{
+				server: &server{
+					upload: true,
+				},
+			},
+			args: args{
+				w:           httptest.NewRecorder(),
+				method:      http.MethodPut,
+				url:         "http://example.com",
+				contentType: "application/json",
+				body:        strings.NewReader(`{}`),
+				pathParam: map[string]string{
+					"gameID": "1",
+				},
+			},
+			wantStatusCode: http.StatusUnsupportedMediaType,
+			wantBody:       "",
+		},
+		{
+			name: "Delete OK",
+			fields: fields{
+				server: &server{
+					delete: true,
+				},
+			},
+			args: args{
+				w:      httptest.NewRecorder(),
+				method: http.MethodDelete,
+				url:    "http://example.com",
+				body:   strings.NewReader(``),
+				pathParam: map[string]string{
+					"gameID": "1",
+				},
+			},
+			wantStatusCode: http.StatusOK,
+			wantBody: func() string {
+				body := deleteCoverResponse(1)
+				str, _ := json.Marshal(body)
+				return string(str)
+			}(),
+		},
+		{
+			name: "Bad gameID NG",
+			fields: fields{
+				server: &server{
+					read: true,
+				},
+			},
+			args: args{
+				w:      httptest.NewRecorder(),
+				method: http.MethodGet,
+				url:    "http://example.com",
+				body:   strings.NewReader(``),
+				pathParam: map[string]string{
+					"gameID": "a",
+				},
+			},
+			wantStatusCode: http.StatusBadRequest,
+			wantBody:       "",
+		},
+		{
+			name: "Bad Method NG",
+			fields: fields{
+				server: &server{},
+			},
+			args: args{
+				w:      httptest.NewRecorder(),
+				method: http.MethodPost,
+				url:    "http://example.com",
+				body:   strings.NewReader(``),
+				pathParam: map[string]string{
+					"gameID": "1",
+				},
+			},
+			wantStatusCode: http.StatusNotFound,
+			wantBody:       "",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := &coverHandler{
+				server: tt.fields.server,
+			}
+			ctx := chi.NewRouteContext()
+			for key, val := range tt.args.pathParam {
+				ctx.URLParams.Add(key, val)
+			}
+			r := httptest.NewRequest(tt.args.method, tt.args.url, tt.args.body)
+			r.Header.Set("Content-Type", tt.args.contentType)
+			r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, ctx))
+			h.HandleCover(tt.args.w, r)
+			if !assert.Equal(t, tt.wantStatusCode, tt.args.w.Code) {
+				return
+			}
+			// NOTE: BodyのStringは\nが入る仕様らしいので削除
+			if tt.wantBody != "" && !assert.Equal(t, tt.wantBody, strings.Replace(tt.args.w.Body.String(), "\n", "", -1)) {
+				return
+			}
+		})
+	}
+}
@@ -0,0 +1,122 @@
+package cover
+
+import (
+	stdErrors "errors"
+	"io"
+	"mysrtafes-backend/pkg/errors"
+	"mysrtafes-backend/pkg/game"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+)
+
+// multipartのフォームの項目名
+const FormKey = "cover"
+
+// NOTE: 画像以外の項目・区切りのための余裕
+const formOverhead = 1 << 20
+
+// Put: NewCoverUpload for request
+// NOTE: 画像の形式・大きさの検証はDomainで行う。上限を超えた分は読み込まず、上限+1byteで返却する
+func NewCoverUpload(r *http.Request) (game.ID, []byte, error) {
+	defer r.Body.Close()
+
+	gameID, err := NewGameID(r)
+	if err != nil {
+		return 0, nil, err
+	}
+
+	r.Body = http.MaxBytesReader(nil, r.Body, int64(game.CoverMaxSize)+formOverhead)
+	err = r.ParseMultipartForm(int64(game.CoverMaxSize))
+	if stdErrors.Is(err, http.ErrNotMultipart) {
+		return 0, nil, errors.NewUnsupportedMediaType(
+			errors.Layer_Request,
+			errors.NewInformation(
+				errors.ID_InvalidParams,
+				err.Error(),
+				[]errors.InvalidParams{
+					errors.NewInvalidParams("Content-Type", r.Header.Get("Content-Type")),
+				},
+			),
+			"multipart form is required",
+		)
+	}
+	var maxBytesError *http.MaxBytesError
+	if stdErrors.As(err, &maxBytesError) {
+		return 0, nil, errors.NewInvalidRequest(
+			errors.Layer_Request,
+			errors.NewInformation(
+				errors.ID_InvalidParams,
+				err.Error(),
+				[]errors.InvalidParams{
+					errors.NewInvalidParams("size", maxBytesError.Limit),
+				},
+			),
+			"request body is too large",
+		)
+	}
+	if err != nil {
+		return 0, nil, errors.NewInvalidRequest(
+			errors.Layer_Request,
+			errors.NewInformation(
+				errors.ID_InvalidParams,
+				err.Error(),
+				nil,
+			),
+			"multipart form parse error",
+		)
+	}
+	defer r.MultipartForm.RemoveAll()
+
+	file, _, err := r.FormFile(FormKey)
+	if err != nil {
+		return 0, nil, errors.NewInvalidRequest(
+			errors.Layer_Request,
+			errors.NewInformation(
+				errors.ID_InvalidParams,
+				err.Error(),
+				[]errors.InvalidParams{
+					errors.NewInvalidParams(FormKey, ""),
+				},
+			),
+			"cover file is required",
+		)
+	}
+	defer file.Close()
+
+	data, err := io.ReadAll(io.LimitReader(file, int64(game.CoverMaxSize)+1))
+	if err != nil {
+		return 0, nil, errors.NewInvalidRequest(
+			errors.Layer_Request,
+			errors.NewInformation(
+				errors.ID_InvalidParams,
+				err.Error(),
+				nil,
+			),
+			"cover file read error",
+		)
+	}
+	return gameID, data, nil
+}
+
+// Get, Delete: NewGameID for request
+func NewGameID(r *http.Request) (game.ID, error) {
+	gameIDStr := chi.URLParam(r, "gameID")
+
+	gameID, err := strconv.Atoi(gameIDStr)
+	if err != nil {
+		return 0, errors.NewInvalidRequest(
+			errors.Layer_Request,
+			errors.NewInformation(
+				errors.ID_InvalidParams,
+				err.Error(),
+				[]errors.InvalidParams{
+					errors.NewInvalidParams("gameID", gameIDStr),
+				},
+			),
+			"gameID convert error",
+		)
+	}
+	return game.ID(gameID), nil
+}
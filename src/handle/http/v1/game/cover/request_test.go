@@ -0,0 +1,171 @@
+package cover
+
+import (
+	"context"
+	"io"
+	"mysrtafes-backend/pkg/game"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/assert"
+)
+
+const testBoundary = "cover-boundary"
+
+func newTestMultipart(key, filename, content string) io.Reader {
+	return strings.NewReader(
+		"--" + testBoundary + "\r\n" +
+			`Content-Disposition: form-data; name="` + key + `"; filename="` + filename + `"` + "\r\n" +
+			"Content-Type: application/octet-stream\r\n" +
+			"\r\n" +
+			content + "\r\n" +
+			"--" + testBoundary + "--\r\n",
+	)
+}
+
+func TestNewCoverUpload(t *testing.T) {
+	type args struct {
+		contentType string
+		body        io.Reader
+		pathParam   map[string]string
+	}
+	tests := []struct {
+		name    string
+		args    args
+		want    game.ID
+		want1   []byte
+		wantErr bool
+	}{
+		{
+			name: "OK",
+			args: args{
+				contentType: "multipart/form-data; boundary=" + testBoundary,
+				body:        newTestMultipart(FormKey, "cover.png", "png"),
+				pathParam: map[string]string{
+					"gameID": "1",
+				},
+			},
+			want:    1,
+			want1:   []byte("png"),
+			wantErr: false,
+		},
+		{
+			name: "上限を超えた分は読み込まない",
+			args: args{
+				contentType: "multipart/form-data; boundary=" + testBoundary,
+				body:        newTestMultipart(FormKey, "cover.png", strings.Repeat("a", int(game.CoverMaxSize)+10)),
+				pathParam: map[string]string{
+					"gameID": "1",
+				},
+			},
+			want:    1,
+			want1:   []byte(strings.Repeat("a", int(game.CoverMaxSize)+1)),
+			wantErr: false,
+		},
+		{
+			name: "multipart以外はエラー",
+			args: args{
+				contentType: "application/json",
+				body:        strings.NewReader(`{}`),
+				pathParam: map[string]string{
+					"gameID": "1",
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "ファイルの項目名違いはエラー",
+			args: args{
+				contentType: "multipart/form-data; boundary=" + testBoundary,
+				body:        newTestMultipart("image", "cover.png", "png"),
+				pathParam: map[string]string{
+					"gameID": "1",
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "リクエストが大きすぎるとエラー",
+			args: args{
+				contentType: "multipart/form-data; boundary=" + testBoundary,
+				body:        newTestMultipart(FormKey, "cover.png", strings.Repeat("a", int(game.CoverMaxSize)+formOverhead)),
+				pathParam: map[string]string{
+					"gameID": "1",
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "bad gameID error",
+			args: args{
+				contentType: "multipart/form-data; boundary=" + testBoundary,
+				body:        newTestMultipart(FormKey, "cover.png", "png"),
+				pathParam: map[string]string{
+					"gameID": "a",
+				},
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := chi.NewRouteContext()
+			for key, val := range tt.args.pathParam {
+				ctx.URLParams.Add(key, val)
+			}
+			r := httptest.NewRequest(http.MethodPut, "http://example.com", tt.args.body)
+			r.Header.Set("Content-Type", tt.args.contentType)
+			r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, ctx))
+			got, got1, err := NewCoverUpload(r)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("NewCoverUpload() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			assert.Equal(t, tt.want, got)
+			assert.Equal(t, tt.want1, got1)
+		})
+	}
+}
+
+func TestNewGameID(t *testing.T) {
+	tests := []struct {
+		name      string
+		pathParam map[string]string
+		want      game.ID
+		wantErr   bool
+	}{
+		{
+			name: "OK",
+			pathParam: map[string]string{
+				"gameID": "1",
+			},
+			want: 1,
+		},
+		{
+			name: "bad gameID error",
+			pathParam: map[string]string{
+				"gameID": "a",
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := chi.NewRouteContext()
+			for key, val := range tt.pathParam {
+				ctx.URLParams.Add(key, val)
+			}
+			r := httptest.NewRequest(http.MethodGet, "http://example.com", nil)
+			r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, ctx))
+			got, err := NewGameID(r)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("NewGameID() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -0,0 +1,83 @@
+package cover
+
+import (
+	"encoding/json"
+	"mysrtafes-backend/pkg/game"
+	"net/http"
+	"time"
+)
+
+type Cover struct {
+	URL          string                `json:"url"`
+	ThumbnailURL string                `json:"thumbnail_url"`
+	ContentType  game.CoverContentType `json:"content_type"`
+	Size         game.CoverSize        `json:"size"`
+	Width        int                   `json:"width"`
+	Height       int                   `json:"height"`
+	CreatedAt    time.Time             `json:"created_at"`
+	UpdatedAt    time.Time             `json:"updated_at"`
+}
+
+type CoverResponse struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+	Data    Cover  `json:"data"`
+}
+
+// write upload response for cover
+func WriteUploadCover(w http.ResponseWriter, cover *game.Cover) error {
+	body := coverResponse(http.StatusOK, "success upload cover", cover)
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	return json.NewEncoder(w).Encode(&body)
+}
+
+// write read response for cover
+func WriteReadCover(w http.ResponseWriter, cover *game.Cover) error {
+	body := coverResponse(http.StatusOK, "success read cover", cover)
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	return json.NewEncoder(w).Encode(&body)
+}
+
+// write delete response for cover
+func WriteDeleteCover(w http.ResponseWriter, gameID game.ID) error {
+	body := deleteCoverResponse(gameID)
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	return json.NewEncoder(w).Encode(&body)
+}
+
+func newCover(cover *game.Cover) Cover {
+	return Cover{
+		URL:          cover.URL,
+		ThumbnailURL: cover.ThumbnailURL,
+		ContentType:  cover.ContentType,
+		Size:         cover.Size,
+		Width:        cover.Width,
+		Height:       cover.Height,
+		CreatedAt:    cover.CreatedAt,
+		UpdatedAt:    cover.UpdatedAt,
+	}
+}
+
+func coverResponse(statusCode int, msg string, cover *game.Cover) interface{} {
+	return CoverResponse{
+		Code:    statusCode,
+		Message: msg,
+		Data:    newCover(cover),
+	}
+}
+
+// NOTE: カバー画像はゲームに1件のため、ゲームのIDを返却する
+func deleteCoverResponse(gameID game.ID) interface{} {
+	return struct {
+		Code    int     `json:"code"`
+		Message string  `json:"message"`
+		Data    game.ID `json:"deleteID"`
+	}{
+		Code:    http.StatusOK,
+		Message: "success delete cover",
+		Data:    gameID,
+	}
+}
@@ -0,0 +1,40 @@
+package cover
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_coverResponse(t *testing.T) {
+	tests := []struct {
+		name       string
+		statusCode int
+		msg        string
+		want       interface{}
+	}{
+		{
+			name:       "ok",
+			statusCode: http.StatusOK,
+			msg:        "OKです",
+			want: CoverResponse{
+				Code:    http.StatusOK,
+				Message: "OKです",
+				Data: Cover{
+					URL:          "/covers/games/1/cover-1.png",
+					ThumbnailURL: "/covers/games/1/thumbnail-1.jpg",
+					ContentType:  "image/png",
+					Size:         1024,
+					Width:        600,
+					Height:       800,
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, coverResponse(tt.statusCode, tt.msg, newTestCover()))
+		})
+	}
+}
@@ -23,6 +23,8 @@ func (h *gameHandler) HandleGame(w http.ResponseWriter, r *http.Request) {
 		h.create(w, r)
 	case http.MethodPut:
 		h.update(w, r)
+	case http.MethodPatch:
+		h.patch(w, r)
 	case http.MethodDelete:
 		h.delete(w, r)
 	default:
@@ -34,10 +36,66 @@ func (h *gameHandler) HandleGameForMultiple(w http.ResponseWriter, r *http.Reque
 	switch r.Method {
 	case http.MethodGet:
 		h.find(w, r)
-	// TODO: 必要であれば複数登録や更新削除を作る
-	// case http.MethodPost:
-	// case http.MethodPut:
-	// case http.MethodDelete:
+	case http.MethodPost:
+		// NOTE: JSON配列・CSVのときは一括登録、それ以外は単体登録
+		if IsGameImport(r) {
+			h.bulkCreate(w, r)
+			return
+		}
+		h.create(w, r)
+	case http.MethodPut:
+		h.bulkUpdate(w, r)
+	case http.MethodDelete:
+		h.bulkDelete(w, r)
+	default:
+		http.NotFound(w, r)
+	}
+}
+
+func (h *gameHandler) HandleGameTrashForMultiple(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+		h.trash(w, r)
+	default:
+		http.NotFound(w, r)
+	}
+}
+
+func (h *gameHandler) HandleGameTrash(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodPost:
+		h.restore(w, r)
+	case http.MethodDelete:
+		h.purge(w, r)
+	default:
+		http.NotFound(w, r)
+	}
+}
+
+func (h *gameHandler) HandleGameMerge(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodPost:
+		h.merge(w, r)
+	default:
+		http.NotFound(w, r)
+	}
+}
+
+// 指定タグを持つゲーム
+func (h *gameHandler) HandleGameForTag(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+		h.findByTag(w, r)
+	default:
+		http.NotFound(w, r)
+	}
+}
+
+// 指定プラットフォームを持つゲーム
+func (h *gameHandler) HandleGameForPlatform(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+		h.findByPlatform(w, r)
 	default:
 		http.NotFound(w, r)
 	}
@@ -62,6 +120,60 @@ func (h *gameHandler) create(w http.ResponseWriter, r *http.Request) {
 	WriteCreateGame(w, game)
 }
 
+func (h *gameHandler) bulkCreate(w http.ResponseWriter, r *http.Request) {
+	rows, mode, err := NewGameImport(r)
+	if err != nil {
+		log.Println(err)
+		errors.WriteError(w, err)
+		return
+	}
+
+	results, err := h.server.Import(rows, mode)
+	if err != nil {
+		log.Println(err)
+		errors.WriteError(w, err)
+		return
+	}
+
+	WriteImportGame(w, results)
+}
+
+func (h *gameHandler) bulkUpdate(w http.ResponseWriter, r *http.Request) {
+	rows, err := NewGameBulkUpdate(r)
+	if err != nil {
+		log.Println(err)
+		errors.WriteError(w, err)
+		return
+	}
+
+	results, err := h.server.BulkUpdate(rows)
+	if err != nil {
+		log.Println(err)
+		errors.WriteError(w, err)
+		return
+	}
+
+	WriteBulkUpdateGame(w, results)
+}
+
+func (h *gameHandler) bulkDelete(w http.ResponseWriter, r *http.Request) {
+	gameIDs, err := NewGameBulkDelete(r)
+	if err != nil {
+		log.Println(err)
+		errors.WriteError(w, err)
+		return
+	}
+
+	results, err := h.server.BulkDelete(gameIDs)
+	if err != nil {
+		log.Println(err)
+		errors.WriteError(w, err)
+		return
+	}
+
+	WriteBulkDeleteGame(w, results)
+}
+
 func (h *gameHandler) read(w http.ResponseWriter, r *http.Request) {
 	gameID, err := NewGameID(r)
 	if err != nil {
@@ -70,14 +182,26 @@ func (h *gameHandler) read(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	game, err := h.server.Read(gameID)
+	include, fields, err := NewGameFields(r)
+	if err != nil {
+		log.Println(err)
+		errors.WriteError(w, err)
+		return
+	}
+
+	game, err := h.server.Read(gameID, include)
 	if err != nil {
 		log.Println(err)
 		errors.WriteError(w, err)
 		return
 	}
 
-	WriteReadGame(w, game)
+	// NOTE: 統合済みのゲームは統合先に転送する
+	if game.ID != gameID {
+		WriteMovedGame(w, r, game.ID)
+		return
+	}
+	WriteReadGame(w, game, fields)
 }
 
 func (h *gameHandler) find(w http.ResponseWriter, r *http.Request) {
@@ -88,14 +212,85 @@ func (h *gameHandler) find(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	games, err := h.server.Find(findOption)
+	_, fields, err := NewGameFields(r)
+	if err != nil {
+		log.Println(err)
+		errors.WriteError(w, err)
+		return
+	}
+
+	games, meta, err := h.server.Find(findOption)
+	if err != nil {
+		log.Println(err)
+		errors.WriteError(w, err)
+		return
+	}
+
+	WriteFindGame(w, games, findOption, meta, fields)
+}
+
+func (h *gameHandler) findByTag(w http.ResponseWriter, r *http.Request) {
+	tagID, err := NewGameTagID(r)
+	if err != nil {
+		log.Println(err)
+		errors.WriteError(w, err)
+		return
+	}
+
+	findOption, err := NewGameFindOption(r)
+	if err != nil {
+		log.Println(err)
+		errors.WriteError(w, err)
+		return
+	}
+
+	_, fields, err := NewGameFields(r)
+	if err != nil {
+		log.Println(err)
+		errors.WriteError(w, err)
+		return
+	}
+
+	games, meta, err := h.server.FindByTag(tagID, findOption)
+	if err != nil {
+		log.Println(err)
+		errors.WriteError(w, err)
+		return
+	}
+
+	WriteFindGame(w, games, findOption, meta, fields)
+}
+
+func (h *gameHandler) findByPlatform(w http.ResponseWriter, r *http.Request) {
+	platformID, err := NewGamePlatformID(r)
+	if err != nil {
+		log.Println(err)
+		errors.WriteError(w, err)
+		return
+	}
+
+	findOption, err := NewGameFindOption(r)
+	if err != nil {
+		log.Println(err)
+		errors.WriteError(w, err)
+		return
+	}
+
+	_, fields, err := NewGameFields(r)
+	if err != nil {
+		log.Println(err)
+		errors.WriteError(w, err)
+		return
+	}
+
+	games, meta, err := h.server.FindByPlatform(platformID, findOption)
 	if err != nil {
 		log.Println(err)
 		errors.WriteError(w, err)
 		return
 	}
 
-	WriteFindGame(w, games, findOption)
+	WriteFindGame(w, games, findOption, meta, fields)
 }
 
 func (h *gameHandler) update(w http.ResponseWriter, r *http.Request) {
@@ -106,7 +301,25 @@ func (h *gameHandler) update(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	game, err = h.server.Update(game, platformIDs, tagIDs)
+	game, err = h.server.Update(game, platformIDs, tagIDs, NewPrecondition(r))
+	if err != nil {
+		log.Println(err)
+		errors.WriteError(w, err)
+		return
+	}
+
+	WriteUpdateGame(w, game)
+}
+
+func (h *gameHandler) patch(w http.ResponseWriter, r *http.Request) {
+	gameID, patcher, err := NewGamePatch(r)
+	if err != nil {
+		log.Println(err)
+		errors.WriteError(w, err)
+		return
+	}
+
+	game, err := h.server.Patch(gameID, patcher, NewPrecondition(r))
 	if err != nil {
 		log.Println(err)
 		errors.WriteError(w, err)
@@ -124,7 +337,7 @@ func (h *gameHandler) delete(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	err = h.server.Delete(gameID)
+	err = h.server.Delete(gameID, NewPrecondition(r))
 	if err != nil {
 		log.Println(err)
 		errors.WriteError(w, err)
@@ -133,3 +346,75 @@ func (h *gameHandler) delete(w http.ResponseWriter, r *http.Request) {
 
 	WriteDeleteGame(w, gameID)
 }
+
+func (h *gameHandler) trash(w http.ResponseWriter, r *http.Request) {
+	findOption, err := NewGameFindOption(r)
+	if err != nil {
+		log.Println(err)
+		errors.WriteError(w, err)
+		return
+	}
+
+	games, meta, err := h.server.Trash(findOption)
+	if err != nil {
+		log.Println(err)
+		errors.WriteError(w, err)
+		return
+	}
+
+	WriteTrashGame(w, games, findOption, meta)
+}
+
+func (h *gameHandler) restore(w http.ResponseWriter, r *http.Request) {
+	gameID, err := NewGameID(r)
+	if err != nil {
+		log.Println(err)
+		errors.WriteError(w, err)
+		return
+	}
+
+	game, err := h.server.Restore(gameID)
+	if err != nil {
+		log.Println(err)
+		errors.WriteError(w, err)
+		return
+	}
+
+	WriteRestoreGame(w, game)
+}
+
+func (h *gameHandler) purge(w http.ResponseWriter, r *http.Request) {
+	gameID, err := NewGameID(r)
+	if err != nil {
+		log.Println(err)
+		errors.WriteError(w, err)
+		return
+	}
+
+	err = h.server.Purge(gameID)
+	if err != nil {
+		log.Println(err)
+		errors.WriteError(w, err)
+		return
+	}
+
+	WritePurgeGame(w, gameID)
+}
+
+func (h *gameHandler) merge(w http.ResponseWriter, r *http.Request) {
+	sourceID, targetID, err := NewGameMerge(r)
+	if err != nil {
+		log.Println(err)
+		errors.WriteError(w, err)
+		return
+	}
+
+	game, err := h.server.Merge(sourceID, targetID)
+	if err != nil {
+		log.Println(err)
+		errors.WriteError(w, err)
+		return
+	}
+
+	WriteMergeGame(w, game)
+}
@@ -0,0 +1,151 @@
+package link
+
+import (
+	"log"
+	"mysrtafes-backend/handle/http/v1/errors"
+	"mysrtafes-backend/pkg/game"
+	"net/http"
+)
+
+type linkHandler struct {
+	server game.LinkServer
+}
+
+func NewLinkHandler(s game.LinkServer) *linkHandler {
+	return &linkHandler{s}
+}
+
+func (h *linkHandler) HandleLink(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+		h.read(w, r)
+	case http.MethodPost:
+		h.create(w, r)
+	case http.MethodPut:
+		h.update(w, r)
+	case http.MethodDelete:
+		h.delete(w, r)
+	default:
+		http.NotFound(w, r)
+	}
+}
+
+func (h *linkHandler) HandleLinkForMultiple(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+		h.find(w, r)
+	case http.MethodPut:
+		h.reorder(w, r)
+	default:
+		http.NotFound(w, r)
+	}
+}
+
+func (h *linkHandler) create(w http.ResponseWriter, r *http.Request) {
+	gameID, link, err := NewLinkCreate(r)
+	if err != nil {
+		// TODO: logの改善(トレーサーなど)
+		log.Println(err)
+		errors.WriteError(w, err)
+		return
+	}
+
+	link, err = h.server.Create(gameID, link)
+	if err != nil {
+		log.Println(err)
+		errors.WriteError(w, err)
+		return
+	}
+
+	WriteCreateLink(w, link)
+}
+
+func (h *linkHandler) read(w http.ResponseWriter, r *http.Request) {
+	gameID, linkID, err := NewLinkID(r)
+	if err != nil {
+		log.Println(err)
+		errors.WriteError(w, err)
+		return
+	}
+
+	link, err := h.server.Read(gameID, linkID)
+	if err != nil {
+		log.Println(err)
+		errors.WriteError(w, err)
+		return
+	}
+
+	WriteReadLink(w, link)
+}
+
+func (h *linkHandler) find(w http.ResponseWriter, r *http.Request) {
+	gameID, err := NewGameID(r)
+	if err != nil {
+		log.Println(err)
+		errors.WriteError(w, err)
+		return
+	}
+
+	links, err := h.server.Find(gameID)
+	if err != nil {
+		log.Println(err)
+		errors.WriteError(w, err)
+		return
+	}
+
+	WriteFindLink(w, links)
+}
+
+func (h *linkHandler) update(w http.ResponseWriter, r *http.Request) {
+	gameID, link, err := NewLinkUpdate(r)
+	if err != nil {
+		log.Println(err)
+		errors.WriteError(w, err)
+		return
+	}
+
+	link, err = h.server.Update(gameID, link)
+	if err != nil {
+		log.Println(err)
+		errors.WriteError(w, err)
+		return
+	}
+
+	WriteUpdateLink(w, link)
+}
+
+func (h *linkHandler) delete(w http.ResponseWriter, r *http.Request) {
+	gameID, linkID, err := NewLinkID(r)
+	if err != nil {
+		log.Println(err)
+		errors.WriteError(w, err)
+		return
+	}
+
+	err = h.server.Delete(gameID, linkID)
+	if err != nil {
+		log.Println(err)
+		errors.WriteError(w, err)
+		return
+	}
+
+	WriteDeleteLink(w, linkID)
+}
+
+func (h *linkHandler) reorder(w http.ResponseWriter, r *http.Request) {
+	gameID, linkIDs, err := NewLinkReorder(r)
+	if err != nil {
+		log.Println(err)
+		errors.WriteError(w, err)
+		return
+	}
+
+	links, err := h.server.Reorder(gameID, linkIDs)
+	if err != nil {
+		log.Println(err)
+		errors.WriteError(w, err)
+		return
+	}
+
+	WriteReorderLink(w, links)
+}
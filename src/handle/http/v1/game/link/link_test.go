@@ -0,0 +1,449 @@
+package link
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"mysrtafes-backend/pkg/errors"
+	"mysrtafes-backend/pkg/game"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/assert"
+)
+
+type server struct {
+	Link  *game.Link
+	Links []*game.Link
+	err   error
+	// flags
+	create, read, find, update, delete, reorder bool
+}
+
+func (s *server) Create(game.ID, *game.Link) (*game.Link, error) {
+	if s.create {
+		return s.Link, s.err
+	}
+	return nil, fmt.Errorf("failed create")
+}
+func (s *server) Read(game.ID, game.LinkID) (*game.Link, error) {
+	if s.read {
+		return s.Link, s.err
+	}
+	return nil, fmt.Errorf("failed read")
+}
+func (s *server) Find(game.ID) ([]*game.Link, error) {
+	if s.find {
+		return s.Links, s.err
+	}
+	return nil, fmt.Errorf("failed find")
+}
+func (s *server) Update(game.ID, *game.Link) (*game.Link, error) {
+	if s.update {
+		return s.Link, s.err
+	}
+	return nil, fmt.Errorf("failed update")
+}
+func (s *server) Delete(game.ID, game.LinkID) error {
+	if s.delete {
+		return s.err
+	}
+	return fmt.Errorf("failed delete")
+}
+func (s *server) Reorder(game.ID, []game.LinkID) ([]*game.Link, error) {
+	if s.reorder {
+		return s.Links, s.err
+	}
+	return nil, fmt.Errorf("failed reorder")
+}
+
+func newTestLink(id game.LinkID, title game.Title, order game.DisplayOrder) *game.Link {
+	url, _ := game.NewURL("http://example.com")
+	return game.NewLinkWithID(id, title, url, "説明").SetDisplayOrder(order)
+}
+
+func TestNewLinkHandler(t *testing.T) {
+	type args struct {
+		s game.LinkServer
+	}
+	tests := []struct {
+		name string
+		args args
+		want *linkHandler
+	}{
+		{
+			name: "ok",
+			args: args{
+				s: &server{},
+			},
+			want: &linkHandler{
+				server: &server{},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, NewLinkHandler(tt.args.s), tt.want)
+		})
+	}
+}
+
+func Test_linkHandler_HandleLink(t *testing.T) {
+	type fields struct {
+		server game.LinkServer
+	}
+	type args struct {
+		w         *httptest.ResponseRecorder
+		method    string
+		url       string
+		body      io.Reader
+		pathParam map[string]string
+	}
+	tests := []struct {
+		name           string
+		fields         fields
+		args           args
+		wantStatusCode int
+		wantBody       string
+	}{
+		{
+			name: "Get OK",
+			fields: fields{
+				server: &server{
+					Link: newTestLink(1, "Get OK", 1),
+					read: true,
+				},
+			},
+			args: args{
+				w:      httptest.NewRecorder(),
+				method: http.MethodGet,
+				url:    "http://example.com/1",
+				body:   strings.NewReader(``),
+				pathParam: map[string]string{
+					"gameID": "1",
+					"linkID": "1",
+				},
+			},
+			wantStatusCode: http.StatusOK,
+			wantBody: func() string {
+				body := linkResponse(http.StatusOK, "success read link", newTestLink(1, "Get OK", 1))
+				str, _ := json.Marshal(body)
+				return string(str)
+			}(),
+		},
+		{
+			name: "Post OK",
+			fields: fields{
+				server: &server{
+					Link:   newTestLink(2, "Post OK", 3),
+					create: true,
+				},
+			},
+			args: args{
+				w:      httptest.NewRecorder(),
+				method: http.MethodPost,
+				url:    "http://example.com",
+				body:   strings.NewReader(`{"title": "Post OK", "url": "http://example.com", "description": "説明"}`),
+				pathParam: map[string]string{
+					"gameID": "1",
+				},
+			},
+			wantStatusCode: http.StatusCreated,
+			wantBody: func() string {
+				body := linkResponse(http.StatusCreated, "success create link", newTestLink(2, "Post OK", 3))
+				str, _ := json.Marshal(body)
+				return string(str)
+			}(),
+		},
+		{
+			name: "Put OK",
+			fields: fields{
+				server: &server{
+					Link:   newTestLink(3, "Put OK", 1),
+					update: true,
+				},
+			},
+			args: args{
+				w:      httptest.NewRecorder(),
+				method: http.MethodPut,
+				url:    "http://example.com/3",
+				body:   strings.NewReader(`{"title": "Put OK", "url": "http://example.com", "description": "説明"}`),
+				pathParam: map[string]string{
+					"gameID": "1",
+					"linkID": "3",
+				},
+			},
+			wantStatusCode: http.StatusOK,
+			wantBody: func() string {
+				body := linkResponse(http.StatusOK, "success update link", newTestLink(3, "Put OK", 1))
+				str, _ := json.Marshal(body)
+				return string(str)
+			}(),
+		},
+		{
+			name: "Delete OK",
+			fields: fields{
+				server: &server{
+					delete: true,
+				},
+			},
+			args: args{
+				w:      httptest.NewRecorder(),
+				method: http.MethodDelete,
+				url:    "http://example.com/4",
+				body:   strings.NewReader(``),
+				pathParam: map[string]string{
+					"gameID": "1",
+					"linkID": "4",
+				},
+			},
+			wantStatusCode: http.StatusOK,
+			wantBody: func() string {
+				body := deleteLinkResponse(4)
+				str, _ := json.Marshal(body)
+				return string(str)
+			}(),
+		},
+		{
+			name: "Delete NotFound NG",
+			fields: fields{
+				server: &server{
+					err:    errors.NewNotFound(errors.Layer_Model, nil, "not found"),
+					delete: true,
+				},
+			},
+			args: args{
+				w:      httptest.NewRecorder(),
+				method: http.MethodDelete,
+				url:    "http://example.com/4",
+				body:   strings.NewReader(``),
+				pathParam: map[string]string{
+					"gameID": "1",
+					"linkID": "4",
+				},
+			},
+			wantStatusCode: http.StatusNotFound,
+			wantBody:       "",
+		},
+		{
+			name: "Bad linkID NG",
+			fields: fields{
+				server: &server{
+					read: true,
+				},
+			},
+			args: args{
+				w:      httptest.NewRecorder(),
+				method: http.MethodGet,
+				url:    "http://example.com/a",
+				body:   strings.NewReader(``),
+				pathParam: map[string]string{
+					"gameID": "1",
+					"linkID": "a",
+				},
+			},
+			wantStatusCode: http.StatusBadRequest,
+			wantBody:       "",
+		},
+		{
+			name: "Bad Method NG",
+			fields: fields{
+				server: &server{},
+			},
+			args: args{
+				w:      httptest.NewRecorder(),
+				method: http.MethodPatch,
+				url:    "http://example.com/4",
+				body:   strings.NewReader(``),
+				pathParam: map[string]string{
+					"gameID": "1",
+					"linkID": "4",
+				},
+			},
+			wantStatusCode: http.StatusNotFound,
+			wantBody:       "",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := &linkHandler{
+				server: tt.fields.server,
+			}
+			ctx := chi.NewRouteContext()
+			for key, val := range tt.args.pathParam {
+				ctx.URLParams.Add(key, val)
+			}
+			r := httptest.NewRequest(tt.args.method, tt.args.url, tt.args.body)
+			r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, ctx))
+			h.HandleLink(tt.args.w, r)
+			if !assert.Equal(t, tt.wantStatusCode, tt.args.w.Code) {
+				return
+			}
+			// NOTE: BodyのStringは\nが入る仕様らしいので削除
+			if tt.wantBody != "" && !assert.Equal(t, tt.wantBody, strings.Replace(tt.args.w.Body.String(), "\n", "", -1)) {
+				return
+			}
+		})
+	}
+}
+
+func Test_linkHandler_HandleLinkForMultiple(t *testing.T) {
+	type fields struct {
+		server game.LinkServer
+	}
+	type args struct {
+		w         *httptest.ResponseRecorder
+		method    string
+		url       string
+		body      io.Reader
+		pathParam map[string]string
+	}
+	tests := []struct {
+		name           string
+		fields         fields
+		args           args
+		wantStatusCode int
+		wantBody       string
+	}{
+		{
+			name: "Find OK",
+			fields: fields{
+				server: &server{
+					Links: []*game.Link{
+						newTestLink(1, "wiki", 1),
+						newTestLink(2, "site", 2),
+					},
+					find: true,
+				},
+			},
+			args: args{
+				w:      httptest.NewRecorder(),
+				method: http.MethodGet,
+				url:    "http://example.com",
+				body:   strings.NewReader(``),
+				pathParam: map[string]string{
+					"gameID": "1",
+				},
+			},
+			wantStatusCode: http.StatusOK,
+			wantBody: func() string {
+				body := linksResponse(http.StatusOK, "success find link", []*game.Link{
+					newTestLink(1, "wiki", 1),
+					newTestLink(2, "site", 2),
+				})
+				str, _ := json.Marshal(body)
+				return string(str)
+			}(),
+		},
+		{
+			name: "Reorder OK",
+			fields: fields{
+				server: &server{
+					Links: []*game.Link{
+						newTestLink(2, "site", 1),
+						newTestLink(1, "wiki", 2),
+					},
+					reorder: true,
+				},
+			},
+			args: args{
+				w:      httptest.NewRecorder(),
+				method: http.MethodPut,
+				url:    "http://example.com",
+				body:   strings.NewReader(`{"link_ids": [2, 1]}`),
+				pathParam: map[string]string{
+					"gameID": "1",
+				},
+			},
+			wantStatusCode: http.StatusOK,
+			wantBody: func() string {
+				body := linksResponse(http.StatusOK, "success reorder link", []*game.Link{
+					newTestLink(2, "site", 1),
+					newTestLink(1, "wiki", 2),
+				})
+				str, _ := json.Marshal(body)
+				return string(str)
+			}(),
+		},
+		{
+			name: "Reorder decode NG",
+			fields: fields{
+				server: &server{
+					reorder: true,
+				},
+			},
+			args: args{
+				w:      httptest.NewRecorder(),
+				method: http.MethodPut,
+				url:    "http://example.com",
+				body:   strings.NewReader(`{"link_ids": [2, 1],}`),
+				pathParam: map[string]string{
+					"gameID": "1",
+				},
+			},
+			wantStatusCode: http.StatusBadRequest,
+			wantBody:       "",
+		},
+		{
+			name: "Find server NG",
+			fields: fields{
+				server: &server{
+					find: false,
+				},
+			},
+			args: args{
+				w:      httptest.NewRecorder(),
+				method: http.MethodGet,
+				url:    "http://example.com",
+				body:   strings.NewReader(``),
+				pathParam: map[string]string{
+					"gameID": "1",
+				},
+			},
+			wantStatusCode: http.StatusInternalServerError,
+			wantBody:       "",
+		},
+		{
+			name: "Bad Method NG",
+			fields: fields{
+				server: &server{},
+			},
+			args: args{
+				w:      httptest.NewRecorder(),
+				method: http.MethodDelete,
+				url:    "http://example.com",
+				body:   strings.NewReader(``),
+				pathParam: map[string]string{
+					"gameID": "1",
+				},
+			},
+			wantStatusCode: http.StatusNotFound,
+			wantBody:       "",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := &linkHandler{
+				server: tt.fields.server,
+			}
+			ctx := chi.NewRouteContext()
+			for key, val := range tt.args.pathParam {
+				ctx.URLParams.Add(key, val)
+			}
+			r := httptest.NewRequest(tt.args.method, tt.args.url, tt.args.body)
+			r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, ctx))
+			h.HandleLinkForMultiple(tt.args.w, r)
+			if !assert.Equal(t, tt.wantStatusCode, tt.args.w.Code) {
+				return
+			}
+			// NOTE: BodyのStringは\nが入る仕様らしいので削除
+			if tt.wantBody != "" && !assert.Equal(t, tt.wantBody, strings.Replace(tt.args.w.Body.String(), "\n", "", -1)) {
+				return
+			}
+		})
+	}
+}
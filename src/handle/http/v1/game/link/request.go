@@ -0,0 +1,216 @@
+package link
+
+import (
+	"encoding/json"
+	"mysrtafes-backend/pkg/errors"
+	"mysrtafes-backend/pkg/game"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+)
+
+// Post: NewLinkEntity for request
+func NewLinkCreate(r *http.Request) (game.ID, *game.Link, error) {
+	defer r.Body.Close()
+
+	gameID, err := NewGameID(r)
+	if err != nil {
+		return 0, nil, err
+	}
+
+	body := struct {
+		Title           game.Title           `json:"title"`
+		URL             string               `json:"url"`
+		LinkDescription game.LinkDescription `json:"description"`
+		Kind            string               `json:"kind"`
+		Store           game.LinkStore       `json:"store"`
+		Region          game.LinkRegion      `json:"region"`
+	}{}
+	err = json.NewDecoder(r.Body).Decode(&body)
+	if err != nil {
+		return 0, nil, errors.NewInvalidRequest(
+			errors.Layer_Request,
+			errors.NewInformation(
+				errors.ID_JsonDecodeError,
+				err.Error(),
+				nil,
+			),
+			"json decode error. bad format request.",
+		)
+	}
+
+	url, err := game.NewURL(body.URL)
+	if err != nil {
+		return 0, nil, errors.NewInvalidRequest(
+			errors.Layer_Request,
+			errors.NewInformation(
+				errors.ID_InvalidParams,
+				err.Error(),
+				[]errors.InvalidParams{
+					errors.NewInvalidParams("url", body.URL),
+				},
+			),
+			"url create error",
+		)
+	}
+
+	kind, err := game.NewLinkKind(body.Kind)
+	if err != nil {
+		return 0, nil, errors.NewInvalidRequest(
+			errors.Layer_Request,
+			errors.NewInformation(
+				errors.ID_InvalidParams,
+				err.Error(),
+				[]errors.InvalidParams{
+					errors.NewInvalidParams("kind", body.Kind),
+				},
+			),
+			"kind create error",
+		)
+	}
+
+	return gameID, game.NewLink(body.Title, url, body.LinkDescription).
+		SetKind(kind).
+		SetStore(body.Store, body.Region), nil
+}
+
+// Get, Find: NewGameID for request
+func NewGameID(r *http.Request) (game.ID, error) {
+	gameIDStr := chi.URLParam(r, "gameID")
+
+	gameID, err := strconv.Atoi(gameIDStr)
+	if err != nil {
+		return 0, errors.NewInvalidRequest(
+			errors.Layer_Request,
+			errors.NewInformation(
+				errors.ID_InvalidParams,
+				err.Error(),
+				[]errors.InvalidParams{
+					errors.NewInvalidParams("gameID", gameIDStr),
+				},
+			),
+			"gameID convert error",
+		)
+	}
+	return game.ID(gameID), nil
+}
+
+// Get, Delete: NewLinkID for request
+func NewLinkID(r *http.Request) (game.ID, game.LinkID, error) {
+	gameID, err := NewGameID(r)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	linkIDStr := chi.URLParam(r, "linkID")
+
+	linkID, err := strconv.Atoi(linkIDStr)
+	if err != nil {
+		return 0, 0, errors.NewInvalidRequest(
+			errors.Layer_Request,
+			errors.NewInformation(
+				errors.ID_InvalidParams,
+				err.Error(),
+				[]errors.InvalidParams{
+					errors.NewInvalidParams("linkID", linkIDStr),
+				},
+			),
+			"linkID convert error",
+		)
+	}
+	return gameID, game.LinkID(linkID), nil
+}
+
+// Update: NewLinkEntity for request
+func NewLinkUpdate(r *http.Request) (game.ID, *game.Link, error) {
+	defer r.Body.Close()
+
+	gameID, linkID, err := NewLinkID(r)
+	if err != nil {
+		return 0, nil, err
+	}
+
+	body := struct {
+		Title           game.Title           `json:"title"`
+		URL             string               `json:"url"`
+		LinkDescription game.LinkDescription `json:"description"`
+		Kind            string               `json:"kind"`
+		Store           game.LinkStore       `json:"store"`
+		Region          game.LinkRegion      `json:"region"`
+	}{}
+	err = json.NewDecoder(r.Body).Decode(&body)
+	if err != nil {
+		return 0, nil, errors.NewInvalidRequest(
+			errors.Layer_Request,
+			errors.NewInformation(
+				errors.ID_JsonDecodeError,
+				err.Error(),
+				nil,
+			),
+			"json decode error. bad format request.",
+		)
+	}
+
+	url, err := game.NewURL(body.URL)
+	if err != nil {
+		return 0, nil, errors.NewInvalidRequest(
+			errors.Layer_Request,
+			errors.NewInformation(
+				errors.ID_InvalidParams,
+				err.Error(),
+				[]errors.InvalidParams{
+					errors.NewInvalidParams("url", body.URL),
+				},
+			),
+			"url create error",
+		)
+	}
+
+	kind, err := game.NewLinkKind(body.Kind)
+	if err != nil {
+		return 0, nil, errors.NewInvalidRequest(
+			errors.Layer_Request,
+			errors.NewInformation(
+				errors.ID_InvalidParams,
+				err.Error(),
+				[]errors.InvalidParams{
+					errors.NewInvalidParams("kind", body.Kind),
+				},
+			),
+			"kind create error",
+		)
+	}
+
+	return gameID, game.NewLinkWithID(linkID, body.Title, url, body.LinkDescription).
+		SetKind(kind).
+		SetStore(body.Store, body.Region), nil
+}
+
+// Reorder: LinkIDs for request
+func NewLinkReorder(r *http.Request) (game.ID, []game.LinkID, error) {
+	defer r.Body.Close()
+
+	gameID, err := NewGameID(r)
+	if err != nil {
+		return 0, nil, err
+	}
+
+	body := struct {
+		LinkIDs []game.LinkID `json:"link_ids"`
+	}{}
+	err = json.NewDecoder(r.Body).Decode(&body)
+	if err != nil {
+		return 0, nil, errors.NewInvalidRequest(
+			errors.Layer_Request,
+			errors.NewInformation(
+				errors.ID_JsonDecodeError,
+				err.Error(),
+				nil,
+			),
+			"json decode error. bad format request.",
+		)
+	}
+
+	return gameID, body.LinkIDs, nil
+}
@@ -0,0 +1,339 @@
+package link
+
+import (
+	"context"
+	"io"
+	"mysrtafes-backend/pkg/game"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewLinkCreate(t *testing.T) {
+	type args struct {
+		body      io.Reader
+		pathParam map[string]string
+	}
+	tests := []struct {
+		name    string
+		args    args
+		want    game.ID
+		want1   *game.Link
+		wantErr bool
+	}{
+		{
+			name: "OK",
+			args: args{
+				body: strings.NewReader(`{"title": "wiki", "url": "http://example.com", "description": "色々知れます"}`),
+				pathParam: map[string]string{
+					"gameID": "1",
+				},
+			},
+			want: 1,
+			want1: &game.Link{
+				Title: "wiki",
+				URL: func() game.URL {
+					u, _ := game.NewURL("http://example.com")
+					return u
+				}(),
+				LinkDescription: "色々知れます",
+			},
+			wantErr: false,
+		},
+		{
+			name: "store OK",
+			args: args{
+				body: strings.NewReader(`{"title": "Steam", "url": "http://example.com", "description": "", "kind": "store", "store": "Steam", "region": "JP"}`),
+				pathParam: map[string]string{
+					"gameID": "1",
+				},
+			},
+			want: 1,
+			want1: &game.Link{
+				Title: "Steam",
+				URL: func() game.URL {
+					u, _ := game.NewURL("http://example.com")
+					return u
+				}(),
+				Kind:   game.LinkKind_Store,
+				Store:  "Steam",
+				Region: "JP",
+			},
+			wantErr: false,
+		},
+		{
+			name: "kind error",
+			args: args{
+				body: strings.NewReader(`{"title": "wiki", "url": "http://example.com", "kind": "blog"}`),
+				pathParam: map[string]string{
+					"gameID": "1",
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "bad gameID error",
+			args: args{
+				body: strings.NewReader(`{"title": "wiki", "url": "http://example.com", "description": "色々知れます"}`),
+				pathParam: map[string]string{
+					"gameID": "a",
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "url decode error",
+			args: args{
+				body: strings.NewReader(`{"title": "wiki", "url": "ht", "description": "色々知れます"}`),
+				pathParam: map[string]string{
+					"gameID": "1",
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "decode error",
+			args: args{
+				body: strings.NewReader(`{"title": "wiki",}`),
+				pathParam: map[string]string{
+					"gameID": "1",
+				},
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := chi.NewRouteContext()
+			for key, val := range tt.args.pathParam {
+				ctx.URLParams.Add(key, val)
+			}
+			r := httptest.NewRequest(http.MethodPost, "http://example.com", tt.args.body)
+			r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, ctx))
+			got, got1, err := NewLinkCreate(r)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("NewLinkCreate() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			assert.Equal(t, tt.want, got)
+			assert.Equal(t, tt.want1, got1)
+		})
+	}
+}
+
+func TestNewLinkID(t *testing.T) {
+	type args struct {
+		pathParam map[string]string
+	}
+	tests := []struct {
+		name    string
+		args    args
+		want    game.ID
+		want1   game.LinkID
+		wantErr bool
+	}{
+		{
+			name: "OK",
+			args: args{
+				pathParam: map[string]string{
+					"gameID": "1",
+					"linkID": "2",
+				},
+			},
+			want:    1,
+			want1:   2,
+			wantErr: false,
+		},
+		{
+			name: "bad gameID error",
+			args: args{
+				pathParam: map[string]string{
+					"gameID": "",
+					"linkID": "2",
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "bad linkID error",
+			args: args{
+				pathParam: map[string]string{
+					"gameID": "1",
+					"linkID": "a",
+				},
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := chi.NewRouteContext()
+			for key, val := range tt.args.pathParam {
+				ctx.URLParams.Add(key, val)
+			}
+			r := httptest.NewRequest(http.MethodGet, "http://example.com", nil)
+			r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, ctx))
+			got, got1, err := NewLinkID(r)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("NewLinkID() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			assert.Equal(t, tt.want, got)
+			assert.Equal(t, tt.want1, got1)
+		})
+	}
+}
+
+func TestNewLinkUpdate(t *testing.T) {
+	type args struct {
+		body      io.Reader
+		pathParam map[string]string
+	}
+	tests := []struct {
+		name    string
+		args    args
+		want    game.ID
+		want1   *game.Link
+		wantErr bool
+	}{
+		{
+			name: "OK",
+			args: args{
+				body: strings.NewReader(`{"title": "wiki", "url": "http://example.com", "description": "色々知れます"}`),
+				pathParam: map[string]string{
+					"gameID": "1",
+					"linkID": "3",
+				},
+			},
+			want: 1,
+			want1: &game.Link{
+				LinkID: 3,
+				Title:  "wiki",
+				URL: func() game.URL {
+					u, _ := game.NewURL("http://example.com")
+					return u
+				}(),
+				LinkDescription: "色々知れます",
+			},
+			wantErr: false,
+		},
+		{
+			name: "bad linkID error",
+			args: args{
+				body: strings.NewReader(`{"title": "wiki", "url": "http://example.com", "description": "色々知れます"}`),
+				pathParam: map[string]string{
+					"gameID": "1",
+					"linkID": "a",
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "url decode error",
+			args: args{
+				body: strings.NewReader(`{"title": "wiki", "url": "ht", "description": "色々知れます"}`),
+				pathParam: map[string]string{
+					"gameID": "1",
+					"linkID": "3",
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "decode error",
+			args: args{
+				body: strings.NewReader(`{"title": "wiki",}`),
+				pathParam: map[string]string{
+					"gameID": "1",
+					"linkID": "3",
+				},
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := chi.NewRouteContext()
+			for key, val := range tt.args.pathParam {
+				ctx.URLParams.Add(key, val)
+			}
+			r := httptest.NewRequest(http.MethodPut, "http://example.com", tt.args.body)
+			r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, ctx))
+			got, got1, err := NewLinkUpdate(r)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("NewLinkUpdate() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			assert.Equal(t, tt.want, got)
+			assert.Equal(t, tt.want1, got1)
+		})
+	}
+}
+
+func TestNewLinkReorder(t *testing.T) {
+	type args struct {
+		body      io.Reader
+		pathParam map[string]string
+	}
+	tests := []struct {
+		name    string
+		args    args
+		want    game.ID
+		want1   []game.LinkID
+		wantErr bool
+	}{
+		{
+			name: "OK",
+			args: args{
+				body: strings.NewReader(`{"link_ids": [3, 1, 2]}`),
+				pathParam: map[string]string{
+					"gameID": "1",
+				},
+			},
+			want:    1,
+			want1:   []game.LinkID{3, 1, 2},
+			wantErr: false,
+		},
+		{
+			name: "bad gameID error",
+			args: args{
+				body: strings.NewReader(`{"link_ids": [3, 1, 2]}`),
+				pathParam: map[string]string{
+					"gameID": "a",
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "decode error",
+			args: args{
+				body: strings.NewReader(`{"link_ids": ["a"]}`),
+				pathParam: map[string]string{
+					"gameID": "1",
+				},
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := chi.NewRouteContext()
+			for key, val := range tt.args.pathParam {
+				ctx.URLParams.Add(key, val)
+			}
+			r := httptest.NewRequest(http.MethodPut, "http://example.com", tt.args.body)
+			r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, ctx))
+			got, got1, err := NewLinkReorder(r)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("NewLinkReorder() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			assert.Equal(t, tt.want, got)
+			assert.Equal(t, tt.want1, got1)
+		})
+	}
+}
@@ -0,0 +1,128 @@
+package link
+
+import (
+	"encoding/json"
+	"mysrtafes-backend/pkg/game"
+	"net/http"
+	"time"
+)
+
+type Link struct {
+	ID           game.LinkID          `json:"id"`
+	Title        game.Title           `json:"title"`
+	URL          string               `json:"url"`
+	Description  game.LinkDescription `json:"description"`
+	DisplayOrder game.DisplayOrder    `json:"display_order"`
+	Kind         string               `json:"kind"`
+	Store        game.LinkStore       `json:"store"`
+	Region       game.LinkRegion      `json:"region"`
+	CreatedAt    time.Time            `json:"created_at"`
+	UpdatedAt    time.Time            `json:"updated_at"`
+}
+
+type LinkResponse struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+	Data    Link   `json:"data"`
+}
+
+type LinksResponse struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+	Data    []Link `json:"data"`
+}
+
+// write create response for link
+func WriteCreateLink(w http.ResponseWriter, link *game.Link) error {
+	body := linkResponse(http.StatusCreated, "success create link", link)
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	return json.NewEncoder(w).Encode(&body)
+}
+
+// write read response for link
+func WriteReadLink(w http.ResponseWriter, link *game.Link) error {
+	body := linkResponse(http.StatusOK, "success read link", link)
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	return json.NewEncoder(w).Encode(&body)
+}
+
+// write update response for link
+func WriteUpdateLink(w http.ResponseWriter, link *game.Link) error {
+	body := linkResponse(http.StatusOK, "success update link", link)
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	return json.NewEncoder(w).Encode(&body)
+}
+
+// write delete response for link
+func WriteDeleteLink(w http.ResponseWriter, linkID game.LinkID) error {
+	body := deleteLinkResponse(linkID)
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	return json.NewEncoder(w).Encode(&body)
+}
+
+// write find response for link
+func WriteFindLink(w http.ResponseWriter, links []*game.Link) error {
+	body := linksResponse(http.StatusOK, "success find link", links)
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	return json.NewEncoder(w).Encode(&body)
+}
+
+// write reorder response for link
+func WriteReorderLink(w http.ResponseWriter, links []*game.Link) error {
+	body := linksResponse(http.StatusOK, "success reorder link", links)
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	return json.NewEncoder(w).Encode(&body)
+}
+
+func newLink(link *game.Link) Link {
+	return Link{
+		ID:           link.LinkID,
+		Title:        link.Title,
+		URL:          link.URL.URL().String(),
+		Description:  link.LinkDescription,
+		DisplayOrder: link.DisplayOrder,
+		Kind:         link.Kind.String(),
+		Store:        link.Store,
+		Region:       link.Region,
+		CreatedAt:    link.CreatedAt,
+		UpdatedAt:    link.UpdatedAt,
+	}
+}
+
+func linkResponse(statusCode int, msg string, link *game.Link) interface{} {
+	return LinkResponse{
+		Code:    statusCode,
+		Message: msg,
+		Data:    newLink(link),
+	}
+}
+
+func deleteLinkResponse(linkID game.LinkID) interface{} {
+	return struct {
+		Code    int         `json:"code"`
+		Message string      `json:"message"`
+		Data    game.LinkID `json:"deleteID"`
+	}{
+		Code:    http.StatusOK,
+		Message: "success delete link",
+		Data:    linkID,
+	}
+}
+
+func linksResponse(statusCode int, msg string, links []*game.Link) interface{} {
+	responses := make([]Link, 0, len(links))
+	for _, link := range links {
+		responses = append(responses, newLink(link))
+	}
+	return LinksResponse{
+		Code:    statusCode,
+		Message: msg,
+		Data:    responses,
+	}
+}
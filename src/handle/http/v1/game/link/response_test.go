@@ -0,0 +1,115 @@
+package link
+
+import (
+	"mysrtafes-backend/pkg/game"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_linkResponse(t *testing.T) {
+	type args struct {
+		statusCode int
+		msg        string
+		link       *game.Link
+	}
+	tests := []struct {
+		name string
+		args args
+		want interface{}
+	}{
+		{
+			name: "ok",
+			args: args{
+				statusCode: http.StatusOK,
+				msg:        "OKです",
+				link:       newTestLink(10, "steam", 2).SetKind(game.LinkKind_Store).SetStore("Steam", "JP"),
+			},
+			want: LinkResponse{
+				Code:    http.StatusOK,
+				Message: "OKです",
+				Data: Link{
+					ID:           10,
+					Title:        "steam",
+					URL:          "http://example.com",
+					Description:  "説明",
+					DisplayOrder: 2,
+					Kind:         "store",
+					Store:        "Steam",
+					Region:       "JP",
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, linkResponse(tt.args.statusCode, tt.args.msg, tt.args.link))
+		})
+	}
+}
+
+func Test_linksResponse(t *testing.T) {
+	type args struct {
+		statusCode int
+		msg        string
+		links      []*game.Link
+	}
+	tests := []struct {
+		name string
+		args args
+		want interface{}
+	}{
+		{
+			name: "ok",
+			args: args{
+				statusCode: http.StatusOK,
+				msg:        "OKです",
+				links: []*game.Link{
+					newTestLink(1, "wiki", 1),
+					newTestLink(2, "site", 2),
+				},
+			},
+			want: LinksResponse{
+				Code:    http.StatusOK,
+				Message: "OKです",
+				Data: []Link{
+					{
+						ID:           1,
+						Title:        "wiki",
+						URL:          "http://example.com",
+						Description:  "説明",
+						DisplayOrder: 1,
+						Kind:         "other",
+					},
+					{
+						ID:           2,
+						Title:        "site",
+						URL:          "http://example.com",
+						Description:  "説明",
+						DisplayOrder: 2,
+						Kind:         "other",
+					},
+				},
+			},
+		},
+		{
+			name: "empty",
+			args: args{
+				statusCode: http.StatusOK,
+				msg:        "OKです",
+				links:      []*game.Link{},
+			},
+			want: LinksResponse{
+				Code:    http.StatusOK,
+				Message: "OKです",
+				Data:    []Link{},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, linksResponse(tt.args.statusCode, tt.args.msg, tt.args.links))
+		})
+	}
+}
@@ -23,6 +23,8 @@ func (h *platformHandler) HandlePlatform(w http.ResponseWriter, r *http.Request)
 		h.create(w, r)
 	case http.MethodPut:
 		h.update(w, r)
+	case http.MethodPatch:
+		h.patch(w, r)
 	case http.MethodDelete:
 		h.delete(w, r)
 	default:
@@ -43,6 +45,35 @@ func (h *platformHandler) HandlePlatformForMultiple(w http.ResponseWriter, r *ht
 	}
 }
 
+func (h *platformHandler) HandlePlatformTrashForMultiple(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+		h.trash(w, r)
+	default:
+		http.NotFound(w, r)
+	}
+}
+
+func (h *platformHandler) HandlePlatformTrash(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodPost:
+		h.restore(w, r)
+	case http.MethodDelete:
+		h.purge(w, r)
+	default:
+		http.NotFound(w, r)
+	}
+}
+
+func (h *platformHandler) HandlePlatformMerge(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodPost:
+		h.merge(w, r)
+	default:
+		http.NotFound(w, r)
+	}
+}
+
 func (h *platformHandler) create(w http.ResponseWriter, r *http.Request) {
 	platform, err := NewPlatformCreate(r)
 	if err != nil {
@@ -88,14 +119,14 @@ func (h *platformHandler) find(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	platforms, err := h.server.Find(findOption)
+	platforms, meta, err := h.server.Find(findOption)
 	if err != nil {
 		log.Println(err)
 		errors.WriteError(w, err)
 		return
 	}
 
-	WriteFindPlatform(w, platforms, findOption)
+	WriteFindPlatform(w, platforms, findOption, meta)
 }
 
 func (h *platformHandler) update(w http.ResponseWriter, r *http.Request) {
@@ -106,7 +137,25 @@ func (h *platformHandler) update(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	platform, err = h.server.Update(platform)
+	platform, err = h.server.Update(platform, NewPrecondition(r))
+	if err != nil {
+		log.Println(err)
+		errors.WriteError(w, err)
+		return
+	}
+
+	WriteUpdatePlatform(w, platform)
+}
+
+func (h *platformHandler) patch(w http.ResponseWriter, r *http.Request) {
+	platformID, patcher, err := NewPlatformPatch(r)
+	if err != nil {
+		log.Println(err)
+		errors.WriteError(w, err)
+		return
+	}
+
+	platform, err := h.server.Patch(platformID, patcher, NewPrecondition(r))
 	if err != nil {
 		log.Println(err)
 		errors.WriteError(w, err)
@@ -124,7 +173,7 @@ func (h *platformHandler) delete(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	err = h.server.Delete(platformID)
+	err = h.server.Delete(platformID, NewPrecondition(r))
 	if err != nil {
 		log.Println(err)
 		errors.WriteError(w, err)
@@ -133,3 +182,75 @@ func (h *platformHandler) delete(w http.ResponseWriter, r *http.Request) {
 
 	WriteDeletePlatform(w, platformID)
 }
+
+func (h *platformHandler) trash(w http.ResponseWriter, r *http.Request) {
+	findOption, err := NewPlatformFindOption(r)
+	if err != nil {
+		log.Println(err)
+		errors.WriteError(w, err)
+		return
+	}
+
+	platforms, meta, err := h.server.Trash(findOption)
+	if err != nil {
+		log.Println(err)
+		errors.WriteError(w, err)
+		return
+	}
+
+	WriteTrashPlatform(w, platforms, findOption, meta)
+}
+
+func (h *platformHandler) restore(w http.ResponseWriter, r *http.Request) {
+	platformID, err := NewPlatformID(r)
+	if err != nil {
+		log.Println(err)
+		errors.WriteError(w, err)
+		return
+	}
+
+	platform, err := h.server.Restore(platformID)
+	if err != nil {
+		log.Println(err)
+		errors.WriteError(w, err)
+		return
+	}
+
+	WriteRestorePlatform(w, platform)
+}
+
+func (h *platformHandler) purge(w http.ResponseWriter, r *http.Request) {
+	platformID, err := NewPlatformID(r)
+	if err != nil {
+		log.Println(err)
+		errors.WriteError(w, err)
+		return
+	}
+
+	err = h.server.Purge(platformID)
+	if err != nil {
+		log.Println(err)
+		errors.WriteError(w, err)
+		return
+	}
+
+	WritePurgePlatform(w, platformID)
+}
+
+func (h *platformHandler) merge(w http.ResponseWriter, r *http.Request) {
+	sourceID, targetID, err := NewPlatformMerge(r)
+	if err != nil {
+		log.Println(err)
+		errors.WriteError(w, err)
+		return
+	}
+
+	platform, err := h.server.Merge(sourceID, targetID)
+	if err != nil {
+		log.Println(err)
+		errors.WriteError(w, err)
+		return
+	}
+
+	WriteMergePlatform(w, platform)
+}
@@ -7,10 +7,12 @@ import (
 	"io"
 	"mysrtafes-backend/pkg/errors"
 	"mysrtafes-backend/pkg/game/platform"
+	"mysrtafes-backend/pkg/version"
 	"net/http"
 	"net/http/httptest"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/go-chi/chi/v5"
 	"github.com/stretchr/testify/assert"
@@ -19,9 +21,10 @@ import (
 type server struct {
 	Platform  *platform.Platform
 	Platforms []*platform.Platform
+	Meta      *platform.FindMeta
 	err       error
 	// flags
-	create, read, find, update, delete bool
+	create, read, find, update, patch, delete, trash, restore, purge, merge bool
 }
 
 func (s *server) Create(*platform.Platform) (*platform.Platform, error) {
@@ -36,25 +39,73 @@ func (s *server) Read(platform.ID) (*platform.Platform, error) {
 	}
 	return nil, fmt.Errorf("failed read")
 }
-func (s *server) Find(*platform.FindOption) ([]*platform.Platform, error) {
+func (s *server) Find(*platform.FindOption) ([]*platform.Platform, *platform.FindMeta, error) {
 	if s.find {
-		return s.Platforms, s.err
+		return s.Platforms, s.Meta, s.err
 	}
-	return nil, fmt.Errorf("failed find")
+	return nil, nil, fmt.Errorf("failed find")
 }
-func (s *server) Update(*platform.Platform) (*platform.Platform, error) {
+func (s *server) Update(_ *platform.Platform, precondition *version.Precondition) (*platform.Platform, error) {
 	if s.update {
+		if err := s.check(precondition); err != nil {
+			return nil, err
+		}
 		return s.Platform, s.err
 	}
 	return nil, fmt.Errorf("failed update")
 }
-func (s *server) Delete(platform.ID) error {
+func (s *server) Patch(_ platform.ID, _ platform.Patcher, precondition *version.Precondition) (*platform.Platform, error) {
+	if s.patch {
+		if err := s.check(precondition); err != nil {
+			return nil, err
+		}
+		return s.Platform, s.err
+	}
+	return nil, fmt.Errorf("failed patch")
+}
+func (s *server) Delete(_ platform.ID, precondition *version.Precondition) error {
 	if s.delete {
+		if err := s.check(precondition); err != nil {
+			return err
+		}
 		return s.err
 	}
 	return fmt.Errorf("failed delete")
 }
 
+// NOTE: 保存済みの版はs.Platformの更新日時とする
+func (s *server) check(precondition *version.Precondition) error {
+	if precondition == nil || s.Platform == nil {
+		return nil
+	}
+	return precondition.Check(s.Platform.UpdatedAt)
+}
+
+func (s *server) Trash(*platform.FindOption) ([]*platform.Platform, *platform.FindMeta, error) {
+	if s.trash {
+		return s.Platforms, s.Meta, s.err
+	}
+	return nil, nil, fmt.Errorf("failed trash")
+}
+func (s *server) Restore(platform.ID) (*platform.Platform, error) {
+	if s.restore {
+		return s.Platform, s.err
+	}
+	return nil, fmt.Errorf("failed restore")
+}
+func (s *server) Purge(platform.ID) error {
+	if s.purge {
+		return s.err
+	}
+	return fmt.Errorf("failed purge")
+}
+func (s *server) Merge(platform.ID, platform.ID) (*platform.Platform, error) {
+	if s.merge {
+		return s.Platform, s.err
+	}
+	return nil, fmt.Errorf("failed merge")
+}
+
 func TestNewPlatformHandler(t *testing.T) {
 	type args struct {
 		s platform.Server
@@ -209,6 +260,42 @@ func Test_platformHandler_HandlePlatform(t *testing.T) {
 				return string(str)
 			}(),
 		},
+		{
+			name: "Patch OK",
+			fields: fields{
+				server: &server{
+					Platform: &platform.Platform{
+						ID:          3,
+						Name:        "Patch OK",
+						Description: "Patch OKです",
+					},
+					patch: true,
+				},
+			},
+			args: args{
+				w:      httptest.NewRecorder(),
+				method: http.MethodPatch,
+				url:    "http://example.com/3",
+				body:   strings.NewReader(`{"name": "Patch OK"}`),
+				pathParam: map[string]string{
+					"platformID": "3",
+				},
+			},
+			wantStatusCode: http.StatusOK,
+			wantBody: func() string {
+				body := platformResponse(
+					http.StatusOK,
+					"success update platform",
+					&platform.Platform{
+						ID:          3,
+						Name:        "Patch OK",
+						Description: "Patch OKです",
+					},
+				)
+				str, _ := json.Marshal(body)
+				return string(str)
+			}(),
+		},
 		{
 			name: "Delete OK",
 			fields: fields{
@@ -251,7 +338,7 @@ func Test_platformHandler_HandlePlatform(t *testing.T) {
 			},
 			args: args{
 				w:      httptest.NewRecorder(),
-				method: http.MethodPatch,
+				method: http.MethodOptions,
 				url:    "http://example.com/4",
 				body:   strings.NewReader(`{"name": "Delete OK", "description": "Delete OKです"}`),
 				pathParam: map[string]string{
@@ -324,6 +411,7 @@ func Test_platformHandler_HandlePlatformForMultiple(t *testing.T) {
 							Description: "Find3 OKです",
 						},
 					},
+					Meta: &platform.FindMeta{TotalCount: 3},
 					find: true,
 				},
 			},
@@ -357,6 +445,7 @@ func Test_platformHandler_HandlePlatformForMultiple(t *testing.T) {
 						},
 					},
 					platform.NewFindOption(),
+					&platform.FindMeta{TotalCount: 3},
 				)
 				str, _ := json.Marshal(body)
 				return string(str)
@@ -707,6 +796,7 @@ func Test_platformHandler_find(t *testing.T) {
 							Description: "Find3 OKです",
 						},
 					},
+					Meta: &platform.FindMeta{TotalCount: 3},
 					find: true,
 				},
 			},
@@ -740,6 +830,7 @@ func Test_platformHandler_find(t *testing.T) {
 						},
 					},
 					platform.NewFindOption(),
+					&platform.FindMeta{TotalCount: 3},
 				)
 				str, _ := json.Marshal(body)
 				return string(str)
@@ -841,6 +932,7 @@ func Test_platformHandler_find(t *testing.T) {
 }
 
 func Test_platformHandler_update(t *testing.T) {
+	updatedAt := time.Date(2023, 4, 1, 12, 0, 0, 0, time.UTC)
 	type fields struct {
 		server platform.Server
 	}
@@ -850,6 +942,7 @@ func Test_platformHandler_update(t *testing.T) {
 		url       string
 		body      io.Reader
 		pathParam map[string]string
+		ifMatch   string
 	}
 	tests := []struct {
 		name           string
@@ -857,6 +950,7 @@ func Test_platformHandler_update(t *testing.T) {
 		args           args
 		wantStatusCode int
 		wantBody       string
+		wantETag       string
 	}{
 		{
 			name: "OK",
@@ -943,6 +1037,58 @@ func Test_platformHandler_update(t *testing.T) {
 			wantStatusCode: http.StatusUnsupportedMediaType,
 			wantBody:       "",
 		},
+		{
+			name: "If-Matchが一致",
+			fields: fields{
+				server: &server{
+					Platform: &platform.Platform{
+						ID:          3,
+						Name:        "OK",
+						Description: "OKです",
+						UpdatedAt:   updatedAt,
+					},
+					update: true,
+				},
+			},
+			args: args{
+				w:      httptest.NewRecorder(),
+				method: http.MethodPut,
+				url:    "http://example.com/3",
+				body:   strings.NewReader(`{"name": "OK", "description": "OKです"}`),
+				pathParam: map[string]string{
+					"platformID": "3",
+				},
+				ifMatch: version.New(updatedAt).ETag(),
+			},
+			wantStatusCode: http.StatusOK,
+			wantETag:       version.New(updatedAt).ETag(),
+		},
+		{
+			name: "If-Matchが不一致",
+			fields: fields{
+				server: &server{
+					Platform: &platform.Platform{
+						ID:          3,
+						Name:        "OK",
+						Description: "OKです",
+						UpdatedAt:   updatedAt,
+					},
+					update: true,
+				},
+			},
+			args: args{
+				w:      httptest.NewRecorder(),
+				method: http.MethodPut,
+				url:    "http://example.com/3",
+				body:   strings.NewReader(`{"name": "OK", "description": "OKです"}`),
+				pathParam: map[string]string{
+					"platformID": "3",
+				},
+				ifMatch: `"old"`,
+			},
+			wantStatusCode: http.StatusPreconditionFailed,
+			wantBody:       "",
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -954,11 +1100,17 @@ func Test_platformHandler_update(t *testing.T) {
 				ctx.URLParams.Add(key, val)
 			}
 			r := httptest.NewRequest(tt.args.method, tt.args.url, tt.args.body)
+			if tt.args.ifMatch != "" {
+				r.Header.Set("If-Match", tt.args.ifMatch)
+			}
 			r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, ctx))
 			h.update(tt.args.w, r)
 			if !assert.Equal(t, tt.wantStatusCode, tt.args.w.Code) {
 				return
 			}
+			if tt.wantETag != "" && !assert.Equal(t, tt.wantETag, tt.args.w.Header().Get("ETag")) {
+				return
+			}
 			// NOTE: BodyのStringは\nが入る仕様らしいので削除
 			if tt.wantBody != "" && !assert.Equal(t, tt.wantBody, strings.Replace(tt.args.w.Body.String(), "\n", "", -1)) {
 				return
@@ -968,6 +1120,7 @@ func Test_platformHandler_update(t *testing.T) {
 }
 
 func Test_platformHandler_delete(t *testing.T) {
+	updatedAt := time.Date(2023, 4, 1, 12, 0, 0, 0, time.UTC)
 	type fields struct {
 		server platform.Server
 	}
@@ -977,6 +1130,7 @@ func Test_platformHandler_delete(t *testing.T) {
 		url       string
 		body      io.Reader
 		pathParam map[string]string
+		ifMatch   string
 	}
 	tests := []struct {
 		name           string
@@ -1062,6 +1216,32 @@ func Test_platformHandler_delete(t *testing.T) {
 			wantStatusCode: http.StatusUnauthorized,
 			wantBody:       "",
 		},
+		{
+			name: "If-Matchが不一致",
+			fields: fields{
+				server: &server{
+					Platform: &platform.Platform{
+						ID:          4,
+						Name:        "OK",
+						Description: "OKです",
+						UpdatedAt:   updatedAt,
+					},
+					delete: true,
+				},
+			},
+			args: args{
+				w:      httptest.NewRecorder(),
+				method: http.MethodDelete,
+				url:    "http://example.com/4",
+				body:   strings.NewReader(`{"name": "OK", "description": "OKです"}`),
+				pathParam: map[string]string{
+					"platformID": "4",
+				},
+				ifMatch: `"old"`,
+			},
+			wantStatusCode: http.StatusPreconditionFailed,
+			wantBody:       "",
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -1073,6 +1253,9 @@ func Test_platformHandler_delete(t *testing.T) {
 				ctx.URLParams.Add(key, val)
 			}
 			r := httptest.NewRequest(tt.args.method, tt.args.url, tt.args.body)
+			if tt.args.ifMatch != "" {
+				r.Header.Set("If-Match", tt.args.ifMatch)
+			}
 			r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, ctx))
 			h.delete(tt.args.w, r)
 			if !assert.Equal(t, tt.wantStatusCode, tt.args.w.Code) {
@@ -1085,3 +1268,263 @@ func Test_platformHandler_delete(t *testing.T) {
 		})
 	}
 }
+
+func Test_platformHandler_HandleTrash(t *testing.T) {
+	deletedAt := time.Date(2023, 4, 1, 12, 0, 0, 0, time.UTC)
+	type fields struct {
+		server platform.Server
+	}
+	type args struct {
+		w         *httptest.ResponseRecorder
+		method    string
+		url       string
+		pathParam map[string]string
+		multiple  bool
+	}
+	tests := []struct {
+		name           string
+		fields         fields
+		args           args
+		wantStatusCode int
+		wantBody       string
+	}{
+		{
+			name: "Trash OK",
+			fields: fields{
+				server: &server{
+					Platforms: []*platform.Platform{
+						{
+							ID:          5,
+							Name:        "Trash OK",
+							Description: "Trash OKです",
+							DeletedAt:   deletedAt,
+						},
+					},
+					Meta:  &platform.FindMeta{TotalCount: 1},
+					trash: true,
+				},
+			},
+			args: args{
+				w:         httptest.NewRecorder(),
+				method:    http.MethodGet,
+				url:       "http://example.com/trash",
+				pathParam: map[string]string{},
+				multiple:  true,
+			},
+			wantStatusCode: http.StatusOK,
+			wantBody:       `{"code":200,"message":"success find trash platform","data":[{"id":5,"name":"Trash OK","description":"Trash OKです","created_at":"0001-01-01T00:00:00Z","updated_at":"0001-01-01T00:00:00Z","deleted_at":"2023-04-01T12:00:00Z"}],"meta":{"total_count":1,"has_next":false}}`,
+		},
+		{
+			name: "Restore OK",
+			fields: fields{
+				server: &server{
+					Platform: &platform.Platform{
+						ID:          5,
+						Name:        "Restore OK",
+						Description: "Restore OKです",
+					},
+					restore: true,
+				},
+			},
+			args: args{
+				w:      httptest.NewRecorder(),
+				method: http.MethodPost,
+				url:    "http://example.com/trash/5/restore",
+				pathParam: map[string]string{
+					"platformID": "5",
+				},
+			},
+			wantStatusCode: http.StatusOK,
+			wantBody:       `{"code":200,"message":"success restore platform","data":{"id":5,"name":"Restore OK","description":"Restore OKです","created_at":"0001-01-01T00:00:00Z","updated_at":"0001-01-01T00:00:00Z"}}`,
+		},
+		{
+			name: "Purge OK",
+			fields: fields{
+				server: &server{
+					purge: true,
+				},
+			},
+			args: args{
+				w:      httptest.NewRecorder(),
+				method: http.MethodDelete,
+				url:    "http://example.com/trash/5",
+				pathParam: map[string]string{
+					"platformID": "5",
+				},
+			},
+			wantStatusCode: http.StatusOK,
+			wantBody:       `{"code":200,"message":"success purge platform","purgeID":5}`,
+		},
+		{
+			name: "Purge NotFound",
+			fields: fields{
+				server: &server{
+					err:   errors.NewNotFound(errors.Layer_Model, nil, "error"),
+					purge: true,
+				},
+			},
+			args: args{
+				w:      httptest.NewRecorder(),
+				method: http.MethodDelete,
+				url:    "http://example.com/trash/5",
+				pathParam: map[string]string{
+					"platformID": "5",
+				},
+			},
+			wantStatusCode: http.StatusNotFound,
+		},
+		{
+			name: "Bad Method NG",
+			fields: fields{
+				server: &server{},
+			},
+			args: args{
+				w:      httptest.NewRecorder(),
+				method: http.MethodPut,
+				url:    "http://example.com/trash/5",
+				pathParam: map[string]string{
+					"platformID": "5",
+				},
+			},
+			wantStatusCode: http.StatusNotFound,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := &platformHandler{
+				server: tt.fields.server,
+			}
+			ctx := chi.NewRouteContext()
+			for key, val := range tt.args.pathParam {
+				ctx.URLParams.Add(key, val)
+			}
+			r := httptest.NewRequest(tt.args.method, tt.args.url, nil)
+			r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, ctx))
+			if tt.args.multiple {
+				h.HandlePlatformTrashForMultiple(tt.args.w, r)
+			} else {
+				h.HandlePlatformTrash(tt.args.w, r)
+			}
+			if !assert.Equal(t, tt.wantStatusCode, tt.args.w.Code) {
+				return
+			}
+			if tt.wantBody != "" {
+				assert.Equal(t, tt.wantBody, strings.Replace(tt.args.w.Body.String(), "\n", "", -1))
+			}
+		})
+	}
+}
+
+func Test_platformHandler_HandlePlatformMerge(t *testing.T) {
+	type fields struct {
+		server platform.Server
+	}
+	type args struct {
+		w         *httptest.ResponseRecorder
+		method    string
+		body      io.Reader
+		pathParam map[string]string
+	}
+	tests := []struct {
+		name           string
+		fields         fields
+		args           args
+		wantStatusCode int
+		wantBody       string
+	}{
+		{
+			name: "Merge OK",
+			fields: fields{
+				server: &server{
+					Platform: &platform.Platform{
+						ID:          1,
+						Name:        "Merge OK",
+						Description: "Merge OKです",
+						Synonyms:    []platform.Name{"旧プラットフォーム"},
+					},
+					merge: true,
+				},
+			},
+			args: args{
+				w:      httptest.NewRecorder(),
+				method: http.MethodPost,
+				body:   strings.NewReader(`{"target_id": 1}`),
+				pathParam: map[string]string{
+					"platformID": "2",
+				},
+			},
+			wantStatusCode: http.StatusOK,
+			wantBody:       `{"code":200,"message":"success merge platform","data":{"id":1,"name":"Merge OK","description":"Merge OKです","created_at":"0001-01-01T00:00:00Z","updated_at":"0001-01-01T00:00:00Z","synonyms":["旧プラットフォーム"]}}`,
+		},
+		{
+			name: "Merge Bad Request",
+			fields: fields{
+				server: &server{
+					merge: true,
+				},
+			},
+			args: args{
+				w:      httptest.NewRecorder(),
+				method: http.MethodPost,
+				body:   strings.NewReader(`{"target_id": "a"}`),
+				pathParam: map[string]string{
+					"platformID": "2",
+				},
+			},
+			wantStatusCode: http.StatusBadRequest,
+		},
+		{
+			name: "Merge NotFound",
+			fields: fields{
+				server: &server{
+					err:   errors.NewNotFound(errors.Layer_Model, nil, "error"),
+					merge: true,
+				},
+			},
+			args: args{
+				w:      httptest.NewRecorder(),
+				method: http.MethodPost,
+				body:   strings.NewReader(`{"target_id": 1}`),
+				pathParam: map[string]string{
+					"platformID": "2",
+				},
+			},
+			wantStatusCode: http.StatusNotFound,
+		},
+		{
+			name: "Bad Method NG",
+			fields: fields{
+				server: &server{},
+			},
+			args: args{
+				w:      httptest.NewRecorder(),
+				method: http.MethodGet,
+				body:   nil,
+				pathParam: map[string]string{
+					"platformID": "2",
+				},
+			},
+			wantStatusCode: http.StatusNotFound,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := &platformHandler{
+				server: tt.fields.server,
+			}
+			ctx := chi.NewRouteContext()
+			for key, val := range tt.args.pathParam {
+				ctx.URLParams.Add(key, val)
+			}
+			r := httptest.NewRequest(tt.args.method, "http://example.com/2/merge", tt.args.body)
+			r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, ctx))
+			h.HandlePlatformMerge(tt.args.w, r)
+			if !assert.Equal(t, tt.wantStatusCode, tt.args.w.Code) {
+				return
+			}
+			if tt.wantBody != "" {
+				assert.Equal(t, tt.wantBody, strings.Replace(tt.args.w.Body.String(), "\n", "", -1))
+			}
+		})
+	}
+}
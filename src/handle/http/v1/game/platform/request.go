@@ -2,23 +2,65 @@ package platform
 
 import (
 	"encoding/json"
+	"mysrtafes-backend/handle/http/v1/cursor"
+	"mysrtafes-backend/handle/http/v1/patch"
 	"mysrtafes-backend/pkg/errors"
 	"mysrtafes-backend/pkg/game/platform"
+	"mysrtafes-backend/pkg/version"
 	"net/http"
 	"net/url"
 	"strconv"
+	"strings"
 
 	"github.com/go-chi/chi/v5"
 )
 
+// カーソルの署名に使うリソース名
+const cursorResource = "platforms"
+
+// 登録・更新リクエスト
+type platformBody struct {
+	Name         platform.Name         `json:"name"`
+	Description  platform.Description  `json:"description"`
+	Manufacturer platform.Manufacturer `json:"manufacturer"`
+	Kind         string                `json:"kind"`
+	// NOTE: 0・未指定のときは未登録
+	Generation  platform.Generation  `json:"generation,omitempty"`
+	ReleaseYear platform.ReleaseYear `json:"release_year,omitempty"`
+}
+
+// 登録・更新リクエストからプラットフォームを生成
+func (body *platformBody) newPlatform(platformID platform.ID) (*platform.Platform, error) {
+	kind, err := platform.NewKind(body.Kind)
+	if err != nil {
+		return nil, errors.NewInvalidRequest(
+			errors.Layer_Request,
+			errors.NewInformation(
+				errors.ID_InvalidParams,
+				err.Error(),
+				[]errors.InvalidParams{
+					errors.NewInvalidParams("kind", body.Kind),
+				},
+			),
+			"kind convert error",
+		)
+	}
+	return platform.NewWithID(
+		platformID,
+		body.Name,
+		body.Description,
+	).
+		SetManufacturer(body.Manufacturer).
+		SetKind(kind).
+		SetGeneration(body.Generation).
+		SetReleaseYear(body.ReleaseYear), nil
+}
+
 // Post: NewPlatformEntity for request
 func NewPlatformCreate(r *http.Request) (*platform.Platform, error) {
 	defer r.Body.Close()
 
-	body := struct {
-		Name        platform.Name        `json:"name"`
-		Description platform.Description `json:"description"`
-	}{}
+	body := platformBody{}
 	err := json.NewDecoder(r.Body).Decode(&body)
 	if err != nil {
 		return nil, errors.NewInvalidRequest(
@@ -32,10 +74,7 @@ func NewPlatformCreate(r *http.Request) (*platform.Platform, error) {
 		)
 	}
 
-	return platform.New(
-		body.Name,
-		body.Description,
-	), nil
+	return body.newPlatform(0)
 }
 
 // Delete: NewPlatformID for request
@@ -59,6 +98,34 @@ func NewPlatformID(r *http.Request) (platform.ID, error) {
 	return platform.ID(platformID), nil
 }
 
+// Merge: NewPlatformMerge for request
+// NOTE: URLのプラットフォームを統合元、target_idのプラットフォームを統合先とする
+func NewPlatformMerge(r *http.Request) (platform.ID, platform.ID, error) {
+	defer r.Body.Close()
+
+	sourceID, err := NewPlatformID(r)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	body := struct {
+		TargetID platform.ID `json:"target_id"`
+	}{}
+	err = json.NewDecoder(r.Body).Decode(&body)
+	if err != nil {
+		return 0, 0, errors.NewInvalidRequest(
+			errors.Layer_Request,
+			errors.NewInformation(
+				errors.ID_JsonDecodeError,
+				err.Error(),
+				nil,
+			),
+			"json decode error. bad format request.",
+		)
+	}
+	return sourceID, body.TargetID, nil
+}
+
 // Find: NewFindOptionEntity for request
 func NewPlatformFindOption(r *http.Request) (*platform.FindOption, error) {
 	// デフォルト値生成
@@ -75,6 +142,16 @@ func NewPlatformFindOption(r *http.Request) (*platform.FindOption, error) {
 		return nil, err
 	}
 
+	// ゲームの数の設定
+	if err := setGameCount(findOption, q); err != nil {
+		return nil, err
+	}
+
+	// 絞り込み設定
+	if err := setFilter(findOption, q); err != nil {
+		return nil, err
+	}
+
 	return findOption, nil
 }
 
@@ -87,10 +164,7 @@ func NewPlatformUpdate(r *http.Request) (*platform.Platform, error) {
 		return nil, err
 	}
 
-	body := struct {
-		Name        platform.Name        `json:"name"`
-		Description platform.Description `json:"description"`
-	}{}
+	body := platformBody{}
 	err = json.NewDecoder(r.Body).Decode(&body)
 	if err != nil {
 		return nil, errors.NewInvalidRequest(
@@ -104,19 +178,45 @@ func NewPlatformUpdate(r *http.Request) (*platform.Platform, error) {
 		)
 	}
 
-	return platform.NewWithID(
-		platformID,
-		body.Name,
-		body.Description,
-	), nil
+	return body.newPlatform(platformID)
+}
+
+// Patch: NewPlatformPatcher for request
+// NOTE: 保存済みのプラットフォームを登録・更新リクエストの形式にしてからJSON Merge Patchを適用する
+func NewPlatformPatch(r *http.Request) (platform.ID, platform.Patcher, error) {
+	platformID, err := NewPlatformID(r)
+	if err != nil {
+		return 0, nil, err
+	}
+
+	mergePatch, err := patch.NewMergePatch(r)
+	if err != nil {
+		return 0, nil, err
+	}
+
+	return platformID, func(current *platform.Platform) (*platform.Platform, error) {
+		body := platformBody{}
+		err := patch.Apply(
+			platformBody{
+				Name:         current.Name,
+				Description:  current.Description,
+				Manufacturer: current.Manufacturer,
+				Kind:         current.Kind.String(),
+				Generation:   current.Generation,
+				ReleaseYear:  current.ReleaseYear,
+			},
+			mergePatch,
+			&body,
+		)
+		if err != nil {
+			return nil, err
+		}
+		return body.newPlatform(current.ID)
+	}, nil
 }
 
 // Find: set order param
 func setOrder(findOption *platform.FindOption, q url.Values) error {
-	// シーク法のときは並び替えするとおかしくなるので禁止
-	if findOption.SearchMode == platform.SearchMode_Seek {
-		return nil
-	}
 	// Descのチェック
 	desc := false
 	var err error
@@ -147,6 +247,18 @@ func setOrder(findOption *platform.FindOption, q url.Values) error {
 	switch q.Get("order") {
 	case "name":
 		findOption.SetOrder(platform.Order_Name, desc)
+	case "updated_at":
+		findOption.SetOrder(platform.Order_UpdatedAt, desc)
+	case "game_count":
+		findOption.SetOrder(platform.Order_GameCount, desc)
+	case "manufacturer":
+		findOption.SetOrder(platform.Order_Manufacturer, desc)
+	case "kind":
+		findOption.SetOrder(platform.Order_Kind, desc)
+	case "generation":
+		findOption.SetOrder(platform.Order_Generation, desc)
+	case "release_year":
+		findOption.SetOrder(platform.Order_ReleaseYear, desc)
 	case "id":
 		findOption.SetOrder(platform.Order_ID, desc)
 	default:
@@ -165,6 +277,148 @@ func setOrder(findOption *platform.FindOption, q url.Values) error {
 	return nil
 }
 
+// Find: set game count param
+// NOTE: with_game_countでゲームの数を返却し、unusedで未使用のプラットフォームに絞り込む
+func setGameCount(findOption *platform.FindOption, q url.Values) error {
+	for _, key := range []string{"with_game_count", "unused"} {
+		if !q.Has(key) {
+			continue
+		}
+		b, err := strconv.ParseBool(q.Get(key))
+		if err != nil {
+			return errors.NewInvalidRequest(
+				errors.Layer_Request,
+				errors.NewInformation(
+					errors.ID_InvalidParams,
+					err.Error(),
+					[]errors.InvalidParams{
+						errors.NewInvalidParams(key, q.Get(key)),
+					},
+				),
+				key+" convert error",
+			)
+		}
+		switch key {
+		case "with_game_count":
+			findOption.SetWithGameCount(b)
+		case "unused":
+			findOption.SetUnused(b)
+		}
+	}
+	return nil
+}
+
+// Find: set filter param
+// NOTE: manufacturers・kinds・generationsはカンマ区切りで複数指定でき、いずれかに一致するものに絞り込む
+func setFilter(findOption *platform.FindOption, q url.Values) error {
+	if q.Has("manufacturers") {
+		manufacturers := []platform.Manufacturer{}
+		for _, manufacturer := range splitValues(q["manufacturers"]) {
+			manufacturers = append(manufacturers, platform.Manufacturer(manufacturer))
+		}
+		findOption.SetManufacturers(manufacturers)
+	}
+
+	if q.Has("kinds") {
+		kinds := []platform.Kind{}
+		for _, kindStr := range splitValues(q["kinds"]) {
+			kind, err := platform.NewKind(kindStr)
+			if err != nil {
+				return errors.NewInvalidRequest(
+					errors.Layer_Request,
+					errors.NewInformation(
+						errors.ID_InvalidParams,
+						err.Error(),
+						[]errors.InvalidParams{
+							errors.NewInvalidParams("kinds", q["kinds"]),
+						},
+					),
+					"kinds convert error",
+				)
+			}
+			kinds = append(kinds, kind)
+		}
+		findOption.SetKinds(kinds)
+	}
+
+	if q.Has("generations") {
+		generations := []platform.Generation{}
+		for _, generationStr := range splitValues(q["generations"]) {
+			generation, err := strconv.ParseUint(generationStr, 10, 8)
+			if err != nil {
+				return errors.NewInvalidRequest(
+					errors.Layer_Request,
+					errors.NewInformation(
+						errors.ID_InvalidParams,
+						err.Error(),
+						[]errors.InvalidParams{
+							errors.NewInvalidParams("generations", q["generations"]),
+						},
+					),
+					"generations convert error",
+				)
+			}
+			generations = append(generations, platform.Generation(generation))
+		}
+		findOption.SetGenerations(generations)
+	}
+
+	// 発売年の範囲
+	// NOTE: 未指定の側は制限なし
+	if !q.Has("release_year_from") && !q.Has("release_year_to") {
+		return nil
+	}
+	from, err := releaseYear(q, "release_year_from")
+	if err != nil {
+		return err
+	}
+	to, err := releaseYear(q, "release_year_to")
+	if err != nil {
+		return err
+	}
+	findOption.SetReleaseYearRange(from, to)
+	return nil
+}
+
+// カンマ区切りの値の分割
+// NOTE: 前後の空白を除き、空の値は無視する
+func splitValues(values []string) []string {
+	splitted := []string{}
+	for _, value := range values {
+		for _, v := range strings.Split(value, ",") {
+			v = strings.TrimSpace(v)
+			if v == "" {
+				continue
+			}
+			splitted = append(splitted, v)
+		}
+	}
+	return splitted
+}
+
+// 発売年の変換
+// NOTE: 未指定のときは0(制限なし)
+func releaseYear(q url.Values, key string) (platform.ReleaseYear, error) {
+	if !q.Has(key) {
+		return 0, nil
+	}
+	year, err := strconv.ParseUint(q.Get(key), 10, 16)
+	if err != nil {
+		return 0, errors.NewInvalidRequest(
+			errors.Layer_Request,
+			errors.NewInformation(
+				errors.ID_InvalidParams,
+				err.Error(),
+				[]errors.InvalidParams{
+					errors.NewInvalidParams(key, q.Get(key)),
+				},
+			),
+			key+" convert error",
+		)
+	}
+	return platform.ReleaseYear(year), nil
+}
+
 // Find: set search mode param
 func setSearchMode(findOption *platform.FindOption, q url.Values) error {
 	// モードがないときは何もせず終了
@@ -212,6 +466,14 @@ func setSearchMode(findOption *platform.FindOption, q url.Values) error {
 			}
 		}
 		findOption.SetSeek(platform.LastID(lastID), platform.Count(count))
+		// NOTE: 続きの位置はカーソルで指定する。last_idはIDの並び順でのみ使える
+		if q.Has("cursor") {
+			c := &platform.Cursor{}
+			if err := cursor.Decode(cursorResource, q.Get("cursor"), c); err != nil {
+				return err
+			}
+			findOption.SetCursor(c)
+		}
 	case "page":
 		// ページネーション法
 		var limit, offset int = 30, 0
@@ -266,3 +528,9 @@ func setSearchMode(findOption *platform.FindOption, q url.Values) error {
 	}
 	return nil
 }
+
+// Put, Patch, Delete: NewPrecondition for request
+// NOTE: If-Match未指定のときはnilになり、無条件で更新する
+func NewPrecondition(r *http.Request) *version.Precondition {
+	return version.NewPrecondition(r.Header.Get("If-Match"))
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"io"
 	"mysrtafes-backend/pkg/game/platform"
+	"mysrtafes-backend/pkg/version"
 	"net/http"
 	"net/http/httptest"
 	"net/url"
@@ -39,6 +40,32 @@ func TestNewPlatformCreate(t *testing.T) {
 			},
 			wantErr: false,
 		},
+		{
+			name: "OK(with spec)",
+			args: args{
+				method: http.MethodPost,
+				url:    "http://example.com",
+				body:   strings.NewReader(`{"name": "platform", "description": "desc", "manufacturer": "任天堂", "kind": "handheld", "generation": 4, "release_year": 1989}`),
+			},
+			want: &platform.Platform{
+				Name:         "platform",
+				Description:  "desc",
+				Manufacturer: "任天堂",
+				Kind:         platform.Kind_Handheld,
+				Generation:   4,
+				ReleaseYear:  1989,
+			},
+			wantErr: false,
+		},
+		{
+			name: "bad kind err",
+			args: args{
+				method: http.MethodPost,
+				url:    "http://example.com",
+				body:   strings.NewReader(`{"name": "platform", "description": "desc", "kind": "console"}`),
+			},
+			wantErr: true,
+		},
 		{
 			name: "decode err",
 			args: args{
@@ -136,6 +163,74 @@ func TestNewPlatformID(t *testing.T) {
 	}
 }
 
+func TestNewPlatformMerge(t *testing.T) {
+	type args struct {
+		body      io.Reader
+		pathParam map[string]string
+	}
+	tests := []struct {
+		name    string
+		args    args
+		want    platform.ID
+		want1   platform.ID
+		wantErr bool
+	}{
+		{
+			name: "OK",
+			args: args{
+				body: strings.NewReader(`{"target_id": 1}`),
+				pathParam: map[string]string{
+					"platformID": "2",
+				},
+			},
+			want:    2,
+			want1:   1,
+			wantErr: false,
+		},
+		{
+			name: "bad platformID error",
+			args: args{
+				body: strings.NewReader(`{"target_id": 1}`),
+				pathParam: map[string]string{
+					"platformID": "a",
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "decode error",
+			args: args{
+				body: strings.NewReader(`{"target_id": "a"}`),
+				pathParam: map[string]string{
+					"platformID": "2",
+				},
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := chi.NewRouteContext()
+			for key, val := range tt.args.pathParam {
+				ctx.URLParams.Add(key, val)
+			}
+			r := httptest.NewRequest(http.MethodPost, "http://example.com", tt.args.body)
+			r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, ctx))
+			got, got1, err := NewPlatformMerge(r)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("NewPlatformMerge() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("NewPlatformMerge() got = %v, want %v", got, tt.want)
+			}
+			if !reflect.DeepEqual(got1, tt.want1) {
+				t.Errorf("NewPlatformMerge() got1 = %v, want %v", got1, tt.want1)
+			}
+		})
+	}
+}
+
 func TestNewPlatformFindOption(t *testing.T) {
 	type args struct {
 		method string
@@ -299,6 +394,163 @@ func TestNewPlatformUpdate(t *testing.T) {
 	}
 }
 
+func TestNewPlatformPatch(t *testing.T) {
+	type args struct {
+		contentType string
+		body        io.Reader
+		pathParam   map[string]string
+		current     *platform.Platform
+	}
+	tests := []struct {
+		name         string
+		args         args
+		wantID       platform.ID
+		want         *platform.Platform
+		wantErr      bool
+		wantPatchErr bool
+	}{
+		{
+			name: "OK",
+			args: args{
+				contentType: "application/merge-patch+json",
+				body:        strings.NewReader(`{"description": "patched"}`),
+				pathParam: map[string]string{
+					"platformID": "1",
+				},
+				current: &platform.Platform{
+					ID:          1,
+					Name:        "platform",
+					Description: "desc",
+				},
+			},
+			wantID: 1,
+			want: &platform.Platform{
+				ID:          1,
+				Name:        "platform",
+				Description: "patched",
+			},
+		},
+		{
+			name: "OK(spec)",
+			args: args{
+				contentType: "application/merge-patch+json",
+				body:        strings.NewReader(`{"kind": "home", "release_year": null}`),
+				pathParam: map[string]string{
+					"platformID": "1",
+				},
+				current: &platform.Platform{
+					ID:           1,
+					Name:         "platform",
+					Description:  "desc",
+					Manufacturer: "セガ",
+					Kind:         platform.Kind_Handheld,
+					Generation:   5,
+					ReleaseYear:  1994,
+				},
+			},
+			wantID: 1,
+			want: &platform.Platform{
+				ID:           1,
+				Name:         "platform",
+				Description:  "desc",
+				Manufacturer: "セガ",
+				Kind:         platform.Kind_Home,
+				Generation:   5,
+			},
+		},
+		{
+			name: "OK(null is delete)",
+			args: args{
+				contentType: "application/json",
+				body:        strings.NewReader(`{"description": null}`),
+				pathParam: map[string]string{
+					"platformID": "1",
+				},
+				current: &platform.Platform{
+					ID:          1,
+					Name:        "platform",
+					Description: "desc",
+				},
+			},
+			wantID: 1,
+			want: &platform.Platform{
+				ID:          1,
+				Name:        "platform",
+				Description: "",
+			},
+		},
+		{
+			name: "bad id err",
+			args: args{
+				contentType: "application/merge-patch+json",
+				body:        strings.NewReader(`{"description": "patched"}`),
+				pathParam: map[string]string{
+					"platformID": "a",
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "content type err",
+			args: args{
+				contentType: "text/plain",
+				body:        strings.NewReader(`{"description": "patched"}`),
+				pathParam: map[string]string{
+					"platformID": "1",
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "type mismatch err",
+			args: args{
+				contentType: "application/merge-patch+json",
+				body:        strings.NewReader(`{"name": 1}`),
+				pathParam: map[string]string{
+					"platformID": "1",
+				},
+				current: &platform.Platform{
+					ID:          1,
+					Name:        "platform",
+					Description: "desc",
+				},
+			},
+			wantID:       1,
+			wantPatchErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := chi.NewRouteContext()
+			for key, val := range tt.args.pathParam {
+				ctx.URLParams.Add(key, val)
+			}
+			r := httptest.NewRequest(http.MethodPatch, "http://example.com", tt.args.body)
+			r.Header.Set("Content-Type", tt.args.contentType)
+			r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, ctx))
+			gotID, patcher, err := NewPlatformPatch(r)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("NewPlatformPatch() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if tt.wantErr {
+				return
+			}
+			if gotID != tt.wantID {
+				t.Errorf("NewPlatformPatch() id = %v, want %v", gotID, tt.wantID)
+			}
+			got, err := patcher(tt.args.current)
+			if (err != nil) != tt.wantPatchErr {
+				t.Errorf("platform.Patcher() error = %v, wantPatchErr %v", err, tt.wantPatchErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("platform.Patcher() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
 func Test_setOrder(t *testing.T) {
 	type args struct {
 		findOption *platform.FindOption
@@ -545,7 +797,7 @@ func Test_setOrder(t *testing.T) {
 			wantErr: false,
 		},
 		{
-			name: "when seek mode, default option",
+			name: "when seek mode, order is available",
 			args: args{
 				findOption: &platform.FindOption{
 					SearchMode: platform.SearchMode_Seek,
@@ -578,10 +830,67 @@ func Test_setOrder(t *testing.T) {
 					Offset: 0,
 				},
 				OrderOption: platform.OrderOption{
-					Order: platform.Order_ID,
-					Desc:  false,
+					Order: platform.Order_Name,
+					Desc:  true,
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "ok desc true, game_count order",
+			args: args{
+				findOption: platform.NewFindOption(),
+				q: url.Values{
+					"desc":  []string{"true"},
+					"order": []string{"game_count"},
 				},
 			},
+			want:    platform.NewFindOption().SetOrder(platform.Order_GameCount, true),
+			wantErr: false,
+		},
+		{
+			name: "ok manufacturer order",
+			args: args{
+				findOption: platform.NewFindOption(),
+				q: url.Values{
+					"order": []string{"manufacturer"},
+				},
+			},
+			want:    platform.NewFindOption().SetOrder(platform.Order_Manufacturer, false),
+			wantErr: false,
+		},
+		{
+			name: "ok kind order",
+			args: args{
+				findOption: platform.NewFindOption(),
+				q: url.Values{
+					"order": []string{"kind"},
+				},
+			},
+			want:    platform.NewFindOption().SetOrder(platform.Order_Kind, false),
+			wantErr: false,
+		},
+		{
+			name: "ok desc true, generation order",
+			args: args{
+				findOption: platform.NewFindOption(),
+				q: url.Values{
+					"desc":  []string{"true"},
+					"order": []string{"generation"},
+				},
+			},
+			want:    platform.NewFindOption().SetOrder(platform.Order_Generation, true),
+			wantErr: false,
+		},
+		{
+			name: "ok release_year order",
+			args: args{
+				findOption: platform.NewFindOption(),
+				q: url.Values{
+					"order": []string{"release_year"},
+				},
+			},
+			want:    platform.NewFindOption().SetOrder(platform.Order_ReleaseYear, false),
 			wantErr: false,
 		},
 		{
@@ -679,6 +988,148 @@ func Test_setOrder(t *testing.T) {
 	}
 }
 
+func Test_setGameCount(t *testing.T) {
+	tests := []struct {
+		name    string
+		q       url.Values
+		want    *platform.FindOption
+		wantErr bool
+	}{
+		{
+			name: "ok no set",
+			q:    url.Values{},
+			want: platform.NewFindOption(),
+		},
+		{
+			name: "ok with_game_count",
+			q: url.Values{
+				"with_game_count": []string{"true"},
+			},
+			want: platform.NewFindOption().SetWithGameCount(true),
+		},
+		{
+			name: "ok with_game_count and unused",
+			q: url.Values{
+				"with_game_count": []string{"1"},
+				"unused":          []string{"true"},
+			},
+			want: platform.NewFindOption().SetWithGameCount(true).SetUnused(true),
+		},
+		{
+			name: "bad with_game_count error",
+			q: url.Values{
+				"with_game_count": []string{"yes"},
+			},
+			wantErr: true,
+		},
+		{
+			name: "bad unused error",
+			q: url.Values{
+				"unused": []string{"no"},
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := platform.NewFindOption()
+			err := setGameCount(got, tt.q)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("setGameCount() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !tt.wantErr && !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("setGameCount() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_setFilter(t *testing.T) {
+	tests := []struct {
+		name    string
+		q       url.Values
+		want    *platform.FindOption
+		wantErr bool
+	}{
+		{
+			name: "ok no set",
+			q:    url.Values{},
+			want: platform.NewFindOption(),
+		},
+		{
+			name: "ok manufacturers",
+			q: url.Values{
+				"manufacturers": []string{"任天堂, セガ", "任天堂"},
+			},
+			want: platform.NewFindOption().SetManufacturers([]platform.Manufacturer{"任天堂", "セガ"}),
+		},
+		{
+			name: "ok kinds",
+			q: url.Values{
+				"kinds": []string{"home,handheld"},
+			},
+			want: platform.NewFindOption().SetKinds([]platform.Kind{platform.Kind_Home, platform.Kind_Handheld}),
+		},
+		{
+			name: "ok generations",
+			q: url.Values{
+				"generations": []string{"3,4,,5"},
+			},
+			want: platform.NewFindOption().SetGenerations([]platform.Generation{3, 4, 5}),
+		},
+		{
+			name: "ok release_year_from and release_year_to",
+			q: url.Values{
+				"release_year_from": []string{"1983"},
+				"release_year_to":   []string{"1994"},
+			},
+			want: platform.NewFindOption().SetReleaseYearRange(1983, 1994),
+		},
+		{
+			name: "ok release_year_to only",
+			q: url.Values{
+				"release_year_to": []string{"1994"},
+			},
+			want: platform.NewFindOption().SetReleaseYearRange(0, 1994),
+		},
+		{
+			name: "bad kinds error",
+			q: url.Values{
+				"kinds": []string{"home,console"},
+			},
+			wantErr: true,
+		},
+		{
+			name: "bad generations error",
+			q: url.Values{
+				"generations": []string{"3,a"},
+			},
+			wantErr: true,
+		},
+		{
+			name: "bad release_year_from error",
+			q: url.Values{
+				"release_year_from": []string{"-1"},
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := platform.NewFindOption()
+			err := setFilter(got, tt.q)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("setFilter() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !tt.wantErr && !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("setFilter() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
 func Test_setSearchMode(t *testing.T) {
 	type args struct {
 		findOption *platform.FindOption
@@ -1261,3 +1712,35 @@ func Test_setSearchMode(t *testing.T) {
 		})
 	}
 }
+
+func TestNewPrecondition(t *testing.T) {
+	tests := []struct {
+		name    string
+		ifMatch string
+		want    *version.Precondition
+	}{
+		{
+			name:    "OK",
+			ifMatch: `"abc"`,
+			want: &version.Precondition{
+				Versions: []version.Version{"abc"},
+			},
+		},
+		{
+			name:    "未指定はnil",
+			ifMatch: "",
+			want:    nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := httptest.NewRequest(http.MethodPut, "http://example.com/1", nil)
+			if tt.ifMatch != "" {
+				r.Header.Set("If-Match", tt.ifMatch)
+			}
+			if got := NewPrecondition(r); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("NewPrecondition() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -2,7 +2,10 @@ package platform
 
 import (
 	"encoding/json"
+	"mysrtafes-backend/handle/http/v1/cache"
+	"mysrtafes-backend/handle/http/v1/cursor"
 	"mysrtafes-backend/pkg/game/platform"
+	"mysrtafes-backend/pkg/version"
 	"net/http"
 	"time"
 )
@@ -11,8 +14,18 @@ type Platform struct {
 	ID          platform.ID          `json:"id"`
 	Name        platform.Name        `json:"name"`
 	Description platform.Description `json:"description"`
-	CreatedAt   time.Time            `json:"created_at"`
-	UpdatedAt   time.Time            `json:"updated_at"`
+	// NOTE: メーカー・種類・世代・発売年は未登録のときは返却しない
+	Manufacturer platform.Manufacturer `json:"manufacturer,omitempty"`
+	Kind         string                `json:"kind,omitempty"`
+	Generation   platform.Generation   `json:"generation,omitempty"`
+	ReleaseYear  platform.ReleaseYear  `json:"release_year,omitempty"`
+	CreatedAt    time.Time             `json:"created_at"`
+	UpdatedAt    time.Time             `json:"updated_at"`
+	DeletedAt    *time.Time            `json:"deleted_at,omitempty"`
+	// NOTE: with_game_count指定時のみ返却する
+	GameCount *platform.GameCount `json:"game_count,omitempty"`
+	// NOTE: 統合したプラットフォームがないときは返却しない
+	Synonyms []platform.Name `json:"synonyms,omitempty"`
 }
 
 type PlatformResponse struct {
@@ -25,6 +38,7 @@ type PlatformsResponse struct {
 	Code    int        `json:"code"`
 	Message string     `json:"message"`
 	Data    []Platform `json:"data"`
+	Meta    *Meta      `json:"meta"`
 }
 
 type Page struct {
@@ -37,6 +51,7 @@ type PlatformsPageResponse struct {
 	Message string     `json:"message"`
 	Data    []Platform `json:"data"`
 	Page    *Page      `json:"page"`
+	Meta    *Meta      `json:"meta"`
 }
 
 type Next struct {
@@ -49,6 +64,17 @@ type PlatformsNextResponse struct {
 	Message string     `json:"message"`
 	Data    []Platform `json:"data"`
 	Next    *Next      `json:"next"`
+	Meta    *Meta      `json:"meta"`
+}
+
+// 検索結果のメタ情報
+// NOTE: next_last_id・next_cursorはseek、next_offsetはpaginationで次ページがあるときのみ返却する
+type Meta struct {
+	TotalCount platform.TotalCount `json:"total_count"`
+	HasNext    bool                `json:"has_next"`
+	NextLastID *platform.LastID    `json:"next_last_id,omitempty"`
+	NextOffset *platform.Offset    `json:"next_offset,omitempty"`
+	NextCursor string              `json:"next_cursor,omitempty"`
 }
 
 // write create response for platform
@@ -63,6 +89,8 @@ func WriteCreatePlatform(w http.ResponseWriter, platform *platform.Platform) err
 func WriteReadPlatform(w http.ResponseWriter, platform *platform.Platform) error {
 	body := platformResponse(http.StatusOK, "success read platform", platform)
 	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("ETag", version.New(platform.UpdatedAt).ETag())
+	cache.SetLastModified(w, platform.UpdatedAt)
 	w.WriteHeader(http.StatusOK)
 	return json.NewEncoder(w).Encode(&body)
 }
@@ -71,6 +99,7 @@ func WriteReadPlatform(w http.ResponseWriter, platform *platform.Platform) error
 func WriteUpdatePlatform(w http.ResponseWriter, platform *platform.Platform) error {
 	body := platformResponse(http.StatusOK, "success update platform", platform)
 	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("ETag", version.New(platform.UpdatedAt).ETag())
 	w.WriteHeader(http.StatusOK)
 	return json.NewEncoder(w).Encode(&body)
 }
@@ -84,23 +113,63 @@ func WriteDeletePlatform(w http.ResponseWriter, platformID platform.ID) error {
 }
 
 // write find response for platform
-func WriteFindPlatform(w http.ResponseWriter, platforms []*platform.Platform, option *platform.FindOption) error {
-	body := platformsResponse(http.StatusOK, "success find platform", platforms, option)
+// NOTE: Last-Modifiedは設定せず、本文から作るETagで検証する
+func WriteFindPlatform(w http.ResponseWriter, platforms []*platform.Platform, option *platform.FindOption, meta *platform.FindMeta) error {
+	body := platformsResponse(http.StatusOK, "success find platform", platforms, option, meta)
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	return json.NewEncoder(w).Encode(&body)
+}
+
+// write trash response for platform
+func WriteTrashPlatform(w http.ResponseWriter, platforms []*platform.Platform, option *platform.FindOption, meta *platform.FindMeta) error {
+	body := platformsResponse(http.StatusOK, "success find trash platform", platforms, option, meta)
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	return json.NewEncoder(w).Encode(&body)
+}
+
+// write restore response for platform
+func WriteRestorePlatform(w http.ResponseWriter, platform *platform.Platform) error {
+	body := platformResponse(http.StatusOK, "success restore platform", platform)
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(http.StatusOK)
 	return json.NewEncoder(w).Encode(&body)
 }
 
+// write purge response for platform
+func WritePurgePlatform(w http.ResponseWriter, platformID platform.ID) error {
+	body := purgePlatformResponse(platformID)
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	return json.NewEncoder(w).Encode(&body)
+}
+
+// write merge response for platform
+func WriteMergePlatform(w http.ResponseWriter, platform *platform.Platform) error {
+	body := platformResponse(http.StatusOK, "success merge platform", platform)
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("ETag", version.New(platform.UpdatedAt).ETag())
+	w.WriteHeader(http.StatusOK)
+	return json.NewEncoder(w).Encode(&body)
+}
+
 func platformResponse(statusCode int, msg string, platform *platform.Platform) interface{} {
 	return PlatformResponse{
 		Code:    statusCode,
 		Message: msg,
 		Data: Platform{
-			ID:          platform.ID,
-			Name:        platform.Name,
-			Description: platform.Description,
-			CreatedAt:   platform.CreatedAt,
-			UpdatedAt:   platform.UpdatedAt,
+			ID:           platform.ID,
+			Name:         platform.Name,
+			Description:  platform.Description,
+			Manufacturer: platform.Manufacturer,
+			Kind:         platform.Kind.String(),
+			Generation:   platform.Generation,
+			ReleaseYear:  platform.ReleaseYear,
+			CreatedAt:    platform.CreatedAt,
+			UpdatedAt:    platform.UpdatedAt,
+			DeletedAt:    deletedAt(platform.DeletedAt),
+			Synonyms:     platform.Synonyms,
 		},
 	}
 }
@@ -117,31 +186,34 @@ func deletePlatformResponse(platformID platform.ID) interface{} {
 	}
 }
 
-func platformsResponse(statusCode int, msg string, platforms []*platform.Platform, option *platform.FindOption) interface{} {
+func platformsResponse(statusCode int, msg string, platforms []*platform.Platform, option *platform.FindOption, meta *platform.FindMeta) interface{} {
 	responses := make([]Platform, 0, len(platforms))
-	var lastID platform.ID
 	for _, platform := range platforms {
 		responses = append(
 			responses,
 			Platform{
-				ID:          platform.ID,
-				Name:        platform.Name,
-				Description: platform.Description,
-				CreatedAt:   platform.CreatedAt,
-				UpdatedAt:   platform.UpdatedAt,
+				ID:           platform.ID,
+				Name:         platform.Name,
+				Description:  platform.Description,
+				Manufacturer: platform.Manufacturer,
+				Kind:         platform.Kind.String(),
+				Generation:   platform.Generation,
+				ReleaseYear:  platform.ReleaseYear,
+				CreatedAt:    platform.CreatedAt,
+				UpdatedAt:    platform.UpdatedAt,
+				DeletedAt:    deletedAt(platform.DeletedAt),
+				GameCount:    gameCount(platform, option),
+				Synonyms:     platform.Synonyms,
 			},
 		)
-		if lastID < platform.ID {
-			lastID = platform.ID
-		}
 	}
 
 	switch option.SearchMode {
 	case platform.SearchMode_Seek:
 		var next *Next
-		if len(responses) == int(option.Seek.Count) {
+		if meta != nil && meta.HasNext {
 			next = &Next{
-				LastID: lastID,
+				LastID: meta.NextLastID,
 				Count:  option.Seek.Count,
 			}
 		}
@@ -151,13 +223,14 @@ func platformsResponse(statusCode int, msg string, platforms []*platform.Platfor
 			Message: msg,
 			Data:    responses,
 			Next:    next,
+			Meta:    newMeta(meta),
 		}
 	case platform.SearchMode_Pagination:
 		var page *Page
-		if len(responses) == int(option.Pagination.Limit) {
+		if meta != nil && meta.HasNext {
 			page = &Page{
 				Limit:  option.Pagination.Limit,
-				Offset: option.Pagination.Offset + len(responses),
+				Offset: meta.NextOffset,
 			}
 		}
 
@@ -166,12 +239,64 @@ func platformsResponse(statusCode int, msg string, platforms []*platform.Platfor
 			Message: msg,
 			Data:    responses,
 			Page:    page,
+			Meta:    newMeta(meta),
 		}
 	default:
 		return PlatformsResponse{
 			Code:    statusCode,
 			Message: msg,
 			Data:    responses,
+			Meta:    newMeta(meta),
 		}
 	}
 }
+
+func purgePlatformResponse(platformID platform.ID) interface{} {
+	return struct {
+		Code    int         `json:"code"`
+		Message string      `json:"message"`
+		Data    platform.ID `json:"purgeID"`
+	}{
+		Code:    http.StatusOK,
+		Message: "success purge platform",
+		Data:    platformID,
+	}
+}
+
+func newMeta(meta *platform.FindMeta) *Meta {
+	if meta == nil {
+		return nil
+	}
+	response := &Meta{
+		TotalCount: meta.TotalCount,
+		HasNext:    meta.HasNext,
+	}
+	if meta.HasNext && meta.NextLastID > 0 {
+		response.NextLastID = &meta.NextLastID
+	}
+	if meta.HasNext && meta.NextOffset > 0 {
+		response.NextOffset = &meta.NextOffset
+	}
+	if meta.HasNext && meta.NextCursor != nil {
+		response.NextCursor = cursor.Encode(cursorResource, meta.NextCursor)
+	}
+	return response
+}
+
+// ゴミ箱にないときは削除日時を返却しない
+func deletedAt(t time.Time) *time.Time {
+	if t.IsZero() {
+		return nil
+	}
+	return &t
+}
+
+// プラットフォームを使うゲームの数
+// NOTE: with_game_count指定時のみ設定し、それ以外はnilにして返却しない
+func gameCount(p *platform.Platform, option *platform.FindOption) *platform.GameCount {
+	if option == nil || !option.WithGameCount {
+		return nil
+	}
+	gameCount := p.GameCount
+	return &gameCount
+}
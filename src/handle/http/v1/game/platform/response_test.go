@@ -14,6 +14,7 @@ func Test_platformsResponse(t *testing.T) {
 		msg        string
 		platforms  []*platform.Platform
 		option     *platform.FindOption
+		meta       *platform.FindMeta
 	}
 	tests := []struct {
 		name string
@@ -43,6 +44,7 @@ func Test_platformsResponse(t *testing.T) {
 						Count:  100,
 					},
 				},
+				meta: &platform.FindMeta{TotalCount: 1001},
 			},
 			want: PlatformsPageResponse{
 				Code:    http.StatusOK,
@@ -55,6 +57,7 @@ func Test_platformsResponse(t *testing.T) {
 					},
 				},
 				Page: nil,
+				Meta: &Meta{TotalCount: 1001},
 			},
 		},
 		{
@@ -80,6 +83,7 @@ func Test_platformsResponse(t *testing.T) {
 						Count:  100,
 					},
 				},
+				meta: &platform.FindMeta{TotalCount: 1002, HasNext: true, NextOffset: 1001},
 			},
 			want: PlatformsPageResponse{
 				Code:    http.StatusOK,
@@ -95,6 +99,7 @@ func Test_platformsResponse(t *testing.T) {
 					Limit:  1,
 					Offset: 1001,
 				},
+				Meta: &Meta{TotalCount: 1002, HasNext: true, NextOffset: func() *platform.Offset { o := 1001; return &o }()},
 			},
 		},
 		{
@@ -120,6 +125,7 @@ func Test_platformsResponse(t *testing.T) {
 						Count:  2,
 					},
 				},
+				meta: &platform.FindMeta{TotalCount: 101},
 			},
 			want: PlatformsNextResponse{
 				Code:    http.StatusOK,
@@ -132,6 +138,7 @@ func Test_platformsResponse(t *testing.T) {
 					},
 				},
 				Next: nil,
+				Meta: &Meta{TotalCount: 101},
 			},
 		},
 		{
@@ -162,6 +169,7 @@ func Test_platformsResponse(t *testing.T) {
 						Count:  2,
 					},
 				},
+				meta: &platform.FindMeta{TotalCount: 400, HasNext: true, NextLastID: 301},
 			},
 			want: PlatformsNextResponse{
 				Code:    http.StatusOK,
@@ -182,6 +190,7 @@ func Test_platformsResponse(t *testing.T) {
 					LastID: 301,
 					Count:  2,
 				},
+				Meta: &Meta{TotalCount: 400, HasNext: true, NextLastID: func() *platform.LastID { id := platform.LastID(301); return &id }()},
 			},
 		},
 		{
@@ -207,6 +216,7 @@ func Test_platformsResponse(t *testing.T) {
 						Count:  100,
 					},
 				},
+				meta: &platform.FindMeta{TotalCount: 1},
 			},
 			want: PlatformsResponse{
 				Code:    http.StatusOK,
@@ -218,15 +228,47 @@ func Test_platformsResponse(t *testing.T) {
 						Description: "OKです",
 					},
 				},
+				Meta: &Meta{TotalCount: 1},
 			},
 		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			got := platformsResponse(tt.args.statusCode, tt.args.msg, tt.args.platforms, tt.args.option)
+			got := platformsResponse(tt.args.statusCode, tt.args.msg, tt.args.platforms, tt.args.option, tt.args.meta)
 			if !assert.Equal(t, tt.want, got) {
 				return
 			}
 		})
 	}
 }
+
+func Test_gameCount(t *testing.T) {
+	entity := &platform.Platform{ID: 1, GameCount: 3}
+	want := platform.GameCount(3)
+	assert.Equal(t, &want, gameCount(entity, platform.NewFindOption().SetWithGameCount(true)))
+	assert.Nil(t, gameCount(entity, platform.NewFindOption()))
+	assert.Nil(t, gameCount(entity, nil))
+}
+
+func Test_platformResponse(t *testing.T) {
+	got := platformResponse(http.StatusOK, "spec", &platform.Platform{
+		ID:           1,
+		Name:         "ゲームボーイ",
+		Manufacturer: "任天堂",
+		Kind:         platform.Kind_Handheld,
+		Generation:   4,
+		ReleaseYear:  1989,
+	})
+	assert.Equal(t, PlatformResponse{
+		Code:    http.StatusOK,
+		Message: "spec",
+		Data: Platform{
+			ID:           1,
+			Name:         "ゲームボーイ",
+			Manufacturer: "任天堂",
+			Kind:         "handheld",
+			Generation:   4,
+			ReleaseYear:  1989,
+		},
+	}, got)
+}
@@ -0,0 +1,110 @@
+package relation
+
+import (
+	"log"
+	"mysrtafes-backend/handle/http/v1/errors"
+	"mysrtafes-backend/pkg/game"
+	"net/http"
+)
+
+type relationHandler struct {
+	server game.RelationServer
+}
+
+func NewRelationHandler(s game.RelationServer) *relationHandler {
+	return &relationHandler{s}
+}
+
+func (h *relationHandler) HandleRelation(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+		h.read(w, r)
+	case http.MethodPost:
+		h.create(w, r)
+	case http.MethodDelete:
+		h.delete(w, r)
+	default:
+		http.NotFound(w, r)
+	}
+}
+
+func (h *relationHandler) HandleRelationForMultiple(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+		h.find(w, r)
+	default:
+		http.NotFound(w, r)
+	}
+}
+
+func (h *relationHandler) create(w http.ResponseWriter, r *http.Request) {
+	gameID, relation, err := NewRelationCreate(r)
+	if err != nil {
+		log.Println(err)
+		errors.WriteError(w, err)
+		return
+	}
+
+	relation, err = h.server.Create(gameID, relation)
+	if err != nil {
+		log.Println(err)
+		errors.WriteError(w, err)
+		return
+	}
+
+	WriteCreateRelation(w, relation)
+}
+
+func (h *relationHandler) read(w http.ResponseWriter, r *http.Request) {
+	gameID, relationID, err := NewRelationID(r)
+	if err != nil {
+		log.Println(err)
+		errors.WriteError(w, err)
+		return
+	}
+
+	relation, err := h.server.Read(gameID, relationID)
+	if err != nil {
+		log.Println(err)
+		errors.WriteError(w, err)
+		return
+	}
+
+	WriteReadRelation(w, relation)
+}
+
+func (h *relationHandler) find(w http.ResponseWriter, r *http.Request) {
+	gameID, err := NewGameID(r)
+	if err != nil {
+		log.Println(err)
+		errors.WriteError(w, err)
+		return
+	}
+
+	relations, err := h.server.Find(gameID)
+	if err != nil {
+		log.Println(err)
+		errors.WriteError(w, err)
+		return
+	}
+
+	WriteFindRelation(w, relations)
+}
+
+func (h *relationHandler) delete(w http.ResponseWriter, r *http.Request) {
+	gameID, relationID, err := NewRelationID(r)
+	if err != nil {
+		log.Println(err)
+		errors.WriteError(w, err)
+		return
+	}
+
+	err = h.server.Delete(gameID, relationID)
+	if err != nil {
+		log.Println(err)
+		errors.WriteError(w, err)
+		return
+	}
+
+	WriteDeleteRelation(w, relationID)
+}
@@ -0,0 +1,406 @@
+package relation
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"mysrtafes-backend/pkg/errors"
+	"mysrtafes-backend/pkg/game"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/assert"
+)
+
+type server struct {
+	Relation  *game.Relation
+	Relations []*game.Relation
+	err       error
+	// flags
+	create, read, find, delete bool
+}
+
+func (s *server) Create(game.ID, *game.Relation) (*game.Relation, error) {
+	if s.create {
+		return s.Relation, s.err
+	}
+	return nil, fmt.Errorf("failed create")
+}
+func (s *server) Read(game.ID, game.RelationID) (*game.Relation, error) {
+	if s.read {
+		return s.Relation, s.err
+	}
+	return nil, fmt.Errorf("failed read")
+}
+func (s *server) Find(game.ID) ([]*game.Relation, error) {
+	if s.find {
+		return s.Relations, s.err
+	}
+	return nil, fmt.Errorf("failed find")
+}
+func (s *server) Delete(game.ID, game.RelationID) error {
+	if s.delete {
+		return s.err
+	}
+	return fmt.Errorf("failed delete")
+}
+
+func newTestRelation(id game.RelationID, kind game.RelationKind, sourceID, targetID game.ID) *game.Relation {
+	return &game.Relation{
+		RelationID: id,
+		Kind:       kind,
+		Source:     game.RelatedGame{ID: sourceID, Name: "関連元"},
+		Target:     game.RelatedGame{ID: targetID, Name: "関連先"},
+	}
+}
+
+func TestNewRelationHandler(t *testing.T) {
+	type args struct {
+		s game.RelationServer
+	}
+	tests := []struct {
+		name string
+		args args
+		want *relationHandler
+	}{
+		{
+			name: "ok",
+			args: args{
+				s: &server{},
+			},
+			want: &relationHandler{
+				server: &server{},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, NewRelationHandler(tt.args.s), tt.want)
+		})
+	}
+}
+
+func Test_relationHandler_HandleRelation(t *testing.T) {
+	type fields struct {
+		server game.RelationServer
+	}
+	type args struct {
+		w         *httptest.ResponseRecorder
+		method    string
+		url       string
+		body      io.Reader
+		pathParam map[string]string
+	}
+	tests := []struct {
+		name           string
+		fields         fields
+		args           args
+		wantStatusCode int
+		wantBody       string
+	}{
+		{
+			name: "Get OK",
+			fields: fields{
+				server: &server{
+					Relation: newTestRelation(1, game.RelationKind_RemakeOf, 2, 1),
+					read:     true,
+				},
+			},
+			args: args{
+				w:      httptest.NewRecorder(),
+				method: http.MethodGet,
+				url:    "http://example.com/1",
+				body:   strings.NewReader(``),
+				pathParam: map[string]string{
+					"gameID":     "1",
+					"relationID": "1",
+				},
+			},
+			wantStatusCode: http.StatusOK,
+			wantBody: func() string {
+				body := relationResponse(http.StatusOK, "success read relation", newTestRelation(1, game.RelationKind_RemakeOf, 2, 1))
+				str, _ := json.Marshal(body)
+				return string(str)
+			}(),
+		},
+		{
+			name: "Post OK",
+			fields: fields{
+				server: &server{
+					Relation: newTestRelation(2, game.RelationKind_SequelOf, 1, 3),
+					create:   true,
+				},
+			},
+			args: args{
+				w:      httptest.NewRecorder(),
+				method: http.MethodPost,
+				url:    "http://example.com",
+				body:   strings.NewReader(`{"kind": "sequel_of", "target_id": 3}`),
+				pathParam: map[string]string{
+					"gameID": "1",
+				},
+			},
+			wantStatusCode: http.StatusCreated,
+			wantBody: func() string {
+				body := relationResponse(http.StatusCreated, "success create relation", newTestRelation(2, game.RelationKind_SequelOf, 1, 3))
+				str, _ := json.Marshal(body)
+				return string(str)
+			}(),
+		},
+		{
+			name: "Post Validate NG",
+			fields: fields{
+				server: &server{
+					err:    errors.NewInvalidValidate(errors.Layer_Model, nil, "cycle"),
+					create: true,
+				},
+			},
+			args: args{
+				w:      httptest.NewRecorder(),
+				method: http.MethodPost,
+				url:    "http://example.com",
+				body:   strings.NewReader(`{"kind": "sequel_of", "target_id": 3}`),
+				pathParam: map[string]string{
+					"gameID": "1",
+				},
+			},
+			wantStatusCode: http.StatusBadRequest,
+			wantBody:       "",
+		},
+		{
+			name: "Post Bad kind NG",
+			fields: fields{
+				server: &server{
+					create: true,
+				},
+			},
+			args: args{
+				w:      httptest.NewRecorder(),
+				method: http.MethodPost,
+				url:    "http://example.com",
+				body:   strings.NewReader(`{"kind": "prequel_of", "target_id": 3}`),
+				pathParam: map[string]string{
+					"gameID": "1",
+				},
+			},
+			wantStatusCode: http.StatusBadRequest,
+			wantBody:       "",
+		},
+		{
+			name: "Delete OK",
+			fields: fields{
+				server: &server{
+					delete: true,
+				},
+			},
+			args: args{
+				w:      httptest.NewRecorder(),
+				method: http.MethodDelete,
+				url:    "http://example.com/4",
+				body:   strings.NewReader(``),
+				pathParam: map[string]string{
+					"gameID":     "1",
+					"relationID": "4",
+				},
+			},
+			wantStatusCode: http.StatusOK,
+			wantBody: func() string {
+				body := deleteRelationResponse(4)
+				str, _ := json.Marshal(body)
+				return string(str)
+			}(),
+		},
+		{
+			name: "Delete NotFound NG",
+			fields: fields{
+				server: &server{
+					err:    errors.NewNotFound(errors.Layer_Model, nil, "not found"),
+					delete: true,
+				},
+			},
+			args: args{
+				w:      httptest.NewRecorder(),
+				method: http.MethodDelete,
+				url:    "http://example.com/4",
+				body:   strings.NewReader(``),
+				pathParam: map[string]string{
+					"gameID":     "1",
+					"relationID": "4",
+				},
+			},
+			wantStatusCode: http.StatusNotFound,
+			wantBody:       "",
+		},
+		{
+			name: "Bad relationID NG",
+			fields: fields{
+				server: &server{
+					read: true,
+				},
+			},
+			args: args{
+				w:      httptest.NewRecorder(),
+				method: http.MethodGet,
+				url:    "http://example.com/a",
+				body:   strings.NewReader(``),
+				pathParam: map[string]string{
+					"gameID":     "1",
+					"relationID": "a",
+				},
+			},
+			wantStatusCode: http.StatusBadRequest,
+			wantBody:       "",
+		},
+		{
+			name: "Bad Method NG",
+			fields: fields{
+				server: &server{},
+			},
+			args: args{
+				w:      httptest.NewRecorder(),
+				method: http.MethodPut,
+				url:    "http://example.com/4",
+				body:   strings.NewReader(``),
+				pathParam: map[string]string{
+					"gameID":     "1",
+					"relationID": "4",
+				},
+			},
+			wantStatusCode: http.StatusNotFound,
+			wantBody:       "",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := &relationHandler{
+				server: tt.fields.server,
+			}
+			ctx := chi.NewRouteContext()
+			for key, val := range tt.args.pathParam {
+				ctx.URLParams.Add(key, val)
+			}
+			r := httptest.NewRequest(tt.args.method, tt.args.url, tt.args.body)
+			r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, ctx))
+			h.HandleRelation(tt.args.w, r)
+			if !assert.Equal(t, tt.wantStatusCode, tt.args.w.Code) {
+				return
+			}
+			// NOTE: BodyのStringは\nが入る仕様らしいので削除
+			if tt.wantBody != "" && !assert.Equal(t, tt.wantBody, strings.Replace(tt.args.w.Body.String(), "\n", "", -1)) {
+				return
+			}
+		})
+	}
+}
+
+func Test_relationHandler_HandleRelationForMultiple(t *testing.T) {
+	type fields struct {
+		server game.RelationServer
+	}
+	type args struct {
+		w         *httptest.ResponseRecorder
+		method    string
+		url       string
+		body      io.Reader
+		pathParam map[string]string
+	}
+	tests := []struct {
+		name           string
+		fields         fields
+		args           args
+		wantStatusCode int
+		wantBody       string
+	}{
+		{
+			name: "Find OK",
+			fields: fields{
+				server: &server{
+					Relations: []*game.Relation{
+						newTestRelation(1, game.RelationKind_RemakeOf, 2, 1),
+						newTestRelation(2, game.RelationKind_PortOf, 1, 3),
+					},
+					find: true,
+				},
+			},
+			args: args{
+				w:      httptest.NewRecorder(),
+				method: http.MethodGet,
+				url:    "http://example.com",
+				body:   strings.NewReader(``),
+				pathParam: map[string]string{
+					"gameID": "1",
+				},
+			},
+			wantStatusCode: http.StatusOK,
+			wantBody: func() string {
+				body := relationsResponse(http.StatusOK, "success find relation", []*game.Relation{
+					newTestRelation(1, game.RelationKind_RemakeOf, 2, 1),
+					newTestRelation(2, game.RelationKind_PortOf, 1, 3),
+				})
+				str, _ := json.Marshal(body)
+				return string(str)
+			}(),
+		},
+		{
+			name: "Find server NG",
+			fields: fields{
+				server: &server{
+					find: false,
+				},
+			},
+			args: args{
+				w:      httptest.NewRecorder(),
+				method: http.MethodGet,
+				url:    "http://example.com",
+				body:   strings.NewReader(``),
+				pathParam: map[string]string{
+					"gameID": "1",
+				},
+			},
+			wantStatusCode: http.StatusInternalServerError,
+			wantBody:       "",
+		},
+		{
+			name: "Bad Method NG",
+			fields: fields{
+				server: &server{},
+			},
+			args: args{
+				w:      httptest.NewRecorder(),
+				method: http.MethodPut,
+				url:    "http://example.com",
+				body:   strings.NewReader(``),
+				pathParam: map[string]string{
+					"gameID": "1",
+				},
+			},
+			wantStatusCode: http.StatusNotFound,
+			wantBody:       "",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := &relationHandler{
+				server: tt.fields.server,
+			}
+			ctx := chi.NewRouteContext()
+			for key, val := range tt.args.pathParam {
+				ctx.URLParams.Add(key, val)
+			}
+			r := httptest.NewRequest(tt.args.method, tt.args.url, tt.args.body)
+			r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, ctx))
+			h.HandleRelationForMultiple(tt.args.w, r)
+			if !assert.Equal(t, tt.wantStatusCode, tt.args.w.Code) {
+				return
+			}
+			// NOTE: BodyのStringは\nが入る仕様らしいので削除
+			if tt.wantBody != "" && !assert.Equal(t, tt.wantBody, strings.Replace(tt.args.w.Body.String(), "\n", "", -1)) {
+				return
+			}
+		})
+	}
+}
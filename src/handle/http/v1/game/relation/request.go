@@ -0,0 +1,103 @@
+package relation
+
+import (
+	"encoding/json"
+	"mysrtafes-backend/pkg/errors"
+	"mysrtafes-backend/pkg/game"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+)
+
+// Post: NewRelationEntity for request
+// NOTE: URLのゲームが関連元になる
+func NewRelationCreate(r *http.Request) (game.ID, *game.Relation, error) {
+	defer r.Body.Close()
+
+	gameID, err := NewGameID(r)
+	if err != nil {
+		return 0, nil, err
+	}
+
+	body := struct {
+		Kind     string  `json:"kind"`
+		TargetID game.ID `json:"target_id"`
+	}{}
+	err = json.NewDecoder(r.Body).Decode(&body)
+	if err != nil {
+		return 0, nil, errors.NewInvalidRequest(
+			errors.Layer_Request,
+			errors.NewInformation(
+				errors.ID_JsonDecodeError,
+				err.Error(),
+				nil,
+			),
+			"json decode error. bad format request.",
+		)
+	}
+
+	kind, err := game.NewRelationKind(body.Kind)
+	if err != nil {
+		return 0, nil, errors.NewInvalidRequest(
+			errors.Layer_Request,
+			errors.NewInformation(
+				errors.ID_InvalidParams,
+				err.Error(),
+				[]errors.InvalidParams{
+					errors.NewInvalidParams("kind", body.Kind),
+				},
+			),
+			"kind create error",
+		)
+	}
+
+	return gameID, game.NewRelation(kind, body.TargetID), nil
+}
+
+// Get, Find: NewGameID for request
+func NewGameID(r *http.Request) (game.ID, error) {
+	gameIDStr := chi.URLParam(r, "gameID")
+
+	gameID, err := strconv.Atoi(gameIDStr)
+	if err != nil {
+		return 0, errors.NewInvalidRequest(
+			errors.Layer_Request,
+			errors.NewInformation(
+				errors.ID_InvalidParams,
+				err.Error(),
+				[]errors.InvalidParams{
+					errors.NewInvalidParams("gameID", gameIDStr),
+				},
+			),
+			"gameID convert error",
+		)
+	}
+	return game.ID(gameID), nil
+}
+
+// Get, Delete: NewRelationID for request
+func NewRelationID(r *http.Request) (game.ID, game.RelationID, error) {
+	gameID, err := NewGameID(r)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	relationIDStr := chi.URLParam(r, "relationID")
+
+	relationID, err := strconv.Atoi(relationIDStr)
+	if err != nil {
+		return 0, 0, errors.NewInvalidRequest(
+			errors.Layer_Request,
+			errors.NewInformation(
+				errors.ID_InvalidParams,
+				err.Error(),
+				[]errors.InvalidParams{
+					errors.NewInvalidParams("relationID", relationIDStr),
+				},
+			),
+			"relationID convert error",
+		)
+	}
+	return gameID, game.RelationID(relationID), nil
+}
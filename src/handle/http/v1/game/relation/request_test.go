@@ -0,0 +1,164 @@
+package relation
+
+import (
+	"context"
+	"io"
+	"mysrtafes-backend/pkg/game"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewRelationCreate(t *testing.T) {
+	type args struct {
+		body      io.Reader
+		pathParam map[string]string
+	}
+	tests := []struct {
+		name    string
+		args    args
+		want    game.ID
+		want1   *game.Relation
+		wantErr bool
+	}{
+		{
+			name: "OK",
+			args: args{
+				body: strings.NewReader(`{"kind": "remake_of", "target_id": 2}`),
+				pathParam: map[string]string{
+					"gameID": "1",
+				},
+			},
+			want: 1,
+			want1: &game.Relation{
+				Kind:   game.RelationKind_RemakeOf,
+				Target: game.RelatedGame{ID: 2},
+			},
+			wantErr: false,
+		},
+		{
+			name: "kind empty error",
+			args: args{
+				body: strings.NewReader(`{"target_id": 2}`),
+				pathParam: map[string]string{
+					"gameID": "1",
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "kind error",
+			args: args{
+				body: strings.NewReader(`{"kind": "prequel_of", "target_id": 2}`),
+				pathParam: map[string]string{
+					"gameID": "1",
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "bad gameID error",
+			args: args{
+				body: strings.NewReader(`{"kind": "remake_of", "target_id": 2}`),
+				pathParam: map[string]string{
+					"gameID": "a",
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "decode error",
+			args: args{
+				body: strings.NewReader(`{"kind": "remake_of",}`),
+				pathParam: map[string]string{
+					"gameID": "1",
+				},
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := chi.NewRouteContext()
+			for key, val := range tt.args.pathParam {
+				ctx.URLParams.Add(key, val)
+			}
+			r := httptest.NewRequest(http.MethodPost, "http://example.com", tt.args.body)
+			r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, ctx))
+			got, got1, err := NewRelationCreate(r)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("NewRelationCreate() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			assert.Equal(t, tt.want, got)
+			assert.Equal(t, tt.want1, got1)
+		})
+	}
+}
+
+func TestNewRelationID(t *testing.T) {
+	type args struct {
+		pathParam map[string]string
+	}
+	tests := []struct {
+		name    string
+		args    args
+		want    game.ID
+		want1   game.RelationID
+		wantErr bool
+	}{
+		{
+			name: "OK",
+			args: args{
+				pathParam: map[string]string{
+					"gameID":     "1",
+					"relationID": "2",
+				},
+			},
+			want:    1,
+			want1:   2,
+			wantErr: false,
+		},
+		{
+			name: "bad gameID error",
+			args: args{
+				pathParam: map[string]string{
+					"gameID":     "",
+					"relationID": "2",
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "bad relationID error",
+			args: args{
+				pathParam: map[string]string{
+					"gameID":     "1",
+					"relationID": "a",
+				},
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := chi.NewRouteContext()
+			for key, val := range tt.args.pathParam {
+				ctx.URLParams.Add(key, val)
+			}
+			r := httptest.NewRequest(http.MethodGet, "http://example.com", nil)
+			r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, ctx))
+			got, got1, err := NewRelationID(r)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("NewRelationID() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			assert.Equal(t, tt.want, got)
+			assert.Equal(t, tt.want1, got1)
+		})
+	}
+}
@@ -0,0 +1,115 @@
+package relation
+
+import (
+	"encoding/json"
+	"mysrtafes-backend/pkg/game"
+	"net/http"
+	"time"
+)
+
+type RelatedGame struct {
+	ID   game.ID   `json:"id"`
+	Name game.Name `json:"name"`
+}
+
+type Relation struct {
+	ID        game.RelationID `json:"id"`
+	Kind      string          `json:"kind"`
+	Source    RelatedGame     `json:"source"`
+	Target    RelatedGame     `json:"target"`
+	CreatedAt time.Time       `json:"created_at"`
+	UpdatedAt time.Time       `json:"updated_at"`
+}
+
+type RelationResponse struct {
+	Code    int      `json:"code"`
+	Message string   `json:"message"`
+	Data    Relation `json:"data"`
+}
+
+type RelationsResponse struct {
+	Code    int        `json:"code"`
+	Message string     `json:"message"`
+	Data    []Relation `json:"data"`
+}
+
+// write create response for relation
+func WriteCreateRelation(w http.ResponseWriter, relation *game.Relation) error {
+	body := relationResponse(http.StatusCreated, "success create relation", relation)
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	return json.NewEncoder(w).Encode(&body)
+}
+
+// write read response for relation
+func WriteReadRelation(w http.ResponseWriter, relation *game.Relation) error {
+	body := relationResponse(http.StatusOK, "success read relation", relation)
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	return json.NewEncoder(w).Encode(&body)
+}
+
+// write delete response for relation
+func WriteDeleteRelation(w http.ResponseWriter, relationID game.RelationID) error {
+	body := deleteRelationResponse(relationID)
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	return json.NewEncoder(w).Encode(&body)
+}
+
+// write find response for relation
+func WriteFindRelation(w http.ResponseWriter, relations []*game.Relation) error {
+	body := relationsResponse(http.StatusOK, "success find relation", relations)
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	return json.NewEncoder(w).Encode(&body)
+}
+
+func newRelation(relation *game.Relation) Relation {
+	return Relation{
+		ID:   relation.RelationID,
+		Kind: relation.Kind.String(),
+		Source: RelatedGame{
+			ID:   relation.Source.ID,
+			Name: relation.Source.Name,
+		},
+		Target: RelatedGame{
+			ID:   relation.Target.ID,
+			Name: relation.Target.Name,
+		},
+		CreatedAt: relation.CreatedAt,
+		UpdatedAt: relation.UpdatedAt,
+	}
+}
+
+func relationResponse(statusCode int, msg string, relation *game.Relation) interface{} {
+	return RelationResponse{
+		Code:    statusCode,
+		Message: msg,
+		Data:    newRelation(relation),
+	}
+}
+
+func deleteRelationResponse(relationID game.RelationID) interface{} {
+	return struct {
+		Code    int             `json:"code"`
+		Message string          `json:"message"`
+		Data    game.RelationID `json:"deleteID"`
+	}{
+		Code:    http.StatusOK,
+		Message: "success delete relation",
+		Data:    relationID,
+	}
+}
+
+func relationsResponse(statusCode int, msg string, relations []*game.Relation) interface{} {
+	responses := make([]Relation, 0, len(relations))
+	for _, relation := range relations {
+		responses = append(responses, newRelation(relation))
+	}
+	return RelationsResponse{
+		Code:    statusCode,
+		Message: msg,
+		Data:    responses,
+	}
+}
@@ -0,0 +1,107 @@
+package relation
+
+import (
+	"mysrtafes-backend/pkg/game"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_relationResponse(t *testing.T) {
+	type args struct {
+		statusCode int
+		msg        string
+		relation   *game.Relation
+	}
+	tests := []struct {
+		name string
+		args args
+		want interface{}
+	}{
+		{
+			name: "ok",
+			args: args{
+				statusCode: http.StatusOK,
+				msg:        "OKです",
+				relation:   newTestRelation(10, game.RelationKind_ExpansionOf, 2, 1),
+			},
+			want: RelationResponse{
+				Code:    http.StatusOK,
+				Message: "OKです",
+				Data: Relation{
+					ID:     10,
+					Kind:   "expansion_of",
+					Source: RelatedGame{ID: 2, Name: "関連元"},
+					Target: RelatedGame{ID: 1, Name: "関連先"},
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, relationResponse(tt.args.statusCode, tt.args.msg, tt.args.relation))
+		})
+	}
+}
+
+func Test_relationsResponse(t *testing.T) {
+	type args struct {
+		statusCode int
+		msg        string
+		relations  []*game.Relation
+	}
+	tests := []struct {
+		name string
+		args args
+		want interface{}
+	}{
+		{
+			name: "ok",
+			args: args{
+				statusCode: http.StatusOK,
+				msg:        "OKです",
+				relations: []*game.Relation{
+					newTestRelation(1, game.RelationKind_RemakeOf, 2, 1),
+					newTestRelation(2, game.RelationKind_SequelOf, 1, 3),
+				},
+			},
+			want: RelationsResponse{
+				Code:    http.StatusOK,
+				Message: "OKです",
+				Data: []Relation{
+					{
+						ID:     1,
+						Kind:   "remake_of",
+						Source: RelatedGame{ID: 2, Name: "関連元"},
+						Target: RelatedGame{ID: 1, Name: "関連先"},
+					},
+					{
+						ID:     2,
+						Kind:   "sequel_of",
+						Source: RelatedGame{ID: 1, Name: "関連元"},
+						Target: RelatedGame{ID: 3, Name: "関連先"},
+					},
+				},
+			},
+		},
+		{
+			name: "empty",
+			args: args{
+				statusCode: http.StatusOK,
+				msg:        "OKです",
+				relations:  []*game.Relation{},
+			},
+			want: RelationsResponse{
+				Code:    http.StatusOK,
+				Message: "OKです",
+				Data:    []Relation{},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, relationsResponse(tt.args.statusCode, tt.args.msg, tt.args.relations))
+		})
+	}
+}
@@ -1,53 +1,211 @@
 package game
 
 import (
+	"bufio"
+	"encoding/csv"
 	"encoding/json"
+	stdErrors "errors"
+	"fmt"
+	"io"
+	"mime"
+	"mysrtafes-backend/handle/http/v1/cursor"
+	"mysrtafes-backend/handle/http/v1/patch"
 	"mysrtafes-backend/pkg/errors"
 	"mysrtafes-backend/pkg/game"
 	"mysrtafes-backend/pkg/game/platform"
+	"mysrtafes-backend/pkg/game/series"
 	"mysrtafes-backend/pkg/game/tag"
+	"mysrtafes-backend/pkg/version"
 	"net/http"
 	"net/url"
 	"strconv"
+	"strings"
 
 	"github.com/go-chi/chi/v5"
 )
 
-func NewGameCreate(r *http.Request) (*game.Game, []platform.ID, []tag.ID, error) {
-	defer r.Body.Close()
+// カーソルの署名に使うリソース名
+const cursorResource = "games"
+
+// 登録・更新リクエストのリンク
+type linkBody struct {
+	LinkID          game.LinkID          `json:"id"`
+	Title           game.Title           `json:"title"`
+	URL             string               `json:"url"`
+	LinkDescription game.LinkDescription `json:"description"`
+	Kind            string               `json:"kind"`
+	Store           game.LinkStore       `json:"store"`
+	Region          game.LinkRegion      `json:"region"`
+}
+
+// 登録・更新リクエストの別名
+type aliasBody struct {
+	Name     game.AliasName     `json:"name"`
+	Language game.AliasLanguage `json:"language"`
+	Kind     string             `json:"kind"`
+}
+
+// 登録・更新リクエスト
+type gameBody struct {
+	Name        game.Name        `json:"name"`
+	Description game.Description `json:"description"`
+	Publisher   game.Publisher   `json:"publisher"`
+	Developer   game.Developer   `json:"developer"`
+	ReleaseDate string           `json:"release_date"`
+	Links       []linkBody       `json:"links"`
+	Aliases     []aliasBody      `json:"aliases"`
+	// NOTE: シリーズに属さないときはnull
+	SeriesID     *series.ID        `json:"series_id"`
+	SeriesNumber game.SeriesNumber `json:"series_number"`
+	PlatformIDs  []platform.ID     `json:"platform_ids"`
+	TagIDs       []tag.ID          `json:"tag_ids"`
+}
 
-	type Link struct {
-		Title           game.Title           `json:"title"`
-		URL             string               `json:"url"`
-		LinkDescription game.LinkDescription `json:"description"`
+// 保存済みのゲームからリクエストを生成
+// NOTE: 部分更新でパッチを適用する元として使用するため、リンクのIDも含める
+func newGameBody(g *game.Game) gameBody {
+	links := make([]linkBody, 0, len(g.Links))
+	for _, link := range g.Links {
+		links = append(links, linkBody{
+			LinkID:          link.LinkID,
+			Title:           link.Title,
+			URL:             link.URL.URL().String(),
+			LinkDescription: link.LinkDescription,
+			Kind:            link.Kind.String(),
+			Store:           link.Store,
+			Region:          link.Region,
+		})
 	}
+	aliases := make([]aliasBody, 0, len(g.Aliases))
+	for _, alias := range g.Aliases {
+		aliases = append(aliases, aliasBody{
+			Name:     alias.Name,
+			Language: alias.Language,
+			Kind:     alias.Kind.String(),
+		})
+	}
+	platformIDs := make([]platform.ID, 0, len(g.Platforms))
+	for _, p := range g.Platforms {
+		platformIDs = append(platformIDs, p.ID)
+	}
+	tagIDs := make([]tag.ID, 0, len(g.Tags))
+	for _, t := range g.Tags {
+		tagIDs = append(tagIDs, t.ID)
+	}
+	return gameBody{
+		Name:         g.Name,
+		Description:  g.Description,
+		Publisher:    g.Publisher,
+		Developer:    g.Developer,
+		ReleaseDate:  g.ReleaseDate.String(),
+		Links:        links,
+		Aliases:      aliases,
+		SeriesID:     g.SeriesID(),
+		SeriesNumber: g.SeriesNumber,
+		PlatformIDs:  platformIDs,
+		TagIDs:       tagIDs,
+	}
+}
 
-	body := struct {
-		Name        game.Name        `json:"name"`
-		Description game.Description `json:"description"`
-		Publisher   game.Publisher   `json:"publisher"`
-		Developer   game.Developer   `json:"developer"`
-		ReleaseDate string           `json:"release_date"`
-		Links       []Link           `json:"links"`
-		PlatformIDs []platform.ID    `json:"platform_ids"`
-		TagIDs      []tag.ID         `json:"tag_ids"`
-	}{}
-	err := json.NewDecoder(r.Body).Decode(&body)
+// 登録用のゲーム生成
+// NOTE: リンクのIDは無視する
+func (body *gameBody) newGame() (*game.Game, error) {
+	releaseDate, err := body.releaseDate()
 	if err != nil {
-		return nil, nil, nil, errors.NewInvalidRequest(
-			errors.Layer_Request,
-			errors.NewInformation(
-				errors.ID_JsonDecodeError,
-				err.Error(),
-				nil,
-			),
-			"json decode error. bad format request.",
-		)
+		return nil, err
+	}
+	links, err := body.links(false)
+	if err != nil {
+		return nil, err
+	}
+	aliases, err := body.aliases()
+	if err != nil {
+		return nil, err
+	}
+	return game.New(
+		body.Name,
+		body.Description,
+		body.Publisher,
+		body.Developer,
+		releaseDate,
+		links,
+	).SetAliases(aliases).SetSeries(body.series(), body.SeriesNumber), nil
+}
+
+// 更新用のゲーム生成
+// NOTE: IDを指定したリンクは既存のリンクとして更新する
+func (body *gameBody) newGameWithID(gameID game.ID) (*game.Game, error) {
+	releaseDate, err := body.releaseDate()
+	if err != nil {
+		return nil, err
+	}
+	return body.newGameWithReleaseDate(gameID, releaseDate)
+}
+
+// 部分更新用のゲーム生成
+// NOTE: 発売日が未登録のゲームは保存済みの値が空文字になるため、空文字・nullは未登録のまま更新する
+func (body *gameBody) newPatchedGame(gameID game.ID) (*game.Game, error) {
+	if body.ReleaseDate == "" {
+		return body.newGameWithReleaseDate(gameID, game.ReleaseDate{})
+	}
+	return body.newGameWithID(gameID)
+}
+
+func (body *gameBody) newGameWithReleaseDate(gameID game.ID, releaseDate game.ReleaseDate) (*game.Game, error) {
+	links, err := body.links(true)
+	if err != nil {
+		return nil, err
+	}
+	aliases, err := body.aliases()
+	if err != nil {
+		return nil, err
+	}
+	return game.NewWithID(
+		gameID,
+		body.Name,
+		body.Description,
+		body.Publisher,
+		body.Developer,
+		releaseDate,
+		links,
+	).SetAliases(aliases).SetSeries(body.series(), body.SeriesNumber), nil
+}
+
+// NOTE: シリーズの指定がないときはnilとする
+func (body *gameBody) series() *series.Series {
+	if body.SeriesID == nil {
+		return nil
+	}
+	return &series.Series{ID: *body.SeriesID}
+}
+
+func (body *gameBody) aliases() ([]*game.Alias, error) {
+	// NOTE: 別名の指定がないときはnilとする
+	var aliases []*game.Alias
+	for _, alias := range body.Aliases {
+		kind, err := game.NewAliasKind(alias.Kind)
+		if err != nil {
+			return nil, errors.NewInvalidRequest(
+				errors.Layer_Request,
+				errors.NewInformation(
+					errors.ID_InvalidParams,
+					err.Error(),
+					[]errors.InvalidParams{
+						errors.NewInvalidParams("aliases.kind", alias.Kind),
+					},
+				),
+				"aliases.kind create error",
+			)
+		}
+		aliases = append(aliases, game.NewAlias(alias.Name, alias.Language, kind))
 	}
+	return aliases, nil
+}
 
+func (body *gameBody) releaseDate() (game.ReleaseDate, error) {
 	releaseDate, err := game.NewReleaseDate(body.ReleaseDate)
 	if err != nil {
-		return nil, nil, nil, errors.NewInvalidRequest(
+		return game.ReleaseDate{}, errors.NewInvalidRequest(
 			errors.Layer_Request,
 			errors.NewInformation(
 				errors.ID_InvalidParams,
@@ -59,12 +217,16 @@ func NewGameCreate(r *http.Request) (*game.Game, []platform.ID, []tag.ID, error)
 			"release_date create error",
 		)
 	}
+	return releaseDate, nil
+}
 
+// NOTE: IDを指定しないときはリンクのIDを無視する
+func (body *gameBody) links(withLinkID bool) ([]*game.Link, error) {
 	Links := make([]*game.Link, 0, len(body.Links))
-	for _, link := range body.Links {
+	for i, link := range body.Links {
 		url, err := game.NewURL(link.URL)
 		if err != nil {
-			return nil, nil, nil, errors.NewInvalidRequest(
+			return nil, errors.NewInvalidRequest(
 				errors.Layer_Request,
 				errors.NewInformation(
 					errors.ID_InvalidParams,
@@ -76,17 +238,55 @@ func NewGameCreate(r *http.Request) (*game.Game, []platform.ID, []tag.ID, error)
 				"links.url create error",
 			)
 		}
-		Links = append(Links, game.NewLink(link.Title, url, link.LinkDescription))
+		kind, err := game.NewLinkKind(link.Kind)
+		if err != nil {
+			return nil, errors.NewInvalidRequest(
+				errors.Layer_Request,
+				errors.NewInformation(
+					errors.ID_InvalidParams,
+					err.Error(),
+					[]errors.InvalidParams{
+						errors.NewInvalidParams("links.kind", link.Kind),
+					},
+				),
+				"links.kind create error",
+			)
+		}
+		linkID := link.LinkID
+		if !withLinkID {
+			linkID = 0
+		}
+		// NOTE: 配列の並び順を表示順とする
+		Links = append(Links, game.NewLinkWithID(linkID, link.Title, url, link.LinkDescription).
+			SetDisplayOrder(game.DisplayOrder(i+1)).
+			SetKind(kind).
+			SetStore(link.Store, link.Region))
 	}
+	return Links, nil
+}
 
-	return game.New(
-		body.Name,
-		body.Description,
-		body.Publisher,
-		body.Developer,
-		releaseDate,
-		Links,
-	), body.PlatformIDs, body.TagIDs, nil
+func NewGameCreate(r *http.Request) (*game.Game, []platform.ID, []tag.ID, error) {
+	defer r.Body.Close()
+
+	body := gameBody{}
+	err := json.NewDecoder(r.Body).Decode(&body)
+	if err != nil {
+		return nil, nil, nil, errors.NewInvalidRequest(
+			errors.Layer_Request,
+			errors.NewInformation(
+				errors.ID_JsonDecodeError,
+				err.Error(),
+				nil,
+			),
+			"json decode error. bad format request.",
+		)
+	}
+
+	g, err := body.newGame()
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	return g, body.PlatformIDs, body.TagIDs, nil
 }
 
 func NewGameID(r *http.Request) (game.ID, error) {
@@ -109,6 +309,50 @@ func NewGameID(r *http.Request) (game.ID, error) {
 	return game.ID(gameID), nil
 }
 
+// Get: NewTagID for request
+// NOTE: 指定タグを持つゲームの検索に使う
+func NewGameTagID(r *http.Request) (tag.ID, error) {
+	tagIDStr := chi.URLParam(r, "tagID")
+
+	tagID, err := strconv.Atoi(tagIDStr)
+	if err != nil {
+		return 0, errors.NewInvalidRequest(
+			errors.Layer_Request,
+			errors.NewInformation(
+				errors.ID_InvalidParams,
+				err.Error(),
+				[]errors.InvalidParams{
+					errors.NewInvalidParams("tagID", tagIDStr),
+				},
+			),
+			"tagID convert error",
+		)
+	}
+	return tag.ID(tagID), nil
+}
+
+// Get: NewPlatformID for request
+// NOTE: 指定プラットフォームを持つゲームの検索に使う
+func NewGamePlatformID(r *http.Request) (platform.ID, error) {
+	platformIDStr := chi.URLParam(r, "platformID")
+
+	platformID, err := strconv.Atoi(platformIDStr)
+	if err != nil {
+		return 0, errors.NewInvalidRequest(
+			errors.Layer_Request,
+			errors.NewInformation(
+				errors.ID_InvalidParams,
+				err.Error(),
+				[]errors.InvalidParams{
+					errors.NewInvalidParams("platformID", platformIDStr),
+				},
+			),
+			"platformID convert error",
+		)
+	}
+	return platform.ID(platformID), nil
+}
+
 func NewGameFindOption(r *http.Request) (*game.FindOption, error) {
 	// デフォルト値生成
 	findOption := game.NewFindOption()
@@ -124,9 +368,37 @@ func NewGameFindOption(r *http.Request) (*game.FindOption, error) {
 		return nil, err
 	}
 
+	// キーワード設定
+	if err := setKeyword(findOption, q); err != nil {
+		return nil, err
+	}
+
+	// タグ・プラットフォーム・リンク種別絞り込み設定
+	if err := setFilter(findOption, q); err != nil {
+		return nil, err
+	}
+
+	// 発売日絞り込み設定
+	if err := setReleasePeriod(findOption, q); err != nil {
+		return nil, err
+	}
+
+	// 読み込む関連設定
+	include, _, err := parseFields(q)
+	if err != nil {
+		return nil, err
+	}
+	findOption.SetInclude(include)
+
 	return findOption, nil
 }
 
+// Get: NewGameFields for request
+// NOTE: 読み込む関連と返却するフィールドを返却する。include・fieldsのいずれも未指定のときはともにnil(すべて)
+func NewGameFields(r *http.Request) (*game.Include, Fields, error) {
+	return parseFields(r.URL.Query())
+}
+
 func NewGameUpdate(r *http.Request) (*game.Game, []platform.ID, []tag.ID, error) {
 	defer r.Body.Close()
 
@@ -135,22 +407,7 @@ func NewGameUpdate(r *http.Request) (*game.Game, []platform.ID, []tag.ID, error)
 		return nil, nil, nil, err
 	}
 
-	type Link struct {
-		Title           game.Title           `json:"title"`
-		URL             string               `json:"url"`
-		LinkDescription game.LinkDescription `json:"description"`
-	}
-
-	body := struct {
-		Name        game.Name        `json:"name"`
-		Description game.Description `json:"description"`
-		Publisher   game.Publisher   `json:"publisher"`
-		Developer   game.Developer   `json:"developer"`
-		ReleaseDate string           `json:"release_date"`
-		Links       []Link           `json:"links"`
-		PlatformIDs []platform.ID    `json:"platform_ids"`
-		TagIDs      []tag.ID         `json:"tag_ids"`
-	}{}
+	body := gameBody{}
 	err = json.NewDecoder(r.Body).Decode(&body)
 	if err != nil {
 		return nil, nil, nil, errors.NewInvalidRequest(
@@ -164,57 +421,154 @@ func NewGameUpdate(r *http.Request) (*game.Game, []platform.ID, []tag.ID, error)
 		)
 	}
 
-	releaseDate, err := game.NewReleaseDate(body.ReleaseDate)
+	g, err := body.newGameWithID(gameID)
 	if err != nil {
-		return nil, nil, nil, errors.NewInvalidRequest(
+		return nil, nil, nil, err
+	}
+	return g, body.PlatformIDs, body.TagIDs, nil
+}
+
+// Patch: NewGamePatcher for request
+// NOTE: 保存済みのゲームを登録・更新リクエストの形式にしてからJSON Merge Patchを適用する。
+// 配列は丸ごと置き換えになるため、既存のリンクを残すときはIDを指定する
+func NewGamePatch(r *http.Request) (game.ID, game.Patcher, error) {
+	gameID, err := NewGameID(r)
+	if err != nil {
+		return 0, nil, err
+	}
+
+	mergePatch, err := patch.NewMergePatch(r)
+	if err != nil {
+		return 0, nil, err
+	}
+
+	return gameID, func(current *game.Game) (*game.Game, []platform.ID, []tag.ID, error) {
+		body := gameBody{}
+		if err := patch.Apply(newGameBody(current), mergePatch, &body); err != nil {
+			return nil, nil, nil, err
+		}
+		g, err := body.newPatchedGame(current.ID)
+		if err != nil {
+			return nil, nil, nil, err
+		}
+		return g, body.PlatformIDs, body.TagIDs, nil
+	}, nil
+}
+
+// Put(multiple): NewUpdateRows for request
+// NOTE: 変換できない要素があっても全体はエラーにせず、その行のみ失敗とする
+func NewGameBulkUpdate(r *http.Request) ([]*game.UpdateRow, error) {
+	defer r.Body.Close()
+
+	elements := []json.RawMessage{}
+	if err := json.NewDecoder(r.Body).Decode(&elements); err != nil {
+		return nil, errors.NewInvalidRequest(
 			errors.Layer_Request,
 			errors.NewInformation(
-				errors.ID_InvalidParams,
+				errors.ID_JsonDecodeError,
 				err.Error(),
-				[]errors.InvalidParams{
-					errors.NewInvalidParams("release_date", body.ReleaseDate),
-				},
+				nil,
 			),
-			"release_date create error",
+			"json decode error. bad format request.",
 		)
 	}
 
-	Links := make([]*game.Link, 0, len(body.Links))
-	for _, link := range body.Links {
-		url, err := game.NewURL(link.URL)
-		if err != nil {
-			return nil, nil, nil, errors.NewInvalidRequest(
+	rows := make([]*game.UpdateRow, 0, len(elements))
+	for _, element := range elements {
+		body := struct {
+			ID game.ID `json:"id"`
+			gameBody
+		}{}
+		if err := json.Unmarshal(element, &body); err != nil {
+			rows = append(rows, game.NewInvalidUpdateRow(body.ID, errors.NewInvalidRequest(
 				errors.Layer_Request,
 				errors.NewInformation(
-					errors.ID_InvalidParams,
+					errors.ID_JsonDecodeError,
 					err.Error(),
-					[]errors.InvalidParams{
-						errors.NewInvalidParams("links.url", link.URL),
-					},
+					nil,
 				),
-				"links.url create error",
-			)
+				"json decode error. bad format row.",
+			)))
+			continue
+		}
+		g, err := body.newGameWithID(body.ID)
+		if err != nil {
+			rows = append(rows, game.NewInvalidUpdateRow(body.ID, err))
+			continue
 		}
-		Links = append(Links, game.NewLink(link.Title, url, link.LinkDescription))
+		rows = append(rows, game.NewUpdateRow(g, body.PlatformIDs, body.TagIDs))
 	}
+	return rows, nil
+}
 
-	return game.NewWithID(
-		gameID,
-		body.Name,
-		body.Description,
-		body.Publisher,
-		body.Developer,
-		releaseDate,
-		Links,
-	), body.PlatformIDs, body.TagIDs, nil
+// Delete(multiple): NewGameIDs for request
+// NOTE: ids=1,2 と ids=1&ids=2 の両方を許容
+func NewGameBulkDelete(r *http.Request) ([]game.ID, error) {
+	q := r.URL.Query()
+	if !q.Has("ids") {
+		return nil, errors.NewInvalidRequest(
+			errors.Layer_Request,
+			errors.NewInformation(
+				errors.ID_InvalidParams,
+				"ids is required",
+				[]errors.InvalidParams{
+					errors.NewInvalidParams("ids", nil),
+				},
+			),
+			"ids nothing error",
+		)
+	}
+	ids, err := parseIDs(q["ids"])
+	if err != nil {
+		return nil, errors.NewInvalidRequest(
+			errors.Layer_Request,
+			errors.NewInformation(
+				errors.ID_InvalidParams,
+				err.Error(),
+				[]errors.InvalidParams{
+					errors.NewInvalidParams("ids", q["ids"]),
+				},
+			),
+			"ids convert error",
+		)
+	}
+	gameIDs := make([]game.ID, 0, len(ids))
+	for _, id := range ids {
+		gameIDs = append(gameIDs, game.ID(id))
+	}
+	return gameIDs, nil
+}
+
+// Merge: NewGameMerge for request
+// NOTE: URLのゲームを統合元、target_idのゲームを統合先とする
+func NewGameMerge(r *http.Request) (game.ID, game.ID, error) {
+	defer r.Body.Close()
+
+	sourceID, err := NewGameID(r)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	body := struct {
+		TargetID game.ID `json:"target_id"`
+	}{}
+	err = json.NewDecoder(r.Body).Decode(&body)
+	if err != nil {
+		return 0, 0, errors.NewInvalidRequest(
+			errors.Layer_Request,
+			errors.NewInformation(
+				errors.ID_JsonDecodeError,
+				err.Error(),
+				nil,
+			),
+			"json decode error. bad format request.",
+		)
+	}
+	return sourceID, body.TargetID, nil
 }
 
 // Find: set order param
 func setOrder(findOption *game.FindOption, q url.Values) error {
-	// シーク法のときは並び替えするとおかしくなるので禁止
-	if findOption.SearchMode == game.SearchMode_Seek {
-		return nil
-	}
 	// Descのチェック
 	desc := false
 	var err error
@@ -245,6 +599,12 @@ func setOrder(findOption *game.FindOption, q url.Values) error {
 	switch q.Get("order") {
 	case "name":
 		findOption.SetOrder(game.Order_Name, desc)
+	case "release_date":
+		findOption.SetOrder(game.Order_ReleaseDate, desc)
+	case "updated_at":
+		findOption.SetOrder(game.Order_UpdatedAt, desc)
+	case "series_number":
+		findOption.SetOrder(game.Order_SeriesNumber, desc)
 	case "id":
 		findOption.SetOrder(game.Order_ID, desc)
 	default:
@@ -310,6 +670,14 @@ func setSearchMode(findOption *game.FindOption, q url.Values) error {
 			}
 		}
 		findOption.SetSeek(game.LastID(lastID), game.Count(count))
+		// NOTE: 続きの位置はカーソルで指定する。last_idはIDの並び順でのみ使える
+		if q.Has("cursor") {
+			c := &game.Cursor{}
+			if err := cursor.Decode(cursorResource, q.Get("cursor"), c); err != nil {
+				return err
+			}
+			findOption.SetCursor(c)
+		}
 	case "page":
 		// ページネーション法
 		var limit, offset int = 30, 0
@@ -364,3 +732,701 @@ func setSearchMode(findOption *game.FindOption, q url.Values) error {
 	}
 	return nil
 }
+
+// Find: set keyword param
+func setKeyword(findOption *game.FindOption, q url.Values) error {
+	// キーワードがないときは何もせず終了
+	if !q.Has("q") {
+		return nil
+	}
+	keyword := game.NewKeyword(q.Get("q"))
+	if !keyword.Valid() {
+		return errors.NewInvalidRequest(
+			errors.Layer_Request,
+			errors.NewInformation(
+				errors.ID_InvalidParams,
+				"q length error",
+				[]errors.InvalidParams{
+					errors.NewInvalidParams("q", q.Get("q")),
+				},
+			),
+			"q length error",
+		)
+	}
+	findOption.SetKeyword(keyword)
+	return nil
+}
+
+// Find: set tag/platform/series/link kind filter param
+func setFilter(findOption *game.FindOption, q url.Values) error {
+	// tag_ids=1,2 と tag_ids=1&tag_ids=2 の両方を許容
+	if q.Has("tag_ids") {
+		ids, err := parseIDs(q["tag_ids"])
+		if err != nil {
+			return errors.NewInvalidRequest(
+				errors.Layer_Request,
+				errors.NewInformation(
+					errors.ID_InvalidParams,
+					err.Error(),
+					[]errors.InvalidParams{
+						errors.NewInvalidParams("tag_ids", q["tag_ids"]),
+					},
+				),
+				"tag_ids convert error",
+			)
+		}
+		tagIDs := make([]tag.ID, 0, len(ids))
+		for _, id := range ids {
+			tagIDs = append(tagIDs, tag.ID(id))
+		}
+		findOption.SetTagIDs(tagIDs)
+	}
+
+	// NOTE: 親タグを指定したときに子孫のタグを持つゲームも対象とする
+	if q.Has("tag_descendants") {
+		tagDescendants, err := strconv.ParseBool(q.Get("tag_descendants"))
+		if err != nil {
+			return errors.NewInvalidRequest(
+				errors.Layer_Request,
+				errors.NewInformation(
+					errors.ID_InvalidParams,
+					err.Error(),
+					[]errors.InvalidParams{
+						errors.NewInvalidParams("tag_descendants", q.Get("tag_descendants")),
+					},
+				),
+				"tag_descendants convert error",
+			)
+		}
+		findOption.SetTagDescendants(tagDescendants)
+	}
+
+	if q.Has("platform_ids") {
+		ids, err := parseIDs(q["platform_ids"])
+		if err != nil {
+			return errors.NewInvalidRequest(
+				errors.Layer_Request,
+				errors.NewInformation(
+					errors.ID_InvalidParams,
+					err.Error(),
+					[]errors.InvalidParams{
+						errors.NewInvalidParams("platform_ids", q["platform_ids"]),
+					},
+				),
+				"platform_ids convert error",
+			)
+		}
+		platformIDs := make([]platform.ID, 0, len(ids))
+		for _, id := range ids {
+			platformIDs = append(platformIDs, platform.ID(id))
+		}
+		findOption.SetPlatformIDs(platformIDs)
+	}
+
+	if q.Has("series_ids") {
+		ids, err := parseIDs(q["series_ids"])
+		if err != nil {
+			return errors.NewInvalidRequest(
+				errors.Layer_Request,
+				errors.NewInformation(
+					errors.ID_InvalidParams,
+					err.Error(),
+					[]errors.InvalidParams{
+						errors.NewInvalidParams("series_ids", q["series_ids"]),
+					},
+				),
+				"series_ids convert error",
+			)
+		}
+		seriesIDs := make([]series.ID, 0, len(ids))
+		for _, id := range ids {
+			seriesIDs = append(seriesIDs, series.ID(id))
+		}
+		findOption.SetSeriesIDs(seriesIDs)
+	}
+
+	if q.Has("link_kinds") {
+		linkKinds := []game.LinkKind{}
+		for _, value := range q["link_kinds"] {
+			for _, kindStr := range strings.Split(value, ",") {
+				kindStr = strings.TrimSpace(kindStr)
+				if kindStr == "" {
+					continue
+				}
+				kind, err := game.NewLinkKind(kindStr)
+				if err != nil {
+					return errors.NewInvalidRequest(
+						errors.Layer_Request,
+						errors.NewInformation(
+							errors.ID_InvalidParams,
+							err.Error(),
+							[]errors.InvalidParams{
+								errors.NewInvalidParams("link_kinds", q["link_kinds"]),
+							},
+						),
+						"link_kinds convert error",
+					)
+				}
+				linkKinds = append(linkKinds, kind)
+			}
+		}
+		findOption.SetLinkKinds(linkKinds)
+	}
+
+	// 絞り込み方法がないときはallで検索
+	if !q.Has("match") {
+		return nil
+	}
+	switch q.Get("match") {
+	case "all":
+		findOption.SetMatch(game.Match_All)
+	case "any":
+		findOption.SetMatch(game.Match_Any)
+	default:
+		return errors.NewInvalidRequest(
+			errors.Layer_Request,
+			errors.NewInformation(
+				errors.ID_InvalidParams,
+				"match convert error",
+				[]errors.InvalidParams{
+					errors.NewInvalidParams("match", q.Get("match")),
+				},
+			),
+			"match convert error",
+		)
+	}
+	return nil
+}
+
+// カンマ区切りのIDリストの変換
+func parseIDs(values []string) ([]uint64, error) {
+	ids := make([]uint64, 0, len(values))
+	for _, value := range values {
+		for _, idStr := range strings.Split(value, ",") {
+			idStr = strings.TrimSpace(idStr)
+			if idStr == "" {
+				continue
+			}
+			id, err := strconv.ParseUint(idStr, 10, 64)
+			if err != nil {
+				return nil, err
+			}
+			ids = append(ids, id)
+		}
+	}
+	return ids, nil
+}
+
+// Find: set release period param
+func setReleasePeriod(findOption *game.FindOption, q url.Values) error {
+	// 発売日の指定がないときは何もせず終了
+	if !q.Has("released_from") && !q.Has("released_to") {
+		return nil
+	}
+
+	var from, to *game.ReleaseDate
+	if q.Has("released_from") {
+		fromStr := q.Get("released_from")
+		releaseDate, err := game.NewReleaseDate(fromStr)
+		if err != nil {
+			return errors.NewInvalidRequest(
+				errors.Layer_Request,
+				errors.NewInformation(
+					errors.ID_InvalidParams,
+					err.Error(),
+					[]errors.InvalidParams{
+						errors.NewInvalidParams("released_from", fromStr),
+					},
+				),
+				"released_from convert error",
+			)
+		}
+		from = &releaseDate
+	}
+	if q.Has("released_to") {
+		toStr := q.Get("released_to")
+		releaseDate, err := game.NewReleaseDate(toStr)
+		if err != nil {
+			return errors.NewInvalidRequest(
+				errors.Layer_Request,
+				errors.NewInformation(
+					errors.ID_InvalidParams,
+					err.Error(),
+					[]errors.InvalidParams{
+						errors.NewInvalidParams("released_to", toStr),
+					},
+				),
+				"released_to convert error",
+			)
+		}
+		to = &releaseDate
+	}
+	findOption.SetReleasePeriod(from, to)
+	return nil
+}
+
+// 返却するフィールド
+// NOTE: nilのときはすべて返却する
+type Fields map[string]bool
+
+// fieldsに指定できるフィールド
+// NOTE: relationsは単体取得のときのみ返却する
+var gameFields = []string{
+	"id", "name", "description", "publisher", "developer", "release_date",
+	"links", "aliases", "series", "tags", "platforms", "cover",
+	"created_at", "updated_at", "deleted_at", "relations",
+}
+
+// includeに指定できる関連
+var gameIncludes = []string{"links", "tags", "platforms"}
+
+// Get: parse fields/include param
+// NOTE: fields=id,name と fields=id&fields=name の両方を許容。idは常に返却する
+// 関連はincludeとfieldsの両方に含まれるもののみ読み込み、読み込まない関連は返却しない
+func parseFields(q url.Values) (*game.Include, Fields, error) {
+	var fields, includes map[string]bool
+	if q.Has("fields") {
+		var err error
+		fields, err = parseNames("fields", q["fields"], gameFields)
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+	if q.Has("include") {
+		var err error
+		includes, err = parseNames("include", q["include"], gameIncludes)
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+	if fields == nil && includes == nil {
+		return nil, nil, nil
+	}
+
+	include := game.NewInclude()
+	loaded := func(name string) bool {
+		return (includes == nil || includes[name]) && (fields == nil || fields[name])
+	}
+	include.Links = loaded("links")
+	include.Tags = loaded("tags")
+	include.Platforms = loaded("platforms")
+	// NOTE: includeに指定できない関連はfieldsのみで判断する
+	include.Aliases = fields == nil || fields["aliases"]
+	include.Series = fields == nil || fields["series"]
+	include.Cover = fields == nil || fields["cover"]
+	include.Relations = fields == nil || fields["relations"]
+
+	selected := Fields{}
+	for _, name := range gameFields {
+		if fields == nil || fields[name] {
+			selected[name] = true
+		}
+	}
+	selected["id"] = true
+	selected["links"] = include.Links
+	selected["tags"] = include.Tags
+	selected["platforms"] = include.Platforms
+	return include, selected, nil
+}
+
+// カンマ区切りの名前リストの変換
+// NOTE: 指定できる名前以外はエラー。空のときはnil
+func parseNames(param string, values []string, allowed []string) (map[string]bool, error) {
+	names := map[string]bool{}
+	for _, value := range values {
+		for _, name := range strings.Split(value, ",") {
+			name = strings.TrimSpace(name)
+			if name == "" {
+				continue
+			}
+			if !contains(allowed, name) {
+				return nil, errors.NewInvalidRequest(
+					errors.Layer_Request,
+					errors.NewInformation(
+						errors.ID_InvalidParams,
+						fmt.Sprintf("%s must be in %s", param, strings.Join(allowed, ",")),
+						[]errors.InvalidParams{
+							errors.NewInvalidParams(param, values),
+						},
+					),
+					param+" convert error",
+				)
+			}
+			names[name] = true
+		}
+	}
+	if len(names) == 0 {
+		return nil, nil
+	}
+	return names, nil
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+// 一括登録のリクエストか
+// NOTE: CSV、またはJSON配列のときは一括登録とする
+func IsGameImport(r *http.Request) bool {
+	mediaType, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	switch mediaType {
+	case "text/csv", "multipart/form-data":
+		return true
+	case "", "application/json":
+		// 先頭の空白以外の文字で判定するため、読み込んだ内容を戻せるようにする
+		reader := bufio.NewReader(r.Body)
+		r.Body = struct {
+			io.Reader
+			io.Closer
+		}{reader, r.Body}
+		for i := 1; ; i++ {
+			buf, _ := reader.Peek(i)
+			if len(buf) < i {
+				return false
+			}
+			switch buf[i-1] {
+			case ' ', '\t', '\r', '\n':
+				continue
+			}
+			return buf[i-1] == '['
+		}
+	default:
+		return false
+	}
+}
+
+// Post(multiple): NewImportRows for request
+func NewGameImport(r *http.Request) ([]*game.ImportRow, game.ImportMode, error) {
+	defer r.Body.Close()
+
+	mode, err := newImportMode(r.URL.Query())
+	if err != nil {
+		return nil, 0, err
+	}
+
+	mediaType, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	var rows []*game.ImportRow
+	switch mediaType {
+	case "", "application/json":
+		rows, err = newImportRowsFromJSON(r.Body)
+	case "text/csv":
+		rows, err = newImportRowsFromCSV(r.Body)
+	case "multipart/form-data":
+		file, _, formErr := r.FormFile("file")
+		if formErr != nil {
+			return nil, 0, errors.NewInvalidRequest(
+				errors.Layer_Request,
+				errors.NewInformation(
+					errors.ID_InvalidParams,
+					formErr.Error(),
+					[]errors.InvalidParams{
+						errors.NewInvalidParams("file", nil),
+					},
+				),
+				"file read error",
+			)
+		}
+		defer file.Close()
+		rows, err = newImportRowsFromCSV(file)
+	default:
+		return nil, 0, errors.NewUnsupportedMediaType(
+			errors.Layer_Request,
+			errors.NewInformation(
+				errors.ID_InvalidParams,
+				"supported content type is application/json, text/csv or multipart/form-data",
+				[]errors.InvalidParams{
+					errors.NewInvalidParams("Content-Type", r.Header.Get("Content-Type")),
+				},
+			),
+			"unsupported content type error",
+		)
+	}
+	if err != nil {
+		return nil, 0, err
+	}
+	return rows, mode, nil
+}
+
+// Post(multiple): set import mode param
+func newImportMode(q url.Values) (game.ImportMode, error) {
+	// モードがないときは全件成功時のみ登録
+	if !q.Has("import_mode") {
+		return game.ImportMode_Transaction, nil
+	}
+	switch q.Get("import_mode") {
+	case "transaction":
+		return game.ImportMode_Transaction, nil
+	case "skip_invalid":
+		return game.ImportMode_SkipInvalid, nil
+	default:
+		return 0, errors.NewInvalidRequest(
+			errors.Layer_Request,
+			errors.NewInformation(
+				errors.ID_InvalidParams,
+				"import_mode convert error",
+				[]errors.InvalidParams{
+					errors.NewInvalidParams("import_mode", q.Get("import_mode")),
+				},
+			),
+			"import_mode convert error",
+		)
+	}
+}
+
+// JSON配列から一括登録の行を生成
+// NOTE: 変換できない要素があっても全体はエラーにせず、その行のみ不正とする
+func newImportRowsFromJSON(r io.Reader) ([]*game.ImportRow, error) {
+	elements := []json.RawMessage{}
+	if err := json.NewDecoder(r).Decode(&elements); err != nil {
+		return nil, errors.NewInvalidRequest(
+			errors.Layer_Request,
+			errors.NewInformation(
+				errors.ID_JsonDecodeError,
+				err.Error(),
+				nil,
+			),
+			"json decode error. bad format request.",
+		)
+	}
+
+	rows := make([]*game.ImportRow, 0, len(elements))
+	for _, element := range elements {
+		body := gameBody{}
+		if err := json.Unmarshal(element, &body); err != nil {
+			rows = append(rows, game.NewInvalidImportRow(errors.NewInvalidRequest(
+				errors.Layer_Request,
+				errors.NewInformation(
+					errors.ID_JsonDecodeError,
+					err.Error(),
+					nil,
+				),
+				"json decode error. bad format row.",
+			)))
+			continue
+		}
+		rows = append(rows, body.newImportRow())
+	}
+	return rows, nil
+}
+
+// CSVの列名
+// NOTE: link_*は同じ列名を繰り返すことで複数のリンクを指定できる
+const (
+	csvColumn_Name            = "name"
+	csvColumn_Description     = "description"
+	csvColumn_Publisher       = "publisher"
+	csvColumn_Developer       = "developer"
+	csvColumn_ReleaseDate     = "release_date"
+	csvColumn_PlatformIDs     = "platform_ids"
+	csvColumn_TagIDs          = "tag_ids"
+	csvColumn_LinkTitle       = "link_title"
+	csvColumn_LinkURL         = "link_url"
+	csvColumn_LinkDescription = "link_description"
+	csvColumn_LinkKind        = "link_kind"
+	csvColumn_LinkStore       = "link_store"
+	csvColumn_LinkRegion      = "link_region"
+)
+
+// CSVから一括登録の行を生成
+// NOTE: 1行目はヘッダー。列数の合わない行や変換できない値のある行はその行のみ不正とする
+func newImportRowsFromCSV(r io.Reader) ([]*game.ImportRow, error) {
+	reader := csv.NewReader(r)
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if err != nil {
+		return nil, errors.NewInvalidRequest(
+			errors.Layer_Request,
+			errors.NewInformation(
+				errors.ID_InvalidParams,
+				err.Error(),
+				[]errors.InvalidParams{
+					errors.NewInvalidParams("header", nil),
+				},
+			),
+			"csv header read error",
+		)
+	}
+
+	columns := map[string][]int{}
+	for i, name := range header {
+		// NOTE: Excelで保存したCSVのBOMを除く
+		if i == 0 {
+			name = strings.TrimPrefix(name, "\ufeff")
+		}
+		name = strings.ToLower(strings.TrimSpace(name))
+		switch name {
+		case csvColumn_Name, csvColumn_Description, csvColumn_Publisher, csvColumn_Developer,
+			csvColumn_ReleaseDate, csvColumn_PlatformIDs, csvColumn_TagIDs:
+			if len(columns[name]) > 0 {
+				return nil, errors.NewInvalidRequest(
+					errors.Layer_Request,
+					errors.NewInformation(
+						errors.ID_InvalidParams,
+						"duplicate column",
+						[]errors.InvalidParams{
+							errors.NewInvalidParams("header", name),
+						},
+					),
+					"csv header duplicate error",
+				)
+			}
+		case csvColumn_LinkTitle, csvColumn_LinkURL, csvColumn_LinkDescription,
+			csvColumn_LinkKind, csvColumn_LinkStore, csvColumn_LinkRegion:
+		default:
+			return nil, errors.NewInvalidRequest(
+				errors.Layer_Request,
+				errors.NewInformation(
+					errors.ID_InvalidParams,
+					"unknown column",
+					[]errors.InvalidParams{
+						errors.NewInvalidParams("header", name),
+					},
+				),
+				"csv header unknown column error",
+			)
+		}
+		columns[name] = append(columns[name], i)
+	}
+	if len(columns[csvColumn_Name]) == 0 {
+		return nil, errors.NewInvalidRequest(
+			errors.Layer_Request,
+			errors.NewInformation(
+				errors.ID_InvalidParams,
+				"name column is required",
+				[]errors.InvalidParams{
+					errors.NewInvalidParams("header", csvColumn_Name),
+				},
+			),
+			"csv header name column error",
+		)
+	}
+
+	rows := []*game.ImportRow{}
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			var parseErr *csv.ParseError
+			if stdErrors.As(err, &parseErr) && parseErr.Err == csv.ErrFieldCount {
+				rows = append(rows, game.NewInvalidImportRow(errors.NewInvalidRequest(
+					errors.Layer_Request,
+					errors.NewInformation(
+						errors.ID_InvalidParams,
+						err.Error(),
+						nil,
+					),
+					"csv field count error",
+				)))
+				continue
+			}
+			return nil, errors.NewInvalidRequest(
+				errors.Layer_Request,
+				errors.NewInformation(
+					errors.ID_InvalidParams,
+					err.Error(),
+					nil,
+				),
+				"csv read error",
+			)
+		}
+		rows = append(rows, newImportRowFromCSVRecord(columns, record))
+	}
+	return rows, nil
+}
+
+// CSVの1行から一括登録の行を生成
+func newImportRowFromCSVRecord(columns map[string][]int, record []string) *game.ImportRow {
+	// 列の値(n番目の同名列)
+	value := func(name string, n int) string {
+		if n >= len(columns[name]) {
+			return ""
+		}
+		return strings.TrimSpace(record[columns[name][n]])
+	}
+
+	body := gameBody{
+		Name:        game.Name(value(csvColumn_Name, 0)),
+		Description: game.Description(value(csvColumn_Description, 0)),
+		Publisher:   game.Publisher(value(csvColumn_Publisher, 0)),
+		Developer:   game.Developer(value(csvColumn_Developer, 0)),
+		ReleaseDate: value(csvColumn_ReleaseDate, 0),
+	}
+
+	platformIDs, err := parseIDs([]string{value(csvColumn_PlatformIDs, 0)})
+	if err != nil {
+		return game.NewInvalidImportRow(errors.NewInvalidRequest(
+			errors.Layer_Request,
+			errors.NewInformation(
+				errors.ID_InvalidParams,
+				err.Error(),
+				[]errors.InvalidParams{
+					errors.NewInvalidParams("platform_ids", value(csvColumn_PlatformIDs, 0)),
+				},
+			),
+			"platform_ids convert error",
+		))
+	}
+	for _, id := range platformIDs {
+		body.PlatformIDs = append(body.PlatformIDs, platform.ID(id))
+	}
+
+	tagIDs, err := parseIDs([]string{value(csvColumn_TagIDs, 0)})
+	if err != nil {
+		return game.NewInvalidImportRow(errors.NewInvalidRequest(
+			errors.Layer_Request,
+			errors.NewInformation(
+				errors.ID_InvalidParams,
+				err.Error(),
+				[]errors.InvalidParams{
+					errors.NewInvalidParams("tag_ids", value(csvColumn_TagIDs, 0)),
+				},
+			),
+			"tag_ids convert error",
+		))
+	}
+	for _, id := range tagIDs {
+		body.TagIDs = append(body.TagIDs, tag.ID(id))
+	}
+
+	// NOTE: タイトルとURLがともに空のリンクは指定なしとする
+	linkCount := len(columns[csvColumn_LinkTitle])
+	if n := len(columns[csvColumn_LinkURL]); n > linkCount {
+		linkCount = n
+	}
+	for n := 0; n < linkCount; n++ {
+		if value(csvColumn_LinkTitle, n) == "" && value(csvColumn_LinkURL, n) == "" {
+			continue
+		}
+		body.Links = append(body.Links, linkBody{
+			Title:           game.Title(value(csvColumn_LinkTitle, n)),
+			URL:             value(csvColumn_LinkURL, n),
+			LinkDescription: game.LinkDescription(value(csvColumn_LinkDescription, n)),
+			Kind:            value(csvColumn_LinkKind, n),
+			Store:           game.LinkStore(value(csvColumn_LinkStore, n)),
+			Region:          game.LinkRegion(value(csvColumn_LinkRegion, n)),
+		})
+	}
+	return body.newImportRow()
+}
+
+func (body *gameBody) newImportRow() *game.ImportRow {
+	g, err := body.newGame()
+	if err != nil {
+		return game.NewInvalidImportRow(err)
+	}
+	return game.NewImportRow(g, body.PlatformIDs, body.TagIDs)
+}
+
+// Put, Patch, Delete: NewPrecondition for request
+// NOTE: If-Match未指定のときはnilになり、無条件で更新する
+func NewPrecondition(r *http.Request) *version.Precondition {
+	return version.NewPrecondition(r.Header.Get("If-Match"))
+}
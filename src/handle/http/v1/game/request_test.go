@@ -3,9 +3,13 @@ package game
 import (
 	"context"
 	"io"
+	"mysrtafes-backend/handle/http/v1/cursor"
+	"mysrtafes-backend/pkg/errors"
 	"mysrtafes-backend/pkg/game"
 	"mysrtafes-backend/pkg/game/platform"
+	"mysrtafes-backend/pkg/game/series"
 	"mysrtafes-backend/pkg/game/tag"
+	"mysrtafes-backend/pkg/version"
 	"net/http"
 	"net/http/httptest"
 	"net/url"
@@ -63,7 +67,7 @@ func TestNewGameCreate(t *testing.T) {
 				Description: "desc",
 				Publisher:   "Nintendo",
 				Developer:   "Chu Soft",
-				ReleaseDate: game.ReleaseDate(time.Date(1997, 1, 22, 0, 0, 0, 0, time.UTC)),
+				ReleaseDate: game.ReleaseDate{Date: time.Date(1997, 1, 22, 0, 0, 0, 0, time.UTC)},
 				Links: []*game.Link{
 					{
 						Title: "wiki",
@@ -72,6 +76,7 @@ func TestNewGameCreate(t *testing.T) {
 							return g
 						}(),
 						LinkDescription: "色々知れます",
+						DisplayOrder:    1,
 					},
 					{
 						Title: "wiki2",
@@ -80,6 +85,7 @@ func TestNewGameCreate(t *testing.T) {
 							return g
 						}(),
 						LinkDescription: "色々知れます2",
+						DisplayOrder:    2,
 					},
 				},
 			},
@@ -145,6 +151,74 @@ func TestNewGameCreate(t *testing.T) {
 			},
 			wantErr: true,
 		},
+		{
+			name: "OK aliases",
+			args: args{
+				method: http.MethodPost,
+				url:    "http://example.com",
+				body: strings.NewReader(`{
+                    "name": "風来のシレン",
+                    "release_date": "1995-12-01",
+                    "aliases": [
+                        {
+                            "name": "ふうらいのしれん",
+                            "language": "ja",
+                            "kind": "reading"
+                        },
+                        {
+                            "name": "Shiren the Wanderer",
+                            "language": "en"
+                        }
+                    ]
+                }`),
+			},
+			want: &game.Game{
+				Name:        "風来のシレン",
+				ReleaseDate: game.ReleaseDate{Date: time.Date(1995, 12, 1, 0, 0, 0, 0, time.UTC)},
+				Links:       []*game.Link{},
+				Aliases: []*game.Alias{
+					{Name: "ふうらいのしれん", Language: "ja", Kind: game.AliasKind_Reading},
+					{Name: "Shiren the Wanderer", Language: "en", Kind: game.AliasKind_Official},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "OK series",
+			args: args{
+				method: http.MethodPost,
+				url:    "http://example.com",
+				body: strings.NewReader(`{
+                    "name": "風来のシレン2 鬼襲来!シレン城!",
+                    "release_date": "2000-09-27",
+                    "series_id": 1,
+                    "series_number": 2
+                }`),
+			},
+			want: (&game.Game{
+				Name:        "風来のシレン2 鬼襲来!シレン城!",
+				ReleaseDate: game.ReleaseDate{Date: time.Date(2000, 9, 27, 0, 0, 0, 0, time.UTC)},
+				Links:       []*game.Link{},
+			}).SetSeries(&series.Series{ID: 1}, 2),
+			wantErr: false,
+		},
+		{
+			name: "alias kind error",
+			args: args{
+				method: http.MethodPost,
+				url:    "http://example.com",
+				body: strings.NewReader(`{
+                    "name": "風来のシレン",
+                    "aliases": [
+                        {
+                            "name": "シレン",
+                            "kind": "nickname"
+                        }
+                    ]
+                }`),
+			},
+			wantErr: true,
+		},
 		{
 			name: "decode err",
 			args: args{
@@ -259,6 +333,86 @@ func TestNewGameID(t *testing.T) {
 	}
 }
 
+func TestNewGameTagID(t *testing.T) {
+	tests := []struct {
+		name    string
+		param   string
+		want    tag.ID
+		wantErr bool
+	}{
+		{
+			name:  "OK",
+			param: "3",
+			want:  3,
+		},
+		{
+			name:    "blank err",
+			param:   "",
+			wantErr: true,
+		},
+		{
+			name:    "decode err",
+			param:   "jh",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := chi.NewRouteContext()
+			ctx.URLParams.Add("tagID", tt.param)
+			r := httptest.NewRequest(http.MethodGet, "http://example.com", nil)
+			r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, ctx))
+
+			got, err := NewGameTagID(r)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("NewGameTagID() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestNewGamePlatformID(t *testing.T) {
+	tests := []struct {
+		name    string
+		param   string
+		want    platform.ID
+		wantErr bool
+	}{
+		{
+			name:  "OK",
+			param: "3",
+			want:  3,
+		},
+		{
+			name:    "blank err",
+			param:   "",
+			wantErr: true,
+		},
+		{
+			name:    "decode err",
+			param:   "jh",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := chi.NewRouteContext()
+			ctx.URLParams.Add("platformID", tt.param)
+			r := httptest.NewRequest(http.MethodGet, "http://example.com", nil)
+			r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, ctx))
+
+			got, err := NewGamePlatformID(r)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("NewGamePlatformID() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
 func TestNewGameFindOption(t *testing.T) {
 	type args struct {
 		method string
@@ -294,6 +448,30 @@ func TestNewGameFindOption(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "keyword with page mode ok",
+			args: args{
+				method: http.MethodGet,
+				url:    "http://example.com?mode=page&limit=10&order=name&q=%EF%BD%BC%EF%BE%9A%EF%BE%9D",
+				body:   strings.NewReader(``),
+			},
+			want: &game.FindOption{
+				SearchMode: game.SearchMode_Pagination,
+				Seek: game.Seek{
+					LastID: 0,
+					Count:  30,
+				},
+				Pagination: game.Pagination{
+					Limit:  10,
+					Offset: 0,
+				},
+				OrderOption: game.OrderOption{
+					Order: game.Order_Name,
+					Desc:  false,
+				},
+				Keyword: "シレン",
+			},
+		},
 		{
 			name: "search mode parse error",
 			args: args{
@@ -304,6 +482,62 @@ func TestNewGameFindOption(t *testing.T) {
 			want:    nil,
 			wantErr: true,
 		},
+		{
+			name: "cursor with seek mode ok",
+			args: args{
+				method: http.MethodGet,
+				url: "http://example.com?mode=seek&count=10&order=name&desc=true&cursor=" + cursor.Encode(cursorResource, &game.Cursor{
+					Order:    game.Order_Name,
+					Desc:     true,
+					ID:       12,
+					SortName: "フウライノシレン",
+					Name:     "風来のシレン",
+				}),
+				body: strings.NewReader(``),
+			},
+			want: &game.FindOption{
+				SearchMode: game.SearchMode_Seek,
+				Seek: game.Seek{
+					LastID: 0,
+					Count:  10,
+					Cursor: &game.Cursor{
+						Order:    game.Order_Name,
+						Desc:     true,
+						ID:       12,
+						SortName: "フウライノシレン",
+						Name:     "風来のシレン",
+					},
+				},
+				Pagination: game.Pagination{
+					Limit:  30,
+					Offset: 0,
+				},
+				OrderOption: game.OrderOption{
+					Order: game.Order_Name,
+					Desc:  true,
+				},
+			},
+		},
+		{
+			name: "tampered cursor error",
+			args: args{
+				method: http.MethodGet,
+				url:    "http://example.com?mode=seek&cursor=" + strings.Split(cursor.Encode(cursorResource, &game.Cursor{ID: 12}), ".")[0] + ".invalid",
+				body:   strings.NewReader(``),
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name: "cursor of other resource error",
+			args: args{
+				method: http.MethodGet,
+				url:    "http://example.com?mode=seek&cursor=" + cursor.Encode("tags", &game.Cursor{ID: 12}),
+				body:   strings.NewReader(``),
+			},
+			want:    nil,
+			wantErr: true,
+		},
 		{
 			name: "order parse error",
 			args: args{
@@ -314,6 +548,46 @@ func TestNewGameFindOption(t *testing.T) {
 			want:    nil,
 			wantErr: true,
 		},
+		{
+			name: "include ok",
+			args: args{
+				method: http.MethodGet,
+				url:    "http://example.com?include=tags",
+				body:   strings.NewReader(``),
+			},
+			want: &game.FindOption{
+				SearchMode: game.SearchMode_All,
+				Seek: game.Seek{
+					LastID: 0,
+					Count:  30,
+				},
+				Pagination: game.Pagination{
+					Limit:  30,
+					Offset: 0,
+				},
+				OrderOption: game.OrderOption{
+					Order: game.Order_ID,
+					Desc:  false,
+				},
+				Include: &game.Include{
+					Aliases:   true,
+					Series:    true,
+					Tags:      true,
+					Cover:     true,
+					Relations: true,
+				},
+			},
+		},
+		{
+			name: "include parse error",
+			args: args{
+				method: http.MethodGet,
+				url:    "http://example.com?include=relations",
+				body:   strings.NewReader(``),
+			},
+			want:    nil,
+			wantErr: true,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -362,6 +636,7 @@ func TestNewGameUpdate(t *testing.T) {
                             "description":"色々知れます"
                         },
                         {
+                            "id": 10,
                             "title": "wiki2",
                             "url": "http://example.com2",
                             "description":"色々知れます2"
@@ -380,7 +655,7 @@ func TestNewGameUpdate(t *testing.T) {
 				Description: "desc",
 				Publisher:   "Nintendo",
 				Developer:   "Chu Soft",
-				ReleaseDate: game.ReleaseDate(time.Date(1997, 1, 22, 0, 0, 0, 0, time.UTC)),
+				ReleaseDate: game.ReleaseDate{Date: time.Date(1997, 1, 22, 0, 0, 0, 0, time.UTC)},
 				Links: []*game.Link{
 					{
 						Title: "wiki",
@@ -389,14 +664,17 @@ func TestNewGameUpdate(t *testing.T) {
 							return g
 						}(),
 						LinkDescription: "色々知れます",
+						DisplayOrder:    1,
 					},
 					{
-						Title: "wiki2",
+						LinkID: 10,
+						Title:  "wiki2",
 						URL: func() game.URL {
 							g, _ := game.NewURL("http://example.com2")
 							return g
 						}(),
 						LinkDescription: "色々知れます2",
+						DisplayOrder:    2,
 					},
 				},
 			},
@@ -679,6 +957,46 @@ func Test_setOrder(t *testing.T) {
 			},
 			wantErr: false,
 		},
+		{
+			name: "ok desc true, release_date order",
+			args: args{
+				findOption: &game.FindOption{
+					SearchMode: game.SearchMode_All,
+					Seek: game.Seek{
+						LastID: 0,
+						Count:  30,
+					},
+					Pagination: game.Pagination{
+						Limit:  30,
+						Offset: 0,
+					},
+					OrderOption: game.OrderOption{
+						Order: game.Order_ID,
+						Desc:  false,
+					},
+				},
+				q: url.Values{
+					"desc":  []string{"true"},
+					"order": []string{"release_date"},
+				},
+			},
+			want: &game.FindOption{
+				SearchMode: game.SearchMode_All,
+				Seek: game.Seek{
+					LastID: 0,
+					Count:  30,
+				},
+				Pagination: game.Pagination{
+					Limit:  30,
+					Offset: 0,
+				},
+				OrderOption: game.OrderOption{
+					Order: game.Order_ReleaseDate,
+					Desc:  true,
+				},
+			},
+			wantErr: false,
+		},
 		{
 			name: "ok desc false, name order",
 			args: args{
@@ -798,7 +1116,7 @@ func Test_setOrder(t *testing.T) {
 			wantErr: false,
 		},
 		{
-			name: "when seek mode, default option",
+			name: "when seek mode, order is available",
 			args: args{
 				findOption: &game.FindOption{
 					SearchMode: game.SearchMode_Seek,
@@ -831,8 +1149,8 @@ func Test_setOrder(t *testing.T) {
 					Offset: 0,
 				},
 				OrderOption: game.OrderOption{
-					Order: game.Order_ID,
-					Desc:  false,
+					Order: game.Order_Name,
+					Desc:  true,
 				},
 			},
 			wantErr: false,
@@ -1505,3 +1823,1004 @@ func Test_setSearchMode(t *testing.T) {
 		})
 	}
 }
+
+func Test_setKeyword(t *testing.T) {
+	type args struct {
+		findOption *game.FindOption
+		q          url.Values
+	}
+	tests := []struct {
+		name    string
+		args    args
+		want    *game.FindOption
+		wantErr bool
+	}{
+		{
+			name: "ok no set",
+			args: args{
+				findOption: game.NewFindOption(),
+				q:          url.Values{},
+			},
+			want:    game.NewFindOption(),
+			wantErr: false,
+		},
+		{
+			name: "ok hiragana",
+			args: args{
+				findOption: game.NewFindOption(),
+				q: url.Values{
+					"q": []string{"しれん"},
+				},
+			},
+			want:    game.NewFindOption().SetKeyword("シレン"),
+			wantErr: false,
+		},
+		{
+			name: "ok half width katakana",
+			args: args{
+				findOption: game.NewFindOption(),
+				q: url.Values{
+					"q": []string{"ｼﾚﾝ"},
+				},
+			},
+			want:    game.NewFindOption().SetKeyword("シレン"),
+			wantErr: false,
+		},
+		{
+			name: "too long keyword error",
+			args: args{
+				findOption: game.NewFindOption(),
+				q: url.Values{
+					"q": []string{strings.Repeat("a", 256)},
+				},
+			},
+			want:    game.NewFindOption(),
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := setKeyword(tt.args.findOption, tt.args.q); (err != nil) != tt.wantErr {
+				t.Errorf("setKeyword() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			assert.Equal(t, tt.want, tt.args.findOption)
+		})
+	}
+}
+
+func Test_setFilter(t *testing.T) {
+	type args struct {
+		findOption *game.FindOption
+		q          url.Values
+	}
+	tests := []struct {
+		name    string
+		args    args
+		want    *game.FindOption
+		wantErr bool
+	}{
+		{
+			name: "ok no set",
+			args: args{
+				findOption: game.NewFindOption(),
+				q:          url.Values{},
+			},
+			want:    game.NewFindOption(),
+			wantErr: false,
+		},
+		{
+			name: "ok comma separated",
+			args: args{
+				findOption: game.NewFindOption(),
+				q: url.Values{
+					"tag_ids":      []string{"1,2"},
+					"platform_ids": []string{"3"},
+				},
+			},
+			want: game.NewFindOption().
+				SetTagIDs([]tag.ID{1, 2}).
+				SetPlatformIDs([]platform.ID{3}),
+			wantErr: false,
+		},
+		{
+			name: "ok repeated param and match any",
+			args: args{
+				findOption: game.NewFindOption(),
+				q: url.Values{
+					"tag_ids": []string{"1", "2,4"},
+					"match":   []string{"any"},
+				},
+			},
+			want: game.NewFindOption().
+				SetTagIDs([]tag.ID{1, 2, 4}).
+				SetMatch(game.Match_Any),
+			wantErr: false,
+		},
+		{
+			name: "ok tag descendants",
+			args: args{
+				findOption: game.NewFindOption(),
+				q: url.Values{
+					"tag_ids":         []string{"1"},
+					"tag_descendants": []string{"true"},
+				},
+			},
+			want: game.NewFindOption().
+				SetTagIDs([]tag.ID{1}).
+				SetTagDescendants(true),
+			wantErr: false,
+		},
+		{
+			name: "bad tag descendants error",
+			args: args{
+				findOption: game.NewFindOption(),
+				q: url.Values{
+					"tag_descendants": []string{"yes"},
+				},
+			},
+			want:    game.NewFindOption(),
+			wantErr: true,
+		},
+		{
+			name: "ok match all",
+			args: args{
+				findOption: game.NewFindOption(),
+				q: url.Values{
+					"platform_ids": []string{"1"},
+					"match":        []string{"all"},
+				},
+			},
+			want: game.NewFindOption().
+				SetPlatformIDs([]platform.ID{1}).
+				SetMatch(game.Match_All),
+			wantErr: false,
+		},
+		{
+			name: "bad tag_ids error",
+			args: args{
+				findOption: game.NewFindOption(),
+				q: url.Values{
+					"tag_ids": []string{"1,a"},
+				},
+			},
+			want:    game.NewFindOption(),
+			wantErr: true,
+		},
+		{
+			name: "bad platform_ids error",
+			args: args{
+				findOption: game.NewFindOption(),
+				q: url.Values{
+					"platform_ids": []string{"-1"},
+				},
+			},
+			want:    game.NewFindOption(),
+			wantErr: true,
+		},
+		{
+			name: "ok series_ids",
+			args: args{
+				findOption: game.NewFindOption(),
+				q: url.Values{
+					"series_ids": []string{"1,2", "2"},
+				},
+			},
+			want: game.NewFindOption().
+				SetSeriesIDs([]series.ID{1, 2}),
+			wantErr: false,
+		},
+		{
+			name: "bad series_ids error",
+			args: args{
+				findOption: game.NewFindOption(),
+				q: url.Values{
+					"series_ids": []string{"a"},
+				},
+			},
+			want:    game.NewFindOption(),
+			wantErr: true,
+		},
+		{
+			name: "ok link kinds",
+			args: args{
+				findOption: game.NewFindOption(),
+				q: url.Values{
+					"link_kinds": []string{"store,leaderboard"},
+				},
+			},
+			want: game.NewFindOption().
+				SetLinkKinds([]game.LinkKind{game.LinkKind_Store, game.LinkKind_Leaderboard}),
+			wantErr: false,
+		},
+		{
+			name: "bad link_kinds error",
+			args: args{
+				findOption: game.NewFindOption(),
+				q: url.Values{
+					"link_kinds": []string{"store,blog"},
+				},
+			},
+			want:    game.NewFindOption(),
+			wantErr: true,
+		},
+		{
+			name: "bad match error",
+			args: args{
+				findOption: game.NewFindOption(),
+				q: url.Values{
+					"match": []string{"some"},
+				},
+			},
+			want:    game.NewFindOption(),
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := setFilter(tt.args.findOption, tt.args.q); (err != nil) != tt.wantErr {
+				t.Errorf("setFilter() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			assert.Equal(t, tt.want, tt.args.findOption)
+		})
+	}
+}
+
+func Test_setReleasePeriod(t *testing.T) {
+	from, _ := game.NewReleaseDate("2000-01-01")
+	to, _ := game.NewReleaseDate("2000-12-31")
+	type args struct {
+		findOption *game.FindOption
+		q          url.Values
+	}
+	tests := []struct {
+		name    string
+		args    args
+		want    *game.FindOption
+		wantErr bool
+	}{
+		{
+			name: "ok no set",
+			args: args{
+				findOption: game.NewFindOption(),
+				q:          url.Values{},
+			},
+			want:    game.NewFindOption(),
+			wantErr: false,
+		},
+		{
+			name: "ok both",
+			args: args{
+				findOption: game.NewFindOption(),
+				q: url.Values{
+					"released_from": []string{"2000-01-01"},
+					"released_to":   []string{"2000-12-31"},
+				},
+			},
+			want:    game.NewFindOption().SetReleasePeriod(&from, &to),
+			wantErr: false,
+		},
+		{
+			name: "ok from only",
+			args: args{
+				findOption: game.NewFindOption(),
+				q: url.Values{
+					"released_from": []string{"2000-01-01"},
+				},
+			},
+			want:    game.NewFindOption().SetReleasePeriod(&from, nil),
+			wantErr: false,
+		},
+		{
+			name: "ok partial precision",
+			args: args{
+				findOption: game.NewFindOption(),
+				q: url.Values{
+					"released_from": []string{"1995"},
+					"released_to":   []string{"1996-03"},
+				},
+			},
+			want: game.NewFindOption().SetReleasePeriod(
+				&game.ReleaseDate{
+					Date:      time.Date(1995, 1, 1, 0, 0, 0, 0, time.UTC),
+					Precision: game.Precision_Year,
+				},
+				&game.ReleaseDate{
+					Date:      time.Date(1996, 3, 1, 0, 0, 0, 0, time.UTC),
+					Precision: game.Precision_Month,
+				},
+			),
+			wantErr: false,
+		},
+		{
+			name: "bad released_from error",
+			args: args{
+				findOption: game.NewFindOption(),
+				q: url.Values{
+					"released_from": []string{"2000/01/01"},
+				},
+			},
+			want:    game.NewFindOption(),
+			wantErr: true,
+		},
+		{
+			name: "bad released_to error",
+			args: args{
+				findOption: game.NewFindOption(),
+				q: url.Values{
+					"released_to": []string{"aaa"},
+				},
+			},
+			want:    game.NewFindOption(),
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := setReleasePeriod(tt.args.findOption, tt.args.q); (err != nil) != tt.wantErr {
+				t.Errorf("setReleasePeriod() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			assert.Equal(t, tt.want, tt.args.findOption)
+		})
+	}
+}
+
+func TestIsGameImport(t *testing.T) {
+	tests := []struct {
+		name        string
+		contentType string
+		body        string
+		want        bool
+	}{
+		{
+			name:        "JSON配列",
+			contentType: "application/json",
+			body:        " \n[{\"name\": \"game\"}]",
+			want:        true,
+		},
+		{
+			name:        "JSONオブジェクト",
+			contentType: "application/json; charset=utf-8",
+			body:        `{"name": "game"}`,
+			want:        false,
+		},
+		{
+			name:        "CSV",
+			contentType: "text/csv",
+			body:        "name\ngame",
+			want:        true,
+		},
+		{
+			name:        "空のボディ",
+			contentType: "",
+			body:        "",
+			want:        false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := httptest.NewRequest(http.MethodPost, "http://example.com", strings.NewReader(tt.body))
+			r.Header.Set("Content-Type", tt.contentType)
+			assert.Equal(t, tt.want, IsGameImport(r))
+			// NOTE: 判定後もボディを読めること
+			body, _ := io.ReadAll(r.Body)
+			assert.Equal(t, tt.body, string(body))
+		})
+	}
+}
+
+func TestNewGameImport(t *testing.T) {
+	releaseDate, _ := game.NewReleaseDate("1995-12")
+	linkURL, _ := game.NewURL("http://example.com")
+	type args struct {
+		url         string
+		contentType string
+		body        string
+	}
+	tests := []struct {
+		name     string
+		args     args
+		want     []*game.ImportRow
+		wantErrs []bool
+		wantMode game.ImportMode
+		wantErr  bool
+	}{
+		{
+			name: "JSON OK",
+			args: args{
+				url:         "http://example.com",
+				contentType: "application/json",
+				body:        `[{"name": "game1", "release_date": "1995-12", "tag_ids": [1, 2]}, {"name": "game2", "release_date": "1995-12-32"}, 1]`,
+			},
+			want: []*game.ImportRow{
+				game.NewImportRow(game.New("game1", "", "", "", releaseDate, []*game.Link{}), nil, []tag.ID{1, 2}),
+			},
+			wantErrs: []bool{false, true, true},
+			wantMode: game.ImportMode_Transaction,
+		},
+		{
+			name: "CSV OK",
+			args: args{
+				url:         "http://example.com?import_mode=skip_invalid",
+				contentType: "text/csv",
+				body: "\ufeffname,release_date,platform_ids,link_title,link_url,link_kind\n" +
+					"game1,1995-12,\"1,2\",公式,http://example.com,official\n" +
+					"game2,1995-12,a,,,\n" +
+					"game3,1995-12\n",
+			},
+			want: []*game.ImportRow{
+				game.NewImportRow(game.New("game1", "", "", "", releaseDate, []*game.Link{
+					game.NewLink("公式", linkURL, "").SetDisplayOrder(1).SetKind(game.LinkKind_Official),
+				}), []platform.ID{1, 2}, nil),
+			},
+			wantErrs: []bool{false, true, true},
+			wantMode: game.ImportMode_SkipInvalid,
+		},
+		{
+			name: "CSV unknown column error",
+			args: args{
+				url:         "http://example.com",
+				contentType: "text/csv",
+				body:        "name,price\ngame1,100\n",
+			},
+			wantErr: true,
+		},
+		{
+			name: "CSV name column error",
+			args: args{
+				url:         "http://example.com",
+				contentType: "text/csv",
+				body:        "description\n説明\n",
+			},
+			wantErr: true,
+		},
+		{
+			name: "import_mode error",
+			args: args{
+				url:         "http://example.com?import_mode=all",
+				contentType: "application/json",
+				body:        `[{"name": "game1", "release_date": "1995"}]`,
+			},
+			wantErr: true,
+		},
+		{
+			name: "unsupported content type error",
+			args: args{
+				url:         "http://example.com",
+				contentType: "application/xml",
+				body:        `<games></games>`,
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := httptest.NewRequest(http.MethodPost, tt.args.url, strings.NewReader(tt.args.body))
+			r.Header.Set("Content-Type", tt.args.contentType)
+			got, gotMode, err := NewGameImport(r)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("NewGameImport() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if tt.wantErr {
+				return
+			}
+			assert.Equal(t, tt.wantMode, gotMode)
+			if !assert.Equal(t, len(tt.wantErrs), len(got)) {
+				return
+			}
+			valid := []*game.ImportRow{}
+			for i, row := range got {
+				assert.Equal(t, tt.wantErrs[i], row.Err != nil, "row %d", i+1)
+				if row.Err == nil {
+					valid = append(valid, row)
+				}
+			}
+			assert.Equal(t, tt.want, valid)
+		})
+	}
+}
+
+func TestNewGamePatch(t *testing.T) {
+	linkURL, _ := game.NewURL("http://example.com")
+	current := func() *game.Game {
+		return &game.Game{
+			ID:          1,
+			Name:        "TestGame",
+			Description: "desc",
+			Publisher:   "Nintendo",
+			Developer:   "Chu Soft",
+			ReleaseDate: game.NewReleaseDateWithPrecision(time.Date(1997, 1, 1, 0, 0, 0, 0, time.UTC), game.Precision_Month),
+			Links: []*game.Link{
+				game.NewLinkWithID(10, "wiki", linkURL, "色々知れます").
+					SetDisplayOrder(1).
+					SetKind(game.LinkKind_Wiki),
+			},
+			Platforms: []*platform.Platform{{ID: 2}},
+			Tags:      []*tag.Tag{{ID: 1}, {ID: 3}},
+		}
+	}
+	type args struct {
+		contentType string
+		body        io.Reader
+		pathParam   map[string]string
+	}
+	// NOTE: 発売日が未登録のゲーム
+	noReleaseDate := func() *game.Game {
+		g := current()
+		g.ReleaseDate = game.ReleaseDate{}
+		return g
+	}
+	tests := []struct {
+		name         string
+		current      func() *game.Game
+		args         args
+		wantID       game.ID
+		want         *game.Game
+		want1        []platform.ID
+		want2        []tag.ID
+		wantErr      bool
+		wantPatchErr bool
+	}{
+		{
+			name: "OK(keep links, platforms and tags)",
+			args: args{
+				contentType: "application/merge-patch+json",
+				body:        strings.NewReader(`{"description": "patched"}`),
+				pathParam: map[string]string{
+					"gameID": "1",
+				},
+			},
+			wantID: 1,
+			want: func() *game.Game {
+				g := current()
+				g.Description = "patched"
+				g.Platforms = nil
+				g.Tags = nil
+				return g
+			}(),
+			want1: []platform.ID{2},
+			want2: []tag.ID{1, 3},
+		},
+		{
+			name: "OK(replace array and delete by null)",
+			args: args{
+				contentType: "application/merge-patch+json",
+				body:        strings.NewReader(`{"publisher": null, "tag_ids": [4], "links": []}`),
+				pathParam: map[string]string{
+					"gameID": "1",
+				},
+			},
+			wantID: 1,
+			want: func() *game.Game {
+				g := current()
+				g.Publisher = ""
+				g.Links = []*game.Link{}
+				g.Platforms = nil
+				g.Tags = nil
+				return g
+			}(),
+			want1: []platform.ID{2},
+			want2: []tag.ID{4},
+		},
+		{
+			name:    "OK(no release date)",
+			current: noReleaseDate,
+			args: args{
+				contentType: "application/merge-patch+json",
+				body:        strings.NewReader(`{"description": "patched"}`),
+				pathParam: map[string]string{
+					"gameID": "1",
+				},
+			},
+			wantID: 1,
+			want: func() *game.Game {
+				g := noReleaseDate()
+				g.Description = "patched"
+				g.Platforms = nil
+				g.Tags = nil
+				return g
+			}(),
+			want1: []platform.ID{2},
+			want2: []tag.ID{1, 3},
+		},
+		{
+			name: "OK(delete release date by null)",
+			args: args{
+				contentType: "application/merge-patch+json",
+				body:        strings.NewReader(`{"release_date": null}`),
+				pathParam: map[string]string{
+					"gameID": "1",
+				},
+			},
+			wantID: 1,
+			want: func() *game.Game {
+				g := noReleaseDate()
+				g.Platforms = nil
+				g.Tags = nil
+				return g
+			}(),
+			want1: []platform.ID{2},
+			want2: []tag.ID{1, 3},
+		},
+		{
+			name: "bad id err",
+			args: args{
+				contentType: "application/merge-patch+json",
+				body:        strings.NewReader(`{"description": "patched"}`),
+				pathParam: map[string]string{
+					"gameID": "a",
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "content type err",
+			args: args{
+				contentType: "text/csv",
+				body:        strings.NewReader(`{"description": "patched"}`),
+				pathParam: map[string]string{
+					"gameID": "1",
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "release_date err",
+			args: args{
+				contentType: "application/merge-patch+json",
+				body:        strings.NewReader(`{"release_date": "1997/01/22"}`),
+				pathParam: map[string]string{
+					"gameID": "1",
+				},
+			},
+			wantID:       1,
+			wantPatchErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := chi.NewRouteContext()
+			for key, val := range tt.args.pathParam {
+				ctx.URLParams.Add(key, val)
+			}
+			r := httptest.NewRequest(http.MethodPatch, "http://example.com", tt.args.body)
+			r.Header.Set("Content-Type", tt.args.contentType)
+			r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, ctx))
+			gotID, patcher, err := NewGamePatch(r)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("NewGamePatch() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if tt.wantErr {
+				return
+			}
+			assert.Equal(t, tt.wantID, gotID)
+			if tt.current == nil {
+				tt.current = current
+			}
+			got, got1, got2, err := patcher(tt.current())
+			if (err != nil) != tt.wantPatchErr {
+				t.Errorf("game.Patcher() error = %v, wantPatchErr %v", err, tt.wantPatchErr)
+				return
+			}
+			assert.Equal(t, tt.want, got)
+			assert.Equal(t, tt.want1, got1)
+			assert.Equal(t, tt.want2, got2)
+		})
+	}
+}
+
+func TestNewGameBulkUpdate(t *testing.T) {
+	releaseDate, _ := game.NewReleaseDate("1995")
+	tests := []struct {
+		name    string
+		body    string
+		want    []*game.UpdateRow
+		wantErr bool
+	}{
+		{
+			name: "OK",
+			body: `[{"id": 1, "name": "game1", "release_date": "1995", "tag_ids": [2]}, {"id": 3, "name": "game3", "release_date": "1995", "platform_ids": [4]}]`,
+			want: []*game.UpdateRow{
+				game.NewUpdateRow(game.NewWithID(1, "game1", "", "", "", releaseDate, []*game.Link{}), nil, []tag.ID{2}),
+				game.NewUpdateRow(game.NewWithID(3, "game3", "", "", "", releaseDate, []*game.Link{}), []platform.ID{4}, nil),
+			},
+			wantErr: false,
+		},
+		{
+			name: "変換できない行は失敗、残りは変換",
+			body: `[{"id": 1, "name": "game1", "release_date": "1995-13"}, {"id": 2, "name": 2}, {"id": 3, "name": "game3", "release_date": "1995"}]`,
+			want: []*game.UpdateRow{
+				game.NewInvalidUpdateRow(1, errors.NewInvalidRequest(errors.Layer_Request, nil, "")),
+				game.NewInvalidUpdateRow(2, errors.NewInvalidRequest(errors.Layer_Request, nil, "")),
+				game.NewUpdateRow(game.NewWithID(3, "game3", "", "", "", releaseDate, []*game.Link{}), nil, nil),
+			},
+		},
+		{
+			name:    "decode error",
+			body:    `{"id": 1, "name": "game1", "release_date": "1995"}`,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := httptest.NewRequest(http.MethodPut, "http://example.com", strings.NewReader(tt.body))
+			got, err := NewGameBulkUpdate(r)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("NewGameBulkUpdate() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !assert.Equal(t, len(tt.want), len(got)) {
+				return
+			}
+			// NOTE: 変換に失敗した行はIDとエラーの種類のみ比べる
+			for i := range tt.want {
+				if tt.want[i].Err != nil {
+					assert.Equal(t, tt.want[i].Game.ID, got[i].Game.ID)
+					assert.IsType(t, tt.want[i].Err, got[i].Err)
+					continue
+				}
+				assert.Equal(t, tt.want[i], got[i])
+			}
+		})
+	}
+}
+
+func TestNewGameBulkDelete(t *testing.T) {
+	tests := []struct {
+		name    string
+		url     string
+		want    []game.ID
+		wantErr bool
+	}{
+		{
+			name:    "OK",
+			url:     "http://example.com?ids=1,2&ids=5",
+			want:    []game.ID{1, 2, 5},
+			wantErr: false,
+		},
+		{
+			name:    "ids nothing error",
+			url:     "http://example.com",
+			wantErr: true,
+		},
+		{
+			name:    "ids convert error",
+			url:     "http://example.com?ids=1,a",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := httptest.NewRequest(http.MethodDelete, tt.url, nil)
+			got, err := NewGameBulkDelete(r)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("NewGameBulkDelete() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestNewGameMerge(t *testing.T) {
+	type args struct {
+		body      io.Reader
+		pathParam map[string]string
+	}
+	tests := []struct {
+		name    string
+		args    args
+		want    game.ID
+		want1   game.ID
+		wantErr bool
+	}{
+		{
+			name: "OK",
+			args: args{
+				body: strings.NewReader(`{"target_id": 1}`),
+				pathParam: map[string]string{
+					"gameID": "2",
+				},
+			},
+			want:    2,
+			want1:   1,
+			wantErr: false,
+		},
+		{
+			name: "bad gameID error",
+			args: args{
+				body: strings.NewReader(`{"target_id": 1}`),
+				pathParam: map[string]string{
+					"gameID": "a",
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "decode error",
+			args: args{
+				body: strings.NewReader(`{"target_id": "a"}`),
+				pathParam: map[string]string{
+					"gameID": "2",
+				},
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := chi.NewRouteContext()
+			for key, val := range tt.args.pathParam {
+				ctx.URLParams.Add(key, val)
+			}
+			r := httptest.NewRequest(http.MethodPost, "http://example.com", tt.args.body)
+			r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, ctx))
+			got, got1, err := NewGameMerge(r)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("NewGameMerge() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			assert.Equal(t, tt.want, got)
+			assert.Equal(t, tt.want1, got1)
+		})
+	}
+}
+
+func TestNewPrecondition(t *testing.T) {
+	tests := []struct {
+		name    string
+		ifMatch string
+		want    *version.Precondition
+	}{
+		{
+			name:    "OK",
+			ifMatch: `"abc", W/"def"`,
+			want: &version.Precondition{
+				Versions: []version.Version{"abc"},
+			},
+		},
+		{
+			name:    "*はいずれにも一致",
+			ifMatch: "*",
+			want: &version.Precondition{
+				Any: true,
+			},
+		},
+		{
+			name:    "未指定はnil",
+			ifMatch: "",
+			want:    nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := httptest.NewRequest(http.MethodPut, "http://example.com/1", nil)
+			if tt.ifMatch != "" {
+				r.Header.Set("If-Match", tt.ifMatch)
+			}
+			assert.Equal(t, tt.want, NewPrecondition(r))
+		})
+	}
+}
+
+func TestNewGameFields(t *testing.T) {
+	tests := []struct {
+		name        string
+		url         string
+		wantInclude *game.Include
+		wantFields  Fields
+		wantErr     bool
+	}{
+		{
+			name:        "未指定はすべて",
+			url:         "http://example.com",
+			wantInclude: nil,
+			wantFields:  nil,
+		},
+		{
+			name: "fieldsのみ",
+			url:  "http://example.com?fields=name,tags",
+			wantInclude: &game.Include{
+				Tags: true,
+			},
+			wantFields: Fields{
+				"id":        true,
+				"name":      true,
+				"links":     false,
+				"tags":      true,
+				"platforms": false,
+			},
+		},
+		{
+			name: "fieldsのみ(includeに指定できない関連)",
+			url:  "http://example.com?fields=series,cover,relations",
+			wantInclude: &game.Include{
+				Series:    true,
+				Cover:     true,
+				Relations: true,
+			},
+			wantFields: Fields{
+				"id":        true,
+				"links":     false,
+				"series":    true,
+				"tags":      false,
+				"platforms": false,
+				"cover":     true,
+				"relations": true,
+			},
+		},
+		{
+			name: "includeのみ",
+			url:  "http://example.com?include=links&include=platforms",
+			wantInclude: &game.Include{
+				Links:     true,
+				Aliases:   true,
+				Series:    true,
+				Platforms: true,
+				Cover:     true,
+				Relations: true,
+			},
+			wantFields: Fields{
+				"id":           true,
+				"name":         true,
+				"description":  true,
+				"publisher":    true,
+				"developer":    true,
+				"release_date": true,
+				"links":        true,
+				"aliases":      true,
+				"series":       true,
+				"tags":         false,
+				"platforms":    true,
+				"cover":        true,
+				"created_at":   true,
+				"updated_at":   true,
+				"deleted_at":   true,
+				"relations":    true,
+			},
+		},
+		{
+			name: "fieldsとincludeの両方に含まれる関連のみ",
+			url:  "http://example.com?fields=id,name,links,tags&include=tags,platforms",
+			wantInclude: &game.Include{
+				Tags: true,
+			},
+			wantFields: Fields{
+				"id":        true,
+				"name":      true,
+				"links":     false,
+				"tags":      true,
+				"platforms": false,
+			},
+		},
+		{
+			name:        "空の指定は未指定と同じ",
+			url:         "http://example.com?fields=&include=",
+			wantInclude: nil,
+			wantFields:  nil,
+		},
+		{
+			name:    "fieldsの変換エラー",
+			url:     "http://example.com?fields=id,password",
+			wantErr: true,
+		},
+		{
+			name:    "includeの変換エラー",
+			url:     "http://example.com?include=aliases",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := httptest.NewRequest(http.MethodGet, tt.url, nil)
+			include, fields, err := NewGameFields(r)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("NewGameFields() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			assert.Equal(t, tt.wantInclude, include)
+			assert.Equal(t, tt.wantFields, fields)
+		})
+	}
+}
@@ -2,10 +2,16 @@ package game
 
 import (
 	"encoding/json"
+	"mysrtafes-backend/handle/http/v1/cursor"
+	"mysrtafes-backend/handle/http/v1/errors"
 	"mysrtafes-backend/pkg/game"
 	"mysrtafes-backend/pkg/game/platform"
+	"mysrtafes-backend/pkg/game/series"
 	"mysrtafes-backend/pkg/game/tag"
+	"mysrtafes-backend/pkg/version"
 	"net/http"
+	"path"
+	"strconv"
 	"time"
 )
 
@@ -15,13 +21,23 @@ type GameResponse struct {
 	Description game.Description   `json:"description"`
 	Publisher   game.Publisher     `json:"publisher"`
 	Developer   game.Developer     `json:"developer"`
+	ReleaseDate string             `json:"release_date"`
 	Links       []LinkResponse     `json:"links"`
+	Aliases     []AliasResponse    `json:"aliases"`
+	Series      *SeriesResponse    `json:"series"`
 	Tags        []TagResponse      `json:"tags"`
 	Platforms   []PlatformResponse `json:"platforms"`
+	Cover       *CoverResponse     `json:"cover"`
 	CreatedAt   time.Time          `json:"created_at"`
 	UpdatedAt   time.Time          `json:"updated_at"`
-	// TODO: LaravelでなぜかReleaseDateを追加するの忘れてた。
-	// ReleaseDate string           `json:"release_date"`
+	DeletedAt   *time.Time         `json:"deleted_at,omitempty"`
+}
+
+// 単体のゲーム
+// NOTE: 関連は両方向をたどるため単体のときのみ返却する
+type GameDetailResponse struct {
+	GameResponse
+	Relations []RelationResponse `json:"relations"`
 }
 
 type PlatformResponse struct {
@@ -41,25 +57,98 @@ type TagResponse struct {
 }
 
 type LinkResponse struct {
-	Title       game.Title           `json:"title"`
-	URL         string               `json:"url"`
-	Description game.LinkDescription `json:"description"`
-	CreatedAt   time.Time            `json:"created_at"`
-	UpdatedAt   time.Time            `json:"updated_at"`
+	ID           game.LinkID          `json:"id"`
+	Title        game.Title           `json:"title"`
+	URL          string               `json:"url"`
+	Description  game.LinkDescription `json:"description"`
+	DisplayOrder game.DisplayOrder    `json:"display_order"`
+	Kind         string               `json:"kind"`
+	Store        game.LinkStore       `json:"store"`
+	Region       game.LinkRegion      `json:"region"`
+	CreatedAt    time.Time            `json:"created_at"`
+	UpdatedAt    time.Time            `json:"updated_at"`
+}
+
+type AliasResponse struct {
+	Name      game.AliasName     `json:"name"`
+	Language  game.AliasLanguage `json:"language"`
+	Kind      string             `json:"kind"`
+	CreatedAt time.Time          `json:"created_at"`
+	UpdatedAt time.Time          `json:"updated_at"`
+}
+
+// シリーズ
+// NOTE: シリーズ内の番号も含めて返却する
+type SeriesResponse struct {
+	ID     series.ID         `json:"id"`
+	Name   series.Name       `json:"name"`
+	Number game.SeriesNumber `json:"number"`
+}
+
+// カバー画像
+// NOTE: 一覧でも表示に使うため、URLのみ返却する
+type CoverResponse struct {
+	URL          string `json:"url"`
+	ThumbnailURL string `json:"thumbnail_url"`
+}
+
+type RelatedGameResponse struct {
+	ID   game.ID   `json:"id"`
+	Name game.Name `json:"name"`
+}
+
+// ゲーム間の関連
+// NOTE: 取得したゲームは関連元・関連先のどちらにもなる
+type RelationResponse struct {
+	ID        game.RelationID     `json:"id"`
+	Kind      string              `json:"kind"`
+	Source    RelatedGameResponse `json:"source"`
+	Target    RelatedGameResponse `json:"target"`
+	CreatedAt time.Time           `json:"created_at"`
+	UpdatedAt time.Time           `json:"updated_at"`
+}
+
+// 検索結果のメタ情報
+// NOTE: next_last_id・next_cursorはseek、next_offsetはpaginationで次ページがあるときのみ返却する
+type MetaResponse struct {
+	TotalCount game.TotalCount `json:"total_count"`
+	HasNext    bool            `json:"has_next"`
+	NextLastID *game.LastID    `json:"next_last_id,omitempty"`
+	NextOffset *game.Offset    `json:"next_offset,omitempty"`
+	NextCursor string          `json:"next_cursor,omitempty"`
+}
+
+// 一括操作の行ごとの結果
+type BulkResultResponse struct {
+	Row    int         `json:"row"`
+	Status string      `json:"status"`
+	ID     game.ID     `json:"id,omitempty"`
+	Error  interface{} `json:"error,omitempty"`
+}
+
+// 一括操作の結果
+type BulkResponse struct {
+	Succeeded int                  `json:"succeeded"`
+	Failed    int                  `json:"failed"`
+	Canceled  int                  `json:"canceled"`
+	Results   []BulkResultResponse `json:"results"`
 }
 
 func WriteCreateGame(w http.ResponseWriter, game *game.Game) error {
-	return writeGame(w, http.StatusCreated, "success create game", game)
+	return writeGame(w, http.StatusCreated, "success create game", game, nil)
 }
 
 // write read response for game
-func WriteReadGame(w http.ResponseWriter, game *game.Game) error {
-	return writeGame(w, http.StatusOK, "success read game", game)
+// NOTE: fieldsがnilのときはすべてのフィールドを返却する
+func WriteReadGame(w http.ResponseWriter, game *game.Game, fields Fields) error {
+	w.Header().Set("ETag", version.New(game.UpdatedAt).ETag())
+	return writeGame(w, http.StatusOK, "success read game", game, fields)
 }
 
 // write update response for game
 func WriteUpdateGame(w http.ResponseWriter, game *game.Game) error {
-	return writeGame(w, http.StatusOK, "success update game", game)
+	w.Header().Set("ETag", version.New(game.UpdatedAt).ETag())
+	return writeGame(w, http.StatusOK, "success update game", game, nil)
 }
 
 // write delete response for game
@@ -77,20 +166,87 @@ func WriteDeleteGame(w http.ResponseWriter, gameID game.ID) error {
 	return json.NewEncoder(w).Encode(&body)
 }
 
+// write merge response for game
+func WriteMergeGame(w http.ResponseWriter, game *game.Game) error {
+	return writeGame(w, http.StatusOK, "success merge game", game, nil)
+}
+
+// write moved response for merged game
+// NOTE: 統合先のURLをLocationに設定し、統合先のIDを返却する
+func WriteMovedGame(w http.ResponseWriter, r *http.Request, gameID game.ID) error {
+	body := struct {
+		Code    int     `json:"code"`
+		Message string  `json:"message"`
+		Data    game.ID `json:"movedID"`
+	}{
+		Code:    http.StatusMovedPermanently,
+		Message: "game is merged",
+		Data:    gameID,
+	}
+	location := path.Join(path.Dir(r.URL.Path), strconv.FormatUint(uint64(gameID), 10))
+	w.Header().Set("Location", location)
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusMovedPermanently)
+	return json.NewEncoder(w).Encode(&body)
+}
+
 // write find response for game
-func WriteFindGame(w http.ResponseWriter, games []*game.Game, option *game.FindOption) error {
-	return writeGames(w, http.StatusOK, "success find game", games, option)
+// NOTE: fieldsがnilのときはすべてのフィールドを返却する
+// 削除やリンクの変更は更新日時で表せないため、Last-Modifiedは設定せず本文から作るETagのみで検証する
+func WriteFindGame(w http.ResponseWriter, games []*game.Game, option *game.FindOption, meta *game.FindMeta, fields Fields) error {
+	return writeGames(w, http.StatusOK, "success find game", games, option, meta, fields)
+}
+
+// write trash response for game
+func WriteTrashGame(w http.ResponseWriter, games []*game.Game, option *game.FindOption, meta *game.FindMeta) error {
+	return writeGames(w, http.StatusOK, "success find trash game", games, option, meta, nil)
+}
+
+// write restore response for game
+func WriteRestoreGame(w http.ResponseWriter, game *game.Game) error {
+	return writeGame(w, http.StatusOK, "success restore game", game, nil)
 }
 
-func writeGame(w http.ResponseWriter, statusCode int, msg string, game *game.Game) error {
+// write purge response for game
+func WritePurgeGame(w http.ResponseWriter, gameID game.ID) error {
+	body := struct {
+		Code    int     `json:"code"`
+		Message string  `json:"message"`
+		Data    game.ID `json:"purgeID"`
+	}{
+		Code:    http.StatusOK,
+		Message: "success purge game",
+		Data:    gameID,
+	}
+	w.Header().Set("Content-Type", "application/json")
+	return json.NewEncoder(w).Encode(&body)
+}
+
+func writeGame(w http.ResponseWriter, statusCode int, msg string, game *game.Game, fields Fields) error {
 	links := make([]LinkResponse, 0, len(game.Links))
 	for _, link := range game.Links {
 		links = append(links, LinkResponse{
-			Title:       link.Title,
-			URL:         link.URL.URL().String(),
-			Description: link.LinkDescription,
-			CreatedAt:   link.CreatedAt,
-			UpdatedAt:   link.UpdatedAt,
+			ID:           link.LinkID,
+			Title:        link.Title,
+			URL:          link.URL.URL().String(),
+			Description:  link.LinkDescription,
+			DisplayOrder: link.DisplayOrder,
+			Kind:         link.Kind.String(),
+			Store:        link.Store,
+			Region:       link.Region,
+			CreatedAt:    link.CreatedAt,
+			UpdatedAt:    link.UpdatedAt,
+		})
+	}
+
+	aliases := make([]AliasResponse, 0, len(game.Aliases))
+	for _, alias := range game.Aliases {
+		aliases = append(aliases, AliasResponse{
+			Name:      alias.Name,
+			Language:  alias.Language,
+			Kind:      alias.Kind.String(),
+			CreatedAt: alias.CreatedAt,
+			UpdatedAt: alias.UpdatedAt,
 		})
 	}
 
@@ -116,44 +272,84 @@ func writeGame(w http.ResponseWriter, statusCode int, msg string, game *game.Gam
 		})
 	}
 
+	relations := make([]RelationResponse, 0, len(game.Relations))
+	for _, relation := range game.Relations {
+		relations = append(relations, RelationResponse{
+			ID:   relation.RelationID,
+			Kind: relation.Kind.String(),
+			Source: RelatedGameResponse{
+				ID:   relation.Source.ID,
+				Name: relation.Source.Name,
+			},
+			Target: RelatedGameResponse{
+				ID:   relation.Target.ID,
+				Name: relation.Target.Name,
+			},
+			CreatedAt: relation.CreatedAt,
+			UpdatedAt: relation.UpdatedAt,
+		})
+	}
+
 	body := struct {
-		Code    int          `json:"code"`
-		Message string       `json:"message"`
-		Data    GameResponse `json:"data"`
+		Code    int         `json:"code"`
+		Message string      `json:"message"`
+		Data    interface{} `json:"data"`
 	}{
 		Code:    statusCode,
 		Message: msg,
-		Data: GameResponse{
-			ID:          game.ID,
-			Name:        game.Name,
-			Description: game.Description,
-			Publisher:   game.Publisher,
-			Developer:   game.Developer,
-			Links:       links,
-			Tags:        tags,
-			Platforms:   platforms,
-			CreatedAt:   game.CreatedAt,
-			UpdatedAt:   game.UpdatedAt,
-			// ReleaseDate: game.ReleaseDate.String(),
-		},
+		Data: fields.filterDetail(GameDetailResponse{
+			GameResponse: GameResponse{
+				ID:          game.ID,
+				Name:        game.Name,
+				Description: game.Description,
+				Publisher:   game.Publisher,
+				Developer:   game.Developer,
+				ReleaseDate: game.ReleaseDate.String(),
+				Links:       links,
+				Aliases:     aliases,
+				Series:      newSeriesResponse(game),
+				Tags:        tags,
+				Platforms:   platforms,
+				Cover:       newCoverResponse(game),
+				CreatedAt:   game.CreatedAt,
+				UpdatedAt:   game.UpdatedAt,
+				DeletedAt:   deletedAt(game.DeletedAt),
+			},
+			Relations: relations,
+		}),
 	}
 
 	w.Header().Set("Content-Type", "application/json")
 	return json.NewEncoder(w).Encode(&body)
 }
 
-func writeGames(w http.ResponseWriter, statusCode int, msg string, games []*game.Game, option *game.FindOption) error {
-	responses := make([]GameResponse, 0, len(games))
-	var lastID game.ID
+func writeGames(w http.ResponseWriter, statusCode int, msg string, games []*game.Game, option *game.FindOption, meta *game.FindMeta, fields Fields) error {
+	responses := make([]interface{}, 0, len(games))
 	for _, game := range games {
 		links := make([]LinkResponse, 0, len(game.Links))
 		for _, link := range game.Links {
 			links = append(links, LinkResponse{
-				Title:       link.Title,
-				URL:         link.URL.URL().String(),
-				Description: link.LinkDescription,
-				CreatedAt:   link.CreatedAt,
-				UpdatedAt:   link.UpdatedAt,
+				ID:           link.LinkID,
+				Title:        link.Title,
+				URL:          link.URL.URL().String(),
+				Description:  link.LinkDescription,
+				DisplayOrder: link.DisplayOrder,
+				Kind:         link.Kind.String(),
+				Store:        link.Store,
+				Region:       link.Region,
+				CreatedAt:    link.CreatedAt,
+				UpdatedAt:    link.UpdatedAt,
+			})
+		}
+
+		aliases := make([]AliasResponse, 0, len(game.Aliases))
+		for _, alias := range game.Aliases {
+			aliases = append(aliases, AliasResponse{
+				Name:      alias.Name,
+				Language:  alias.Language,
+				Kind:      alias.Kind.String(),
+				CreatedAt: alias.CreatedAt,
+				UpdatedAt: alias.UpdatedAt,
 			})
 		}
 
@@ -180,23 +376,24 @@ func writeGames(w http.ResponseWriter, statusCode int, msg string, games []*game
 		}
 		responses = append(
 			responses,
-			GameResponse{
+			fields.filter(GameResponse{
 				ID:          game.ID,
 				Name:        game.Name,
 				Description: game.Description,
 				Publisher:   game.Publisher,
 				Developer:   game.Developer,
+				ReleaseDate: game.ReleaseDate.String(),
 				Links:       links,
+				Aliases:     aliases,
+				Series:      newSeriesResponse(game),
 				Tags:        tags,
 				Platforms:   platforms,
+				Cover:       newCoverResponse(game),
 				CreatedAt:   game.CreatedAt,
 				UpdatedAt:   game.UpdatedAt,
-				// ReleaseDate: game.ReleaseDate.String(),
-			},
+				DeletedAt:   deletedAt(game.DeletedAt),
+			}),
 		)
-		if lastID < game.ID {
-			lastID = game.ID
-		}
 	}
 
 	switch option.SearchMode {
@@ -206,23 +403,25 @@ func writeGames(w http.ResponseWriter, statusCode int, msg string, games []*game
 			Count  game.Count  `json:"count"`
 		}
 		var next *Next
-		if len(responses) == int(option.Seek.Count) {
+		if meta != nil && meta.HasNext {
 			next = &Next{
-				LastID: lastID,
+				LastID: meta.NextLastID,
 				Count:  option.Seek.Count,
 			}
 		}
 
 		body := struct {
-			Code    int            `json:"code"`
-			Message string         `json:"message"`
-			Data    []GameResponse `json:"data"`
-			Next    *Next          `json:"next"`
+			Code    int           `json:"code"`
+			Message string        `json:"message"`
+			Data    []interface{} `json:"data"`
+			Next    *Next         `json:"next"`
+			Meta    *MetaResponse `json:"meta"`
 		}{
 			Code:    statusCode,
 			Message: msg,
 			Data:    responses,
 			Next:    next,
+			Meta:    newMetaResponse(meta),
 		}
 		w.Header().Set("Content-Type", "application/json")
 		return json.NewEncoder(w).Encode(&body)
@@ -232,37 +431,233 @@ func writeGames(w http.ResponseWriter, statusCode int, msg string, games []*game
 			Offset game.Offset `json:"offset"`
 		}
 		var next *Next
-		if len(responses) == int(option.Pagination.Limit) {
+		if meta != nil && meta.HasNext {
 			next = &Next{
 				Limit:  option.Pagination.Limit,
-				Offset: option.Pagination.Offset + len(responses),
+				Offset: meta.NextOffset,
 			}
 		}
 
 		body := struct {
-			Code    int            `json:"code"`
-			Message string         `json:"message"`
-			Data    []GameResponse `json:"data"`
-			Next    *Next          `json:"next"`
+			Code    int           `json:"code"`
+			Message string        `json:"message"`
+			Data    []interface{} `json:"data"`
+			Next    *Next         `json:"next"`
+			Meta    *MetaResponse `json:"meta"`
 		}{
 			Code:    statusCode,
 			Message: msg,
 			Data:    responses,
 			Next:    next,
+			Meta:    newMetaResponse(meta),
 		}
 		w.Header().Set("Content-Type", "application/json")
 		return json.NewEncoder(w).Encode(&body)
 	default:
 		body := struct {
-			Code    int            `json:"code"`
-			Message string         `json:"message"`
-			Data    []GameResponse `json:"data"`
+			Code    int           `json:"code"`
+			Message string        `json:"message"`
+			Data    []interface{} `json:"data"`
+			Meta    *MetaResponse `json:"meta"`
 		}{
 			Code:    statusCode,
 			Message: msg,
 			Data:    responses,
+			Meta:    newMetaResponse(meta),
 		}
 		w.Header().Set("Content-Type", "application/json")
 		return json.NewEncoder(w).Encode(&body)
 	}
 }
+
+// シリーズに属さないときはnull
+func newSeriesResponse(g *game.Game) *SeriesResponse {
+	if g.Series == nil {
+		return nil
+	}
+	return &SeriesResponse{
+		ID:     g.Series.ID,
+		Name:   g.Series.Name,
+		Number: g.SeriesNumber,
+	}
+}
+
+func newCoverResponse(g *game.Game) *CoverResponse {
+	if g.Cover == nil {
+		return nil
+	}
+	return &CoverResponse{
+		URL:          g.Cover.URL,
+		ThumbnailURL: g.Cover.ThumbnailURL,
+	}
+}
+
+func newMetaResponse(meta *game.FindMeta) *MetaResponse {
+	if meta == nil {
+		return nil
+	}
+	response := &MetaResponse{
+		TotalCount: meta.TotalCount,
+		HasNext:    meta.HasNext,
+	}
+	if meta.HasNext && meta.NextLastID > 0 {
+		response.NextLastID = &meta.NextLastID
+	}
+	if meta.HasNext && meta.NextOffset > 0 {
+		response.NextOffset = &meta.NextOffset
+	}
+	if meta.HasNext && meta.NextCursor != nil {
+		response.NextCursor = cursor.Encode(cursorResource, meta.NextCursor)
+	}
+	return response
+}
+
+// write import response for game
+// NOTE: 1件も登録できなかったときは400で返却する
+func WriteImportGame(w http.ResponseWriter, results []*game.ImportResult) error {
+	data := BulkResponse{
+		Results: make([]BulkResultResponse, 0, len(results)),
+	}
+	for i, result := range results {
+		response := BulkResultResponse{
+			Row:    i + 1,
+			Status: result.Status.String(),
+		}
+		switch result.Status {
+		case game.BulkStatus_Succeeded:
+			data.Succeeded++
+			response.ID = result.Game.ID
+		case game.BulkStatus_Failed:
+			data.Failed++
+			response.Error = errors.NewErrorBody(result.Err)
+		default:
+			data.Canceled++
+		}
+		data.Results = append(data.Results, response)
+	}
+
+	statusCode, msg := http.StatusCreated, "success import game"
+	if data.Succeeded == 0 {
+		statusCode, msg = http.StatusBadRequest, "failed import game"
+	}
+	body := struct {
+		Code    int          `json:"code"`
+		Message string       `json:"message"`
+		Data    BulkResponse `json:"data"`
+	}{
+		Code:    statusCode,
+		Message: msg,
+		Data:    data,
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(statusCode)
+	return json.NewEncoder(w).Encode(&body)
+}
+
+// write bulk update response for game
+func WriteBulkUpdateGame(w http.ResponseWriter, results []*game.BulkResult) error {
+	return writeBulk(w, "update game", results)
+}
+
+// write bulk delete response for game
+func WriteBulkDeleteGame(w http.ResponseWriter, results []*game.BulkResult) error {
+	return writeBulk(w, "delete game", results)
+}
+
+// NOTE: 1件も成功しなかったときは400で返却する
+func writeBulk(w http.ResponseWriter, operation string, results []*game.BulkResult) error {
+	data := BulkResponse{
+		Results: make([]BulkResultResponse, 0, len(results)),
+	}
+	for i, result := range results {
+		response := BulkResultResponse{
+			Row:    i + 1,
+			Status: result.Status.String(),
+			ID:     result.ID,
+		}
+		switch result.Status {
+		case game.BulkStatus_Succeeded:
+			data.Succeeded++
+		case game.BulkStatus_Failed:
+			data.Failed++
+			response.Error = errors.NewErrorBody(result.Err)
+		default:
+			data.Canceled++
+		}
+		data.Results = append(data.Results, response)
+	}
+
+	statusCode, msg := http.StatusOK, "success bulk "+operation
+	if data.Succeeded == 0 {
+		statusCode, msg = http.StatusBadRequest, "failed bulk "+operation
+	}
+	body := struct {
+		Code    int          `json:"code"`
+		Message string       `json:"message"`
+		Data    BulkResponse `json:"data"`
+	}{
+		Code:    statusCode,
+		Message: msg,
+		Data:    data,
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(statusCode)
+	return json.NewEncoder(w).Encode(&body)
+}
+
+// ゴミ箱にないときは削除日時を返却しない
+func deletedAt(t time.Time) *time.Time {
+	if t.IsZero() {
+		return nil
+	}
+	return &t
+}
+
+// 指定されたフィールドのみにする
+// NOTE: fieldsがnilのときはそのまま返却する。キーはGameResponseのJSONのキーに合わせる
+func (f Fields) filter(r GameResponse) interface{} {
+	if f == nil {
+		return r
+	}
+	return f.selectGame(r)
+}
+
+// 指定されたフィールドのみにする(単体)
+func (f Fields) filterDetail(r GameDetailResponse) interface{} {
+	if f == nil {
+		return r
+	}
+	selected := f.selectGame(r.GameResponse)
+	if f["relations"] {
+		selected["relations"] = r.Relations
+	}
+	return selected
+}
+
+func (f Fields) selectGame(r GameResponse) map[string]interface{} {
+	selected := make(map[string]interface{}, len(f))
+	set := func(key string, value interface{}) {
+		if f[key] {
+			selected[key] = value
+		}
+	}
+	set("id", r.ID)
+	set("name", r.Name)
+	set("description", r.Description)
+	set("publisher", r.Publisher)
+	set("developer", r.Developer)
+	set("release_date", r.ReleaseDate)
+	set("links", r.Links)
+	set("aliases", r.Aliases)
+	set("series", r.Series)
+	set("tags", r.Tags)
+	set("platforms", r.Platforms)
+	set("cover", r.Cover)
+	set("created_at", r.CreatedAt)
+	set("updated_at", r.UpdatedAt)
+	// NOTE: ゴミ箱にないときは返却しない(omitempty)
+	if r.DeletedAt != nil {
+		set("deleted_at", r.DeletedAt)
+	}
+	return selected
+}
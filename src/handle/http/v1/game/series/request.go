@@ -0,0 +1,313 @@
+package series
+
+import (
+	"encoding/json"
+	"mysrtafes-backend/handle/http/v1/cursor"
+	"mysrtafes-backend/handle/http/v1/patch"
+	"mysrtafes-backend/pkg/errors"
+	"mysrtafes-backend/pkg/game/series"
+	"net/http"
+	"net/url"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+)
+
+// カーソルの署名に使うリソース名
+const cursorResource = "series"
+
+// 登録・更新リクエスト
+type seriesBody struct {
+	Name        series.Name        `json:"name"`
+	Description series.Description `json:"description"`
+}
+
+// Post: NewSeriesEntity for request
+func NewSeriesCreate(r *http.Request) (*series.Series, error) {
+	defer r.Body.Close()
+
+	body := seriesBody{}
+	err := json.NewDecoder(r.Body).Decode(&body)
+	if err != nil {
+		return nil, errors.NewInvalidRequest(
+			errors.Layer_Request,
+			errors.NewInformation(
+				errors.ID_JsonDecodeError,
+				err.Error(),
+				nil,
+			),
+			"json decode error. bad format request.",
+		)
+	}
+
+	return series.New(
+		body.Name,
+		body.Description,
+	), nil
+}
+
+// Delete: NewSeriesID for request
+func NewSeriesID(r *http.Request) (series.ID, error) {
+	seriesIDStr := chi.URLParam(r, "seriesID")
+
+	seriesID, err := strconv.Atoi(seriesIDStr)
+	if err != nil {
+		return 0, errors.NewInvalidRequest(
+			errors.Layer_Request,
+			errors.NewInformation(
+				errors.ID_InvalidParams,
+				err.Error(),
+				[]errors.InvalidParams{
+					errors.NewInvalidParams("seriesID", seriesIDStr),
+				},
+			),
+			"seriesID convert error",
+		)
+	}
+	return series.ID(seriesID), nil
+}
+
+// Find: FindOptionEntity for request
+func NewSeriesFindOption(r *http.Request) (*series.FindOption, error) {
+	// デフォルト値生成
+	findOption := series.NewFindOption()
+
+	q := r.URL.Query()
+	// 検索設定
+	if err := setSearchMode(findOption, q); err != nil {
+		return nil, err
+	}
+
+	// 並び替え設定
+	if err := setOrder(findOption, q); err != nil {
+		return nil, err
+	}
+
+	return findOption, nil
+}
+
+// Update: NewSeriesEntity for request
+func NewSeriesUpdate(r *http.Request) (*series.Series, error) {
+	defer r.Body.Close()
+
+	seriesID, err := NewSeriesID(r)
+	if err != nil {
+		return nil, err
+	}
+
+	body := seriesBody{}
+	err = json.NewDecoder(r.Body).Decode(&body)
+	if err != nil {
+		return nil, errors.NewInvalidRequest(
+			errors.Layer_Request,
+			errors.NewInformation(
+				errors.ID_JsonDecodeError,
+				err.Error(),
+				nil,
+			),
+			"json decode error. bad format request.",
+		)
+	}
+
+	return series.NewWithID(
+		seriesID,
+		body.Name,
+		body.Description,
+	), nil
+}
+
+// Patch: NewSeriesPatcher for request
+// NOTE: 保存済みのシリーズを登録・更新リクエストの形式にしてからJSON Merge Patchを適用する
+func NewSeriesPatch(r *http.Request) (series.ID, series.Patcher, error) {
+	seriesID, err := NewSeriesID(r)
+	if err != nil {
+		return 0, nil, err
+	}
+
+	mergePatch, err := patch.NewMergePatch(r)
+	if err != nil {
+		return 0, nil, err
+	}
+
+	return seriesID, func(current *series.Series) (*series.Series, error) {
+		body := seriesBody{}
+		err := patch.Apply(
+			seriesBody{
+				Name:        current.Name,
+				Description: current.Description,
+			},
+			mergePatch,
+			&body,
+		)
+		if err != nil {
+			return nil, err
+		}
+		return series.NewWithID(
+			current.ID,
+			body.Name,
+			body.Description,
+		), nil
+	}, nil
+}
+
+// Find: set order param
+func setOrder(findOption *series.FindOption, q url.Values) error {
+	// Descのチェック
+	desc := false
+	var err error
+	if q.Has("desc") {
+		desc, err = strconv.ParseBool(q.Get("desc"))
+		if err != nil {
+			return errors.NewInvalidRequest(
+				errors.Layer_Request,
+				errors.NewInformation(
+					errors.ID_InvalidParams,
+					err.Error(),
+					[]errors.InvalidParams{
+						errors.NewInvalidParams("desc", q.Get("desc")),
+					},
+				),
+				"desc convert error",
+			)
+		}
+	}
+
+	// 並び順がないときはIDのOrderで返却
+	if !q.Has("order") {
+		findOption.SetOrder(series.Order_ID, desc)
+		return nil
+	}
+
+	// 並び順のチェック
+	switch q.Get("order") {
+	case "name":
+		findOption.SetOrder(series.Order_Name, desc)
+	case "updated_at":
+		findOption.SetOrder(series.Order_UpdatedAt, desc)
+	case "id":
+		findOption.SetOrder(series.Order_ID, desc)
+	default:
+		return errors.NewInvalidRequest(
+			errors.Layer_Request,
+			errors.NewInformation(
+				errors.ID_InvalidParams,
+				"order convert error",
+				[]errors.InvalidParams{
+					errors.NewInvalidParams("order", q.Get("order")),
+				},
+			),
+			"order convert error",
+		)
+	}
+	return nil
+}
+
+// Find: set search mode param
+func setSearchMode(findOption *series.FindOption, q url.Values) error {
+	// モードがないときは何もせず終了
+	if !q.Has("mode") {
+		return nil
+	}
+	// 検索モードのチェック
+	var err error
+	switch q.Get("mode") {
+	case "seek":
+		// シーク法
+		var lastID, count int = 0, 30
+		if q.Has("last_id") {
+			lastIDStr := q.Get("last_id")
+			lastID, err = strconv.Atoi(lastIDStr)
+			if err != nil {
+				return errors.NewInvalidRequest(
+					errors.Layer_Request,
+					errors.NewInformation(
+						errors.ID_InvalidParams,
+						err.Error(),
+						[]errors.InvalidParams{
+							errors.NewInvalidParams("last_id", lastIDStr),
+						},
+					),
+					"last_id convert error",
+				)
+			}
+		}
+		if q.Has("count") {
+			CountStr := q.Get("count")
+			count, err = strconv.Atoi(CountStr)
+			if err != nil {
+				return errors.NewInvalidRequest(
+					errors.Layer_Request,
+					errors.NewInformation(
+						errors.ID_InvalidParams,
+						err.Error(),
+						[]errors.InvalidParams{
+							errors.NewInvalidParams("count", CountStr),
+						},
+					),
+					"count convert error",
+				)
+			}
+		}
+		findOption.SetSeek(series.LastID(lastID), series.Count(count))
+		// NOTE: 続きの位置はカーソルで指定する。last_idはIDの並び順でのみ使える
+		if q.Has("cursor") {
+			c := &series.Cursor{}
+			if err := cursor.Decode(cursorResource, q.Get("cursor"), c); err != nil {
+				return err
+			}
+			findOption.SetCursor(c)
+		}
+	case "page":
+		// ページネーション法
+		var limit, offset int = 30, 0
+		var err error
+		if q.Has("limit") {
+			limitStr := q.Get("limit")
+			limit, err = strconv.Atoi(limitStr)
+			if err != nil {
+				return errors.NewInvalidRequest(
+					errors.Layer_Request,
+					errors.NewInformation(
+						errors.ID_InvalidParams,
+						err.Error(),
+						[]errors.InvalidParams{
+							errors.NewInvalidParams("limit", limitStr),
+						},
+					),
+					"limit convert error",
+				)
+			}
+		}
+		if q.Has("offset") {
+			OffsetStr := q.Get("offset")
+			offset, err = strconv.Atoi(OffsetStr)
+			if err != nil {
+				return errors.NewInvalidRequest(
+					errors.Layer_Request,
+					errors.NewInformation(
+						errors.ID_InvalidParams,
+						err.Error(),
+						[]errors.InvalidParams{
+							errors.NewInvalidParams("offset", OffsetStr),
+						},
+					),
+					"offset convert error",
+				)
+			}
+		}
+		findOption.SetPagination(series.Limit(limit), series.Offset(offset))
+	default:
+		return errors.NewInvalidRequest(
+			errors.Layer_Request,
+			errors.NewInformation(
+				errors.ID_InvalidParams,
+				"nothing mode error",
+				[]errors.InvalidParams{
+					errors.NewInvalidParams("mode", q.Get("mode")),
+				},
+			),
+			"nothing mode error",
+		)
+	}
+	return nil
+}
@@ -0,0 +1,1382 @@
+package series
+
+import (
+	"context"
+	"io"
+	"mysrtafes-backend/pkg/game/series"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+)
+
+func TestNewSeriesCreate(t *testing.T) {
+	type args struct {
+		method string
+		url    string
+		body   io.Reader
+	}
+	tests := []struct {
+		name    string
+		args    args
+		want    *series.Series
+		wantErr bool
+	}{
+		{
+			name: "OK",
+			args: args{
+				method: http.MethodPost,
+				url:    "http://example.com",
+				body:   strings.NewReader(`{"name": "series", "description": "desc"}`),
+			},
+			want: &series.Series{
+				Name:        "series",
+				Description: "desc",
+			},
+			wantErr: false,
+		},
+		{
+			name: "decode err",
+			args: args{
+				method: http.MethodPost,
+				url:    "http://example.com",
+				body:   strings.NewReader(`{"name": "series", "description": "desc",}`),
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := httptest.NewRequest(tt.args.method, tt.args.url, tt.args.body)
+			got, err := NewSeriesCreate(r)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("NewSeriesCreate() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("NewSeriesCreate() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNewSeriesID(t *testing.T) {
+	type args struct {
+		method    string
+		url       string
+		body      io.Reader
+		pathParam map[string]string
+	}
+	tests := []struct {
+		name    string
+		args    args
+		want    series.ID
+		wantErr bool
+	}{
+		{
+			name: "OK",
+			args: args{
+				method: http.MethodPost,
+				url:    "http://example.com/1",
+				body:   strings.NewReader(``),
+				pathParam: map[string]string{
+					"seriesID": "1",
+				},
+			},
+			want:    1,
+			wantErr: false,
+		},
+		{
+			name: "blank err",
+			args: args{
+				method: http.MethodPost,
+				url:    "http://example.com/1",
+				body:   strings.NewReader(``),
+				pathParam: map[string]string{
+					"seriesID": "",
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "decode err",
+			args: args{
+				method: http.MethodPost,
+				url:    "http://example.com",
+				body:   strings.NewReader(``),
+				pathParam: map[string]string{
+					"seriesID": "jh",
+				},
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := chi.NewRouteContext()
+			for key, val := range tt.args.pathParam {
+				ctx.URLParams.Add(key, val)
+			}
+			r := httptest.NewRequest(tt.args.method, tt.args.url, tt.args.body)
+			r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, ctx))
+			got, err := NewSeriesID(r)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("NewSeriesID() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("NewSeriesID() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNewSeriesFindOption(t *testing.T) {
+	type args struct {
+		method string
+		url    string
+		body   io.Reader
+	}
+	tests := []struct {
+		name    string
+		args    args
+		want    *series.FindOption
+		wantErr bool
+	}{
+		{
+			name: "no option ok(default)",
+			args: args{
+				method: http.MethodGet,
+				url:    "http://example.com",
+				body:   strings.NewReader(``),
+			},
+			want: &series.FindOption{
+				SearchMode: series.SearchMode_All,
+				Seek: series.Seek{
+					LastID: 0,
+					Count:  30,
+				},
+				Pagination: series.Pagination{
+					Limit:  30,
+					Offset: 0,
+				},
+				OrderOption: series.OrderOption{
+					Order: series.Order_ID,
+					Desc:  false,
+				},
+			},
+		},
+		{
+			name: "search mode parse error",
+			args: args{
+				method: http.MethodGet,
+				url:    "http://example.com?mode=seek&last_id=aaa",
+				body:   strings.NewReader(``),
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name: "order parse error",
+			args: args{
+				method: http.MethodGet,
+				url:    "http://example.com?desc=test",
+				body:   strings.NewReader(``),
+			},
+			want:    nil,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := httptest.NewRequest(tt.args.method, tt.args.url, tt.args.body)
+			got, err := NewSeriesFindOption(r)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("NewSeriesFindOption() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("NewSeriesFindOption() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNewSeriesUpdate(t *testing.T) {
+	type args struct {
+		method    string
+		url       string
+		body      io.Reader
+		pathParam map[string]string
+	}
+	tests := []struct {
+		name    string
+		args    args
+		want    *series.Series
+		wantErr bool
+	}{
+		{
+			name: "OK",
+			args: args{
+				method: http.MethodPut,
+				url:    "http://example.com",
+				body:   strings.NewReader(`{"name": "series", "description": "desc"}`),
+				pathParam: map[string]string{
+					"seriesID": "1",
+				},
+			},
+			want: &series.Series{
+				ID:          1,
+				Name:        "series",
+				Description: "desc",
+			},
+			wantErr: false,
+		},
+		{
+			name: "OK(through id test)",
+			args: args{
+				method: http.MethodPut,
+				url:    "http://example.com",
+				body:   strings.NewReader(`{"id": 3, "name": "series", "description": "desc"}`),
+				pathParam: map[string]string{
+					"seriesID": "1",
+				},
+			},
+			want: &series.Series{
+				ID:          1,
+				Name:        "series",
+				Description: "desc",
+			},
+			wantErr: false,
+		},
+		{
+			name: "bad id err",
+			args: args{
+				method: http.MethodPut,
+				url:    "http://example.com",
+				body:   strings.NewReader(`{"name": "series", "description": "desc",}`),
+				pathParam: map[string]string{
+					"seriesID": "a",
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "decode err",
+			args: args{
+				method: http.MethodPut,
+				url:    "http://example.com",
+				body:   strings.NewReader(`{"name": "series", "description": "desc",}`),
+				pathParam: map[string]string{
+					"seriesID": "1",
+				},
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := chi.NewRouteContext()
+			for key, val := range tt.args.pathParam {
+				ctx.URLParams.Add(key, val)
+			}
+			r := httptest.NewRequest(tt.args.method, tt.args.url, tt.args.body)
+			r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, ctx))
+			got, err := NewSeriesUpdate(r)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("NewSeriesUpdate() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("NewSeriesUpdate() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNewSeriesPatch(t *testing.T) {
+	type args struct {
+		contentType string
+		body        io.Reader
+		pathParam   map[string]string
+		current     *series.Series
+	}
+	tests := []struct {
+		name         string
+		args         args
+		wantID       series.ID
+		want         *series.Series
+		wantErr      bool
+		wantPatchErr bool
+	}{
+		{
+			name: "OK",
+			args: args{
+				contentType: "application/merge-patch+json",
+				body:        strings.NewReader(`{"description": "patched"}`),
+				pathParam: map[string]string{
+					"seriesID": "1",
+				},
+				current: &series.Series{
+					ID:          1,
+					Name:        "series",
+					Description: "desc",
+				},
+			},
+			wantID: 1,
+			want: &series.Series{
+				ID:          1,
+				Name:        "series",
+				Description: "patched",
+			},
+		},
+		{
+			name: "OK(null is delete)",
+			args: args{
+				contentType: "application/json",
+				body:        strings.NewReader(`{"description": null}`),
+				pathParam: map[string]string{
+					"seriesID": "1",
+				},
+				current: &series.Series{
+					ID:          1,
+					Name:        "series",
+					Description: "desc",
+				},
+			},
+			wantID: 1,
+			want: &series.Series{
+				ID:          1,
+				Name:        "series",
+				Description: "",
+			},
+		},
+		{
+			name: "bad id err",
+			args: args{
+				contentType: "application/merge-patch+json",
+				body:        strings.NewReader(`{"description": "patched"}`),
+				pathParam: map[string]string{
+					"seriesID": "a",
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "content type err",
+			args: args{
+				contentType: "text/plain",
+				body:        strings.NewReader(`{"description": "patched"}`),
+				pathParam: map[string]string{
+					"seriesID": "1",
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "type mismatch err",
+			args: args{
+				contentType: "application/merge-patch+json",
+				body:        strings.NewReader(`{"name": 1}`),
+				pathParam: map[string]string{
+					"seriesID": "1",
+				},
+				current: &series.Series{
+					ID:          1,
+					Name:        "series",
+					Description: "desc",
+				},
+			},
+			wantID:       1,
+			wantPatchErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := chi.NewRouteContext()
+			for key, val := range tt.args.pathParam {
+				ctx.URLParams.Add(key, val)
+			}
+			r := httptest.NewRequest(http.MethodPatch, "http://example.com", tt.args.body)
+			r.Header.Set("Content-Type", tt.args.contentType)
+			r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, ctx))
+			gotID, patcher, err := NewSeriesPatch(r)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("NewSeriesPatch() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if tt.wantErr {
+				return
+			}
+			if gotID != tt.wantID {
+				t.Errorf("NewSeriesPatch() id = %v, want %v", gotID, tt.wantID)
+			}
+			got, err := patcher(tt.args.current)
+			if (err != nil) != tt.wantPatchErr {
+				t.Errorf("series.Patcher() error = %v, wantPatchErr %v", err, tt.wantPatchErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("series.Patcher() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_setOrder(t *testing.T) {
+	type args struct {
+		findOption *series.FindOption
+		q          url.Values
+	}
+	tests := []struct {
+		name    string
+		args    args
+		want    *series.FindOption
+		wantErr bool
+	}{
+		{
+			name: "ok no set",
+			args: args{
+				findOption: &series.FindOption{
+					SearchMode: series.SearchMode_All,
+					Seek: series.Seek{
+						LastID: 0,
+						Count:  30,
+					},
+					Pagination: series.Pagination{
+						Limit:  30,
+						Offset: 0,
+					},
+					OrderOption: series.OrderOption{
+						Order: series.Order_ID,
+						Desc:  false,
+					},
+				},
+				q: url.Values{},
+			},
+			want: &series.FindOption{
+				SearchMode: series.SearchMode_All,
+				Seek: series.Seek{
+					LastID: 0,
+					Count:  30,
+				},
+				Pagination: series.Pagination{
+					Limit:  30,
+					Offset: 0,
+				},
+				OrderOption: series.OrderOption{
+					Order: series.Order_ID,
+					Desc:  false,
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "ok desc true, no order",
+			args: args{
+				findOption: &series.FindOption{
+					SearchMode: series.SearchMode_All,
+					Seek: series.Seek{
+						LastID: 0,
+						Count:  30,
+					},
+					Pagination: series.Pagination{
+						Limit:  30,
+						Offset: 0,
+					},
+					OrderOption: series.OrderOption{
+						Order: series.Order_ID,
+						Desc:  false,
+					},
+				},
+				q: url.Values{
+					"desc": []string{"true"},
+				},
+			},
+			want: &series.FindOption{
+				SearchMode: series.SearchMode_All,
+				Seek: series.Seek{
+					LastID: 0,
+					Count:  30,
+				},
+				Pagination: series.Pagination{
+					Limit:  30,
+					Offset: 0,
+				},
+				OrderOption: series.OrderOption{
+					Order: series.Order_ID,
+					Desc:  true,
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "ok desc true, name order",
+			args: args{
+				findOption: &series.FindOption{
+					SearchMode: series.SearchMode_All,
+					Seek: series.Seek{
+						LastID: 0,
+						Count:  30,
+					},
+					Pagination: series.Pagination{
+						Limit:  30,
+						Offset: 0,
+					},
+					OrderOption: series.OrderOption{
+						Order: series.Order_ID,
+						Desc:  false,
+					},
+				},
+				q: url.Values{
+					"desc":  []string{"true"},
+					"order": []string{"name"},
+				},
+			},
+			want: &series.FindOption{
+				SearchMode: series.SearchMode_All,
+				Seek: series.Seek{
+					LastID: 0,
+					Count:  30,
+				},
+				Pagination: series.Pagination{
+					Limit:  30,
+					Offset: 0,
+				},
+				OrderOption: series.OrderOption{
+					Order: series.Order_Name,
+					Desc:  true,
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "ok desc false, name order",
+			args: args{
+				findOption: &series.FindOption{
+					SearchMode: series.SearchMode_All,
+					Seek: series.Seek{
+						LastID: 0,
+						Count:  30,
+					},
+					Pagination: series.Pagination{
+						Limit:  30,
+						Offset: 0,
+					},
+					OrderOption: series.OrderOption{
+						Order: series.Order_ID,
+						Desc:  false,
+					},
+				},
+				q: url.Values{
+					"desc":  []string{"false"},
+					"order": []string{"name"},
+				},
+			},
+			want: &series.FindOption{
+				SearchMode: series.SearchMode_All,
+				Seek: series.Seek{
+					LastID: 0,
+					Count:  30,
+				},
+				Pagination: series.Pagination{
+					Limit:  30,
+					Offset: 0,
+				},
+				OrderOption: series.OrderOption{
+					Order: series.Order_Name,
+					Desc:  false,
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "ok no desc, id order",
+			args: args{
+				findOption: &series.FindOption{
+					SearchMode: series.SearchMode_All,
+					Seek: series.Seek{
+						LastID: 0,
+						Count:  30,
+					},
+					Pagination: series.Pagination{
+						Limit:  30,
+						Offset: 0,
+					},
+					OrderOption: series.OrderOption{
+						Order: series.Order_ID,
+						Desc:  false,
+					},
+				},
+				q: url.Values{
+					"order": []string{"id"},
+				},
+			},
+			want: &series.FindOption{
+				SearchMode: series.SearchMode_All,
+				Seek: series.Seek{
+					LastID: 0,
+					Count:  30,
+				},
+				Pagination: series.Pagination{
+					Limit:  30,
+					Offset: 0,
+				},
+				OrderOption: series.OrderOption{
+					Order: series.Order_ID,
+					Desc:  false,
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "ok no desc, name order",
+			args: args{
+				findOption: &series.FindOption{
+					SearchMode: series.SearchMode_All,
+					Seek: series.Seek{
+						LastID: 0,
+						Count:  30,
+					},
+					Pagination: series.Pagination{
+						Limit:  30,
+						Offset: 0,
+					},
+					OrderOption: series.OrderOption{
+						Order: series.Order_ID,
+						Desc:  false,
+					},
+				},
+				q: url.Values{
+					"order": []string{"name"},
+				},
+			},
+			want: &series.FindOption{
+				SearchMode: series.SearchMode_All,
+				Seek: series.Seek{
+					LastID: 0,
+					Count:  30,
+				},
+				Pagination: series.Pagination{
+					Limit:  30,
+					Offset: 0,
+				},
+				OrderOption: series.OrderOption{
+					Order: series.Order_Name,
+					Desc:  false,
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "when seek mode, order is available",
+			args: args{
+				findOption: &series.FindOption{
+					SearchMode: series.SearchMode_Seek,
+					Seek: series.Seek{
+						LastID: 0,
+						Count:  30,
+					},
+					Pagination: series.Pagination{
+						Limit:  30,
+						Offset: 0,
+					},
+					OrderOption: series.OrderOption{
+						Order: series.Order_ID,
+						Desc:  false,
+					},
+				},
+				q: url.Values{
+					"desc":  []string{"true"},
+					"order": []string{"name"},
+				},
+			},
+			want: &series.FindOption{
+				SearchMode: series.SearchMode_Seek,
+				Seek: series.Seek{
+					LastID: 0,
+					Count:  30,
+				},
+				Pagination: series.Pagination{
+					Limit:  30,
+					Offset: 0,
+				},
+				OrderOption: series.OrderOption{
+					Order: series.Order_Name,
+					Desc:  true,
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "bad desc error",
+			args: args{
+				findOption: &series.FindOption{
+					SearchMode: series.SearchMode_All,
+					Seek: series.Seek{
+						LastID: 0,
+						Count:  30,
+					},
+					Pagination: series.Pagination{
+						Limit:  30,
+						Offset: 0,
+					},
+					OrderOption: series.OrderOption{
+						Order: series.Order_ID,
+						Desc:  false,
+					},
+				},
+				q: url.Values{
+					"desc":  []string{"aaa"},
+					"order": []string{"name"},
+				},
+			},
+			want: &series.FindOption{
+				SearchMode: series.SearchMode_All,
+				Seek: series.Seek{
+					LastID: 0,
+					Count:  30,
+				},
+				Pagination: series.Pagination{
+					Limit:  30,
+					Offset: 0,
+				},
+				OrderOption: series.OrderOption{
+					Order: series.Order_ID,
+					Desc:  false,
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "bad order error",
+			args: args{
+				findOption: &series.FindOption{
+					SearchMode: series.SearchMode_All,
+					Seek: series.Seek{
+						LastID: 0,
+						Count:  30,
+					},
+					Pagination: series.Pagination{
+						Limit:  30,
+						Offset: 0,
+					},
+					OrderOption: series.OrderOption{
+						Order: series.Order_ID,
+						Desc:  false,
+					},
+				},
+				q: url.Values{
+					"desc":  []string{"true"},
+					"order": []string{"description"},
+				},
+			},
+			want: &series.FindOption{
+				SearchMode: series.SearchMode_All,
+				Seek: series.Seek{
+					LastID: 0,
+					Count:  30,
+				},
+				Pagination: series.Pagination{
+					Limit:  30,
+					Offset: 0,
+				},
+				OrderOption: series.OrderOption{
+					Order: series.Order_ID,
+					Desc:  false,
+				},
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := setOrder(tt.args.findOption, tt.args.q); (err != nil) != tt.wantErr {
+				t.Errorf("setOrder() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func Test_setSearchMode(t *testing.T) {
+	type args struct {
+		findOption *series.FindOption
+		q          url.Values
+	}
+	tests := []struct {
+		name    string
+		args    args
+		want    *series.FindOption
+		wantErr bool
+	}{
+		{
+			name: "ok no set",
+			args: args{
+				findOption: &series.FindOption{
+					SearchMode: series.SearchMode_All,
+					Seek: series.Seek{
+						LastID: 0,
+						Count:  30,
+					},
+					Pagination: series.Pagination{
+						Limit:  30,
+						Offset: 0,
+					},
+					OrderOption: series.OrderOption{
+						Order: series.Order_ID,
+						Desc:  false,
+					},
+				},
+				q: url.Values{},
+			},
+			want: &series.FindOption{
+				SearchMode: series.SearchMode_All,
+				Seek: series.Seek{
+					LastID: 0,
+					Count:  30,
+				},
+				Pagination: series.Pagination{
+					Limit:  30,
+					Offset: 0,
+				},
+				OrderOption: series.OrderOption{
+					Order: series.Order_ID,
+					Desc:  false,
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "ok seak default",
+			args: args{
+				findOption: &series.FindOption{
+					SearchMode: series.SearchMode_All,
+					Seek: series.Seek{
+						LastID: 100,
+						Count:  300,
+					},
+					Pagination: series.Pagination{
+						Limit:  30,
+						Offset: 0,
+					},
+					OrderOption: series.OrderOption{
+						Order: series.Order_ID,
+						Desc:  false,
+					},
+				},
+				q: url.Values{
+					"mode": []string{"seek"},
+				},
+			},
+			want: &series.FindOption{
+				SearchMode: series.SearchMode_Seek,
+				Seek: series.Seek{
+					LastID: 0,
+					Count:  30,
+				},
+				Pagination: series.Pagination{
+					Limit:  30,
+					Offset: 0,
+				},
+				OrderOption: series.OrderOption{
+					Order: series.Order_ID,
+					Desc:  false,
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "ok seak with last_id",
+			args: args{
+				findOption: &series.FindOption{
+					SearchMode: series.SearchMode_All,
+					Seek: series.Seek{
+						LastID: 0,
+						Count:  300,
+					},
+					Pagination: series.Pagination{
+						Limit:  30,
+						Offset: 0,
+					},
+					OrderOption: series.OrderOption{
+						Order: series.Order_ID,
+						Desc:  false,
+					},
+				},
+				q: url.Values{
+					"mode":    []string{"seek"},
+					"last_id": []string{"1"},
+				},
+			},
+			want: &series.FindOption{
+				SearchMode: series.SearchMode_Seek,
+				Seek: series.Seek{
+					LastID: 1,
+					Count:  30,
+				},
+				Pagination: series.Pagination{
+					Limit:  30,
+					Offset: 0,
+				},
+				OrderOption: series.OrderOption{
+					Order: series.Order_ID,
+					Desc:  false,
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "ok seak with count",
+			args: args{
+				findOption: &series.FindOption{
+					SearchMode: series.SearchMode_All,
+					Seek: series.Seek{
+						LastID: 0,
+						Count:  300,
+					},
+					Pagination: series.Pagination{
+						Limit:  30,
+						Offset: 0,
+					},
+					OrderOption: series.OrderOption{
+						Order: series.Order_ID,
+						Desc:  false,
+					},
+				},
+				q: url.Values{
+					"mode":  []string{"seek"},
+					"count": []string{"100"},
+				},
+			},
+			want: &series.FindOption{
+				SearchMode: series.SearchMode_Seek,
+				Seek: series.Seek{
+					LastID: 0,
+					Count:  100,
+				},
+				Pagination: series.Pagination{
+					Limit:  30,
+					Offset: 0,
+				},
+				OrderOption: series.OrderOption{
+					Order: series.Order_ID,
+					Desc:  false,
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "ok seak with both param",
+			args: args{
+				findOption: &series.FindOption{
+					SearchMode: series.SearchMode_All,
+					Seek: series.Seek{
+						LastID: 0,
+						Count:  300,
+					},
+					Pagination: series.Pagination{
+						Limit:  30,
+						Offset: 0,
+					},
+					OrderOption: series.OrderOption{
+						Order: series.Order_ID,
+						Desc:  false,
+					},
+				},
+				q: url.Values{
+					"mode":    []string{"seek"},
+					"last_id": []string{"100"},
+					"count":   []string{"500"},
+				},
+			},
+			want: &series.FindOption{
+				SearchMode: series.SearchMode_Seek,
+				Seek: series.Seek{
+					LastID: 100,
+					Count:  500,
+				},
+				Pagination: series.Pagination{
+					Limit:  30,
+					Offset: 0,
+				},
+				OrderOption: series.OrderOption{
+					Order: series.Order_ID,
+					Desc:  false,
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "bad last_id error",
+			args: args{
+				findOption: &series.FindOption{
+					SearchMode: series.SearchMode_All,
+					Seek: series.Seek{
+						LastID: 0,
+						Count:  30,
+					},
+					Pagination: series.Pagination{
+						Limit:  30,
+						Offset: 0,
+					},
+					OrderOption: series.OrderOption{
+						Order: series.Order_ID,
+						Desc:  false,
+					},
+				},
+				q: url.Values{
+					"mode":    []string{"seek"},
+					"last_id": []string{"aaa"},
+					"count":   []string{"500"},
+				},
+			},
+			want: &series.FindOption{
+				SearchMode: series.SearchMode_All,
+				Seek: series.Seek{
+					LastID: 0,
+					Count:  30,
+				},
+				Pagination: series.Pagination{
+					Limit:  30,
+					Offset: 0,
+				},
+				OrderOption: series.OrderOption{
+					Order: series.Order_ID,
+					Desc:  false,
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "bad count error",
+			args: args{
+				findOption: &series.FindOption{
+					SearchMode: series.SearchMode_All,
+					Seek: series.Seek{
+						LastID: 0,
+						Count:  30,
+					},
+					Pagination: series.Pagination{
+						Limit:  30,
+						Offset: 0,
+					},
+					OrderOption: series.OrderOption{
+						Order: series.Order_ID,
+						Desc:  false,
+					},
+				},
+				q: url.Values{
+					"mode":    []string{"seek"},
+					"last_id": []string{"1"},
+					"count":   []string{"aaa"},
+				},
+			},
+			want: &series.FindOption{
+				SearchMode: series.SearchMode_All,
+				Seek: series.Seek{
+					LastID: 0,
+					Count:  30,
+				},
+				Pagination: series.Pagination{
+					Limit:  30,
+					Offset: 0,
+				},
+				OrderOption: series.OrderOption{
+					Order: series.Order_ID,
+					Desc:  false,
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "ok page default",
+			args: args{
+				findOption: &series.FindOption{
+					SearchMode: series.SearchMode_All,
+					Seek: series.Seek{
+						LastID: 0,
+						Count:  30,
+					},
+					Pagination: series.Pagination{
+						Limit:  300,
+						Offset: 1,
+					},
+					OrderOption: series.OrderOption{
+						Order: series.Order_ID,
+						Desc:  false,
+					},
+				},
+				q: url.Values{
+					"mode": []string{"page"},
+				},
+			},
+			want: &series.FindOption{
+				SearchMode: series.SearchMode_Pagination,
+				Seek: series.Seek{
+					LastID: 0,
+					Count:  30,
+				},
+				Pagination: series.Pagination{
+					Limit:  30,
+					Offset: 0,
+				},
+				OrderOption: series.OrderOption{
+					Order: series.Order_ID,
+					Desc:  false,
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "ok page with limit",
+			args: args{
+				findOption: &series.FindOption{
+					SearchMode: series.SearchMode_All,
+					Seek: series.Seek{
+						LastID: 0,
+						Count:  30,
+					},
+					Pagination: series.Pagination{
+						Limit:  300,
+						Offset: 1,
+					},
+					OrderOption: series.OrderOption{
+						Order: series.Order_ID,
+						Desc:  false,
+					},
+				},
+				q: url.Values{
+					"mode":  []string{"page"},
+					"limit": []string{"25"},
+				},
+			},
+			want: &series.FindOption{
+				SearchMode: series.SearchMode_Pagination,
+				Seek: series.Seek{
+					LastID: 0,
+					Count:  30,
+				},
+				Pagination: series.Pagination{
+					Limit:  25,
+					Offset: 0,
+				},
+				OrderOption: series.OrderOption{
+					Order: series.Order_ID,
+					Desc:  false,
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "ok page with limit",
+			args: args{
+				findOption: &series.FindOption{
+					SearchMode: series.SearchMode_All,
+					Seek: series.Seek{
+						LastID: 0,
+						Count:  30,
+					},
+					Pagination: series.Pagination{
+						Limit:  300,
+						Offset: 1,
+					},
+					OrderOption: series.OrderOption{
+						Order: series.Order_ID,
+						Desc:  false,
+					},
+				},
+				q: url.Values{
+					"mode":   []string{"page"},
+					"offset": []string{"99"},
+				},
+			},
+			want: &series.FindOption{
+				SearchMode: series.SearchMode_Pagination,
+				Seek: series.Seek{
+					LastID: 0,
+					Count:  30,
+				},
+				Pagination: series.Pagination{
+					Limit:  30,
+					Offset: 99,
+				},
+				OrderOption: series.OrderOption{
+					Order: series.Order_ID,
+					Desc:  false,
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "ok page with both param",
+			args: args{
+				findOption: &series.FindOption{
+					SearchMode: series.SearchMode_All,
+					Seek: series.Seek{
+						LastID: 0,
+						Count:  30,
+					},
+					Pagination: series.Pagination{
+						Limit:  300,
+						Offset: 1,
+					},
+					OrderOption: series.OrderOption{
+						Order: series.Order_ID,
+						Desc:  false,
+					},
+				},
+				q: url.Values{
+					"mode":   []string{"page"},
+					"offset": []string{"999"},
+					"limit":  []string{"103"},
+				},
+			},
+			want: &series.FindOption{
+				SearchMode: series.SearchMode_Pagination,
+				Seek: series.Seek{
+					LastID: 0,
+					Count:  30,
+				},
+				Pagination: series.Pagination{
+					Limit:  103,
+					Offset: 999,
+				},
+				OrderOption: series.OrderOption{
+					Order: series.Order_ID,
+					Desc:  false,
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "bad offset error",
+			args: args{
+				findOption: &series.FindOption{
+					SearchMode: series.SearchMode_All,
+					Seek: series.Seek{
+						LastID: 0,
+						Count:  30,
+					},
+					Pagination: series.Pagination{
+						Limit:  30,
+						Offset: 1,
+					},
+					OrderOption: series.OrderOption{
+						Order: series.Order_ID,
+						Desc:  false,
+					},
+				},
+				q: url.Values{
+					"mode":   []string{"page"},
+					"offset": []string{"test"},
+				},
+			},
+			want: &series.FindOption{
+				SearchMode: series.SearchMode_All,
+				Seek: series.Seek{
+					LastID: 0,
+					Count:  30,
+				},
+				Pagination: series.Pagination{
+					Limit:  30,
+					Offset: 1,
+				},
+				OrderOption: series.OrderOption{
+					Order: series.Order_ID,
+					Desc:  false,
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "bad limit error",
+			args: args{
+				findOption: &series.FindOption{
+					SearchMode: series.SearchMode_All,
+					Seek: series.Seek{
+						LastID: 0,
+						Count:  30,
+					},
+					Pagination: series.Pagination{
+						Limit:  30,
+						Offset: 1,
+					},
+					OrderOption: series.OrderOption{
+						Order: series.Order_ID,
+						Desc:  false,
+					},
+				},
+				q: url.Values{
+					"mode":  []string{"page"},
+					"limit": []string{"test"},
+				},
+			},
+			want: &series.FindOption{
+				SearchMode: series.SearchMode_All,
+				Seek: series.Seek{
+					LastID: 0,
+					Count:  30,
+				},
+				Pagination: series.Pagination{
+					Limit:  30,
+					Offset: 1,
+				},
+				OrderOption: series.OrderOption{
+					Order: series.Order_ID,
+					Desc:  false,
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "bad mode error",
+			args: args{
+				findOption: &series.FindOption{
+					SearchMode: series.SearchMode_All,
+					Seek: series.Seek{
+						LastID: 0,
+						Count:  30,
+					},
+					Pagination: series.Pagination{
+						Limit:  30,
+						Offset: 1,
+					},
+					OrderOption: series.OrderOption{
+						Order: series.Order_ID,
+						Desc:  false,
+					},
+				},
+				q: url.Values{
+					"mode": []string{"god"},
+				},
+			},
+			want: &series.FindOption{
+				SearchMode: series.SearchMode_All,
+				Seek: series.Seek{
+					LastID: 0,
+					Count:  30,
+				},
+				Pagination: series.Pagination{
+					Limit:  30,
+					Offset: 1,
+				},
+				OrderOption: series.OrderOption{
+					Order: series.Order_ID,
+					Desc:  false,
+				},
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := setSearchMode(tt.args.findOption, tt.args.q); (err != nil) != tt.wantErr {
+				t.Errorf("setSearchMode() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,205 @@
+package series
+
+import (
+	"encoding/json"
+	"mysrtafes-backend/handle/http/v1/cursor"
+	"mysrtafes-backend/pkg/game/series"
+	"net/http"
+	"time"
+)
+
+type Series struct {
+	ID          series.ID          `json:"id"`
+	Name        series.Name        `json:"name"`
+	Description series.Description `json:"description"`
+	CreatedAt   time.Time          `json:"created_at"`
+	UpdatedAt   time.Time          `json:"updated_at"`
+}
+
+type SeriesResponse struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+	Data    Series `json:"data"`
+}
+
+type SeriesListResponse struct {
+	Code    int      `json:"code"`
+	Message string   `json:"message"`
+	Data    []Series `json:"data"`
+	Meta    *Meta    `json:"meta"`
+}
+
+type Next struct {
+	LastID series.LastID `json:"last_id"`
+	Count  series.Count  `json:"count"`
+}
+
+type SeriesListNextResponse struct {
+	Code    int      `json:"code"`
+	Message string   `json:"message"`
+	Data    []Series `json:"data"`
+	Next    *Next    `json:"next"`
+	Meta    *Meta    `json:"meta"`
+}
+
+type Page struct {
+	Limit  series.Limit  `json:"limit"`
+	Offset series.Offset `json:"offset"`
+}
+
+type SeriesListPageResponse struct {
+	Code    int      `json:"code"`
+	Message string   `json:"message"`
+	Data    []Series `json:"data"`
+	Page    *Page    `json:"page"`
+	Meta    *Meta    `json:"meta"`
+}
+
+// 検索結果のメタ情報
+// NOTE: next_last_id・next_cursorはseek、next_offsetはpaginationで次ページがあるときのみ返却する
+type Meta struct {
+	TotalCount series.TotalCount `json:"total_count"`
+	HasNext    bool              `json:"has_next"`
+	NextLastID *series.LastID    `json:"next_last_id,omitempty"`
+	NextOffset *series.Offset    `json:"next_offset,omitempty"`
+	NextCursor string            `json:"next_cursor,omitempty"`
+}
+
+// write create response for series
+func WriteCreateSeries(w http.ResponseWriter, series *series.Series) error {
+	body := seriesResponse(http.StatusCreated, "success create series", series)
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	return json.NewEncoder(w).Encode(&body)
+}
+
+// write read response for series
+func WriteReadSeries(w http.ResponseWriter, series *series.Series) error {
+	body := seriesResponse(http.StatusOK, "success read series", series)
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	return json.NewEncoder(w).Encode(&body)
+}
+
+// write update response for series
+func WriteUpdateSeries(w http.ResponseWriter, series *series.Series) error {
+	body := seriesResponse(http.StatusOK, "success update series", series)
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	return json.NewEncoder(w).Encode(&body)
+}
+
+// write delete response for series
+func WriteDeleteSeries(w http.ResponseWriter, seriesID series.ID) error {
+	body := deleteSeriesResponse(seriesID)
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	return json.NewEncoder(w).Encode(&body)
+}
+
+// write find response for series
+func WriteFindSeries(w http.ResponseWriter, list []*series.Series, option *series.FindOption, meta *series.FindMeta) error {
+	body := seriesListResponse(http.StatusOK, "success find series", list, option, meta)
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	return json.NewEncoder(w).Encode(&body)
+}
+
+func seriesResponse(statusCode int, msg string, s *series.Series) interface{} {
+	return SeriesResponse{
+		Code:    statusCode,
+		Message: msg,
+		Data:    newSeries(s),
+	}
+}
+
+func deleteSeriesResponse(seriesID series.ID) interface{} {
+	return struct {
+		Code    int       `json:"code"`
+		Message string    `json:"message"`
+		Data    series.ID `json:"deleteID"`
+	}{
+		Code:    http.StatusOK,
+		Message: "success delete series",
+		Data:    seriesID,
+	}
+}
+
+func seriesListResponse(statusCode int, msg string, list []*series.Series, option *series.FindOption, meta *series.FindMeta) interface{} {
+	responses := make([]Series, 0, len(list))
+	for _, s := range list {
+		responses = append(responses, newSeries(s))
+	}
+
+	switch option.SearchMode {
+	case series.SearchMode_Seek:
+		var next *Next
+		if meta != nil && meta.HasNext {
+			next = &Next{
+				LastID: meta.NextLastID,
+				Count:  option.Seek.Count,
+			}
+		}
+
+		return SeriesListNextResponse{
+			Code:    statusCode,
+			Message: msg,
+			Data:    responses,
+			Next:    next,
+			Meta:    newMeta(meta),
+		}
+	case series.SearchMode_Pagination:
+		var page *Page
+		if meta != nil && meta.HasNext {
+			page = &Page{
+				Limit:  option.Pagination.Limit,
+				Offset: meta.NextOffset,
+			}
+		}
+
+		return SeriesListPageResponse{
+			Code:    statusCode,
+			Message: msg,
+			Data:    responses,
+			Page:    page,
+			Meta:    newMeta(meta),
+		}
+	default:
+		return SeriesListResponse{
+			Code:    statusCode,
+			Message: msg,
+			Data:    responses,
+			Meta:    newMeta(meta),
+		}
+	}
+}
+
+func newSeries(s *series.Series) Series {
+	return Series{
+		ID:          s.ID,
+		Name:        s.Name,
+		Description: s.Description,
+		CreatedAt:   s.CreatedAt,
+		UpdatedAt:   s.UpdatedAt,
+	}
+}
+
+func newMeta(meta *series.FindMeta) *Meta {
+	if meta == nil {
+		return nil
+	}
+	response := &Meta{
+		TotalCount: meta.TotalCount,
+		HasNext:    meta.HasNext,
+	}
+	if meta.HasNext && meta.NextLastID > 0 {
+		response.NextLastID = &meta.NextLastID
+	}
+	if meta.HasNext && meta.NextOffset > 0 {
+		response.NextOffset = &meta.NextOffset
+	}
+	if meta.HasNext && meta.NextCursor != nil {
+		response.NextCursor = cursor.Encode(cursorResource, meta.NextCursor)
+	}
+	return response
+}
@@ -0,0 +1,280 @@
+package series
+
+import (
+	"mysrtafes-backend/pkg/game/series"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_seriesResponse(t *testing.T) {
+	type args struct {
+		statusCode int
+		msg        string
+		series     *series.Series
+	}
+	tests := []struct {
+		name string
+		args args
+		want interface{}
+	}{
+		{
+			name: "ok",
+			args: args{
+				statusCode: http.StatusOK,
+				msg:        "OKです",
+				series: &series.Series{
+					ID:          100,
+					Name:        "シレン",
+					Description: "ローグライク",
+				},
+			},
+			want: SeriesResponse{
+				Code:    http.StatusOK,
+				Message: "OKです",
+				Data: Series{
+					ID:          100,
+					Name:        "シレン",
+					Description: "ローグライク",
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, seriesResponse(tt.args.statusCode, tt.args.msg, tt.args.series))
+		})
+	}
+}
+
+func Test_seriesListResponse(t *testing.T) {
+	type args struct {
+		statusCode int
+		msg        string
+		list       []*series.Series
+		option     *series.FindOption
+		meta       *series.FindMeta
+	}
+	tests := []struct {
+		name string
+		args args
+		want interface{}
+	}{
+		{
+			name: "non page",
+			args: args{
+				statusCode: http.StatusOK,
+				msg:        "non page",
+				list: []*series.Series{
+					{
+						ID:          4,
+						Name:        "OK",
+						Description: "OKです",
+					},
+				},
+				option: &series.FindOption{
+					SearchMode: series.SearchMode_Pagination,
+					Pagination: series.Pagination{
+						Limit:  100,
+						Offset: 1000,
+					},
+					Seek: series.Seek{
+						LastID: 1,
+						Count:  100,
+					},
+				},
+				meta: &series.FindMeta{TotalCount: 1001},
+			},
+			want: SeriesListPageResponse{
+				Code:    http.StatusOK,
+				Message: "non page",
+				Data: []Series{
+					{
+						ID:          4,
+						Name:        "OK",
+						Description: "OKです",
+					},
+				},
+				Page: nil,
+				Meta: &Meta{TotalCount: 1001},
+			},
+		},
+		{
+			name: "page",
+			args: args{
+				statusCode: http.StatusOK,
+				msg:        "page",
+				list: []*series.Series{
+					{
+						ID:          4,
+						Name:        "OK",
+						Description: "OKです",
+					},
+				},
+				option: &series.FindOption{
+					SearchMode: series.SearchMode_Pagination,
+					Pagination: series.Pagination{
+						Limit:  1,
+						Offset: 1000,
+					},
+					Seek: series.Seek{
+						LastID: 1,
+						Count:  100,
+					},
+				},
+				meta: &series.FindMeta{TotalCount: 1002, HasNext: true, NextOffset: 1001},
+			},
+			want: SeriesListPageResponse{
+				Code:    http.StatusOK,
+				Message: "page",
+				Data: []Series{
+					{
+						ID:          4,
+						Name:        "OK",
+						Description: "OKです",
+					},
+				},
+				Page: &Page{
+					Limit:  1,
+					Offset: 1001,
+				},
+				Meta: &Meta{TotalCount: 1002, HasNext: true, NextOffset: func() *series.Offset { o := 1001; return &o }()},
+			},
+		},
+		{
+			name: "non seek",
+			args: args{
+				statusCode: http.StatusOK,
+				msg:        "non seek",
+				list: []*series.Series{
+					{
+						ID:          101,
+						Name:        "OK",
+						Description: "OKです",
+					},
+				},
+				option: &series.FindOption{
+					SearchMode: series.SearchMode_Seek,
+					Pagination: series.Pagination{
+						Limit:  100,
+						Offset: 1000,
+					},
+					Seek: series.Seek{
+						LastID: 100,
+						Count:  2,
+					},
+				},
+				meta: &series.FindMeta{TotalCount: 101},
+			},
+			want: SeriesListNextResponse{
+				Code:    http.StatusOK,
+				Message: "non seek",
+				Data: []Series{
+					{
+						ID:          101,
+						Name:        "OK",
+						Description: "OKです",
+					},
+				},
+				Next: nil,
+				Meta: &Meta{TotalCount: 101},
+			},
+		},
+		{
+			name: "seek",
+			args: args{
+				statusCode: http.StatusOK,
+				msg:        "seek",
+				list: []*series.Series{
+					{
+						ID:          101,
+						Name:        "OK",
+						Description: "OKです",
+					},
+					{
+						ID:          301,
+						Name:        "OK",
+						Description: "OKです",
+					},
+				},
+				option: &series.FindOption{
+					SearchMode: series.SearchMode_Seek,
+					Pagination: series.Pagination{
+						Limit:  1,
+						Offset: 1000,
+					},
+					Seek: series.Seek{
+						LastID: 1,
+						Count:  2,
+					},
+				},
+				meta: &series.FindMeta{TotalCount: 400, HasNext: true, NextLastID: 301},
+			},
+			want: SeriesListNextResponse{
+				Code:    http.StatusOK,
+				Message: "seek",
+				Data: []Series{
+					{
+						ID:          101,
+						Name:        "OK",
+						Description: "OKです",
+					},
+					{
+						ID:          301,
+						Name:        "OK",
+						Description: "OKです",
+					},
+				},
+				Next: &Next{
+					LastID: 301,
+					Count:  2,
+				},
+				Meta: &Meta{TotalCount: 400, HasNext: true, NextLastID: func() *series.LastID { id := series.LastID(301); return &id }()},
+			},
+		},
+		{
+			name: "all",
+			args: args{
+				statusCode: http.StatusOK,
+				msg:        "all",
+				list: []*series.Series{
+					{
+						ID:          4,
+						Name:        "OK",
+						Description: "OKです",
+					},
+				},
+				option: &series.FindOption{
+					SearchMode: series.SearchMode_All,
+					Pagination: series.Pagination{
+						Limit:  1,
+						Offset: 1000,
+					},
+					Seek: series.Seek{
+						LastID: 1,
+						Count:  100,
+					},
+				},
+				meta: &series.FindMeta{TotalCount: 1},
+			},
+			want: SeriesListResponse{
+				Code:    http.StatusOK,
+				Message: "all",
+				Data: []Series{
+					{
+						ID:          4,
+						Name:        "OK",
+						Description: "OKです",
+					},
+				},
+				Meta: &Meta{TotalCount: 1},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, seriesListResponse(tt.args.statusCode, tt.args.msg, tt.args.list, tt.args.option, tt.args.meta))
+		})
+	}
+}
@@ -0,0 +1,155 @@
+package series
+
+import (
+	"log"
+	"mysrtafes-backend/handle/http/v1/errors"
+	"mysrtafes-backend/pkg/game/series"
+	"net/http"
+)
+
+type seriesHandler struct {
+	server series.Server
+}
+
+func NewSeriesHandler(s series.Server) *seriesHandler {
+	return &seriesHandler{s}
+}
+
+func (h *seriesHandler) HandleSeries(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+		h.read(w, r)
+	case http.MethodPost:
+		h.create(w, r)
+	case http.MethodPut:
+		h.update(w, r)
+	case http.MethodPatch:
+		h.patch(w, r)
+	case http.MethodDelete:
+		h.delete(w, r)
+	default:
+		http.NotFound(w, r)
+	}
+}
+
+func (h *seriesHandler) HandleSeriesForMultiple(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+		h.find(w, r)
+	// TODO: 必要であれば複数登録や更新削除を作る
+	// case http.MethodPost:
+	// case http.MethodPut:
+	// case http.MethodDelete:
+	default:
+		http.NotFound(w, r)
+	}
+}
+
+func (h *seriesHandler) create(w http.ResponseWriter, r *http.Request) {
+	sr, err := NewSeriesCreate(r)
+	if err != nil {
+		// TODO: logの改善(トレーサーなど)
+		log.Println(err)
+		errors.WriteError(w, err)
+		return
+	}
+
+	sr, err = h.server.Create(sr)
+	if err != nil {
+		log.Println(err)
+		errors.WriteError(w, err)
+		return
+	}
+
+	WriteCreateSeries(w, sr)
+}
+
+func (h *seriesHandler) read(w http.ResponseWriter, r *http.Request) {
+	seriesID, err := NewSeriesID(r)
+	if err != nil {
+		log.Println(err)
+		errors.WriteError(w, err)
+		return
+	}
+
+	sr, err := h.server.Read(seriesID)
+	if err != nil {
+		log.Println(err)
+		errors.WriteError(w, err)
+		return
+	}
+
+	WriteReadSeries(w, sr)
+}
+
+func (h *seriesHandler) find(w http.ResponseWriter, r *http.Request) {
+	findOption, err := NewSeriesFindOption(r)
+	if err != nil {
+		log.Println(err)
+		errors.WriteError(w, err)
+		return
+	}
+
+	list, meta, err := h.server.Find(findOption)
+	if err != nil {
+		log.Println(err)
+		errors.WriteError(w, err)
+		return
+	}
+
+	WriteFindSeries(w, list, findOption, meta)
+}
+
+func (h *seriesHandler) update(w http.ResponseWriter, r *http.Request) {
+	sr, err := NewSeriesUpdate(r)
+	if err != nil {
+		log.Println(err)
+		errors.WriteError(w, err)
+		return
+	}
+
+	sr, err = h.server.Update(sr)
+	if err != nil {
+		log.Println(err)
+		errors.WriteError(w, err)
+		return
+	}
+
+	WriteUpdateSeries(w, sr)
+}
+
+func (h *seriesHandler) patch(w http.ResponseWriter, r *http.Request) {
+	seriesID, patcher, err := NewSeriesPatch(r)
+	if err != nil {
+		log.Println(err)
+		errors.WriteError(w, err)
+		return
+	}
+
+	sr, err := h.server.Patch(seriesID, patcher)
+	if err != nil {
+		log.Println(err)
+		errors.WriteError(w, err)
+		return
+	}
+
+	WriteUpdateSeries(w, sr)
+}
+
+func (h *seriesHandler) delete(w http.ResponseWriter, r *http.Request) {
+	seriesID, err := NewSeriesID(r)
+	if err != nil {
+		log.Println(err)
+		errors.WriteError(w, err)
+		return
+	}
+
+	err = h.server.Delete(seriesID)
+	if err != nil {
+		log.Println(err)
+		errors.WriteError(w, err)
+		return
+	}
+
+	WriteDeleteSeries(w, seriesID)
+}
@@ -2,23 +2,59 @@ package tag
 
 import (
 	"encoding/json"
+	"mysrtafes-backend/handle/http/v1/cursor"
+	"mysrtafes-backend/handle/http/v1/patch"
 	"mysrtafes-backend/pkg/errors"
 	"mysrtafes-backend/pkg/game/tag"
+	"mysrtafes-backend/pkg/version"
 	"net/http"
 	"net/url"
 	"strconv"
+	"strings"
 
 	"github.com/go-chi/chi/v5"
 )
 
+// カーソルの署名に使うリソース名
+const cursorResource = "tags"
+
+// 登録・更新リクエスト
+type tagBody struct {
+	Name        tag.Name        `json:"name"`
+	Description tag.Description `json:"description"`
+	Category    string          `json:"category"`
+	// NOTE: 0・未指定のときは親タグなし
+	ParentID tag.ID `json:"parent_id,omitempty"`
+}
+
+// 登録・更新リクエストからタグを生成
+func (body *tagBody) newTag(tagID tag.ID) (*tag.Tag, error) {
+	category, err := tag.NewCategory(body.Category)
+	if err != nil {
+		return nil, errors.NewInvalidRequest(
+			errors.Layer_Request,
+			errors.NewInformation(
+				errors.ID_InvalidParams,
+				err.Error(),
+				[]errors.InvalidParams{
+					errors.NewInvalidParams("category", body.Category),
+				},
+			),
+			"category convert error",
+		)
+	}
+	return tag.NewWithID(
+		tagID,
+		body.Name,
+		body.Description,
+	).SetCategory(category).SetParentID(body.ParentID), nil
+}
+
 // Post: NewTagEntity for request
 func NewTagCreate(r *http.Request) (*tag.Tag, error) {
 	defer r.Body.Close()
 
-	body := struct {
-		Name        tag.Name        `json:"name"`
-		Description tag.Description `json:"description"`
-	}{}
+	body := tagBody{}
 	err := json.NewDecoder(r.Body).Decode(&body)
 	if err != nil {
 		return nil, errors.NewInvalidRequest(
@@ -32,10 +68,7 @@ func NewTagCreate(r *http.Request) (*tag.Tag, error) {
 		)
 	}
 
-	return tag.New(
-		body.Name,
-		body.Description,
-	), nil
+	return body.newTag(0)
 }
 
 // Delete: NewTagID for request
@@ -59,6 +92,34 @@ func NewTagID(r *http.Request) (tag.ID, error) {
 	return tag.ID(tagID), nil
 }
 
+// Merge: NewTagMerge for request
+// NOTE: URLのタグを統合元、target_idのタグを統合先とする
+func NewTagMerge(r *http.Request) (tag.ID, tag.ID, error) {
+	defer r.Body.Close()
+
+	sourceID, err := NewTagID(r)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	body := struct {
+		TargetID tag.ID `json:"target_id"`
+	}{}
+	err = json.NewDecoder(r.Body).Decode(&body)
+	if err != nil {
+		return 0, 0, errors.NewInvalidRequest(
+			errors.Layer_Request,
+			errors.NewInformation(
+				errors.ID_JsonDecodeError,
+				err.Error(),
+				nil,
+			),
+			"json decode error. bad format request.",
+		)
+	}
+	return sourceID, body.TargetID, nil
+}
+
 // Find: FindOptionEntity for request
 func NewTagFindOption(r *http.Request) (*tag.FindOption, error) {
 	// デフォルト値生成
@@ -75,6 +136,16 @@ func NewTagFindOption(r *http.Request) (*tag.FindOption, error) {
 		return nil, err
 	}
 
+	// ゲームの数の設定
+	if err := setGameCount(findOption, q); err != nil {
+		return nil, err
+	}
+
+	// 分類絞り込み設定
+	if err := setCategories(findOption, q); err != nil {
+		return nil, err
+	}
+
 	return findOption, nil
 }
 
@@ -87,10 +158,7 @@ func NewTagUpdate(r *http.Request) (*tag.Tag, error) {
 		return nil, err
 	}
 
-	body := struct {
-		Name        tag.Name        `json:"name"`
-		Description tag.Description `json:"description"`
-	}{}
+	body := tagBody{}
 	err = json.NewDecoder(r.Body).Decode(&body)
 	if err != nil {
 		return nil, errors.NewInvalidRequest(
@@ -104,19 +172,43 @@ func NewTagUpdate(r *http.Request) (*tag.Tag, error) {
 		)
 	}
 
-	return tag.NewWithID(
-		tagID,
-		body.Name,
-		body.Description,
-	), nil
+	return body.newTag(tagID)
+}
+
+// Patch: NewTagPatcher for request
+// NOTE: 保存済みのタグを登録・更新リクエストの形式にしてからJSON Merge Patchを適用する
+func NewTagPatch(r *http.Request) (tag.ID, tag.Patcher, error) {
+	tagID, err := NewTagID(r)
+	if err != nil {
+		return 0, nil, err
+	}
+
+	mergePatch, err := patch.NewMergePatch(r)
+	if err != nil {
+		return 0, nil, err
+	}
+
+	return tagID, func(current *tag.Tag) (*tag.Tag, error) {
+		body := tagBody{}
+		err := patch.Apply(
+			tagBody{
+				Name:        current.Name,
+				Description: current.Description,
+				Category:    current.Category.String(),
+				ParentID:    current.ParentID,
+			},
+			mergePatch,
+			&body,
+		)
+		if err != nil {
+			return nil, err
+		}
+		return body.newTag(current.ID)
+	}, nil
 }
 
 // Find: set order param
 func setOrder(findOption *tag.FindOption, q url.Values) error {
-	// シーク法のときは並び替えするとおかしくなるので禁止
-	if findOption.SearchMode == tag.SearchMode_Seek {
-		return nil
-	}
 	// Descのチェック
 	desc := false
 	var err error
@@ -147,6 +239,10 @@ func setOrder(findOption *tag.FindOption, q url.Values) error {
 	switch q.Get("order") {
 	case "name":
 		findOption.SetOrder(tag.Order_Name, desc)
+	case "updated_at":
+		findOption.SetOrder(tag.Order_UpdatedAt, desc)
+	case "game_count":
+		findOption.SetOrder(tag.Order_GameCount, desc)
 	case "id":
 		findOption.SetOrder(tag.Order_ID, desc)
 	default:
@@ -165,6 +261,71 @@ func setOrder(findOption *tag.FindOption, q url.Values) error {
 	return nil
 }
 
+// Find: set game count param
+// NOTE: with_game_countでゲームの数を返却し、unusedで未使用のタグに絞り込む
+func setGameCount(findOption *tag.FindOption, q url.Values) error {
+	for _, key := range []string{"with_game_count", "unused"} {
+		if !q.Has(key) {
+			continue
+		}
+		b, err := strconv.ParseBool(q.Get(key))
+		if err != nil {
+			return errors.NewInvalidRequest(
+				errors.Layer_Request,
+				errors.NewInformation(
+					errors.ID_InvalidParams,
+					err.Error(),
+					[]errors.InvalidParams{
+						errors.NewInvalidParams(key, q.Get(key)),
+					},
+				),
+				key+" convert error",
+			)
+		}
+		switch key {
+		case "with_game_count":
+			findOption.SetWithGameCount(b)
+		case "unused":
+			findOption.SetUnused(b)
+		}
+	}
+	return nil
+}
+
+// Find: set categories param
+// NOTE: カンマ区切りで複数指定でき、いずれかの分類のタグに絞り込む
+func setCategories(findOption *tag.FindOption, q url.Values) error {
+	if !q.Has("categories") {
+		return nil
+	}
+	categories := []tag.Category{}
+	for _, value := range q["categories"] {
+		for _, categoryStr := range strings.Split(value, ",") {
+			categoryStr = strings.TrimSpace(categoryStr)
+			if categoryStr == "" {
+				continue
+			}
+			category, err := tag.NewCategory(categoryStr)
+			if err != nil {
+				return errors.NewInvalidRequest(
+					errors.Layer_Request,
+					errors.NewInformation(
+						errors.ID_InvalidParams,
+						err.Error(),
+						[]errors.InvalidParams{
+							errors.NewInvalidParams("categories", q["categories"]),
+						},
+					),
+					"categories convert error",
+				)
+			}
+			categories = append(categories, category)
+		}
+	}
+	findOption.SetCategories(categories)
+	return nil
+}
+
 // Find: set search mode param
 func setSearchMode(findOption *tag.FindOption, q url.Values) error {
 	// モードがないときは何もせず終了
@@ -212,6 +373,14 @@ func setSearchMode(findOption *tag.FindOption, q url.Values) error {
 			}
 		}
 		findOption.SetSeek(tag.LastID(lastID), tag.Count(count))
+		// NOTE: 続きの位置はカーソルで指定する。last_idはIDの並び順でのみ使える
+		if q.Has("cursor") {
+			c := &tag.Cursor{}
+			if err := cursor.Decode(cursorResource, q.Get("cursor"), c); err != nil {
+				return err
+			}
+			findOption.SetCursor(c)
+		}
 	case "page":
 		// ページネーション法
 		var limit, offset int = 30, 0
@@ -266,3 +435,9 @@ func setSearchMode(findOption *tag.FindOption, q url.Values) error {
 	}
 	return nil
 }
+
+// Put, Patch, Delete: NewPrecondition for request
+// NOTE: If-Match未指定のときはnilになり、無条件で更新する
+func NewPrecondition(r *http.Request) *version.Precondition {
+	return version.NewPrecondition(r.Header.Get("If-Match"))
+}
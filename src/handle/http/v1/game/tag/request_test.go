@@ -4,6 +4,7 @@ import (
 	"context"
 	"io"
 	"mysrtafes-backend/pkg/game/tag"
+	"mysrtafes-backend/pkg/version"
 	"net/http"
 	"net/http/httptest"
 	"net/url"
@@ -39,6 +40,30 @@ func TestNewTagCreate(t *testing.T) {
 			},
 			wantErr: false,
 		},
+		{
+			name: "OK(分類・親タグ)",
+			args: args{
+				method: http.MethodPost,
+				url:    "http://example.com",
+				body:   strings.NewReader(`{"name": "tag", "description": "desc", "category": "mechanic", "parent_id": 3}`),
+			},
+			want: &tag.Tag{
+				Name:        "tag",
+				Description: "desc",
+				Category:    tag.Category_Mechanic,
+				ParentID:    3,
+			},
+			wantErr: false,
+		},
+		{
+			name: "category err",
+			args: args{
+				method: http.MethodPost,
+				url:    "http://example.com",
+				body:   strings.NewReader(`{"name": "tag", "description": "desc", "category": "series"}`),
+			},
+			wantErr: true,
+		},
 		{
 			name: "decode err",
 			args: args{
@@ -135,6 +160,74 @@ func TestNewTagID(t *testing.T) {
 	}
 }
 
+func TestNewTagMerge(t *testing.T) {
+	type args struct {
+		body      io.Reader
+		pathParam map[string]string
+	}
+	tests := []struct {
+		name    string
+		args    args
+		want    tag.ID
+		want1   tag.ID
+		wantErr bool
+	}{
+		{
+			name: "OK",
+			args: args{
+				body: strings.NewReader(`{"target_id": 1}`),
+				pathParam: map[string]string{
+					"tagID": "2",
+				},
+			},
+			want:    2,
+			want1:   1,
+			wantErr: false,
+		},
+		{
+			name: "bad tagID error",
+			args: args{
+				body: strings.NewReader(`{"target_id": 1}`),
+				pathParam: map[string]string{
+					"tagID": "a",
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "decode error",
+			args: args{
+				body: strings.NewReader(`{"target_id": "a"}`),
+				pathParam: map[string]string{
+					"tagID": "2",
+				},
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := chi.NewRouteContext()
+			for key, val := range tt.args.pathParam {
+				ctx.URLParams.Add(key, val)
+			}
+			r := httptest.NewRequest(http.MethodPost, "http://example.com", tt.args.body)
+			r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, ctx))
+			got, got1, err := NewTagMerge(r)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("NewTagMerge() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("NewTagMerge() got = %v, want %v", got, tt.want)
+			}
+			if !reflect.DeepEqual(got1, tt.want1) {
+				t.Errorf("NewTagMerge() got1 = %v, want %v", got1, tt.want1)
+			}
+		})
+	}
+}
+
 func TestNewTagFindOption(t *testing.T) {
 	type args struct {
 		method string
@@ -298,6 +391,135 @@ func TestNewTagUpdate(t *testing.T) {
 	}
 }
 
+func TestNewTagPatch(t *testing.T) {
+	type args struct {
+		contentType string
+		body        io.Reader
+		pathParam   map[string]string
+		current     *tag.Tag
+	}
+	tests := []struct {
+		name         string
+		args         args
+		wantID       tag.ID
+		want         *tag.Tag
+		wantErr      bool
+		wantPatchErr bool
+	}{
+		{
+			name: "OK",
+			args: args{
+				contentType: "application/merge-patch+json",
+				body:        strings.NewReader(`{"description": "patched"}`),
+				pathParam: map[string]string{
+					"tagID": "1",
+				},
+				current: &tag.Tag{
+					ID:          1,
+					Name:        "tag",
+					Description: "desc",
+				},
+			},
+			wantID: 1,
+			want: &tag.Tag{
+				ID:          1,
+				Name:        "tag",
+				Description: "patched",
+			},
+		},
+		{
+			name: "OK(null is delete)",
+			args: args{
+				contentType: "application/json",
+				body:        strings.NewReader(`{"description": null}`),
+				pathParam: map[string]string{
+					"tagID": "1",
+				},
+				current: &tag.Tag{
+					ID:          1,
+					Name:        "tag",
+					Description: "desc",
+				},
+			},
+			wantID: 1,
+			want: &tag.Tag{
+				ID:          1,
+				Name:        "tag",
+				Description: "",
+			},
+		},
+		{
+			name: "bad id err",
+			args: args{
+				contentType: "application/merge-patch+json",
+				body:        strings.NewReader(`{"description": "patched"}`),
+				pathParam: map[string]string{
+					"tagID": "a",
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "content type err",
+			args: args{
+				contentType: "text/plain",
+				body:        strings.NewReader(`{"description": "patched"}`),
+				pathParam: map[string]string{
+					"tagID": "1",
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "type mismatch err",
+			args: args{
+				contentType: "application/merge-patch+json",
+				body:        strings.NewReader(`{"name": 1}`),
+				pathParam: map[string]string{
+					"tagID": "1",
+				},
+				current: &tag.Tag{
+					ID:          1,
+					Name:        "tag",
+					Description: "desc",
+				},
+			},
+			wantID:       1,
+			wantPatchErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := chi.NewRouteContext()
+			for key, val := range tt.args.pathParam {
+				ctx.URLParams.Add(key, val)
+			}
+			r := httptest.NewRequest(http.MethodPatch, "http://example.com", tt.args.body)
+			r.Header.Set("Content-Type", tt.args.contentType)
+			r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, ctx))
+			gotID, patcher, err := NewTagPatch(r)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("NewTagPatch() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if tt.wantErr {
+				return
+			}
+			if gotID != tt.wantID {
+				t.Errorf("NewTagPatch() id = %v, want %v", gotID, tt.wantID)
+			}
+			got, err := patcher(tt.args.current)
+			if (err != nil) != tt.wantPatchErr {
+				t.Errorf("tag.Patcher() error = %v, wantPatchErr %v", err, tt.wantPatchErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("tag.Patcher() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
 func Test_setOrder(t *testing.T) {
 	type args struct {
 		findOption *tag.FindOption
@@ -544,7 +766,7 @@ func Test_setOrder(t *testing.T) {
 			wantErr: false,
 		},
 		{
-			name: "when seek mode, default option",
+			name: "when seek mode, order is available",
 			args: args{
 				findOption: &tag.FindOption{
 					SearchMode: tag.SearchMode_Seek,
@@ -577,12 +799,24 @@ func Test_setOrder(t *testing.T) {
 					Offset: 0,
 				},
 				OrderOption: tag.OrderOption{
-					Order: tag.Order_ID,
-					Desc:  false,
+					Order: tag.Order_Name,
+					Desc:  true,
 				},
 			},
 			wantErr: false,
 		},
+		{
+			name: "ok desc true, game_count order",
+			args: args{
+				findOption: tag.NewFindOption(),
+				q: url.Values{
+					"desc":  []string{"true"},
+					"order": []string{"game_count"},
+				},
+			},
+			want:    tag.NewFindOption().SetOrder(tag.Order_GameCount, true),
+			wantErr: false,
+		},
 		{
 			name: "bad desc error",
 			args: args{
@@ -673,6 +907,105 @@ func Test_setOrder(t *testing.T) {
 	}
 }
 
+func Test_setGameCount(t *testing.T) {
+	tests := []struct {
+		name    string
+		q       url.Values
+		want    *tag.FindOption
+		wantErr bool
+	}{
+		{
+			name: "ok no set",
+			q:    url.Values{},
+			want: tag.NewFindOption(),
+		},
+		{
+			name: "ok with_game_count",
+			q: url.Values{
+				"with_game_count": []string{"true"},
+			},
+			want: tag.NewFindOption().SetWithGameCount(true),
+		},
+		{
+			name: "ok with_game_count and unused",
+			q: url.Values{
+				"with_game_count": []string{"1"},
+				"unused":          []string{"true"},
+			},
+			want: tag.NewFindOption().SetWithGameCount(true).SetUnused(true),
+		},
+		{
+			name: "bad with_game_count error",
+			q: url.Values{
+				"with_game_count": []string{"yes"},
+			},
+			wantErr: true,
+		},
+		{
+			name: "bad unused error",
+			q: url.Values{
+				"unused": []string{"no"},
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := tag.NewFindOption()
+			err := setGameCount(got, tt.q)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("setGameCount() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !tt.wantErr && !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("setGameCount() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_setCategories(t *testing.T) {
+	tests := []struct {
+		name    string
+		q       url.Values
+		want    *tag.FindOption
+		wantErr bool
+	}{
+		{
+			name: "ok no set",
+			q:    url.Values{},
+			want: tag.NewFindOption(),
+		},
+		{
+			name: "ok comma separated and repeated param",
+			q: url.Values{
+				"categories": []string{"genre,mechanic", "difficulty"},
+			},
+			want: tag.NewFindOption().SetCategories([]tag.Category{tag.Category_Genre, tag.Category_Mechanic, tag.Category_Difficulty}),
+		},
+		{
+			name: "bad category error",
+			q: url.Values{
+				"categories": []string{"genre,series"},
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := tag.NewFindOption()
+			err := setCategories(got, tt.q)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("setCategories() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !tt.wantErr && !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("setCategories() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
 func Test_setSearchMode(t *testing.T) {
 	type args struct {
 		findOption *tag.FindOption
@@ -1251,3 +1584,35 @@ func Test_setSearchMode(t *testing.T) {
 		})
 	}
 }
+
+func TestNewPrecondition(t *testing.T) {
+	tests := []struct {
+		name    string
+		ifMatch string
+		want    *version.Precondition
+	}{
+		{
+			name:    "OK",
+			ifMatch: `"abc"`,
+			want: &version.Precondition{
+				Versions: []version.Version{"abc"},
+			},
+		},
+		{
+			name:    "未指定はnil",
+			ifMatch: "",
+			want:    nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := httptest.NewRequest(http.MethodPut, "http://example.com/1", nil)
+			if tt.ifMatch != "" {
+				r.Header.Set("If-Match", tt.ifMatch)
+			}
+			if got := NewPrecondition(r); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("NewPrecondition() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
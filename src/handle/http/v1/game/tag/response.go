@@ -2,7 +2,10 @@ package tag
 
 import (
 	"encoding/json"
+	"mysrtafes-backend/handle/http/v1/cache"
+	"mysrtafes-backend/handle/http/v1/cursor"
 	"mysrtafes-backend/pkg/game/tag"
+	"mysrtafes-backend/pkg/version"
 	"net/http"
 	"time"
 )
@@ -11,8 +14,17 @@ type Tag struct {
 	ID          tag.ID          `json:"id"`
 	Name        tag.Name        `json:"name"`
 	Description tag.Description `json:"description"`
-	CreatedAt   time.Time       `json:"created_at"`
-	UpdatedAt   time.Time       `json:"updated_at"`
+	// NOTE: 未分類のときは返却しない
+	Category string `json:"category,omitempty"`
+	// NOTE: 親タグがないときは返却しない
+	ParentID  tag.ID     `json:"parent_id,omitempty"`
+	CreatedAt time.Time  `json:"created_at"`
+	UpdatedAt time.Time  `json:"updated_at"`
+	DeletedAt *time.Time `json:"deleted_at,omitempty"`
+	// NOTE: with_game_count指定時のみ返却する
+	GameCount *tag.GameCount `json:"game_count,omitempty"`
+	// NOTE: 統合したタグがないときは返却しない
+	Synonyms []tag.Name `json:"synonyms,omitempty"`
 }
 
 type TagResponse struct {
@@ -25,6 +37,7 @@ type TagsResponse struct {
 	Code    int    `json:"code"`
 	Message string `json:"message"`
 	Data    []Tag  `json:"data"`
+	Meta    *Meta  `json:"meta"`
 }
 
 type Next struct {
@@ -37,6 +50,7 @@ type TagsNextResponse struct {
 	Message string `json:"message"`
 	Data    []Tag  `json:"data"`
 	Next    *Next  `json:"next"`
+	Meta    *Meta  `json:"meta"`
 }
 
 type Page struct {
@@ -49,6 +63,17 @@ type TagsPageResponse struct {
 	Message string `json:"message"`
 	Data    []Tag  `json:"data"`
 	Page    *Page  `json:"page"`
+	Meta    *Meta  `json:"meta"`
+}
+
+// 検索結果のメタ情報
+// NOTE: next_last_id・next_cursorはseek、next_offsetはpaginationで次ページがあるときのみ返却する
+type Meta struct {
+	TotalCount tag.TotalCount `json:"total_count"`
+	HasNext    bool           `json:"has_next"`
+	NextLastID *tag.LastID    `json:"next_last_id,omitempty"`
+	NextOffset *tag.Offset    `json:"next_offset,omitempty"`
+	NextCursor string         `json:"next_cursor,omitempty"`
 }
 
 // write create response for tag
@@ -63,6 +88,8 @@ func WriteCreateTag(w http.ResponseWriter, tag *tag.Tag) error {
 func WriteReadTag(w http.ResponseWriter, tag *tag.Tag) error {
 	body := tagResponse(http.StatusOK, "success read tag", tag)
 	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("ETag", version.New(tag.UpdatedAt).ETag())
+	cache.SetLastModified(w, tag.UpdatedAt)
 	w.WriteHeader(http.StatusOK)
 	return json.NewEncoder(w).Encode(&body)
 }
@@ -71,6 +98,7 @@ func WriteReadTag(w http.ResponseWriter, tag *tag.Tag) error {
 func WriteUpdateTag(w http.ResponseWriter, tag *tag.Tag) error {
 	body := tagResponse(http.StatusOK, "success update tag", tag)
 	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("ETag", version.New(tag.UpdatedAt).ETag())
 	w.WriteHeader(http.StatusOK)
 	return json.NewEncoder(w).Encode(&body)
 }
@@ -84,13 +112,47 @@ func WriteDeleteTag(w http.ResponseWriter, tagID tag.ID) error {
 }
 
 // write find response for tag
-func WriteFindTag(w http.ResponseWriter, tags []*tag.Tag, option *tag.FindOption) error {
-	body := tagsResponse(http.StatusOK, "success find tag", tags, option)
+// NOTE: ゴミ箱への移動は一覧の更新日時に表れないため、Last-Modifiedは設定しない
+func WriteFindTag(w http.ResponseWriter, tags []*tag.Tag, option *tag.FindOption, meta *tag.FindMeta) error {
+	body := tagsResponse(http.StatusOK, "success find tag", tags, option, meta)
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	return json.NewEncoder(w).Encode(&body)
+}
+
+// write trash response for tag
+func WriteTrashTag(w http.ResponseWriter, tags []*tag.Tag, option *tag.FindOption, meta *tag.FindMeta) error {
+	body := tagsResponse(http.StatusOK, "success find trash tag", tags, option, meta)
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	return json.NewEncoder(w).Encode(&body)
+}
+
+// write restore response for tag
+func WriteRestoreTag(w http.ResponseWriter, tag *tag.Tag) error {
+	body := tagResponse(http.StatusOK, "success restore tag", tag)
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(http.StatusOK)
 	return json.NewEncoder(w).Encode(&body)
 }
 
+// write purge response for tag
+func WritePurgeTag(w http.ResponseWriter, tagID tag.ID) error {
+	body := purgeTagResponse(tagID)
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	return json.NewEncoder(w).Encode(&body)
+}
+
+// write merge response for tag
+func WriteMergeTag(w http.ResponseWriter, tag *tag.Tag) error {
+	body := tagResponse(http.StatusOK, "success merge tag", tag)
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("ETag", version.New(tag.UpdatedAt).ETag())
+	w.WriteHeader(http.StatusOK)
+	return json.NewEncoder(w).Encode(&body)
+}
+
 func tagResponse(statusCode int, msg string, tag *tag.Tag) interface{} {
 	return TagResponse{
 		Code:    statusCode,
@@ -99,8 +161,12 @@ func tagResponse(statusCode int, msg string, tag *tag.Tag) interface{} {
 			ID:          tag.ID,
 			Name:        tag.Name,
 			Description: tag.Description,
+			Category:    tag.Category.String(),
+			ParentID:    tag.ParentID,
 			CreatedAt:   tag.CreatedAt,
 			UpdatedAt:   tag.UpdatedAt,
+			DeletedAt:   deletedAt(tag.DeletedAt),
+			Synonyms:    tag.Synonyms,
 		},
 	}
 }
@@ -117,9 +183,8 @@ func deleteTagResponse(tagID tag.ID) interface{} {
 	}
 }
 
-func tagsResponse(statusCode int, msg string, tags []*tag.Tag, option *tag.FindOption) interface{} {
+func tagsResponse(statusCode int, msg string, tags []*tag.Tag, option *tag.FindOption, meta *tag.FindMeta) interface{} {
 	responses := make([]Tag, 0, len(tags))
-	var lastID tag.ID
 	for _, tag := range tags {
 		responses = append(
 			responses,
@@ -127,21 +192,23 @@ func tagsResponse(statusCode int, msg string, tags []*tag.Tag, option *tag.FindO
 				ID:          tag.ID,
 				Name:        tag.Name,
 				Description: tag.Description,
+				Category:    tag.Category.String(),
+				ParentID:    tag.ParentID,
 				CreatedAt:   tag.CreatedAt,
 				UpdatedAt:   tag.UpdatedAt,
+				DeletedAt:   deletedAt(tag.DeletedAt),
+				GameCount:   gameCount(tag, option),
+				Synonyms:    tag.Synonyms,
 			},
 		)
-		if lastID < tag.ID {
-			lastID = tag.ID
-		}
 	}
 
 	switch option.SearchMode {
 	case tag.SearchMode_Seek:
 		var next *Next
-		if len(responses) == int(option.Seek.Count) {
+		if meta != nil && meta.HasNext {
 			next = &Next{
-				LastID: lastID,
+				LastID: meta.NextLastID,
 				Count:  option.Seek.Count,
 			}
 		}
@@ -151,13 +218,14 @@ func tagsResponse(statusCode int, msg string, tags []*tag.Tag, option *tag.FindO
 			Message: msg,
 			Data:    responses,
 			Next:    next,
+			Meta:    newMeta(meta),
 		}
 	case tag.SearchMode_Pagination:
 		var page *Page
-		if len(responses) == int(option.Pagination.Limit) {
+		if meta != nil && meta.HasNext {
 			page = &Page{
 				Limit:  option.Pagination.Limit,
-				Offset: option.Pagination.Offset + len(responses),
+				Offset: meta.NextOffset,
 			}
 		}
 
@@ -166,12 +234,64 @@ func tagsResponse(statusCode int, msg string, tags []*tag.Tag, option *tag.FindO
 			Message: msg,
 			Data:    responses,
 			Page:    page,
+			Meta:    newMeta(meta),
 		}
 	default:
 		return TagsResponse{
 			Code:    statusCode,
 			Message: msg,
 			Data:    responses,
+			Meta:    newMeta(meta),
 		}
 	}
 }
+
+func purgeTagResponse(tagID tag.ID) interface{} {
+	return struct {
+		Code    int    `json:"code"`
+		Message string `json:"message"`
+		Data    tag.ID `json:"purgeID"`
+	}{
+		Code:    http.StatusOK,
+		Message: "success purge tag",
+		Data:    tagID,
+	}
+}
+
+func newMeta(meta *tag.FindMeta) *Meta {
+	if meta == nil {
+		return nil
+	}
+	response := &Meta{
+		TotalCount: meta.TotalCount,
+		HasNext:    meta.HasNext,
+	}
+	if meta.HasNext && meta.NextLastID > 0 {
+		response.NextLastID = &meta.NextLastID
+	}
+	if meta.HasNext && meta.NextOffset > 0 {
+		response.NextOffset = &meta.NextOffset
+	}
+	if meta.HasNext && meta.NextCursor != nil {
+		response.NextCursor = cursor.Encode(cursorResource, meta.NextCursor)
+	}
+	return response
+}
+
+// ゴミ箱にないときは削除日時を返却しない
+func deletedAt(t time.Time) *time.Time {
+	if t.IsZero() {
+		return nil
+	}
+	return &t
+}
+
+// タグを使うゲームの数
+// NOTE: with_game_count指定時のみ設定し、それ以外はnilにして返却しない
+func gameCount(t *tag.Tag, option *tag.FindOption) *tag.GameCount {
+	if option == nil || !option.WithGameCount {
+		return nil
+	}
+	gameCount := t.GameCount
+	return &gameCount
+}
@@ -40,6 +40,31 @@ func Test_tagResponse(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "ok(分類・親タグ)",
+			args: args{
+				statusCode: http.StatusOK,
+				msg:        "OKです",
+				tag: &tag.Tag{
+					ID:          101,
+					Name:        "ローグライク",
+					Description: "Tagかも",
+					Category:    tag.Category_Genre,
+					ParentID:    100,
+				},
+			},
+			want: TagResponse{
+				Code:    http.StatusOK,
+				Message: "OKです",
+				Data: Tag{
+					ID:          101,
+					Name:        "ローグライク",
+					Description: "Tagかも",
+					Category:    "genre",
+					ParentID:    100,
+				},
+			},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -54,6 +79,7 @@ func Test_tagsResponse(t *testing.T) {
 		msg        string
 		tags       []*tag.Tag
 		option     *tag.FindOption
+		meta       *tag.FindMeta
 	}
 	tests := []struct {
 		name string
@@ -83,6 +109,7 @@ func Test_tagsResponse(t *testing.T) {
 						Count:  100,
 					},
 				},
+				meta: &tag.FindMeta{TotalCount: 1001},
 			},
 			want: TagsPageResponse{
 				Code:    http.StatusOK,
@@ -95,6 +122,7 @@ func Test_tagsResponse(t *testing.T) {
 					},
 				},
 				Page: nil,
+				Meta: &Meta{TotalCount: 1001},
 			},
 		},
 		{
@@ -120,6 +148,7 @@ func Test_tagsResponse(t *testing.T) {
 						Count:  100,
 					},
 				},
+				meta: &tag.FindMeta{TotalCount: 1002, HasNext: true, NextOffset: 1001},
 			},
 			want: TagsPageResponse{
 				Code:    http.StatusOK,
@@ -135,6 +164,7 @@ func Test_tagsResponse(t *testing.T) {
 					Limit:  1,
 					Offset: 1001,
 				},
+				Meta: &Meta{TotalCount: 1002, HasNext: true, NextOffset: func() *tag.Offset { o := 1001; return &o }()},
 			},
 		},
 		{
@@ -160,6 +190,7 @@ func Test_tagsResponse(t *testing.T) {
 						Count:  2,
 					},
 				},
+				meta: &tag.FindMeta{TotalCount: 101},
 			},
 			want: TagsNextResponse{
 				Code:    http.StatusOK,
@@ -172,6 +203,7 @@ func Test_tagsResponse(t *testing.T) {
 					},
 				},
 				Next: nil,
+				Meta: &Meta{TotalCount: 101},
 			},
 		},
 		{
@@ -202,6 +234,7 @@ func Test_tagsResponse(t *testing.T) {
 						Count:  2,
 					},
 				},
+				meta: &tag.FindMeta{TotalCount: 400, HasNext: true, NextLastID: 301},
 			},
 			want: TagsNextResponse{
 				Code:    http.StatusOK,
@@ -222,6 +255,7 @@ func Test_tagsResponse(t *testing.T) {
 					LastID: 301,
 					Count:  2,
 				},
+				Meta: &Meta{TotalCount: 400, HasNext: true, NextLastID: func() *tag.LastID { id := tag.LastID(301); return &id }()},
 			},
 		},
 		{
@@ -247,6 +281,7 @@ func Test_tagsResponse(t *testing.T) {
 						Count:  100,
 					},
 				},
+				meta: &tag.FindMeta{TotalCount: 1},
 			},
 			want: TagsResponse{
 				Code:    http.StatusOK,
@@ -258,12 +293,21 @@ func Test_tagsResponse(t *testing.T) {
 						Description: "OKです",
 					},
 				},
+				Meta: &Meta{TotalCount: 1},
 			},
 		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			assert.Equal(t, tt.want, tagsResponse(tt.args.statusCode, tt.args.msg, tt.args.tags, tt.args.option))
+			assert.Equal(t, tt.want, tagsResponse(tt.args.statusCode, tt.args.msg, tt.args.tags, tt.args.option, tt.args.meta))
 		})
 	}
 }
+
+func Test_gameCount(t *testing.T) {
+	entity := &tag.Tag{ID: 1, GameCount: 3}
+	want := tag.GameCount(3)
+	assert.Equal(t, &want, gameCount(entity, tag.NewFindOption().SetWithGameCount(true)))
+	assert.Nil(t, gameCount(entity, tag.NewFindOption()))
+	assert.Nil(t, gameCount(entity, nil))
+}
@@ -23,6 +23,8 @@ func (h *tagHandler) HandleTag(w http.ResponseWriter, r *http.Request) {
 		h.create(w, r)
 	case http.MethodPut:
 		h.update(w, r)
+	case http.MethodPatch:
+		h.patch(w, r)
 	case http.MethodDelete:
 		h.delete(w, r)
 	default:
@@ -43,6 +45,35 @@ func (h *tagHandler) HandleTagForMultiple(w http.ResponseWriter, r *http.Request
 	}
 }
 
+func (h *tagHandler) HandleTagTrashForMultiple(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+		h.trash(w, r)
+	default:
+		http.NotFound(w, r)
+	}
+}
+
+func (h *tagHandler) HandleTagTrash(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodPost:
+		h.restore(w, r)
+	case http.MethodDelete:
+		h.purge(w, r)
+	default:
+		http.NotFound(w, r)
+	}
+}
+
+func (h *tagHandler) HandleTagMerge(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodPost:
+		h.merge(w, r)
+	default:
+		http.NotFound(w, r)
+	}
+}
+
 func (h *tagHandler) create(w http.ResponseWriter, r *http.Request) {
 	tag, err := NewTagCreate(r)
 	if err != nil {
@@ -88,14 +119,14 @@ func (h *tagHandler) find(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	tags, err := h.server.Find(findOption)
+	tags, meta, err := h.server.Find(findOption)
 	if err != nil {
 		log.Println(err)
 		errors.WriteError(w, err)
 		return
 	}
 
-	WriteFindTag(w, tags, findOption)
+	WriteFindTag(w, tags, findOption, meta)
 }
 
 func (h *tagHandler) update(w http.ResponseWriter, r *http.Request) {
@@ -106,7 +137,25 @@ func (h *tagHandler) update(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	tag, err = h.server.Update(tag)
+	tag, err = h.server.Update(tag, NewPrecondition(r))
+	if err != nil {
+		log.Println(err)
+		errors.WriteError(w, err)
+		return
+	}
+
+	WriteUpdateTag(w, tag)
+}
+
+func (h *tagHandler) patch(w http.ResponseWriter, r *http.Request) {
+	tagID, patcher, err := NewTagPatch(r)
+	if err != nil {
+		log.Println(err)
+		errors.WriteError(w, err)
+		return
+	}
+
+	tag, err := h.server.Patch(tagID, patcher, NewPrecondition(r))
 	if err != nil {
 		log.Println(err)
 		errors.WriteError(w, err)
@@ -124,7 +173,7 @@ func (h *tagHandler) delete(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	err = h.server.Delete(tagID)
+	err = h.server.Delete(tagID, NewPrecondition(r))
 	if err != nil {
 		log.Println(err)
 		errors.WriteError(w, err)
@@ -133,3 +182,75 @@ func (h *tagHandler) delete(w http.ResponseWriter, r *http.Request) {
 
 	WriteDeleteTag(w, tagID)
 }
+
+func (h *tagHandler) trash(w http.ResponseWriter, r *http.Request) {
+	findOption, err := NewTagFindOption(r)
+	if err != nil {
+		log.Println(err)
+		errors.WriteError(w, err)
+		return
+	}
+
+	tags, meta, err := h.server.Trash(findOption)
+	if err != nil {
+		log.Println(err)
+		errors.WriteError(w, err)
+		return
+	}
+
+	WriteTrashTag(w, tags, findOption, meta)
+}
+
+func (h *tagHandler) restore(w http.ResponseWriter, r *http.Request) {
+	tagID, err := NewTagID(r)
+	if err != nil {
+		log.Println(err)
+		errors.WriteError(w, err)
+		return
+	}
+
+	tag, err := h.server.Restore(tagID)
+	if err != nil {
+		log.Println(err)
+		errors.WriteError(w, err)
+		return
+	}
+
+	WriteRestoreTag(w, tag)
+}
+
+func (h *tagHandler) purge(w http.ResponseWriter, r *http.Request) {
+	tagID, err := NewTagID(r)
+	if err != nil {
+		log.Println(err)
+		errors.WriteError(w, err)
+		return
+	}
+
+	err = h.server.Purge(tagID)
+	if err != nil {
+		log.Println(err)
+		errors.WriteError(w, err)
+		return
+	}
+
+	WritePurgeTag(w, tagID)
+}
+
+func (h *tagHandler) merge(w http.ResponseWriter, r *http.Request) {
+	sourceID, targetID, err := NewTagMerge(r)
+	if err != nil {
+		log.Println(err)
+		errors.WriteError(w, err)
+		return
+	}
+
+	tag, err := h.server.Merge(sourceID, targetID)
+	if err != nil {
+		log.Println(err)
+		errors.WriteError(w, err)
+		return
+	}
+
+	WriteMergeTag(w, tag)
+}
@@ -7,10 +7,12 @@ import (
 	"io"
 	"mysrtafes-backend/pkg/errors"
 	"mysrtafes-backend/pkg/game/tag"
+	"mysrtafes-backend/pkg/version"
 	"net/http"
 	"net/http/httptest"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/go-chi/chi/v5"
 	"github.com/stretchr/testify/assert"
@@ -19,9 +21,10 @@ import (
 type server struct {
 	Tag  *tag.Tag
 	Tags []*tag.Tag
+	Meta *tag.FindMeta
 	err  error
 	// flags
-	create, read, find, update, delete bool
+	create, read, find, update, patch, delete, trash, restore, purge, merge bool
 }
 
 func (s *server) Create(*tag.Tag) (*tag.Tag, error) {
@@ -36,25 +39,73 @@ func (s *server) Read(tag.ID) (*tag.Tag, error) {
 	}
 	return nil, fmt.Errorf("failed read")
 }
-func (s *server) Find(*tag.FindOption) ([]*tag.Tag, error) {
+func (s *server) Find(*tag.FindOption) ([]*tag.Tag, *tag.FindMeta, error) {
 	if s.find {
-		return s.Tags, s.err
+		return s.Tags, s.Meta, s.err
 	}
-	return nil, fmt.Errorf("failed find")
+	return nil, nil, fmt.Errorf("failed find")
 }
-func (s *server) Update(*tag.Tag) (*tag.Tag, error) {
+func (s *server) Update(_ *tag.Tag, precondition *version.Precondition) (*tag.Tag, error) {
 	if s.update {
+		if err := s.check(precondition); err != nil {
+			return nil, err
+		}
 		return s.Tag, s.err
 	}
 	return nil, fmt.Errorf("failed update")
 }
-func (s *server) Delete(tag.ID) error {
+func (s *server) Patch(_ tag.ID, _ tag.Patcher, precondition *version.Precondition) (*tag.Tag, error) {
+	if s.patch {
+		if err := s.check(precondition); err != nil {
+			return nil, err
+		}
+		return s.Tag, s.err
+	}
+	return nil, fmt.Errorf("failed patch")
+}
+func (s *server) Delete(_ tag.ID, precondition *version.Precondition) error {
 	if s.delete {
+		if err := s.check(precondition); err != nil {
+			return err
+		}
 		return s.err
 	}
 	return fmt.Errorf("failed delete")
 }
 
+// NOTE: 保存済みの版はs.Tagの更新日時とする
+func (s *server) check(precondition *version.Precondition) error {
+	if precondition == nil || s.Tag == nil {
+		return nil
+	}
+	return precondition.Check(s.Tag.UpdatedAt)
+}
+
+func (s *server) Trash(*tag.FindOption) ([]*tag.Tag, *tag.FindMeta, error) {
+	if s.trash {
+		return s.Tags, s.Meta, s.err
+	}
+	return nil, nil, fmt.Errorf("failed trash")
+}
+func (s *server) Restore(tag.ID) (*tag.Tag, error) {
+	if s.restore {
+		return s.Tag, s.err
+	}
+	return nil, fmt.Errorf("failed restore")
+}
+func (s *server) Purge(tag.ID) error {
+	if s.purge {
+		return s.err
+	}
+	return fmt.Errorf("failed purge")
+}
+func (s *server) Merge(tag.ID, tag.ID) (*tag.Tag, error) {
+	if s.merge {
+		return s.Tag, s.err
+	}
+	return nil, fmt.Errorf("failed merge")
+}
+
 func TestNewTagHandler(t *testing.T) {
 	type args struct {
 		s tag.Server
@@ -209,6 +260,42 @@ func Test_tagHandler_HandleTag(t *testing.T) {
 				return string(str)
 			}(),
 		},
+		{
+			name: "Patch OK",
+			fields: fields{
+				server: &server{
+					Tag: &tag.Tag{
+						ID:          3,
+						Name:        "Patch OK",
+						Description: "Patch OKです",
+					},
+					patch: true,
+				},
+			},
+			args: args{
+				w:      httptest.NewRecorder(),
+				method: http.MethodPatch,
+				url:    "http://example.com/3",
+				body:   strings.NewReader(`{"name": "Patch OK"}`),
+				pathParam: map[string]string{
+					"tagID": "3",
+				},
+			},
+			wantStatusCode: http.StatusOK,
+			wantBody: func() string {
+				body := tagResponse(
+					http.StatusOK,
+					"success update tag",
+					&tag.Tag{
+						ID:          3,
+						Name:        "Patch OK",
+						Description: "Patch OKです",
+					},
+				)
+				str, _ := json.Marshal(body)
+				return string(str)
+			}(),
+		},
 		{
 			name: "Delete OK",
 			fields: fields{
@@ -251,7 +338,7 @@ func Test_tagHandler_HandleTag(t *testing.T) {
 			},
 			args: args{
 				w:      httptest.NewRecorder(),
-				method: http.MethodPatch,
+				method: http.MethodOptions,
 				url:    "http://example.com/4",
 				body:   strings.NewReader(`{"name": "Delete OK", "description": "Delete OKです"}`),
 				pathParam: map[string]string{
@@ -324,6 +411,7 @@ func Test_tagHandler_HandleTagForMultiple(t *testing.T) {
 							Description: "Find3 OKです",
 						},
 					},
+					Meta: &tag.FindMeta{TotalCount: 3},
 					find: true,
 				},
 			},
@@ -357,6 +445,7 @@ func Test_tagHandler_HandleTagForMultiple(t *testing.T) {
 						},
 					},
 					tag.NewFindOption(),
+					&tag.FindMeta{TotalCount: 3},
 				)
 				str, _ := json.Marshal(body)
 				return string(str)
@@ -707,6 +796,7 @@ func Test_tagHandler_find(t *testing.T) {
 							Description: "Find3 OKです",
 						},
 					},
+					Meta: &tag.FindMeta{TotalCount: 3},
 					find: true,
 				},
 			},
@@ -740,6 +830,7 @@ func Test_tagHandler_find(t *testing.T) {
 						},
 					},
 					tag.NewFindOption(),
+					&tag.FindMeta{TotalCount: 3},
 				)
 				str, _ := json.Marshal(body)
 				return string(str)
@@ -841,6 +932,7 @@ func Test_tagHandler_find(t *testing.T) {
 }
 
 func Test_tagHandler_update(t *testing.T) {
+	updatedAt := time.Date(2023, 4, 1, 12, 0, 0, 0, time.UTC)
 	type fields struct {
 		server tag.Server
 	}
@@ -850,6 +942,7 @@ func Test_tagHandler_update(t *testing.T) {
 		url       string
 		body      io.Reader
 		pathParam map[string]string
+		ifMatch   string
 	}
 	tests := []struct {
 		name           string
@@ -857,6 +950,7 @@ func Test_tagHandler_update(t *testing.T) {
 		args           args
 		wantStatusCode int
 		wantBody       string
+		wantETag       string
 	}{
 		{
 			name: "OK",
@@ -943,6 +1037,58 @@ func Test_tagHandler_update(t *testing.T) {
 			wantStatusCode: http.StatusUnsupportedMediaType,
 			wantBody:       "",
 		},
+		{
+			name: "If-Matchが一致",
+			fields: fields{
+				server: &server{
+					Tag: &tag.Tag{
+						ID:          3,
+						Name:        "OK",
+						Description: "OKです",
+						UpdatedAt:   updatedAt,
+					},
+					update: true,
+				},
+			},
+			args: args{
+				w:      httptest.NewRecorder(),
+				method: http.MethodPut,
+				url:    "http://example.com/3",
+				body:   strings.NewReader(`{"name": "OK", "description": "OKです"}`),
+				pathParam: map[string]string{
+					"tagID": "3",
+				},
+				ifMatch: version.New(updatedAt).ETag(),
+			},
+			wantStatusCode: http.StatusOK,
+			wantETag:       version.New(updatedAt).ETag(),
+		},
+		{
+			name: "If-Matchが不一致",
+			fields: fields{
+				server: &server{
+					Tag: &tag.Tag{
+						ID:          3,
+						Name:        "OK",
+						Description: "OKです",
+						UpdatedAt:   updatedAt,
+					},
+					update: true,
+				},
+			},
+			args: args{
+				w:      httptest.NewRecorder(),
+				method: http.MethodPut,
+				url:    "http://example.com/3",
+				body:   strings.NewReader(`{"name": "OK", "description": "OKです"}`),
+				pathParam: map[string]string{
+					"tagID": "3",
+				},
+				ifMatch: `"old"`,
+			},
+			wantStatusCode: http.StatusPreconditionFailed,
+			wantBody:       "",
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -954,11 +1100,17 @@ func Test_tagHandler_update(t *testing.T) {
 				ctx.URLParams.Add(key, val)
 			}
 			r := httptest.NewRequest(tt.args.method, tt.args.url, tt.args.body)
+			if tt.args.ifMatch != "" {
+				r.Header.Set("If-Match", tt.args.ifMatch)
+			}
 			r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, ctx))
 			h.update(tt.args.w, r)
 			if !assert.Equal(t, tt.wantStatusCode, tt.args.w.Code) {
 				return
 			}
+			if tt.wantETag != "" && !assert.Equal(t, tt.wantETag, tt.args.w.Header().Get("ETag")) {
+				return
+			}
 			// NOTE: BodyのStringは\nが入る仕様らしいので削除
 			if tt.wantBody != "" && !assert.Equal(t, tt.wantBody, strings.Replace(tt.args.w.Body.String(), "\n", "", -1)) {
 				return
@@ -968,6 +1120,7 @@ func Test_tagHandler_update(t *testing.T) {
 }
 
 func Test_tagHandler_delete(t *testing.T) {
+	updatedAt := time.Date(2023, 4, 1, 12, 0, 0, 0, time.UTC)
 	type fields struct {
 		server tag.Server
 	}
@@ -977,6 +1130,7 @@ func Test_tagHandler_delete(t *testing.T) {
 		url       string
 		body      io.Reader
 		pathParam map[string]string
+		ifMatch   string
 	}
 	tests := []struct {
 		name           string
@@ -1062,6 +1216,32 @@ func Test_tagHandler_delete(t *testing.T) {
 			wantStatusCode: http.StatusUnauthorized,
 			wantBody:       "",
 		},
+		{
+			name: "If-Matchが不一致",
+			fields: fields{
+				server: &server{
+					Tag: &tag.Tag{
+						ID:          4,
+						Name:        "OK",
+						Description: "OKです",
+						UpdatedAt:   updatedAt,
+					},
+					delete: true,
+				},
+			},
+			args: args{
+				w:      httptest.NewRecorder(),
+				method: http.MethodDelete,
+				url:    "http://example.com/4",
+				body:   strings.NewReader(`{"name": "OK", "description": "OKです"}`),
+				pathParam: map[string]string{
+					"tagID": "4",
+				},
+				ifMatch: `"old"`,
+			},
+			wantStatusCode: http.StatusPreconditionFailed,
+			wantBody:       "",
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -1073,6 +1253,9 @@ func Test_tagHandler_delete(t *testing.T) {
 				ctx.URLParams.Add(key, val)
 			}
 			r := httptest.NewRequest(tt.args.method, tt.args.url, tt.args.body)
+			if tt.args.ifMatch != "" {
+				r.Header.Set("If-Match", tt.args.ifMatch)
+			}
 			r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, ctx))
 			h.delete(tt.args.w, r)
 			if !assert.Equal(t, tt.wantStatusCode, tt.args.w.Code) {
@@ -1085,3 +1268,263 @@ func Test_tagHandler_delete(t *testing.T) {
 		})
 	}
 }
+
+func Test_tagHandler_HandleTrash(t *testing.T) {
+	deletedAt := time.Date(2023, 4, 1, 12, 0, 0, 0, time.UTC)
+	type fields struct {
+		server tag.Server
+	}
+	type args struct {
+		w         *httptest.ResponseRecorder
+		method    string
+		url       string
+		pathParam map[string]string
+		multiple  bool
+	}
+	tests := []struct {
+		name           string
+		fields         fields
+		args           args
+		wantStatusCode int
+		wantBody       string
+	}{
+		{
+			name: "Trash OK",
+			fields: fields{
+				server: &server{
+					Tags: []*tag.Tag{
+						{
+							ID:          5,
+							Name:        "Trash OK",
+							Description: "Trash OKです",
+							DeletedAt:   deletedAt,
+						},
+					},
+					Meta:  &tag.FindMeta{TotalCount: 1},
+					trash: true,
+				},
+			},
+			args: args{
+				w:         httptest.NewRecorder(),
+				method:    http.MethodGet,
+				url:       "http://example.com/trash",
+				pathParam: map[string]string{},
+				multiple:  true,
+			},
+			wantStatusCode: http.StatusOK,
+			wantBody:       `{"code":200,"message":"success find trash tag","data":[{"id":5,"name":"Trash OK","description":"Trash OKです","created_at":"0001-01-01T00:00:00Z","updated_at":"0001-01-01T00:00:00Z","deleted_at":"2023-04-01T12:00:00Z"}],"meta":{"total_count":1,"has_next":false}}`,
+		},
+		{
+			name: "Restore OK",
+			fields: fields{
+				server: &server{
+					Tag: &tag.Tag{
+						ID:          5,
+						Name:        "Restore OK",
+						Description: "Restore OKです",
+					},
+					restore: true,
+				},
+			},
+			args: args{
+				w:      httptest.NewRecorder(),
+				method: http.MethodPost,
+				url:    "http://example.com/trash/5/restore",
+				pathParam: map[string]string{
+					"tagID": "5",
+				},
+			},
+			wantStatusCode: http.StatusOK,
+			wantBody:       `{"code":200,"message":"success restore tag","data":{"id":5,"name":"Restore OK","description":"Restore OKです","created_at":"0001-01-01T00:00:00Z","updated_at":"0001-01-01T00:00:00Z"}}`,
+		},
+		{
+			name: "Purge OK",
+			fields: fields{
+				server: &server{
+					purge: true,
+				},
+			},
+			args: args{
+				w:      httptest.NewRecorder(),
+				method: http.MethodDelete,
+				url:    "http://example.com/trash/5",
+				pathParam: map[string]string{
+					"tagID": "5",
+				},
+			},
+			wantStatusCode: http.StatusOK,
+			wantBody:       `{"code":200,"message":"success purge tag","purgeID":5}`,
+		},
+		{
+			name: "Purge NotFound",
+			fields: fields{
+				server: &server{
+					err:   errors.NewNotFound(errors.Layer_Model, nil, "error"),
+					purge: true,
+				},
+			},
+			args: args{
+				w:      httptest.NewRecorder(),
+				method: http.MethodDelete,
+				url:    "http://example.com/trash/5",
+				pathParam: map[string]string{
+					"tagID": "5",
+				},
+			},
+			wantStatusCode: http.StatusNotFound,
+		},
+		{
+			name: "Bad Method NG",
+			fields: fields{
+				server: &server{},
+			},
+			args: args{
+				w:      httptest.NewRecorder(),
+				method: http.MethodPut,
+				url:    "http://example.com/trash/5",
+				pathParam: map[string]string{
+					"tagID": "5",
+				},
+			},
+			wantStatusCode: http.StatusNotFound,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := &tagHandler{
+				server: tt.fields.server,
+			}
+			ctx := chi.NewRouteContext()
+			for key, val := range tt.args.pathParam {
+				ctx.URLParams.Add(key, val)
+			}
+			r := httptest.NewRequest(tt.args.method, tt.args.url, nil)
+			r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, ctx))
+			if tt.args.multiple {
+				h.HandleTagTrashForMultiple(tt.args.w, r)
+			} else {
+				h.HandleTagTrash(tt.args.w, r)
+			}
+			if !assert.Equal(t, tt.wantStatusCode, tt.args.w.Code) {
+				return
+			}
+			if tt.wantBody != "" {
+				assert.Equal(t, tt.wantBody, strings.Replace(tt.args.w.Body.String(), "\n", "", -1))
+			}
+		})
+	}
+}
+
+func Test_tagHandler_HandleTagMerge(t *testing.T) {
+	type fields struct {
+		server tag.Server
+	}
+	type args struct {
+		w         *httptest.ResponseRecorder
+		method    string
+		body      io.Reader
+		pathParam map[string]string
+	}
+	tests := []struct {
+		name           string
+		fields         fields
+		args           args
+		wantStatusCode int
+		wantBody       string
+	}{
+		{
+			name: "Merge OK",
+			fields: fields{
+				server: &server{
+					Tag: &tag.Tag{
+						ID:          1,
+						Name:        "Merge OK",
+						Description: "Merge OKです",
+						Synonyms:    []tag.Name{"旧タグ"},
+					},
+					merge: true,
+				},
+			},
+			args: args{
+				w:      httptest.NewRecorder(),
+				method: http.MethodPost,
+				body:   strings.NewReader(`{"target_id": 1}`),
+				pathParam: map[string]string{
+					"tagID": "2",
+				},
+			},
+			wantStatusCode: http.StatusOK,
+			wantBody:       `{"code":200,"message":"success merge tag","data":{"id":1,"name":"Merge OK","description":"Merge OKです","created_at":"0001-01-01T00:00:00Z","updated_at":"0001-01-01T00:00:00Z","synonyms":["旧タグ"]}}`,
+		},
+		{
+			name: "Merge Bad Request",
+			fields: fields{
+				server: &server{
+					merge: true,
+				},
+			},
+			args: args{
+				w:      httptest.NewRecorder(),
+				method: http.MethodPost,
+				body:   strings.NewReader(`{"target_id": "a"}`),
+				pathParam: map[string]string{
+					"tagID": "2",
+				},
+			},
+			wantStatusCode: http.StatusBadRequest,
+		},
+		{
+			name: "Merge NotFound",
+			fields: fields{
+				server: &server{
+					err:   errors.NewNotFound(errors.Layer_Model, nil, "error"),
+					merge: true,
+				},
+			},
+			args: args{
+				w:      httptest.NewRecorder(),
+				method: http.MethodPost,
+				body:   strings.NewReader(`{"target_id": 1}`),
+				pathParam: map[string]string{
+					"tagID": "2",
+				},
+			},
+			wantStatusCode: http.StatusNotFound,
+		},
+		{
+			name: "Bad Method NG",
+			fields: fields{
+				server: &server{},
+			},
+			args: args{
+				w:      httptest.NewRecorder(),
+				method: http.MethodGet,
+				body:   nil,
+				pathParam: map[string]string{
+					"tagID": "2",
+				},
+			},
+			wantStatusCode: http.StatusNotFound,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := &tagHandler{
+				server: tt.fields.server,
+			}
+			ctx := chi.NewRouteContext()
+			for key, val := range tt.args.pathParam {
+				ctx.URLParams.Add(key, val)
+			}
+			r := httptest.NewRequest(tt.args.method, "http://example.com/2/merge", tt.args.body)
+			r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, ctx))
+			h.HandleTagMerge(tt.args.w, r)
+			if !assert.Equal(t, tt.wantStatusCode, tt.args.w.Code) {
+				return
+			}
+			if tt.wantBody != "" {
+				assert.Equal(t, tt.wantBody, strings.Replace(tt.args.w.Body.String(), "\n", "", -1))
+			}
+		})
+	}
+}
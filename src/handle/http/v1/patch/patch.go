@@ -0,0 +1,134 @@
+package patch
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"mime"
+	"mysrtafes-backend/pkg/errors"
+	"net/http"
+)
+
+// JSON Merge Patch(RFC 7396)のContent-Type
+const ContentType = "application/merge-patch+json"
+
+// Patch: NewMergePatch for request
+// NOTE: application/merge-patch+json と application/json を許容し、オブジェクト以外のパッチは受け付けない
+func NewMergePatch(r *http.Request) ([]byte, error) {
+	defer r.Body.Close()
+
+	mediaType, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	switch mediaType {
+	case "", ContentType, "application/json":
+	default:
+		return nil, errors.NewUnsupportedMediaType(
+			errors.Layer_Request,
+			errors.NewInformation(
+				errors.ID_InvalidParams,
+				"supported content type is application/merge-patch+json or application/json",
+				[]errors.InvalidParams{
+					errors.NewInvalidParams("Content-Type", r.Header.Get("Content-Type")),
+				},
+			),
+			"unsupported content type error",
+		)
+	}
+
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		return nil, errors.NewInvalidRequest(
+			errors.Layer_Request,
+			errors.NewInformation(
+				errors.ID_JsonDecodeError,
+				err.Error(),
+				nil,
+			),
+			"request body read error",
+		)
+	}
+	patch := map[string]interface{}{}
+	if err := json.Unmarshal(body, &patch); err != nil || patch == nil {
+		detail := "merge patch must be a json object"
+		if err != nil {
+			detail = err.Error()
+		}
+		return nil, errors.NewInvalidRequest(
+			errors.Layer_Request,
+			errors.NewInformation(
+				errors.ID_JsonDecodeError,
+				detail,
+				nil,
+			),
+			"json decode error. bad format request.",
+		)
+	}
+	return body, nil
+}
+
+// 保存済みの値にパッチを適用する
+// NOTE: currentをJSONにしてパッチを適用し、結果をpatchedにデコードする。patchedはゼロ値を渡すこと
+func Apply(current interface{}, mergePatch []byte, patched interface{}) error {
+	target, err := json.Marshal(current)
+	if err != nil {
+		return errors.NewInternalServerError(
+			errors.Layer_Request,
+			errors.NewInformation(
+				errors.ID_UnknownError,
+				err.Error(),
+				nil,
+			),
+			"json encode error",
+		)
+	}
+	merged, err := Merge(target, mergePatch)
+	if err == nil {
+		err = json.Unmarshal(merged, patched)
+	}
+	if err != nil {
+		return errors.NewInvalidRequest(
+			errors.Layer_Request,
+			errors.NewInformation(
+				errors.ID_JsonDecodeError,
+				err.Error(),
+				nil,
+			),
+			"json decode error. bad format request.",
+		)
+	}
+	return nil
+}
+
+// パッチの適用
+// NOTE: nullのメンバーは削除し、オブジェクト同士は再帰的にマージする。配列は丸ごと置き換える
+func Merge(target, patch []byte) ([]byte, error) {
+	var t, p interface{}
+	if len(bytes.TrimSpace(target)) > 0 {
+		if err := json.Unmarshal(target, &t); err != nil {
+			return nil, err
+		}
+	}
+	if err := json.Unmarshal(patch, &p); err != nil {
+		return nil, err
+	}
+	return json.Marshal(merge(t, p))
+}
+
+func merge(target, patch interface{}) interface{} {
+	p, ok := patch.(map[string]interface{})
+	if !ok {
+		// オブジェクト以外のパッチは値の置き換え
+		return patch
+	}
+	t, ok := target.(map[string]interface{})
+	if !ok {
+		t = map[string]interface{}{}
+	}
+	for key, value := range p {
+		if value == nil {
+			delete(t, key)
+			continue
+		}
+		t[key] = merge(t[key], value)
+	}
+	return t
+}
@@ -0,0 +1,110 @@
+package patch
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMerge(t *testing.T) {
+	// NOTE: RFC 7396 Appendix A のテストケース
+	tests := []struct {
+		name   string
+		target string
+		patch  string
+		want   string
+	}{
+		{name: "replace", target: `{"a":"b"}`, patch: `{"a":"c"}`, want: `{"a":"c"}`},
+		{name: "add", target: `{"a":"b"}`, patch: `{"b":"c"}`, want: `{"a":"b","b":"c"}`},
+		{name: "delete", target: `{"a":"b"}`, patch: `{"a":null}`, want: `{}`},
+		{name: "delete one", target: `{"a":"b","b":"c"}`, patch: `{"a":null}`, want: `{"b":"c"}`},
+		{name: "array replace", target: `{"a":["b"]}`, patch: `{"a":"c"}`, want: `{"a":"c"}`},
+		{name: "value to array", target: `{"a":"c"}`, patch: `{"a":["b"]}`, want: `{"a":["b"]}`},
+		{name: "nested", target: `{"a":{"b":"c"}}`, patch: `{"a":{"b":"d","c":null}}`, want: `{"a":{"b":"d"}}`},
+		{name: "array of objects", target: `{"a":[{"b":"c"}]}`, patch: `{"a":[1]}`, want: `{"a":[1]}`},
+		{name: "array target", target: `["a","b"]`, patch: `["c","d"]`, want: `["c","d"]`},
+		{name: "object to array", target: `{"a":"b"}`, patch: `["c"]`, want: `["c"]`},
+		{name: "null patch", target: `{"a":"foo"}`, patch: `null`, want: `null`},
+		{name: "string patch", target: `{"a":"foo"}`, patch: `"bar"`, want: `"bar"`},
+		{name: "keep null", target: `{"e":null}`, patch: `{"a":1}`, want: `{"a":1,"e":null}`},
+		{name: "array target to object", target: `[1,2]`, patch: `{"a":"b","c":null}`, want: `{"a":"b"}`},
+		{name: "deep null", target: `{}`, patch: `{"a":{"bb":{"ccc":null}}}`, want: `{"a":{"bb":{}}}`},
+		{name: "empty target", target: ``, patch: `{"a":"b"}`, want: `{"a":"b"}`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Merge([]byte(tt.target), []byte(tt.patch))
+			if err != nil {
+				t.Errorf("Merge() error = %v", err)
+				return
+			}
+			assert.JSONEq(t, tt.want, string(got))
+		})
+	}
+}
+
+func TestNewMergePatch(t *testing.T) {
+	tests := []struct {
+		name        string
+		contentType string
+		body        string
+		want        string
+		wantErr     bool
+	}{
+		{
+			name:        "OK merge patch",
+			contentType: "application/merge-patch+json",
+			body:        `{"name":"test"}`,
+			want:        `{"name":"test"}`,
+			wantErr:     false,
+		},
+		{
+			name:        "OK json",
+			contentType: "application/json; charset=utf-8",
+			body:        `{"description":null}`,
+			want:        `{"description":null}`,
+			wantErr:     false,
+		},
+		{
+			name:        "unsupported content type error",
+			contentType: "text/plain",
+			body:        `{"name":"test"}`,
+			wantErr:     true,
+		},
+		{
+			name:        "json decode error",
+			contentType: "application/merge-patch+json",
+			body:        `{"name":`,
+			wantErr:     true,
+		},
+		{
+			name:        "not object error",
+			contentType: "application/merge-patch+json",
+			body:        `["name"]`,
+			wantErr:     true,
+		},
+		{
+			name:        "null error",
+			contentType: "application/merge-patch+json",
+			body:        `null`,
+			wantErr:     true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := httptest.NewRequest(http.MethodPatch, "http://example.com", strings.NewReader(tt.body))
+			r.Header.Set("Content-Type", tt.contentType)
+			got, err := NewMergePatch(r)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("NewMergePatch() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !tt.wantErr {
+				assert.JSONEq(t, tt.want, string(got))
+			}
+		})
+	}
+}
@@ -23,6 +23,10 @@ const (
 	ID_DBDeleteError
 	ID_DBDataFormatError
 	ID_DBTableJoinError
+	ID_ImageDecodeError
+	ID_StorageSaveError
+	ID_StorageDeleteError
+	ID_VersionMismatch
 	ID_UnknownError
 )
 
@@ -44,6 +48,14 @@ func (id ID) Detail() (Code, Message) {
 		return "E10005", "database data format error"
 	case ID_DBTableJoinError:
 		return "E10006", "database table join error"
+	case ID_ImageDecodeError:
+		return "E00003", "image decode error"
+	case ID_VersionMismatch:
+		return "E00004", "version mismatch error"
+	case ID_StorageSaveError:
+		return "E20001", "storage save error"
+	case ID_StorageDeleteError:
+		return "E20002", "storage delete error"
 	default:
 		return "E99999", "unknown error"
 	}
@@ -106,6 +106,33 @@ func (r *unsupportedMediaType) Error() string {
 
 func (r *unsupportedMediaType) ErrorUnsupportedMediaTypeError() {}
 
+// 前提条件の不一致
+// NOTE: If-Matchで指定された版が保存済みの版と異なるときに使う
+type PreconditionFailedError interface {
+	error
+	ErrorPreconditionFailedError() // ダミーメソッド
+}
+
+type preconditionFailed struct {
+	layer Layer
+	info  *Information
+	msg   string
+}
+
+func NewPreconditionFailed(layer Layer, info *Information, msg string) PreconditionFailedError {
+	return &preconditionFailed{layer, info, msg}
+}
+
+func (r *preconditionFailed) Information() *Information {
+	return r.info
+}
+
+func (r *preconditionFailed) Error() string {
+	return fmt.Sprintf("%s: PRECONDITION_FAILED: %s", r.layer, r.msg)
+}
+
+func (r *preconditionFailed) ErrorPreconditionFailedError() {}
+
 // 未認証
 type UnauthorizedError interface {
 	error
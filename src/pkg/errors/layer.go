@@ -9,6 +9,7 @@ const (
 	Layer_Domain
 	Layer_Repository
 	Layer_Model
+	Layer_Storage
 )
 
 func (l Layer) String() string {
@@ -23,6 +24,8 @@ func (l Layer) String() string {
 		return "REPOSITORY"
 	case Layer_Model:
 		return "MODEL"
+	case Layer_Storage:
+		return "STORAGE"
 	default:
 		return "UNKNOWN"
 	}
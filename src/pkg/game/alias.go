@@ -0,0 +1,134 @@
+package game
+
+import (
+	"fmt"
+	"regexp"
+	"time"
+)
+
+// 別名
+type AliasName string
+
+// 1 ≦ name.length ≦ 255
+func (n AliasName) Valid() bool {
+	return len(n) > 0 && len(n) < 256
+}
+
+// 別名の言語(ja, en, ja-Latn など)
+type AliasLanguage string
+
+var aliasLanguagePattern = regexp.MustCompile(`^[A-Za-z]{2,3}(-[A-Za-z0-9]{1,8})*$`)
+
+// 0 ≦ language.length ≦ 35 かつ BCP 47 の形式
+func (l AliasLanguage) Valid() bool {
+	// NOTE: 必須情報ではない
+	if l == "" {
+		return true
+	}
+	return len(l) < 36 && aliasLanguagePattern.MatchString(string(l))
+}
+
+// 別名の種別
+type AliasKind uint8
+
+// NOTE: 種別未指定の別名は正式名称として扱う
+const (
+	AliasKind_Official AliasKind = iota
+	AliasKind_Abbreviation
+	AliasKind_Reading
+	AliasKind_Former
+	AliasKind_MAX
+)
+
+// official, abbreviation, reading, former を受け付ける
+// NOTE: 空文字は正式名称とする
+func NewAliasKind(kind string) (AliasKind, error) {
+	if kind == "" {
+		return AliasKind_Official, nil
+	}
+	for k := AliasKind_Official; k < AliasKind_MAX; k++ {
+		if k.String() == kind {
+			return k, nil
+		}
+	}
+	return AliasKind_Official, fmt.Errorf("alias kind format error: %q", kind)
+}
+
+func (k AliasKind) Valid() bool {
+	return k < AliasKind_MAX
+}
+
+func (k AliasKind) String() string {
+	switch k {
+	case AliasKind_Abbreviation:
+		return "abbreviation"
+	case AliasKind_Reading:
+		return "reading"
+	case AliasKind_Former:
+		return "former"
+	default:
+		return "official"
+	}
+}
+
+// 別名
+// NOTE: 名前・言語・種別の組でゲームごとに一意
+type Alias struct {
+	Name      AliasName
+	Language  AliasLanguage
+	Kind      AliasKind
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+func NewAlias(name AliasName, language AliasLanguage, kind AliasKind) *Alias {
+	return &Alias{
+		Name:     name,
+		Language: language,
+		Kind:     kind,
+	}
+}
+
+// 一意になる組
+func (a *Alias) key() string {
+	return fmt.Sprintf("%s\n%s\n%d", a.Name, a.Language, a.Kind)
+}
+
+// 別名の設定
+func (g *Game) SetAliases(aliases []*Alias) *Game {
+	g.Aliases = aliases
+	return g
+}
+
+// 読み
+// NOTE: 読みの別名がないときは名前を読みとする
+func (g *Game) Reading() string {
+	for _, alias := range g.Aliases {
+		if alias.Kind == AliasKind_Reading {
+			return string(alias.Name)
+		}
+	}
+	return string(g.Name)
+}
+
+// 並び替え用の名前
+// NOTE: 読みを正規化してカタカナにそろえ、五十音順に並ぶようにする
+func (g *Game) SortName() string {
+	return NormalizeText(g.Reading())
+}
+
+func equalAliases(a, b []*Alias) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	keys := make(map[string]bool, len(a))
+	for _, alias := range a {
+		keys[alias.key()] = true
+	}
+	for _, alias := range b {
+		if !keys[alias.key()] {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,174 @@
+package game
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAliasName_Valid(t *testing.T) {
+	tests := []struct {
+		name string
+		n    AliasName
+		want bool
+	}{
+		{
+			name: "1文字",
+			n:    "a",
+			want: true,
+		},
+		{
+			name: "255文字",
+			n:    AliasName(strings.Repeat("a", 255)),
+			want: true,
+		},
+		{
+			name: "空文字",
+			n:    "",
+			want: false,
+		},
+		{
+			name: "256文字",
+			n:    AliasName(strings.Repeat("a", 256)),
+			want: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.n.Valid())
+		})
+	}
+}
+
+func TestAliasLanguage_Valid(t *testing.T) {
+	tests := []struct {
+		name string
+		l    AliasLanguage
+		want bool
+	}{
+		{
+			name: "未指定",
+			l:    "",
+			want: true,
+		},
+		{
+			name: "言語のみ",
+			l:    "ja",
+			want: true,
+		},
+		{
+			name: "文字種付き",
+			l:    "ja-Latn",
+			want: true,
+		},
+		{
+			name: "地域付き",
+			l:    "en-US",
+			want: true,
+		},
+		{
+			name: "言語が1文字",
+			l:    "j",
+			want: false,
+		},
+		{
+			name: "区切りがアンダースコア",
+			l:    "en_US",
+			want: false,
+		},
+		{
+			name: "36文字",
+			l:    AliasLanguage("ja" + strings.Repeat("-abcdefgh", 3) + "-abcdefg"),
+			want: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.l.Valid())
+		})
+	}
+}
+
+func TestNewAliasKind(t *testing.T) {
+	tests := []struct {
+		name    string
+		kind    string
+		want    AliasKind
+		wantErr bool
+	}{
+		{
+			name: "正式名称",
+			kind: "official",
+			want: AliasKind_Official,
+		},
+		{
+			name: "略称",
+			kind: "abbreviation",
+			want: AliasKind_Abbreviation,
+		},
+		{
+			name: "読み",
+			kind: "reading",
+			want: AliasKind_Reading,
+		},
+		{
+			name: "旧題",
+			kind: "former",
+			want: AliasKind_Former,
+		},
+		{
+			name: "未指定は正式名称",
+			kind: "",
+			want: AliasKind_Official,
+		},
+		{
+			name:    "存在しない種別",
+			kind:    "nickname",
+			want:    AliasKind_Official,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := NewAliasKind(tt.kind)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("NewAliasKind() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestGame_SortName(t *testing.T) {
+	tests := []struct {
+		name string
+		g    *Game
+		want string
+	}{
+		{
+			name: "読みの別名で並べる",
+			g: &Game{
+				Name: "風来のシレン",
+				Aliases: []*Alias{
+					NewAlias("シレン", "ja", AliasKind_Abbreviation),
+					NewAlias("ふうらいのしれん", "ja", AliasKind_Reading),
+				},
+			},
+			want: "フウライノシレン",
+		},
+		{
+			name: "読みがないときは名前で並べる",
+			g: &Game{
+				Name: "ﾄﾙﾈｺの大冒険",
+			},
+			want: "トルネコノ大冒険",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.g.SortName())
+		})
+	}
+}
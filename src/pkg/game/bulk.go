@@ -0,0 +1,144 @@
+package game
+
+import (
+	"mysrtafes-backend/pkg/game/platform"
+	"mysrtafes-backend/pkg/game/tag"
+)
+
+// 一括操作の最大件数
+const BulkLimit = 1000
+
+// 一括登録のモード
+type ImportMode uint8
+
+const (
+	// 1件でも失敗したらすべて登録しない
+	ImportMode_Transaction ImportMode = iota
+	// 失敗した行を飛ばして登録する
+	ImportMode_SkipInvalid
+	ImportMode_MAX
+)
+
+func (m ImportMode) Valid() bool {
+	return m < ImportMode_MAX
+}
+
+// 一括登録の1行
+// NOTE: リクエストの変換に失敗した行はErrに保持し、登録しない
+type ImportRow struct {
+	Game        *Game
+	PlatformIDs []platform.ID
+	TagIDs      []tag.ID
+	Err         error
+}
+
+func NewImportRow(game *Game, platformIDs []platform.ID, tagIDs []tag.ID) *ImportRow {
+	return &ImportRow{
+		Game:        game,
+		PlatformIDs: platformIDs,
+		TagIDs:      tagIDs,
+	}
+}
+
+func NewInvalidImportRow(err error) *ImportRow {
+	return &ImportRow{
+		Err: err,
+	}
+}
+
+// 一括操作の行ごとの状態
+type BulkStatus uint8
+
+const (
+	// 成功
+	BulkStatus_Succeeded BulkStatus = iota
+	// 失敗
+	BulkStatus_Failed
+	// 他の行の失敗により取り消し
+	BulkStatus_Canceled
+)
+
+func (s BulkStatus) String() string {
+	switch s {
+	case BulkStatus_Succeeded:
+		return "succeeded"
+	case BulkStatus_Failed:
+		return "failed"
+	default:
+		return "canceled"
+	}
+}
+
+// 一括登録の行ごとの結果
+type ImportResult struct {
+	Status BulkStatus
+	Game   *Game
+	Err    error
+}
+
+func NewImportSucceeded(game *Game) *ImportResult {
+	return &ImportResult{
+		Status: BulkStatus_Succeeded,
+		Game:   game,
+	}
+}
+
+func NewImportFailed(err error) *ImportResult {
+	return &ImportResult{
+		Status: BulkStatus_Failed,
+		Err:    err,
+	}
+}
+
+func NewImportCanceled() *ImportResult {
+	return &ImportResult{
+		Status: BulkStatus_Canceled,
+	}
+}
+
+// 一括更新の1件
+// NOTE: リクエストの変換に失敗した行はErrに保持し、更新しない
+type UpdateRow struct {
+	Game        *Game
+	PlatformIDs []platform.ID
+	TagIDs      []tag.ID
+	Err         error
+}
+
+func NewUpdateRow(game *Game, platformIDs []platform.ID, tagIDs []tag.ID) *UpdateRow {
+	return &UpdateRow{
+		Game:        game,
+		PlatformIDs: platformIDs,
+		TagIDs:      tagIDs,
+	}
+}
+
+// NOTE: 結果にIDを含めるため、変換できたときはIDのみ設定したゲームを持つ
+func NewInvalidUpdateRow(id ID, err error) *UpdateRow {
+	return &UpdateRow{
+		Game: &Game{ID: id},
+		Err:  err,
+	}
+}
+
+// 一括更新・削除のIDごとの結果
+type BulkResult struct {
+	ID     ID
+	Status BulkStatus
+	Err    error
+}
+
+func NewBulkSucceeded(id ID) *BulkResult {
+	return &BulkResult{
+		ID:     id,
+		Status: BulkStatus_Succeeded,
+	}
+}
+
+func NewBulkFailed(id ID, err error) *BulkResult {
+	return &BulkResult{
+		ID:     id,
+		Status: BulkStatus_Failed,
+		Err:    err,
+	}
+}
@@ -0,0 +1,502 @@
+package game
+
+import (
+	"fmt"
+	"mysrtafes-backend/pkg/errors"
+	"mysrtafes-backend/pkg/game/platform"
+	"mysrtafes-backend/pkg/game/tag"
+	"mysrtafes-backend/pkg/version"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type repository struct {
+	game        *Game
+	games       []*Game
+	meta        *FindMeta
+	results     []*ImportResult
+	bulkResults []*BulkResult
+	patched     *Game
+	// NOTE: GamePatchに渡されたフィールドを記録する
+	patchFields *[]Field
+	// NOTE: 指定があるときはGameReadでIDごとのゲームを返却し、ないIDはNotFoundとする
+	gamesByID map[ID]*Game
+	// NOTE: 指定があるときは保存時に読み込む更新日時とし、ないときはgameの更新日時とする
+	stored   *time.Time
+	redirect *Redirect
+	err      error
+	// flags
+	create, read, find, update, patch, delete, trash, restore, purge, importing, bulkUpdate, bulkDelete, merge bool
+}
+
+func (r repository) GameCreate(*Game, []platform.ID, []tag.ID) (*Game, error) {
+	if r.create {
+		return r.game, r.err
+	}
+	return nil, fmt.Errorf("failed create")
+}
+func (r repository) GameRead(id ID, _ *Include) (*Game, error) {
+	if r.read && r.gamesByID != nil {
+		if g, ok := r.gamesByID[id]; ok {
+			return g, nil
+		}
+		return nil, errors.NewNotFound(errors.Layer_Model, nil, "not found")
+	}
+	if r.read {
+		return r.game, r.err
+	}
+	return nil, fmt.Errorf("failed read")
+}
+func (r repository) GameFind(*FindOption) ([]*Game, *FindMeta, error) {
+	if r.find {
+		return r.games, r.meta, r.err
+	}
+	return nil, nil, fmt.Errorf("failed find")
+}
+func (r repository) GameFindByTag(tag.ID, *FindOption) ([]*Game, *FindMeta, error) {
+	if r.find {
+		return r.games, r.meta, r.err
+	}
+	return nil, nil, fmt.Errorf("failed find")
+}
+func (r repository) GameFindByPlatform(platform.ID, *FindOption) ([]*Game, *FindMeta, error) {
+	if r.find {
+		return r.games, r.meta, r.err
+	}
+	return nil, nil, fmt.Errorf("failed find")
+}
+
+// NOTE: 保存時の前提条件のチェック
+func (r repository) checkPrecondition(precondition *version.Precondition) error {
+	if r.stored != nil {
+		return precondition.Check(*r.stored)
+	}
+	if r.game != nil {
+		return precondition.Check(r.game.UpdatedAt)
+	}
+	return nil
+}
+
+func (r repository) GameUpdate(_ *Game, _ []platform.ID, _ []tag.ID, precondition *version.Precondition) (*Game, error) {
+	if r.update {
+		if err := r.checkPrecondition(precondition); err != nil {
+			return nil, err
+		}
+		return r.game, r.err
+	}
+	return nil, fmt.Errorf("failed update")
+}
+func (r repository) GamePatch(_ *Game, _ []platform.ID, _ []tag.ID, fields []Field, precondition *version.Precondition) (*Game, error) {
+	if r.patchFields != nil {
+		*r.patchFields = fields
+	}
+	if r.patch {
+		if err := r.checkPrecondition(precondition); err != nil {
+			return nil, err
+		}
+		return r.patched, r.err
+	}
+	return nil, fmt.Errorf("failed patch")
+}
+func (r repository) GameDelete(_ ID, precondition *version.Precondition) error {
+	if r.delete {
+		if err := r.checkPrecondition(precondition); err != nil {
+			return err
+		}
+		return r.err
+	}
+	return fmt.Errorf("failed delete")
+}
+func (r repository) GameTrash(*FindOption) ([]*Game, *FindMeta, error) {
+	if r.trash {
+		return r.games, r.meta, r.err
+	}
+	return nil, nil, fmt.Errorf("failed trash")
+}
+func (r repository) GameRestore(ID) (*Game, error) {
+	if r.restore {
+		return r.game, r.err
+	}
+	return nil, fmt.Errorf("failed restore")
+}
+func (r repository) GamePurge(ID) error {
+	if r.purge {
+		return r.err
+	}
+	return fmt.Errorf("failed purge")
+}
+func (r repository) GameImport(rows []*ImportRow, _ ImportMode) ([]*ImportResult, error) {
+	if r.importing {
+		return r.results[:len(rows)], r.err
+	}
+	return nil, fmt.Errorf("failed import")
+}
+
+func (r repository) GameBulkUpdate(rows []*UpdateRow) ([]*BulkResult, error) {
+	if r.bulkUpdate {
+		return r.bulkResults[:len(rows)], r.err
+	}
+	return nil, fmt.Errorf("failed bulk update")
+}
+func (r repository) GameBulkDelete(ids []ID) ([]*BulkResult, error) {
+	if r.bulkDelete {
+		return r.bulkResults[:len(ids)], r.err
+	}
+	return nil, fmt.Errorf("failed bulk delete")
+}
+
+// NOTE: 読み込んだ統合先・統合元から統合後のゲームを作り、保存後のゲームとしてgameを返却する
+func (r repository) GameMerge(sourceID ID, targetID ID, merge Merger) (*Game, error) {
+	if r.merge && r.err != nil {
+		return nil, r.err
+	}
+	if r.merge {
+		source, err := r.GameRead(sourceID, nil)
+		if err != nil {
+			return nil, err
+		}
+		target, err := r.GameRead(targetID, nil)
+		if err != nil {
+			return nil, err
+		}
+		if _, _, _, err := merge(target, source); err != nil {
+			return nil, err
+		}
+		return r.game, nil
+	}
+	return nil, fmt.Errorf("failed merge")
+}
+func (r repository) GameRedirect(ID) (*Redirect, error) {
+	if r.redirect != nil {
+		return r.redirect, nil
+	}
+	return nil, errors.NewNotFound(errors.Layer_Model, nil, "not found")
+}
+
+func TestImportMode_Valid(t *testing.T) {
+	tests := []struct {
+		name string
+		m    ImportMode
+		want bool
+	}{
+		{
+			name: "トランザクション",
+			m:    ImportMode_Transaction,
+			want: true,
+		},
+		{
+			name: "不正行スキップ",
+			m:    ImportMode_SkipInvalid,
+			want: true,
+		},
+		{
+			name: "範囲外",
+			m:    ImportMode_MAX,
+			want: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.m.Valid())
+		})
+	}
+}
+
+func Test_server_Import(t *testing.T) {
+	invalidErr := fmt.Errorf("invalid row")
+	type fields struct {
+		repository Repository
+	}
+	type args struct {
+		rows []*ImportRow
+		mode ImportMode
+	}
+	tests := []struct {
+		name    string
+		fields  fields
+		args    args
+		want    []BulkStatus
+		wantErr bool
+	}{
+		{
+			name: "全件OK",
+			fields: fields{
+				repository: repository{
+					results: []*ImportResult{
+						NewImportSucceeded(&Game{ID: 1}),
+						NewImportSucceeded(&Game{ID: 2}),
+					},
+					importing: true,
+				},
+			},
+			args: args{
+				rows: []*ImportRow{
+					NewImportRow(&Game{Name: "game1"}, nil, nil),
+					NewImportRow(&Game{Name: "game2"}, nil, nil),
+				},
+				mode: ImportMode_Transaction,
+			},
+			want: []BulkStatus{BulkStatus_Succeeded, BulkStatus_Succeeded},
+		},
+		{
+			name: "トランザクションモードで不正行があるときは登録しない",
+			fields: fields{
+				repository: repository{
+					importing: false,
+				},
+			},
+			args: args{
+				rows: []*ImportRow{
+					NewImportRow(&Game{Name: "game1"}, nil, nil),
+					NewImportRow(&Game{Name: ""}, nil, nil),
+					NewInvalidImportRow(invalidErr),
+				},
+				mode: ImportMode_Transaction,
+			},
+			want: []BulkStatus{BulkStatus_Canceled, BulkStatus_Failed, BulkStatus_Failed},
+		},
+		{
+			name: "スキップモードで不正行は飛ばす",
+			fields: fields{
+				repository: repository{
+					results: []*ImportResult{
+						NewImportSucceeded(&Game{ID: 1}),
+					},
+					importing: true,
+				},
+			},
+			args: args{
+				rows: []*ImportRow{
+					NewImportRow(&Game{Name: ""}, nil, nil),
+					NewImportRow(&Game{Name: "game2"}, nil, nil),
+				},
+				mode: ImportMode_SkipInvalid,
+			},
+			want: []BulkStatus{BulkStatus_Failed, BulkStatus_Succeeded},
+		},
+		{
+			name: "空の行リスト",
+			fields: fields{
+				repository: repository{
+					importing: true,
+				},
+			},
+			args: args{
+				rows: []*ImportRow{},
+				mode: ImportMode_Transaction,
+			},
+			wantErr: true,
+		},
+		{
+			name: "モードのバリデートエラー",
+			fields: fields{
+				repository: repository{
+					importing: true,
+				},
+			},
+			args: args{
+				rows: []*ImportRow{
+					NewImportRow(&Game{Name: "game1"}, nil, nil),
+				},
+				mode: ImportMode_MAX,
+			},
+			wantErr: true,
+		},
+		{
+			name: "リポジトリエラー",
+			fields: fields{
+				repository: repository{
+					importing: false,
+				},
+			},
+			args: args{
+				rows: []*ImportRow{
+					NewImportRow(&Game{Name: "game1"}, nil, nil),
+				},
+				mode: ImportMode_SkipInvalid,
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := &server{
+				repository: tt.fields.repository,
+			}
+			got, err := s.Import(tt.args.rows, tt.args.mode)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("server.Import() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if tt.wantErr {
+				return
+			}
+			statuses := make([]BulkStatus, 0, len(got))
+			for _, result := range got {
+				statuses = append(statuses, result.Status)
+			}
+			assert.Equal(t, tt.want, statuses)
+		})
+	}
+}
+
+func Test_server_BulkUpdate(t *testing.T) {
+	type fields struct {
+		repository Repository
+	}
+	type args struct {
+		rows []*UpdateRow
+	}
+	tests := []struct {
+		name    string
+		fields  fields
+		args    args
+		want    []*BulkResult
+		wantErr bool
+	}{
+		{
+			name: "不正な件は失敗、残りを更新",
+			fields: fields{
+				repository: repository{
+					bulkResults: []*BulkResult{
+						NewBulkSucceeded(1),
+						NewBulkFailed(3, fmt.Errorf("not found")),
+					},
+					bulkUpdate: true,
+				},
+			},
+			args: args{
+				rows: []*UpdateRow{
+					NewUpdateRow(&Game{ID: 1, Name: "game1"}, nil, nil),
+					NewUpdateRow(&Game{ID: 2, Name: ""}, nil, nil),
+					NewUpdateRow(&Game{ID: 1, Name: "game1"}, nil, nil),
+					NewUpdateRow(&Game{ID: 3, Name: "game3"}, nil, nil),
+					NewInvalidUpdateRow(4, fmt.Errorf("convert error")),
+				},
+			},
+			want: []*BulkResult{
+				{ID: 1, Status: BulkStatus_Succeeded},
+				{ID: 2, Status: BulkStatus_Failed},
+				{ID: 1, Status: BulkStatus_Failed},
+				{ID: 3, Status: BulkStatus_Failed},
+				{ID: 4, Status: BulkStatus_Failed},
+			},
+		},
+		{
+			name: "件数エラー",
+			fields: fields{
+				repository: repository{
+					bulkUpdate: true,
+				},
+			},
+			args: args{
+				rows: []*UpdateRow{},
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := &server{
+				repository: tt.fields.repository,
+			}
+			got, err := s.BulkUpdate(tt.args.rows)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("server.BulkUpdate() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !assert.Equal(t, len(tt.want), len(got)) {
+				return
+			}
+			for i := range tt.want {
+				assert.Equal(t, tt.want[i].ID, got[i].ID)
+				assert.Equal(t, tt.want[i].Status, got[i].Status)
+			}
+		})
+	}
+}
+
+func Test_server_BulkDelete(t *testing.T) {
+	type fields struct {
+		repository Repository
+	}
+	type args struct {
+		ids []ID
+	}
+	tests := []struct {
+		name    string
+		fields  fields
+		args    args
+		want    []*BulkResult
+		wantErr bool
+	}{
+		{
+			name: "不正なIDは失敗、残りを削除",
+			fields: fields{
+				repository: repository{
+					bulkResults: []*BulkResult{
+						NewBulkSucceeded(1),
+						NewBulkSucceeded(2),
+					},
+					bulkDelete: true,
+				},
+			},
+			args: args{
+				ids: []ID{1, 0, 2, 2},
+			},
+			want: []*BulkResult{
+				{ID: 1, Status: BulkStatus_Succeeded},
+				{ID: 0, Status: BulkStatus_Failed},
+				{ID: 2, Status: BulkStatus_Succeeded},
+				{ID: 2, Status: BulkStatus_Failed},
+			},
+		},
+		{
+			name: "全件不正のときはリポジトリを呼ばない",
+			fields: fields{
+				repository: repository{
+					bulkDelete: false,
+				},
+			},
+			args: args{
+				ids: []ID{0},
+			},
+			want: []*BulkResult{
+				{ID: 0, Status: BulkStatus_Failed},
+			},
+		},
+		{
+			name: "リポジトリエラー",
+			fields: fields{
+				repository: repository{
+					bulkDelete: false,
+				},
+			},
+			args: args{
+				ids: []ID{1},
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := &server{
+				repository: tt.fields.repository,
+			}
+			got, err := s.BulkDelete(tt.args.ids)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("server.BulkDelete() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !assert.Equal(t, len(tt.want), len(got)) {
+				return
+			}
+			for i := range tt.want {
+				assert.Equal(t, tt.want[i].ID, got[i].ID)
+				assert.Equal(t, tt.want[i].Status, got[i].Status)
+			}
+		})
+	}
+}
@@ -0,0 +1,368 @@
+package game
+
+import (
+	"bytes"
+	"fmt"
+	"image"
+	"image/color"
+	"image/jpeg"
+	"mysrtafes-backend/pkg/errors"
+	"net/http"
+	"time"
+
+	// NOTE: image.Decodeで読み込める形式の登録
+	_ "image/gif"
+	_ "image/png"
+)
+
+// カバー画像の形式
+// NOTE: 受け付けた画像のMIMEタイプ。リクエストのヘッダではなく画像の中身から判定する
+type CoverContentType string
+
+const (
+	CoverContentType_JPEG CoverContentType = "image/jpeg"
+	CoverContentType_PNG  CoverContentType = "image/png"
+	CoverContentType_GIF  CoverContentType = "image/gif"
+)
+
+// 画像の中身から形式を判定する
+func NewCoverContentType(data []byte) CoverContentType {
+	return CoverContentType(http.DetectContentType(data))
+}
+
+// image/jpeg, image/png, image/gif を受け付ける
+func (c CoverContentType) Valid() bool {
+	switch c {
+	case CoverContentType_JPEG, CoverContentType_PNG, CoverContentType_GIF:
+		return true
+	default:
+		return false
+	}
+}
+
+// 保存するファイルの拡張子
+func (c CoverContentType) Extension() string {
+	switch c {
+	case CoverContentType_JPEG:
+		return ".jpg"
+	case CoverContentType_PNG:
+		return ".png"
+	case CoverContentType_GIF:
+		return ".gif"
+	default:
+		return ""
+	}
+}
+
+// カバー画像のファイルサイズ(byte)
+type CoverSize int64
+
+// 5MiB
+const CoverMaxSize CoverSize = 5 << 20
+
+// 1 ≦ size ≦ 5MiB
+func (s CoverSize) Valid() bool {
+	return s > 0 && s <= CoverMaxSize
+}
+
+// カバー画像の一辺の最大ピクセル数
+// NOTE: 展開後のメモリを抑えるため、デコード前にヘッダの大きさで弾く
+const CoverMaxDimension = 4096
+
+// サムネイルの大きさ
+// NOTE: パッケージ画像に合わせた縦長の固定サイズ。縦横比が異なるときは中央を切り抜く
+const (
+	CoverThumbnailWidth  = 240
+	CoverThumbnailHeight = 320
+)
+
+// サムネイルの形式
+const CoverThumbnailContentType = CoverContentType_JPEG
+
+// ストレージ上のファイルの識別子
+type CoverKey string
+
+// カバー画像
+// NOTE: URLは保存したストレージが返却したもの
+type Cover struct {
+	Key          CoverKey
+	URL          string
+	ContentType  CoverContentType
+	Size         CoverSize
+	Width        int
+	Height       int
+	ThumbnailKey CoverKey
+	ThumbnailURL string
+	CreatedAt    time.Time
+	UpdatedAt    time.Time
+}
+
+// サムネイルの生成
+// NOTE: 中央を固定サイズの縦横比で切り抜き、範囲内の画素を平均して縮小する。透過部分は白で塗る
+func NewCoverThumbnail(src image.Image) image.Image {
+	bounds := src.Bounds()
+	crop := bounds
+	// NOTE: 縦横比を整数のまま比べるため掛け合わせる
+	if bounds.Dx()*CoverThumbnailHeight > bounds.Dy()*CoverThumbnailWidth {
+		width := bounds.Dy() * CoverThumbnailWidth / CoverThumbnailHeight
+		crop.Min.X += (bounds.Dx() - width) / 2
+		crop.Max.X = crop.Min.X + width
+	} else {
+		height := bounds.Dx() * CoverThumbnailHeight / CoverThumbnailWidth
+		crop.Min.Y += (bounds.Dy() - height) / 2
+		crop.Max.Y = crop.Min.Y + height
+	}
+
+	dst := image.NewRGBA(image.Rect(0, 0, CoverThumbnailWidth, CoverThumbnailHeight))
+	for y := 0; y < CoverThumbnailHeight; y++ {
+		y0 := crop.Min.Y + y*crop.Dy()/CoverThumbnailHeight
+		y1 := crop.Min.Y + (y+1)*crop.Dy()/CoverThumbnailHeight
+		if y1 <= y0 {
+			y1 = y0 + 1
+		}
+		for x := 0; x < CoverThumbnailWidth; x++ {
+			x0 := crop.Min.X + x*crop.Dx()/CoverThumbnailWidth
+			x1 := crop.Min.X + (x+1)*crop.Dx()/CoverThumbnailWidth
+			if x1 <= x0 {
+				x1 = x0 + 1
+			}
+			var r, g, b, n uint64
+			for sy := y0; sy < y1; sy++ {
+				for sx := x0; sx < x1; sx++ {
+					sr, sg, sb, sa := src.At(sx, sy).RGBA()
+					// NOTE: 乗算済みの値のため、白との合成は不透明度の残りを足す
+					r += uint64(sr + 0xffff - sa)
+					g += uint64(sg + 0xffff - sa)
+					b += uint64(sb + 0xffff - sa)
+					n++
+				}
+			}
+			dst.SetRGBA(x, y, color.RGBA{
+				R: uint8(r / n >> 8),
+				G: uint8(g / n >> 8),
+				B: uint8(b / n >> 8),
+				A: 0xff,
+			})
+		}
+	}
+	return dst
+}
+
+// カバー画像の保存先
+// NOTE: ファイルの実体の置き場所を差し替えられるようにする。Saveは公開用のURLを返却する
+type CoverStorage interface {
+	Save(key CoverKey, contentType CoverContentType, data []byte) (string, error)
+	Delete(key CoverKey) error
+}
+
+type CoverRepository interface {
+	CoverRead(ID) (*Cover, error)
+	CoverSave(ID, *Cover) (*Cover, error)
+	CoverDelete(ID) error
+}
+
+type CoverServer interface {
+	Upload(ID, []byte) (*Cover, error)
+	Read(ID) (*Cover, error)
+	Delete(ID) error
+}
+
+type coverServer struct {
+	repository CoverRepository
+	storage    CoverStorage
+}
+
+func NewCoverServer(repo CoverRepository, storage CoverStorage) CoverServer {
+	return &coverServer{repo, storage}
+}
+
+// GameCoverの登録
+// NOTE: 登録済みのときは差し替え、保存に成功してから以前のファイルを削除する
+func (s *coverServer) Upload(gameID ID, data []byte) (*Cover, error) {
+	// GameIDのValidate
+	if !gameID.Valid() {
+		return nil, errors.NewInvalidRequest(
+			errors.Layer_Domain,
+			errors.NewInformation(
+				errors.ID_InvalidParams,
+				"",
+				[]errors.InvalidParams{
+					errors.NewInvalidParams("gameID", gameID),
+				},
+			),
+			"gameID Valid error",
+		)
+	}
+	// SizeのValidate
+	size := CoverSize(len(data))
+	if !size.Valid() {
+		return nil, errors.NewInvalidRequest(
+			errors.Layer_Domain,
+			errors.NewInformation(
+				errors.ID_InvalidParams,
+				fmt.Sprintf("cover size must be between 1 and %d bytes", CoverMaxSize),
+				[]errors.InvalidParams{
+					errors.NewInvalidParams("size", size),
+				},
+			),
+			"size Valid error",
+		)
+	}
+	// ContentTypeのValidate
+	contentType := NewCoverContentType(data)
+	if !contentType.Valid() {
+		return nil, errors.NewUnsupportedMediaType(
+			errors.Layer_Domain,
+			errors.NewInformation(
+				errors.ID_InvalidParams,
+				"cover must be jpeg, png or gif",
+				[]errors.InvalidParams{
+					errors.NewInvalidParams("content_type", contentType),
+				},
+			),
+			"content_type Valid error",
+		)
+	}
+	// 大きさのValidate
+	config, _, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil {
+		return nil, errors.NewInvalidRequest(
+			errors.Layer_Domain,
+			errors.NewInformation(
+				errors.ID_ImageDecodeError,
+				err.Error(),
+				nil,
+			),
+			"image decode config error",
+		)
+	}
+	if config.Width > CoverMaxDimension || config.Height > CoverMaxDimension {
+		return nil, errors.NewInvalidRequest(
+			errors.Layer_Domain,
+			errors.NewInformation(
+				errors.ID_InvalidParams,
+				fmt.Sprintf("cover must be at most %dx%d pixels", CoverMaxDimension, CoverMaxDimension),
+				[]errors.InvalidParams{
+					errors.NewInvalidParams("width", config.Width),
+					errors.NewInvalidParams("height", config.Height),
+				},
+			),
+			"dimension Valid error",
+		)
+	}
+	img, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, errors.NewInvalidRequest(
+			errors.Layer_Domain,
+			errors.NewInformation(
+				errors.ID_ImageDecodeError,
+				err.Error(),
+				nil,
+			),
+			"image decode error",
+		)
+	}
+	var thumbnail bytes.Buffer
+	if err := jpeg.Encode(&thumbnail, NewCoverThumbnail(img), &jpeg.Options{Quality: 85}); err != nil {
+		return nil, errors.NewInternalServerError(
+			errors.Layer_Domain,
+			errors.NewInformation(
+				errors.ID_UnknownError,
+				err.Error(),
+				nil,
+			),
+			"thumbnail encode error",
+		)
+	}
+
+	// 以前のカバー画像
+	// NOTE: 未登録のときはNotFoundになるため無視する
+	previous, err := s.repository.CoverRead(gameID)
+	if _, ok := err.(errors.NotFoundError); ok {
+		previous = nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	// NOTE: 差し替え時にキャッシュが残らないよう、キーに登録日時を含める
+	now := time.Now().UnixNano()
+	cover := &Cover{
+		Key:          CoverKey(fmt.Sprintf("games/%d/cover-%d%s", gameID, now, contentType.Extension())),
+		ContentType:  contentType,
+		Size:         size,
+		Width:        config.Width,
+		Height:       config.Height,
+		ThumbnailKey: CoverKey(fmt.Sprintf("games/%d/thumbnail-%d%s", gameID, now, CoverThumbnailContentType.Extension())),
+	}
+	cover.URL, err = s.storage.Save(cover.Key, contentType, data)
+	if err != nil {
+		return nil, err
+	}
+	cover.ThumbnailURL, err = s.storage.Save(cover.ThumbnailKey, CoverThumbnailContentType, thumbnail.Bytes())
+	if err != nil {
+		s.storage.Delete(cover.Key)
+		return nil, err
+	}
+	saved, err := s.repository.CoverSave(gameID, cover)
+	if err != nil {
+		// NOTE: 登録できなかったファイルは残さない
+		s.storage.Delete(cover.Key)
+		s.storage.Delete(cover.ThumbnailKey)
+		return nil, err
+	}
+
+	// NOTE: 登録は完了しているため、以前のファイルの削除に失敗してもエラーにしない
+	if previous != nil {
+		s.storage.Delete(previous.Key)
+		s.storage.Delete(previous.ThumbnailKey)
+	}
+	return saved, nil
+}
+
+// GameCoverの取得
+func (s *coverServer) Read(gameID ID) (*Cover, error) {
+	// GameIDのValidate
+	if !gameID.Valid() {
+		return nil, errors.NewInvalidRequest(
+			errors.Layer_Domain,
+			errors.NewInformation(
+				errors.ID_InvalidParams,
+				"",
+				[]errors.InvalidParams{
+					errors.NewInvalidParams("gameID", gameID),
+				},
+			),
+			"gameID Valid error",
+		)
+	}
+	return s.repository.CoverRead(gameID)
+}
+
+// GameCoverの削除
+// NOTE: 登録を削除してからファイルを削除する。ファイルの削除に失敗してもエラーにしない
+func (s *coverServer) Delete(gameID ID) error {
+	// GameIDのValidate
+	if !gameID.Valid() {
+		return errors.NewInvalidRequest(
+			errors.Layer_Domain,
+			errors.NewInformation(
+				errors.ID_InvalidParams,
+				"",
+				[]errors.InvalidParams{
+					errors.NewInvalidParams("gameID", gameID),
+				},
+			),
+			"gameID Valid error",
+		)
+	}
+	cover, err := s.repository.CoverRead(gameID)
+	if err != nil {
+		return err
+	}
+	if err := s.repository.CoverDelete(gameID); err != nil {
+		return err
+	}
+	s.storage.Delete(cover.Key)
+	s.storage.Delete(cover.ThumbnailKey)
+	return nil
+}
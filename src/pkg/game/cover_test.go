@@ -0,0 +1,396 @@
+package game
+
+import (
+	"bytes"
+	"fmt"
+	"image"
+	"image/color"
+	"image/png"
+	"mysrtafes-backend/pkg/errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type coverRepository struct {
+	cover *Cover
+	err   error
+	// 読み込みのみのエラー
+	readErr error
+	// flags
+	read, save, delete bool
+}
+
+func (r *coverRepository) CoverRead(ID) (*Cover, error) {
+	if r.read {
+		return r.cover, r.readErr
+	}
+	return nil, fmt.Errorf("failed read")
+}
+func (r *coverRepository) CoverSave(_ ID, c *Cover) (*Cover, error) {
+	if r.save {
+		if r.err != nil {
+			return nil, r.err
+		}
+		return c, nil
+	}
+	return nil, fmt.Errorf("failed save")
+}
+func (r *coverRepository) CoverDelete(ID) error {
+	if r.delete {
+		return r.err
+	}
+	return fmt.Errorf("failed delete")
+}
+
+type coverStorage struct {
+	saved   []CoverKey
+	deleted []CoverKey
+	err     error
+}
+
+func (s *coverStorage) Save(key CoverKey, _ CoverContentType, _ []byte) (string, error) {
+	if s.err != nil {
+		return "", s.err
+	}
+	s.saved = append(s.saved, key)
+	return "/covers/" + string(key), nil
+}
+func (s *coverStorage) Delete(key CoverKey) error {
+	s.deleted = append(s.deleted, key)
+	return nil
+}
+
+func newTestImage(width, height int) []byte {
+	img := image.NewRGBA(image.Rect(0, 0, width, height))
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			img.Set(x, y, color.RGBA{R: uint8(x), G: uint8(y), B: 0x80, A: 0xff})
+		}
+	}
+	var buf bytes.Buffer
+	png.Encode(&buf, img)
+	return buf.Bytes()
+}
+
+func TestCoverContentType_Valid(t *testing.T) {
+	tests := []struct {
+		name string
+		data []byte
+		want bool
+	}{
+		{
+			name: "png",
+			data: newTestImage(1, 1),
+			want: true,
+		},
+		{
+			name: "gif",
+			data: []byte("GIF89a"),
+			want: true,
+		},
+		{
+			name: "jpeg",
+			data: []byte("\xFF\xD8\xFF"),
+			want: true,
+		},
+		{
+			name: "テキストはエラー",
+			data: []byte("cover"),
+			want: false,
+		},
+		{
+			name: "webpはエラー",
+			data: []byte("RIFF\x00\x00\x00\x00WEBPVP"),
+			want: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, NewCoverContentType(tt.data).Valid())
+		})
+	}
+}
+
+func TestNewCoverThumbnail(t *testing.T) {
+	tests := []struct {
+		name string
+		img  image.Image
+	}{
+		{
+			name: "縮小",
+			img:  image.NewRGBA(image.Rect(0, 0, 600, 800)),
+		},
+		{
+			name: "横長は中央を切り抜く",
+			img:  image.NewRGBA(image.Rect(0, 0, 1200, 400)),
+		},
+		{
+			name: "拡大",
+			img:  image.NewRGBA(image.Rect(0, 0, 30, 40)),
+		},
+		{
+			name: "原点以外から始まる画像",
+			img:  image.NewRGBA(image.Rect(10, 20, 310, 420)),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := NewCoverThumbnail(tt.img)
+			assert.Equal(t, image.Rect(0, 0, CoverThumbnailWidth, CoverThumbnailHeight), got.Bounds())
+			// NOTE: 透過部分は白で塗る
+			r, g, b, a := got.At(0, 0).RGBA()
+			assert.Equal(t, []uint32{0xffff, 0xffff, 0xffff, 0xffff}, []uint32{r, g, b, a})
+		})
+	}
+}
+
+func Test_coverServer_Upload(t *testing.T) {
+	type fields struct {
+		repository *coverRepository
+		storage    *coverStorage
+	}
+	type args struct {
+		gameID ID
+		data   []byte
+	}
+	tests := []struct {
+		name        string
+		fields      fields
+		args        args
+		wantErr     error
+		wantSaved   int
+		wantDeleted []CoverKey
+	}{
+		{
+			name: "OK",
+			fields: fields{
+				repository: &coverRepository{
+					readErr: errors.NewNotFound(errors.Layer_Model, nil, "not found"),
+					read:    true,
+					save:    true,
+				},
+				storage: &coverStorage{},
+			},
+			args: args{
+				gameID: 1,
+				data:   newTestImage(60, 80),
+			},
+			wantSaved: 2,
+		},
+		{
+			name: "差し替えは以前のファイルを削除",
+			fields: fields{
+				repository: &coverRepository{
+					cover: &Cover{Key: "games/1/cover-1.png", ThumbnailKey: "games/1/thumbnail-1.jpg"},
+					read:  true,
+					save:  true,
+				},
+				storage: &coverStorage{},
+			},
+			args: args{
+				gameID: 1,
+				data:   newTestImage(60, 80),
+			},
+			wantSaved:   2,
+			wantDeleted: []CoverKey{"games/1/cover-1.png", "games/1/thumbnail-1.jpg"},
+		},
+		{
+			name: "gameIDのバリデートエラー",
+			fields: fields{
+				repository: &coverRepository{},
+				storage:    &coverStorage{},
+			},
+			args: args{
+				gameID: 0,
+				data:   newTestImage(60, 80),
+			},
+			wantErr: errors.NewInvalidRequest(errors.Layer_Domain, nil, "gameID Valid error"),
+		},
+		{
+			name: "空のファイルはエラー",
+			fields: fields{
+				repository: &coverRepository{},
+				storage:    &coverStorage{},
+			},
+			args: args{
+				gameID: 1,
+				data:   []byte{},
+			},
+			wantErr: errors.NewInvalidRequest(errors.Layer_Domain, nil, "size Valid error"),
+		},
+		{
+			name: "上限を超えるファイルはエラー",
+			fields: fields{
+				repository: &coverRepository{},
+				storage:    &coverStorage{},
+			},
+			args: args{
+				gameID: 1,
+				data:   make([]byte, CoverMaxSize+1),
+			},
+			wantErr: errors.NewInvalidRequest(errors.Layer_Domain, nil, "size Valid error"),
+		},
+		{
+			name: "画像以外はエラー",
+			fields: fields{
+				repository: &coverRepository{},
+				storage:    &coverStorage{},
+			},
+			args: args{
+				gameID: 1,
+				data:   []byte(strings.Repeat("cover", 10)),
+			},
+			wantErr: errors.NewUnsupportedMediaType(errors.Layer_Domain, nil, "content_type Valid error"),
+		},
+		{
+			name: "ヘッダの壊れた画像はエラー",
+			fields: fields{
+				repository: &coverRepository{},
+				storage:    &coverStorage{},
+			},
+			args: args{
+				gameID: 1,
+				data:   newTestImage(60, 80)[:8],
+			},
+			wantErr: errors.NewInvalidRequest(errors.Layer_Domain, nil, "image decode config error"),
+		},
+		{
+			name: "途中で壊れた画像はエラー",
+			fields: fields{
+				repository: &coverRepository{},
+				storage:    &coverStorage{},
+			},
+			args: args{
+				gameID: 1,
+				data:   newTestImage(60, 80)[:40],
+			},
+			wantErr: errors.NewInvalidRequest(errors.Layer_Domain, nil, "image decode error"),
+		},
+		{
+			name: "大きすぎる画像はエラー",
+			fields: fields{
+				repository: &coverRepository{},
+				storage:    &coverStorage{},
+			},
+			args: args{
+				gameID: 1,
+				data:   newTestImage(CoverMaxDimension+1, 1),
+			},
+			wantErr: errors.NewInvalidRequest(errors.Layer_Domain, nil, "dimension Valid error"),
+		},
+		{
+			name: "ストレージのエラー",
+			fields: fields{
+				repository: &coverRepository{
+					readErr: errors.NewNotFound(errors.Layer_Model, nil, "not found"),
+					read:    true,
+					save:    true,
+				},
+				storage: &coverStorage{
+					err: errors.NewInternalServerError(errors.Layer_Storage, nil, "save error"),
+				},
+			},
+			args: args{
+				gameID: 1,
+				data:   newTestImage(60, 80),
+			},
+			wantErr: errors.NewInternalServerError(errors.Layer_Storage, nil, "save error"),
+		},
+		{
+			name: "登録のエラーは保存したファイルを削除",
+			fields: fields{
+				repository: &coverRepository{
+					readErr: errors.NewNotFound(errors.Layer_Model, nil, "not found"),
+					err:     errors.NewNotFound(errors.Layer_Model, nil, "game not found"),
+					read:    true,
+					save:    true,
+				},
+				storage: &coverStorage{},
+			},
+			args: args{
+				gameID: 1,
+				data:   newTestImage(60, 80),
+			},
+			wantErr:   errors.NewNotFound(errors.Layer_Model, nil, "game not found"),
+			wantSaved: 2,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := NewCoverServer(tt.fields.repository, tt.fields.storage)
+			got, err := s.Upload(tt.args.gameID, tt.args.data)
+			if tt.wantErr != nil {
+				assert.Equal(t, tt.wantErr.Error(), fmt.Sprint(err))
+				assert.Nil(t, got)
+				assert.Len(t, tt.fields.storage.saved, tt.wantSaved)
+				// NOTE: 保存したファイルはすべて削除する
+				assert.Equal(t, len(tt.fields.storage.saved), len(tt.fields.storage.deleted))
+				return
+			}
+			if !assert.NoError(t, err) {
+				return
+			}
+			assert.Len(t, tt.fields.storage.saved, tt.wantSaved)
+			assert.Equal(t, tt.wantDeleted, tt.fields.storage.deleted)
+			assert.Equal(t, CoverContentType_PNG, got.ContentType)
+			assert.Equal(t, CoverSize(len(tt.args.data)), got.Size)
+			assert.Equal(t, []int{60, 80}, []int{got.Width, got.Height})
+			assert.True(t, strings.HasPrefix(string(got.Key), "games/1/cover-"))
+			assert.True(t, strings.HasSuffix(string(got.Key), ".png"))
+			assert.True(t, strings.HasPrefix(string(got.ThumbnailKey), "games/1/thumbnail-"))
+			assert.True(t, strings.HasSuffix(string(got.ThumbnailKey), ".jpg"))
+			assert.Equal(t, "/covers/"+string(got.Key), got.URL)
+			assert.Equal(t, "/covers/"+string(got.ThumbnailKey), got.ThumbnailURL)
+		})
+	}
+}
+
+func Test_coverServer_Delete(t *testing.T) {
+	tests := []struct {
+		name        string
+		repository  *coverRepository
+		gameID      ID
+		wantErr     bool
+		wantDeleted []CoverKey
+	}{
+		{
+			name: "OK",
+			repository: &coverRepository{
+				cover:  &Cover{Key: "games/1/cover-1.png", ThumbnailKey: "games/1/thumbnail-1.jpg"},
+				read:   true,
+				delete: true,
+			},
+			gameID:      1,
+			wantDeleted: []CoverKey{"games/1/cover-1.png", "games/1/thumbnail-1.jpg"},
+		},
+		{
+			name: "未登録はエラー",
+			repository: &coverRepository{
+				readErr: errors.NewNotFound(errors.Layer_Model, nil, "not found"),
+				read:    true,
+				delete:  true,
+			},
+			gameID:  1,
+			wantErr: true,
+		},
+		{
+			name:       "gameIDのバリデートエラー",
+			repository: &coverRepository{},
+			gameID:     0,
+			wantErr:    true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			storage := &coverStorage{}
+			err := NewCoverServer(tt.repository, storage).Delete(tt.gameID)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Delete() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			assert.Equal(t, tt.wantDeleted, storage.deleted)
+		})
+	}
+}
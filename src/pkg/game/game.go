@@ -1,7 +1,9 @@
 package game
 
 import (
+	"fmt"
 	"mysrtafes-backend/pkg/game/platform"
+	"mysrtafes-backend/pkg/game/series"
 	"mysrtafes-backend/pkg/game/tag"
 	"net/url"
 	"time"
@@ -50,24 +52,87 @@ func (d Developer) Valid() bool {
 	return len(d) >= 0 && len(d) < 256
 }
 
+// 発売日の精度
+type Precision uint8
+
+const (
+	Precision_Day Precision = iota
+	Precision_Month
+	Precision_Year
+)
+
+func (p Precision) Valid() bool {
+	return p <= Precision_Year
+}
+
+func (p Precision) layout() string {
+	switch p {
+	case Precision_Year:
+		return "2006"
+	case Precision_Month:
+		return "2006-01"
+	default:
+		return "2006-01-02"
+	}
+}
+
 // 発売日
-type ReleaseDate time.Time
+// NOTE: 年や年月までしか判明していないゲームのために精度を持つ。Dateは期間の初日
+type ReleaseDate struct {
+	Date      time.Time
+	Precision Precision
+}
 
+// 1995, 1995-12, 1995-12-01 の形式を受け付ける
 func NewReleaseDate(date string) (ReleaseDate, error) {
 	// NOTE: 空文字判定のValidationめんどくさいので必須にしたい。
-	dateTime, err := time.Parse("2006-01-02", date)
-	if err != nil {
-		return ReleaseDate{}, err
+	for _, precision := range []Precision{Precision_Day, Precision_Month, Precision_Year} {
+		if len(date) != len(precision.layout()) {
+			continue
+		}
+		dateTime, err := time.Parse(precision.layout(), date)
+		if err != nil {
+			return ReleaseDate{}, err
+		}
+		return ReleaseDate{dateTime, precision}, nil
 	}
-	return ReleaseDate(dateTime), nil
+	return ReleaseDate{}, fmt.Errorf("release date format error: %q", date)
 }
 
+func NewReleaseDateWithPrecision(date time.Time, precision Precision) ReleaseDate {
+	// 精度に合わせて期間の初日に丸める
+	switch precision {
+	case Precision_Year:
+		date = time.Date(date.Year(), 1, 1, 0, 0, 0, 0, date.Location())
+	case Precision_Month:
+		date = time.Date(date.Year(), date.Month(), 1, 0, 0, 0, 0, date.Location())
+	}
+	return ReleaseDate{date, precision}
+}
+
+// 期間の初日
 func (r ReleaseDate) Time() time.Time {
-	return time.Time(r)
+	return r.Date
+}
+
+// 期間の最終日
+func (r ReleaseDate) End() time.Time {
+	switch r.Precision {
+	case Precision_Year:
+		return r.Date.AddDate(1, 0, -1)
+	case Precision_Month:
+		return r.Date.AddDate(0, 1, -1)
+	default:
+		return r.Date
+	}
 }
 
 func (r ReleaseDate) String() string {
-	return r.Time().Format("2006-01-02")
+	// NOTE: 未登録の発売日は空文字
+	if r.Date.IsZero() {
+		return ""
+	}
+	return r.Date.Format(r.Precision.layout())
 }
 
 // LinkID
@@ -112,12 +177,88 @@ func (d LinkDescription) Valid() bool {
 	return len(d) >= 0 && len(d) <= 2048
 }
 
+// リンクの表示順
+// NOTE: 小さいものから表示する
+type DisplayOrder uint
+
+// リンク種別
+type LinkKind uint8
+
+// NOTE: 種別未登録のリンクはその他として扱う
+const (
+	LinkKind_Other LinkKind = iota
+	LinkKind_Official
+	LinkKind_Store
+	LinkKind_Wiki
+	LinkKind_Leaderboard
+	LinkKind_Video
+	LinkKind_MAX
+)
+
+// official, store, wiki, leaderboard, video, other を受け付ける
+// NOTE: 空文字はその他とする
+func NewLinkKind(kind string) (LinkKind, error) {
+	if kind == "" {
+		return LinkKind_Other, nil
+	}
+	for k := LinkKind_Other; k < LinkKind_MAX; k++ {
+		if k.String() == kind {
+			return k, nil
+		}
+	}
+	return LinkKind_Other, fmt.Errorf("link kind format error: %q", kind)
+}
+
+func (k LinkKind) Valid() bool {
+	return k < LinkKind_MAX
+}
+
+func (k LinkKind) String() string {
+	switch k {
+	case LinkKind_Official:
+		return "official"
+	case LinkKind_Store:
+		return "store"
+	case LinkKind_Wiki:
+		return "wiki"
+	case LinkKind_Leaderboard:
+		return "leaderboard"
+	case LinkKind_Video:
+		return "video"
+	default:
+		return "other"
+	}
+}
+
+// 販売ストア名(Steam, Nintendo eShop など)
+type LinkStore string
+
+// 0 ≦ store.length ≦ 255
+func (s LinkStore) Valid() bool {
+	// NOTE: 必須情報ではない
+	return len(s) >= 0 && len(s) < 256
+}
+
+// 販売地域(JP, US など)
+type LinkRegion string
+
+// 0 ≦ region.length ≦ 16
+func (r LinkRegion) Valid() bool {
+	// NOTE: 必須情報ではない
+	return len(r) >= 0 && len(r) <= 16
+}
+
 // リンク
+// NOTE: Store, Regionはストアページのときのみ指定できる
 type Link struct {
 	LinkID          LinkID
 	Title           Title
 	URL             URL
 	LinkDescription LinkDescription
+	DisplayOrder    DisplayOrder
+	Kind            LinkKind
+	Store           LinkStore
+	Region          LinkRegion
 	CreatedAt       time.Time
 	UpdatedAt       time.Time
 }
@@ -130,6 +271,46 @@ func NewLink(title Title, url URL, description LinkDescription) *Link {
 	}
 }
 
+func NewLinkWithID(id LinkID, title Title, url URL, description LinkDescription) *Link {
+	return &Link{
+		LinkID:          id,
+		Title:           title,
+		URL:             url,
+		LinkDescription: description,
+	}
+}
+
+// 表示順の設定
+func (l *Link) SetDisplayOrder(order DisplayOrder) *Link {
+	l.DisplayOrder = order
+	return l
+}
+
+// 種別の設定
+func (l *Link) SetKind(kind LinkKind) *Link {
+	l.Kind = kind
+	return l
+}
+
+// ストア情報の設定
+func (l *Link) SetStore(store LinkStore, region LinkRegion) *Link {
+	l.Store = store
+	l.Region = region
+	return l
+}
+
+// ストア・地域の指定がストアページ以外にないか
+func (l *Link) ValidStore() bool {
+	if l.Kind == LinkKind_Store {
+		return true
+	}
+	return l.Store == "" && l.Region == ""
+}
+
+// シリーズ内の番号
+// NOTE: 0は番号なし(外伝など)
+type SeriesNumber uint16
+
 // ゲームマスタ
 type Game struct {
 	ID          ID
@@ -139,10 +320,20 @@ type Game struct {
 	Developer   Developer
 	ReleaseDate ReleaseDate
 	Links       []*Link
-	Platforms   []*platform.Platform
-	Tags        []*tag.Tag
-	CreatedAt   time.Time
-	UpdatedAt   time.Time
+	Aliases     []*Alias
+	// NOTE: シリーズに属さないときはnil
+	Series       *series.Series
+	SeriesNumber SeriesNumber
+	Platforms    []*platform.Platform
+	Tags         []*tag.Tag
+	// NOTE: 単体取得のときのみ読み込む
+	Relations []*Relation
+	// NOTE: カバー画像未登録のときはnil
+	Cover     *Cover
+	CreatedAt time.Time
+	UpdatedAt time.Time
+	// NOTE: ゴミ箱にないときはゼロ値
+	DeletedAt time.Time
 }
 
 func New(
@@ -182,3 +373,128 @@ func NewWithID(
 		Links:       links,
 	}
 }
+
+// シリーズの設定
+// NOTE: シリーズに属さないときはnilを指定する
+func (g *Game) SetSeries(s *series.Series, number SeriesNumber) *Game {
+	g.Series = s
+	g.SeriesNumber = number
+	return g
+}
+
+// シリーズのID
+// NOTE: シリーズに属さないときはnil
+func (g *Game) SeriesID() *series.ID {
+	if g.Series == nil {
+		return nil
+	}
+	id := g.Series.ID
+	return &id
+}
+
+// 番号の指定がシリーズに属するときのみか
+func (g *Game) ValidSeriesNumber() bool {
+	return g.Series != nil || g.SeriesNumber == 0
+}
+
+// 更新対象のフィールド
+type Field uint8
+
+const (
+	Field_Name Field = iota
+	Field_Description
+	Field_Publisher
+	Field_Developer
+	Field_ReleaseDate
+	Field_Links
+	Field_Platforms
+	Field_Tags
+	Field_Aliases
+	Field_Series
+)
+
+// 変更されたフィールド
+// NOTE: リンクは並び順も含めて比較し、別名・プラットフォーム・タグは順不同で比較する
+func (g *Game) ChangedFields(after *Game, platformIDs []platform.ID, tagIDs []tag.ID) []Field {
+	fields := []Field{}
+	if g.Name != after.Name {
+		fields = append(fields, Field_Name)
+	}
+	if g.Description != after.Description {
+		fields = append(fields, Field_Description)
+	}
+	if g.Publisher != after.Publisher {
+		fields = append(fields, Field_Publisher)
+	}
+	if g.Developer != after.Developer {
+		fields = append(fields, Field_Developer)
+	}
+	if g.ReleaseDate.String() != after.ReleaseDate.String() {
+		fields = append(fields, Field_ReleaseDate)
+	}
+	if !equalLinks(g.Links, after.Links) {
+		fields = append(fields, Field_Links)
+	}
+
+	currentPlatformIDs := make([]platform.ID, 0, len(g.Platforms))
+	for _, p := range g.Platforms {
+		currentPlatformIDs = append(currentPlatformIDs, p.ID)
+	}
+	if !equalIDs(currentPlatformIDs, platformIDs) {
+		fields = append(fields, Field_Platforms)
+	}
+	currentTagIDs := make([]tag.ID, 0, len(g.Tags))
+	for _, t := range g.Tags {
+		currentTagIDs = append(currentTagIDs, t.ID)
+	}
+	if !equalIDs(currentTagIDs, tagIDs) {
+		fields = append(fields, Field_Tags)
+	}
+	if !equalAliases(g.Aliases, after.Aliases) {
+		fields = append(fields, Field_Aliases)
+	}
+	if !equalSeriesID(g.SeriesID(), after.SeriesID()) || g.SeriesNumber != after.SeriesNumber {
+		fields = append(fields, Field_Series)
+	}
+	return fields
+}
+
+func equalLinks(a, b []*Link) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i].LinkID != b[i].LinkID ||
+			a[i].Title != b[i].Title ||
+			a[i].URL.URL().String() != b[i].URL.URL().String() ||
+			a[i].LinkDescription != b[i].LinkDescription ||
+			a[i].Kind != b[i].Kind ||
+			a[i].Store != b[i].Store ||
+			a[i].Region != b[i].Region {
+			return false
+		}
+	}
+	return true
+}
+
+func equalSeriesID(a, b *series.ID) bool {
+	if a == nil || b == nil {
+		return a == nil && b == nil
+	}
+	return *a == *b
+}
+
+func equalIDs[T comparable](a, b []T) bool {
+	as := make(map[T]bool, len(a))
+	for _, id := range a {
+		as[id] = true
+	}
+	bs := make(map[T]bool, len(b))
+	for _, id := range b {
+		if !as[id] {
+			return false
+		}
+		bs[id] = true
+	}
+	return len(as) == len(bs)
+}
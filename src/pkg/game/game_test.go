@@ -2,6 +2,9 @@ package game
 
 import (
 	"math/rand"
+	"mysrtafes-backend/pkg/game/platform"
+	"mysrtafes-backend/pkg/game/series"
+	"mysrtafes-backend/pkg/game/tag"
 	"net/url"
 	"testing"
 	"time"
@@ -250,10 +253,32 @@ func Test発売日の生成(t *testing.T) {
 			},
 			want: func() ReleaseDate {
 				date := time.Date(2000, 9, 27, 0, 0, 0, 0, time.UTC)
-				return ReleaseDate(date)
+				return ReleaseDate{Date: date}
 			}(),
 			wantErr: false,
 		},
+		{
+			name: "年月",
+			args: args{
+				date: "1995-12",
+			},
+			want: ReleaseDate{
+				Date:      time.Date(1995, 12, 1, 0, 0, 0, 0, time.UTC),
+				Precision: Precision_Month,
+			},
+			wantErr: false,
+		},
+		{
+			name: "年",
+			args: args{
+				date: "1995",
+			},
+			want: ReleaseDate{
+				Date:      time.Date(1995, 1, 1, 0, 0, 0, 0, time.UTC),
+				Precision: Precision_Year,
+			},
+			wantErr: false,
+		},
 		{
 			name: "空文字",
 			args: args{
@@ -261,6 +286,13 @@ func Test発売日の生成(t *testing.T) {
 			},
 			wantErr: true,
 		},
+		{
+			name: "存在しない月",
+			args: args{
+				date: "1995-13",
+			},
+			wantErr: true,
+		},
 		{
 			name: "指定フォーマットではない",
 			args: args{
@@ -291,10 +323,31 @@ func TestReleaseDate_String(t *testing.T) {
 			name: "OK",
 			r: func() ReleaseDate {
 				date := time.Date(2000, 9, 27, 0, 0, 0, 0, time.UTC)
-				return ReleaseDate(date)
+				return ReleaseDate{Date: date}
 			}(),
 			want: "2000-09-27",
 		},
+		{
+			name: "年月",
+			r: ReleaseDate{
+				Date:      time.Date(1995, 12, 1, 0, 0, 0, 0, time.UTC),
+				Precision: Precision_Month,
+			},
+			want: "1995-12",
+		},
+		{
+			name: "年",
+			r: ReleaseDate{
+				Date:      time.Date(1995, 1, 1, 0, 0, 0, 0, time.UTC),
+				Precision: Precision_Year,
+			},
+			want: "1995",
+		},
+		{
+			name: "未登録",
+			r:    ReleaseDate{},
+			want: "",
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -313,7 +366,7 @@ func Test発売日から時間への型変換(t *testing.T) {
 			name: "変換",
 			r: func() ReleaseDate {
 				t := time.Date(2000, 9, 27, 0, 0, 0, 0, time.UTC)
-				return ReleaseDate(t)
+				return ReleaseDate{Date: t}
 			}(),
 			want: func() time.Time {
 				t := time.Date(2000, 9, 27, 0, 0, 0, 0, time.UTC)
@@ -328,6 +381,115 @@ func Test発売日から時間への型変換(t *testing.T) {
 	}
 }
 
+func TestPrecision_Valid(t *testing.T) {
+	tests := []struct {
+		name string
+		p    Precision
+		want bool
+	}{
+		{
+			name: "日",
+			p:    Precision_Day,
+			want: true,
+		},
+		{
+			name: "年",
+			p:    Precision_Year,
+			want: true,
+		},
+		{
+			name: "範囲外",
+			p:    Precision_Year + 1,
+			want: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.p.Valid())
+		})
+	}
+}
+
+func TestNewReleaseDateWithPrecision(t *testing.T) {
+	type args struct {
+		date      time.Time
+		precision Precision
+	}
+	tests := []struct {
+		name string
+		args args
+		want ReleaseDate
+	}{
+		{
+			name: "日",
+			args: args{
+				date:      time.Date(1995, 12, 24, 0, 0, 0, 0, time.UTC),
+				precision: Precision_Day,
+			},
+			want: ReleaseDate{
+				Date:      time.Date(1995, 12, 24, 0, 0, 0, 0, time.UTC),
+				Precision: Precision_Day,
+			},
+		},
+		{
+			name: "月は月初に丸める",
+			args: args{
+				date:      time.Date(1995, 12, 24, 0, 0, 0, 0, time.UTC),
+				precision: Precision_Month,
+			},
+			want: ReleaseDate{
+				Date:      time.Date(1995, 12, 1, 0, 0, 0, 0, time.UTC),
+				Precision: Precision_Month,
+			},
+		},
+		{
+			name: "年は年初に丸める",
+			args: args{
+				date:      time.Date(1995, 12, 24, 0, 0, 0, 0, time.UTC),
+				precision: Precision_Year,
+			},
+			want: ReleaseDate{
+				Date:      time.Date(1995, 1, 1, 0, 0, 0, 0, time.UTC),
+				Precision: Precision_Year,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, NewReleaseDateWithPrecision(tt.args.date, tt.args.precision))
+		})
+	}
+}
+
+func TestReleaseDate_End(t *testing.T) {
+	tests := []struct {
+		name string
+		r    ReleaseDate
+		want time.Time
+	}{
+		{
+			name: "日",
+			r:    NewReleaseDateWithPrecision(time.Date(1996, 2, 10, 0, 0, 0, 0, time.UTC), Precision_Day),
+			want: time.Date(1996, 2, 10, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name: "月(閏年)",
+			r:    NewReleaseDateWithPrecision(time.Date(1996, 2, 10, 0, 0, 0, 0, time.UTC), Precision_Month),
+			want: time.Date(1996, 2, 29, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name: "年",
+			r:    NewReleaseDateWithPrecision(time.Date(1996, 2, 10, 0, 0, 0, 0, time.UTC), Precision_Year),
+			want: time.Date(1996, 12, 31, 0, 0, 0, 0, time.UTC),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.r.End())
+		})
+	}
+}
+
 func TestLinkID_Valid(t *testing.T) {
 	tests := []struct {
 		name string
@@ -525,6 +687,120 @@ func TestLinkDescription_Valid(t *testing.T) {
 	}
 }
 
+func TestNewLinkKind(t *testing.T) {
+	tests := []struct {
+		name    string
+		kind    string
+		want    LinkKind
+		wantErr bool
+	}{
+		{
+			name: "公式サイト",
+			kind: "official",
+			want: LinkKind_Official,
+		},
+		{
+			name: "ストア",
+			kind: "store",
+			want: LinkKind_Store,
+		},
+		{
+			name: "リーダーボード",
+			kind: "leaderboard",
+			want: LinkKind_Leaderboard,
+		},
+		{
+			name: "その他",
+			kind: "other",
+			want: LinkKind_Other,
+		},
+		{
+			name: "未指定はその他",
+			kind: "",
+			want: LinkKind_Other,
+		},
+		{
+			name:    "存在しない種別",
+			kind:    "blog",
+			want:    LinkKind_Other,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := NewLinkKind(tt.kind)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("NewLinkKind() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestLinkKind_Valid(t *testing.T) {
+	tests := []struct {
+		name string
+		k    LinkKind
+		want bool
+	}{
+		{
+			name: "その他",
+			k:    LinkKind_Other,
+			want: true,
+		},
+		{
+			name: "動画",
+			k:    LinkKind_Video,
+			want: true,
+		},
+		{
+			name: "範囲外",
+			k:    LinkKind_MAX,
+			want: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.k.Valid())
+		})
+	}
+}
+
+func TestLink_ValidStore(t *testing.T) {
+	tests := []struct {
+		name string
+		l    *Link
+		want bool
+	}{
+		{
+			name: "ストアページ",
+			l:    (&Link{}).SetKind(LinkKind_Store).SetStore("Steam", "JP"),
+			want: true,
+		},
+		{
+			name: "ストアページ以外でストア情報なし",
+			l:    (&Link{}).SetKind(LinkKind_Wiki),
+			want: true,
+		},
+		{
+			name: "ストアページ以外でストア指定",
+			l:    (&Link{}).SetKind(LinkKind_Official).SetStore("Steam", ""),
+			want: false,
+		},
+		{
+			name: "ストアページ以外で地域指定",
+			l:    (&Link{}).SetKind(LinkKind_Video).SetStore("", "JP"),
+			want: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.l.ValidStore())
+		})
+	}
+}
+
 func TestNewLink(t *testing.T) {
 	type args struct {
 		title       Title
@@ -586,7 +862,7 @@ func TestNew(t *testing.T) {
 				description: "test",
 				publisher:   "中",
 				developer:   "Nintendo",
-				releaseDate: ReleaseDate(time.Date(2000, 9, 27, 0, 0, 0, 0, time.UTC)),
+				releaseDate: ReleaseDate{Date: time.Date(2000, 9, 27, 0, 0, 0, 0, time.UTC)},
 				links:       []*Link{},
 			},
 			want: &Game{
@@ -594,7 +870,7 @@ func TestNew(t *testing.T) {
 				Description: "test",
 				Publisher:   "中",
 				Developer:   "Nintendo",
-				ReleaseDate: ReleaseDate(time.Date(2000, 9, 27, 0, 0, 0, 0, time.UTC)),
+				ReleaseDate: ReleaseDate{Date: time.Date(2000, 9, 27, 0, 0, 0, 0, time.UTC)},
 				Links:       []*Link{},
 			},
 		},
@@ -630,7 +906,7 @@ func TestNewWithID(t *testing.T) {
 				description: "test",
 				publisher:   "中",
 				developer:   "Nintendo",
-				releaseDate: ReleaseDate(time.Date(2000, 9, 27, 0, 0, 0, 0, time.UTC)),
+				releaseDate: ReleaseDate{Date: time.Date(2000, 9, 27, 0, 0, 0, 0, time.UTC)},
 				links:       []*Link{},
 			},
 			want: &Game{
@@ -639,7 +915,7 @@ func TestNewWithID(t *testing.T) {
 				Description: "test",
 				Publisher:   "中",
 				Developer:   "Nintendo",
-				ReleaseDate: ReleaseDate(time.Date(2000, 9, 27, 0, 0, 0, 0, time.UTC)),
+				ReleaseDate: ReleaseDate{Date: time.Date(2000, 9, 27, 0, 0, 0, 0, time.UTC)},
 				Links:       []*Link{},
 			},
 		},
@@ -651,3 +927,170 @@ func TestNewWithID(t *testing.T) {
 		})
 	}
 }
+
+func TestGame_ChangedFields(t *testing.T) {
+	linkURL, _ := NewURL("http://example.com")
+	otherURL, _ := NewURL("http://example.com/other")
+	current := &Game{
+		ID:          1,
+		Name:        "TestGame",
+		Description: "desc",
+		Publisher:   "Nintendo",
+		Developer:   "Chu Soft",
+		ReleaseDate: NewReleaseDateWithPrecision(time.Date(1997, 1, 1, 0, 0, 0, 0, time.UTC), Precision_Year),
+		Links: []*Link{
+			NewLinkWithID(1, "wiki", linkURL, "").SetDisplayOrder(3),
+			NewLinkWithID(2, "official", linkURL, "").SetDisplayOrder(5).SetKind(LinkKind_Official),
+		},
+		Platforms: []*platform.Platform{{ID: 1}, {ID: 2}},
+		Tags:      []*tag.Tag{{ID: 3}},
+	}
+	type args struct {
+		after       *Game
+		platformIDs []platform.ID
+		tagIDs      []tag.ID
+	}
+	tests := []struct {
+		name string
+		args args
+		want []Field
+	}{
+		{
+			name: "変更なし(表示順の値とプラットフォームの順番は比較しない)",
+			args: args{
+				after: &Game{
+					Name:        "TestGame",
+					Description: "desc",
+					Publisher:   "Nintendo",
+					Developer:   "Chu Soft",
+					ReleaseDate: NewReleaseDateWithPrecision(time.Date(1997, 6, 1, 0, 0, 0, 0, time.UTC), Precision_Year),
+					Links: []*Link{
+						NewLinkWithID(1, "wiki", linkURL, "").SetDisplayOrder(1),
+						NewLinkWithID(2, "official", linkURL, "").SetDisplayOrder(2).SetKind(LinkKind_Official),
+					},
+				},
+				platformIDs: []platform.ID{2, 1},
+				tagIDs:      []tag.ID{3},
+			},
+			want: []Field{},
+		},
+		{
+			name: "全て変更",
+			args: args{
+				after: &Game{
+					Name:        "TestGame2",
+					Description: "desc2",
+					Publisher:   "Nintendo2",
+					Developer:   "Chu Soft2",
+					ReleaseDate: NewReleaseDateWithPrecision(time.Date(1997, 1, 1, 0, 0, 0, 0, time.UTC), Precision_Month),
+					Links: []*Link{
+						NewLinkWithID(1, "wiki", otherURL, ""),
+						NewLinkWithID(2, "official", linkURL, "").SetKind(LinkKind_Official),
+					},
+				},
+				platformIDs: []platform.ID{1},
+				tagIDs:      []tag.ID{3, 4},
+			},
+			want: []Field{
+				Field_Name,
+				Field_Description,
+				Field_Publisher,
+				Field_Developer,
+				Field_ReleaseDate,
+				Field_Links,
+				Field_Platforms,
+				Field_Tags,
+			},
+		},
+		{
+			name: "リンクの並び替え",
+			args: args{
+				after: &Game{
+					Name:        "TestGame",
+					Description: "desc",
+					Publisher:   "Nintendo",
+					Developer:   "Chu Soft",
+					ReleaseDate: current.ReleaseDate,
+					Links: []*Link{
+						NewLinkWithID(2, "official", linkURL, "").SetKind(LinkKind_Official),
+						NewLinkWithID(1, "wiki", linkURL, ""),
+					},
+				},
+				platformIDs: []platform.ID{1, 2},
+				tagIDs:      []tag.ID{3},
+			},
+			want: []Field{Field_Links},
+		},
+		{
+			name: "別名の追加",
+			args: args{
+				after: &Game{
+					Name:        "TestGame",
+					Description: "desc",
+					Publisher:   "Nintendo",
+					Developer:   "Chu Soft",
+					ReleaseDate: current.ReleaseDate,
+					Links:       current.Links,
+					Aliases: []*Alias{
+						NewAlias("てすとげーむ", "ja", AliasKind_Reading),
+					},
+				},
+				platformIDs: []platform.ID{1, 2},
+				tagIDs:      []tag.ID{3},
+			},
+			want: []Field{Field_Aliases},
+		},
+		{
+			name: "シリーズの追加",
+			args: args{
+				after: (&Game{
+					Name:        "TestGame",
+					Description: "desc",
+					Publisher:   "Nintendo",
+					Developer:   "Chu Soft",
+					ReleaseDate: current.ReleaseDate,
+					Links:       current.Links,
+				}).SetSeries(&series.Series{ID: 1}, 2),
+				platformIDs: []platform.ID{1, 2},
+				tagIDs:      []tag.ID{3},
+			},
+			want: []Field{Field_Series},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, current.ChangedFields(tt.args.after, tt.args.platformIDs, tt.args.tagIDs))
+		})
+	}
+}
+
+func TestGame_ValidSeriesNumber(t *testing.T) {
+	tests := []struct {
+		name string
+		g    *Game
+		want bool
+	}{
+		{
+			name: "シリーズと番号",
+			g:    (&Game{}).SetSeries(&series.Series{ID: 1}, 2),
+			want: true,
+		},
+		{
+			name: "シリーズなし番号なし",
+			g:    &Game{},
+			want: true,
+		},
+		{
+			name: "シリーズなしの番号",
+			g:    (&Game{}).SetSeries(nil, 2),
+			want: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.g.ValidSeriesNumber(); got != tt.want {
+				t.Errorf("Game.ValidSeriesNumber() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,117 @@
+package game
+
+import (
+	"strings"
+	"unicode"
+)
+
+// 検索キーワード
+type Keyword string
+
+// NOTE: 入力は正規化して保持する
+func NewKeyword(keyword string) Keyword {
+	return Keyword(NormalizeText(keyword))
+}
+
+// 0 ≦ keyword.length ≦ 255
+func (k Keyword) Valid() bool {
+	// NOTE: 必須情報ではない
+	return len(k) >= 0 && len(k) < 256
+}
+
+// 空白区切りの検索語
+func (k Keyword) Terms() []string {
+	return strings.Fields(string(k))
+}
+
+// 検索用文字列
+// NOTE: フィールドをまたいだ一致を防ぐために改行で区切る。別名(読みを含む)も検索対象とする
+func (g *Game) SearchText() string {
+	texts := []string{
+		string(g.Name),
+		string(g.Publisher),
+		string(g.Developer),
+		string(g.Description),
+	}
+	for _, alias := range g.Aliases {
+		texts = append(texts, string(alias.Name))
+	}
+	return NormalizeText(strings.Join(texts, "\n"))
+}
+
+// 検索用の文字列正規化
+// - ひらがな → カタカナ
+// - 半角カナ → 全角カナ(濁点・半濁点は合成)
+// - 全角英数記号 → 半角英数記号
+// - 大文字 → 小文字
+func NormalizeText(s string) string {
+	normalized := make([]rune, 0, len(s))
+	for _, r := range s {
+		switch {
+		case r == 'ﾞ' || r == '゛' || r == '\u3099':
+			// 濁点は直前の文字と合成する
+			if n := len(normalized); n > 0 {
+				if v, ok := voiced(normalized[n-1]); ok {
+					normalized[n-1] = v
+					continue
+				}
+			}
+			normalized = append(normalized, '゛')
+		case r == 'ﾟ' || r == '゜' || r == '\u309a':
+			// 半濁点は直前の文字と合成する
+			if n := len(normalized); n > 0 {
+				if v, ok := semiVoiced(normalized[n-1]); ok {
+					normalized[n-1] = v
+					continue
+				}
+			}
+			normalized = append(normalized, '゜')
+		case r >= 'ぁ' && r <= 'ゖ':
+			// ひらがな → カタカナ
+			normalized = append(normalized, r+('ァ'-'ぁ'))
+		case r >= '｡' && r <= 'ﾝ':
+			// 半角カナ → 全角カナ
+			normalized = append(normalized, halfWidthKana[r-'｡'])
+		case r >= '！' && r <= '～':
+			// 全角英数記号 → 半角英数記号
+			normalized = append(normalized, unicode.ToLower(r-('！'-'!')))
+		case r == '　':
+			// 全角スペース → 半角スペース
+			normalized = append(normalized, ' ')
+		default:
+			normalized = append(normalized, unicode.ToLower(r))
+		}
+	}
+	return string(normalized)
+}
+
+// U+FF61(｡) ~ U+FF9D(ﾝ)
+var halfWidthKana = []rune(
+	"。「」、・ヲァィゥェォャュョッー" +
+		"アイウエオカキクケコサシスセソタチツテト" +
+		"ナニヌネノハヒフヘホマミムメモヤユヨラリルレロワン",
+)
+
+// 濁点付きカタカナ
+func voiced(r rune) (rune, bool) {
+	switch {
+	case r == 'ウ':
+		return 'ヴ', true
+	case r >= 'カ' && r <= 'チ' && (r-'カ')%2 == 0:
+		// カ行・サ行・タ、チ
+		return r + 1, true
+	case r == 'ツ' || r == 'テ' || r == 'ト':
+		return r + 1, true
+	case r >= 'ハ' && r <= 'ホ' && (r-'ハ')%3 == 0:
+		return r + 1, true
+	}
+	return r, false
+}
+
+// 半濁点付きカタカナ
+func semiVoiced(r rune) (rune, bool) {
+	if r >= 'ハ' && r <= 'ホ' && (r-'ハ')%3 == 0 {
+		return r + 2, true
+	}
+	return r, false
+}
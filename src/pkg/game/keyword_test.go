@@ -0,0 +1,206 @@
+package game
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNormalizeText(t *testing.T) {
+	tests := []struct {
+		name string
+		s    string
+		want string
+	}{
+		{
+			name: "ひらがな",
+			s:    "ふうらいのしれん",
+			want: "フウライノシレン",
+		},
+		{
+			name: "カタカナ",
+			s:    "シレン",
+			want: "シレン",
+		},
+		{
+			name: "半角カナ",
+			s:    "ｼﾚﾝ",
+			want: "シレン",
+		},
+		{
+			name: "半角カナ 濁点・半濁点",
+			s:    "ﾄﾞﾗｺﾞﾝｸｴｽﾄ ﾎﾟｹﾓﾝ ｳﾞｨ",
+			want: "ドラゴンクエスト ポケモン ヴィ",
+		},
+		{
+			name: "合成できない濁点",
+			s:    "ｱﾞ",
+			want: "ア゛",
+		},
+		{
+			name: "全角英数",
+			s:    "ＳＨＩＲＥＮ５　ｐｌｕｓ",
+			want: "shiren5 plus",
+		},
+		{
+			name: "大文字",
+			s:    "Shiren The Wanderer",
+			want: "shiren the wanderer",
+		},
+		{
+			name: "漢字はそのまま",
+			s:    "風来のシレン",
+			want: "風来ノシレン",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, NormalizeText(tt.s))
+		})
+	}
+}
+
+func TestNewKeyword(t *testing.T) {
+	tests := []struct {
+		name    string
+		keyword string
+		want    Keyword
+	}{
+		{
+			name:    "ひらがな",
+			keyword: "しれん",
+			want:    "シレン",
+		},
+		{
+			name:    "カタカナ",
+			keyword: "シレン",
+			want:    "シレン",
+		},
+		{
+			name:    "半角カナ",
+			keyword: "ｼﾚﾝ",
+			want:    "シレン",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, NewKeyword(tt.keyword))
+		})
+	}
+}
+
+func TestKeyword_Valid(t *testing.T) {
+	tests := []struct {
+		name string
+		k    Keyword
+		want bool
+	}{
+		{
+			name: "OK",
+			k:    "シレン",
+			want: true,
+		},
+		{
+			name: "空文字",
+			k:    "",
+			want: true,
+		},
+		{
+			name: "長すぎる文字列のギリギリ",
+			k: func() Keyword {
+				var letters = []rune("abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789")
+
+				s := make([]rune, 255)
+				for i := range s {
+					s[i] = letters[rand.Intn(len(letters))]
+				}
+				return Keyword(s)
+			}(),
+			want: true,
+		},
+		{
+			name: "長すぎる文字列",
+			k: func() Keyword {
+				var letters = []rune("abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789")
+
+				s := make([]rune, 256)
+				for i := range s {
+					s[i] = letters[rand.Intn(len(letters))]
+				}
+				return Keyword(s)
+			}(),
+			want: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.k.Valid())
+		})
+	}
+}
+
+func TestKeyword_Terms(t *testing.T) {
+	tests := []struct {
+		name string
+		k    Keyword
+		want []string
+	}{
+		{
+			name: "空文字",
+			k:    "",
+			want: []string{},
+		},
+		{
+			name: "単語",
+			k:    "シレン",
+			want: []string{"シレン"},
+		},
+		{
+			name: "複数単語",
+			k:    NewKeyword(" しれん　ｽﾊﾟｲｸ "),
+			want: []string{"シレン", "スパイク"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.ElementsMatch(t, tt.want, tt.k.Terms())
+		})
+	}
+}
+
+func TestGame_SearchText(t *testing.T) {
+	tests := []struct {
+		name string
+		g    *Game
+		want string
+	}{
+		{
+			name: "OK",
+			g: &Game{
+				Name:        "風来のシレン",
+				Description: "不思議のダンジョン",
+				Publisher:   "チュンソフト",
+				Developer:   "ＣＨＵＮＳＯＦＴ",
+			},
+			want: "風来ノシレン\nチュンソフト\nchunsoft\n不思議ノダンジョン",
+		},
+		{
+			name: "別名を含む",
+			g: &Game{
+				Name:      "風来のシレン",
+				Publisher: "チュンソフト",
+				Aliases: []*Alias{
+					NewAlias("Mystery Dungeon: Shiren the Wanderer", "en", AliasKind_Official),
+					NewAlias("ふうらいのしれん", "ja", AliasKind_Reading),
+				},
+			},
+			want: "風来ノシレン\nチュンソフト\n\n\nmystery dungeon: shiren the wanderer\nフウライノシレン",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.g.SearchText())
+		})
+	}
+}
@@ -0,0 +1,318 @@
+package game
+
+import "mysrtafes-backend/pkg/errors"
+
+type LinkRepository interface {
+	LinkCreate(ID, *Link) (*Link, error)
+	LinkRead(ID, LinkID) (*Link, error)
+	LinkFind(ID) ([]*Link, error)
+	LinkUpdate(ID, *Link) (*Link, error)
+	LinkDelete(ID, LinkID) error
+	LinkReorder(ID, []LinkID) ([]*Link, error)
+}
+
+type LinkServer interface {
+	Create(ID, *Link) (*Link, error)
+	Read(ID, LinkID) (*Link, error)
+	Find(ID) ([]*Link, error)
+	Update(ID, *Link) (*Link, error)
+	Delete(ID, LinkID) error
+	Reorder(ID, []LinkID) ([]*Link, error)
+}
+
+type linkServer struct {
+	repository LinkRepository
+}
+
+func NewLinkServer(repo LinkRepository) LinkServer {
+	return &linkServer{repo}
+}
+
+// GameLinkの作成
+func (s *linkServer) Create(gameID ID, l *Link) (*Link, error) {
+	// GameIDのValidate
+	if !gameID.Valid() {
+		return nil, errors.NewInvalidRequest(
+			errors.Layer_Domain,
+			errors.NewInformation(
+				errors.ID_InvalidParams,
+				"",
+				[]errors.InvalidParams{
+					errors.NewInvalidParams("gameID", gameID),
+				},
+			),
+			"gameID Valid error",
+		)
+	}
+	// TitleのValidate
+	if !l.Title.Valid() {
+		return nil, errors.NewInvalidRequest(
+			errors.Layer_Domain,
+			errors.NewInformation(
+				errors.ID_InvalidParams,
+				"",
+				[]errors.InvalidParams{
+					errors.NewInvalidParams("title", l.Title),
+				},
+			),
+			"title Valid error",
+		)
+	}
+	// DescriptionのValidate
+	if !l.LinkDescription.Valid() {
+		return nil, errors.NewInvalidRequest(
+			errors.Layer_Domain,
+			errors.NewInformation(
+				errors.ID_InvalidParams,
+				"",
+				[]errors.InvalidParams{
+					errors.NewInvalidParams("description", l.LinkDescription),
+				},
+			),
+			"description Valid error",
+		)
+	}
+	// KindのValidate
+	if !l.Kind.Valid() {
+		return nil, errors.NewInvalidRequest(
+			errors.Layer_Domain,
+			errors.NewInformation(
+				errors.ID_InvalidParams,
+				"",
+				[]errors.InvalidParams{
+					errors.NewInvalidParams("kind", l.Kind),
+				},
+			),
+			"kind Valid error",
+		)
+	}
+	// Store, RegionのValidate
+	if !l.Store.Valid() || !l.Region.Valid() || !l.ValidStore() {
+		return nil, errors.NewInvalidRequest(
+			errors.Layer_Domain,
+			errors.NewInformation(
+				errors.ID_InvalidParams,
+				"store and region are only for store links",
+				[]errors.InvalidParams{
+					errors.NewInvalidParams("store", l.Store),
+					errors.NewInvalidParams("region", l.Region),
+				},
+			),
+			"store Valid error",
+		)
+	}
+	return s.repository.LinkCreate(gameID, l)
+}
+
+// GameLinkの取得
+func (s *linkServer) Read(gameID ID, linkID LinkID) (*Link, error) {
+	// GameIDのValidate
+	if !gameID.Valid() {
+		return nil, errors.NewInvalidRequest(
+			errors.Layer_Domain,
+			errors.NewInformation(
+				errors.ID_InvalidParams,
+				"",
+				[]errors.InvalidParams{
+					errors.NewInvalidParams("gameID", gameID),
+				},
+			),
+			"gameID Valid error",
+		)
+	}
+	// LinkIDのValidate
+	if !linkID.Valid() {
+		return nil, errors.NewInvalidRequest(
+			errors.Layer_Domain,
+			errors.NewInformation(
+				errors.ID_InvalidParams,
+				"",
+				[]errors.InvalidParams{
+					errors.NewInvalidParams("linkID", linkID),
+				},
+			),
+			"linkID Valid error",
+		)
+	}
+	return s.repository.LinkRead(gameID, linkID)
+}
+
+// GameLinkの一覧取得
+func (s *linkServer) Find(gameID ID) ([]*Link, error) {
+	// GameIDのValidate
+	if !gameID.Valid() {
+		return nil, errors.NewInvalidRequest(
+			errors.Layer_Domain,
+			errors.NewInformation(
+				errors.ID_InvalidParams,
+				"",
+				[]errors.InvalidParams{
+					errors.NewInvalidParams("gameID", gameID),
+				},
+			),
+			"gameID Valid error",
+		)
+	}
+	return s.repository.LinkFind(gameID)
+}
+
+// GameLinkの更新
+func (s *linkServer) Update(gameID ID, l *Link) (*Link, error) {
+	// GameIDのValidate
+	if !gameID.Valid() {
+		return nil, errors.NewInvalidRequest(
+			errors.Layer_Domain,
+			errors.NewInformation(
+				errors.ID_InvalidParams,
+				"",
+				[]errors.InvalidParams{
+					errors.NewInvalidParams("gameID", gameID),
+				},
+			),
+			"gameID Valid error",
+		)
+	}
+	// LinkIDのValidate
+	if !l.LinkID.Valid() {
+		return nil, errors.NewInvalidRequest(
+			errors.Layer_Domain,
+			errors.NewInformation(
+				errors.ID_InvalidParams,
+				"",
+				[]errors.InvalidParams{
+					errors.NewInvalidParams("linkID", l.LinkID),
+				},
+			),
+			"linkID Valid error",
+		)
+	}
+	// TitleのValidate
+	if !l.Title.Valid() {
+		return nil, errors.NewInvalidRequest(
+			errors.Layer_Domain,
+			errors.NewInformation(
+				errors.ID_InvalidParams,
+				"",
+				[]errors.InvalidParams{
+					errors.NewInvalidParams("title", l.Title),
+				},
+			),
+			"title Valid error",
+		)
+	}
+	// DescriptionのValidate
+	if !l.LinkDescription.Valid() {
+		return nil, errors.NewInvalidRequest(
+			errors.Layer_Domain,
+			errors.NewInformation(
+				errors.ID_InvalidParams,
+				"",
+				[]errors.InvalidParams{
+					errors.NewInvalidParams("description", l.LinkDescription),
+				},
+			),
+			"description Valid error",
+		)
+	}
+	// KindのValidate
+	if !l.Kind.Valid() {
+		return nil, errors.NewInvalidRequest(
+			errors.Layer_Domain,
+			errors.NewInformation(
+				errors.ID_InvalidParams,
+				"",
+				[]errors.InvalidParams{
+					errors.NewInvalidParams("kind", l.Kind),
+				},
+			),
+			"kind Valid error",
+		)
+	}
+	// Store, RegionのValidate
+	if !l.Store.Valid() || !l.Region.Valid() || !l.ValidStore() {
+		return nil, errors.NewInvalidRequest(
+			errors.Layer_Domain,
+			errors.NewInformation(
+				errors.ID_InvalidParams,
+				"store and region are only for store links",
+				[]errors.InvalidParams{
+					errors.NewInvalidParams("store", l.Store),
+					errors.NewInvalidParams("region", l.Region),
+				},
+			),
+			"store Valid error",
+		)
+	}
+	return s.repository.LinkUpdate(gameID, l)
+}
+
+// GameLinkの削除
+func (s *linkServer) Delete(gameID ID, linkID LinkID) error {
+	// GameIDのValidate
+	if !gameID.Valid() {
+		return errors.NewInvalidRequest(
+			errors.Layer_Domain,
+			errors.NewInformation(
+				errors.ID_InvalidParams,
+				"",
+				[]errors.InvalidParams{
+					errors.NewInvalidParams("gameID", gameID),
+				},
+			),
+			"gameID Valid error",
+		)
+	}
+	// LinkIDのValidate
+	if !linkID.Valid() {
+		return errors.NewInvalidRequest(
+			errors.Layer_Domain,
+			errors.NewInformation(
+				errors.ID_InvalidParams,
+				"",
+				[]errors.InvalidParams{
+					errors.NewInvalidParams("linkID", linkID),
+				},
+			),
+			"linkID Valid error",
+		)
+	}
+	return s.repository.LinkDelete(gameID, linkID)
+}
+
+// GameLinkの並び替え
+// NOTE: linkIDsの並び順がそのまま表示順になる。ゲームの全リンクを指定する必要がある
+func (s *linkServer) Reorder(gameID ID, linkIDs []LinkID) ([]*Link, error) {
+	// GameIDのValidate
+	if !gameID.Valid() {
+		return nil, errors.NewInvalidRequest(
+			errors.Layer_Domain,
+			errors.NewInformation(
+				errors.ID_InvalidParams,
+				"",
+				[]errors.InvalidParams{
+					errors.NewInvalidParams("gameID", gameID),
+				},
+			),
+			"gameID Valid error",
+		)
+	}
+	// LinkIDsのValidate
+	exists := make(map[LinkID]bool, len(linkIDs))
+	for _, linkID := range linkIDs {
+		if !linkID.Valid() || exists[linkID] {
+			return nil, errors.NewInvalidRequest(
+				errors.Layer_Domain,
+				errors.NewInformation(
+					errors.ID_InvalidParams,
+					"",
+					[]errors.InvalidParams{
+						errors.NewInvalidParams("link_ids", linkID),
+					},
+				),
+				"link_ids Valid error",
+			)
+		}
+		exists[linkID] = true
+	}
+	return s.repository.LinkReorder(gameID, linkIDs)
+}
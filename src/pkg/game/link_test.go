@@ -0,0 +1,280 @@
+package game
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+)
+
+type linkRepository struct {
+	link  *Link
+	links []*Link
+	err   error
+	// flags
+	create, read, find, update, delete, reorder bool
+}
+
+func (r linkRepository) LinkCreate(ID, *Link) (*Link, error) {
+	if r.create {
+		return r.link, r.err
+	}
+	return nil, fmt.Errorf("failed create")
+}
+func (r linkRepository) LinkRead(ID, LinkID) (*Link, error) {
+	if r.read {
+		return r.link, r.err
+	}
+	return nil, fmt.Errorf("failed read")
+}
+func (r linkRepository) LinkFind(ID) ([]*Link, error) {
+	if r.find {
+		return r.links, r.err
+	}
+	return nil, fmt.Errorf("failed find")
+}
+func (r linkRepository) LinkUpdate(ID, *Link) (*Link, error) {
+	if r.update {
+		return r.link, r.err
+	}
+	return nil, fmt.Errorf("failed update")
+}
+func (r linkRepository) LinkDelete(ID, LinkID) error {
+	if r.delete {
+		return r.err
+	}
+	return fmt.Errorf("failed delete")
+}
+func (r linkRepository) LinkReorder(ID, []LinkID) ([]*Link, error) {
+	if r.reorder {
+		return r.links, r.err
+	}
+	return nil, fmt.Errorf("failed reorder")
+}
+
+func TestNewLinkServer(t *testing.T) {
+	type args struct {
+		repo LinkRepository
+	}
+	tests := []struct {
+		name string
+		args args
+		want LinkServer
+	}{
+		{
+			name: "new",
+			args: args{
+				repo: linkRepository{},
+			},
+			want: &linkServer{
+				repository: linkRepository{},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := NewLinkServer(tt.args.repo); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("NewLinkServer() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_linkServer_Create(t *testing.T) {
+	type fields struct {
+		repository LinkRepository
+	}
+	type args struct {
+		gameID ID
+		l      *Link
+	}
+	tests := []struct {
+		name    string
+		fields  fields
+		args    args
+		want    *Link
+		wantErr bool
+	}{
+		{
+			name: "OK",
+			fields: fields{
+				repository: linkRepository{
+					link: &Link{
+						LinkID:       1,
+						Title:        "OK",
+						DisplayOrder: 1,
+					},
+					create: true,
+				},
+			},
+			args: args{
+				gameID: 1,
+				l: &Link{
+					Title: "OK",
+				},
+			},
+			want: &Link{
+				LinkID:       1,
+				Title:        "OK",
+				DisplayOrder: 1,
+			},
+		},
+		{
+			name: "gameIDのバリデートエラー",
+			fields: fields{
+				repository: linkRepository{
+					create: true,
+				},
+			},
+			args: args{
+				gameID: 0,
+				l: &Link{
+					Title: "NG",
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "ストアページ以外のストア情報エラー",
+			fields: fields{
+				repository: linkRepository{
+					create: true,
+				},
+			},
+			args: args{
+				gameID: 1,
+				l: &Link{
+					Title:  "NG",
+					Kind:   LinkKind_Wiki,
+					Store:  "Steam",
+					Region: "JP",
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "種別のバリデートエラー",
+			fields: fields{
+				repository: linkRepository{
+					create: true,
+				},
+			},
+			args: args{
+				gameID: 1,
+				l: &Link{
+					Title: "NG",
+					Kind:  LinkKind_MAX,
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "タイトルのバリデートエラー",
+			fields: fields{
+				repository: linkRepository{
+					create: true,
+				},
+			},
+			args: args{
+				gameID: 1,
+				l: &Link{
+					Title: "",
+				},
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := &linkServer{
+				repository: tt.fields.repository,
+			}
+			got, err := s.Create(tt.args.gameID, tt.args.l)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("linkServer.Create() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("linkServer.Create() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_linkServer_Reorder(t *testing.T) {
+	type fields struct {
+		repository LinkRepository
+	}
+	type args struct {
+		gameID  ID
+		linkIDs []LinkID
+	}
+	tests := []struct {
+		name    string
+		fields  fields
+		args    args
+		want    []*Link
+		wantErr bool
+	}{
+		{
+			name: "OK",
+			fields: fields{
+				repository: linkRepository{
+					links: []*Link{
+						{LinkID: 2, DisplayOrder: 1},
+						{LinkID: 1, DisplayOrder: 2},
+					},
+					reorder: true,
+				},
+			},
+			args: args{
+				gameID:  1,
+				linkIDs: []LinkID{2, 1},
+			},
+			want: []*Link{
+				{LinkID: 2, DisplayOrder: 1},
+				{LinkID: 1, DisplayOrder: 2},
+			},
+		},
+		{
+			name: "linkIDの重複エラー",
+			fields: fields{
+				repository: linkRepository{
+					reorder: true,
+				},
+			},
+			args: args{
+				gameID:  1,
+				linkIDs: []LinkID{1, 1},
+			},
+			wantErr: true,
+		},
+		{
+			name: "linkIDのバリデートエラー",
+			fields: fields{
+				repository: linkRepository{
+					reorder: true,
+				},
+			},
+			args: args{
+				gameID:  1,
+				linkIDs: []LinkID{0},
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := &linkServer{
+				repository: tt.fields.repository,
+			}
+			got, err := s.Reorder(tt.args.gameID, tt.args.linkIDs)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("linkServer.Reorder() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("linkServer.Reorder() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,168 @@
+package game
+
+import (
+	"mysrtafes-backend/pkg/errors"
+	"mysrtafes-backend/pkg/game/platform"
+	"mysrtafes-backend/pkg/game/tag"
+	"time"
+)
+
+// 統合による転送
+// NOTE: 統合元(From)のIDで取得したときに統合先(To)を返却する
+type Redirect struct {
+	From      ID
+	To        ID
+	CreatedAt time.Time
+}
+
+// 統合後のゲーム
+// NOTE: 統合先(g)を基に統合元のタグ・プラットフォーム・リンク・別名を重複なく加え、統合元の名前を旧題として残す。
+// 統合先で未登録の項目は統合元の値で補う。引数のゲームは変更しない
+func (g *Game) Merge(source *Game) (*Game, []platform.ID, []tag.ID) {
+	merged := *g
+
+	// 未登録の項目の補完
+	if merged.Description == "" {
+		merged.Description = source.Description
+	}
+	if merged.Publisher == "" {
+		merged.Publisher = source.Publisher
+	}
+	if merged.Developer == "" {
+		merged.Developer = source.Developer
+	}
+	if merged.ReleaseDate.Time().IsZero() {
+		merged.ReleaseDate = source.ReleaseDate
+	}
+	if merged.Series == nil {
+		merged.Series = source.Series
+		merged.SeriesNumber = source.SeriesNumber
+	}
+
+	// リンク
+	// NOTE: 同じURLのリンクは統合先のものを残し、統合元のリンクは末尾に追加する
+	links := make([]*Link, 0, len(g.Links)+len(source.Links))
+	urls := make(map[string]bool, len(g.Links))
+	var order DisplayOrder
+	for _, link := range g.Links {
+		links = append(links, link)
+		urls[link.URL.URL().String()] = true
+		if link.DisplayOrder > order {
+			order = link.DisplayOrder
+		}
+	}
+	for _, link := range source.Links {
+		if urls[link.URL.URL().String()] {
+			continue
+		}
+		urls[link.URL.URL().String()] = true
+		order++
+		moved := *link
+		moved.LinkID = 0
+		moved.DisplayOrder = order
+		links = append(links, &moved)
+	}
+	merged.Links = links
+
+	// 別名
+	aliases := make([]*Alias, 0, len(g.Aliases)+len(source.Aliases)+1)
+	keys := make(map[string]bool, len(g.Aliases))
+	appendAlias := func(alias *Alias) {
+		if keys[alias.key()] {
+			return
+		}
+		keys[alias.key()] = true
+		aliases = append(aliases, alias)
+	}
+	for _, alias := range g.Aliases {
+		appendAlias(alias)
+	}
+	for _, alias := range source.Aliases {
+		appendAlias(alias)
+	}
+	if source.Name != g.Name {
+		appendAlias(NewAlias(AliasName(source.Name), "", AliasKind_Former))
+	}
+	merged.Aliases = aliases
+
+	// プラットフォーム・タグ
+	platformIDs := make([]platform.ID, 0, len(g.Platforms)+len(source.Platforms))
+	existsPlatform := make(map[platform.ID]bool, len(g.Platforms))
+	for _, p := range append(append([]*platform.Platform{}, g.Platforms...), source.Platforms...) {
+		if existsPlatform[p.ID] {
+			continue
+		}
+		existsPlatform[p.ID] = true
+		platformIDs = append(platformIDs, p.ID)
+	}
+	tagIDs := make([]tag.ID, 0, len(g.Tags)+len(source.Tags))
+	existsTag := make(map[tag.ID]bool, len(g.Tags))
+	for _, t := range append(append([]*tag.Tag{}, g.Tags...), source.Tags...) {
+		if existsTag[t.ID] {
+			continue
+		}
+		existsTag[t.ID] = true
+		tagIDs = append(tagIDs, t.ID)
+	}
+
+	return &merged, platformIDs, tagIDs
+}
+
+// 統合
+// NOTE: 統合元は削除し、統合元のIDでの取得は統合先に転送する
+func (s *server) Merge(sourceID ID, targetID ID) (*Game, error) {
+	// SourceIDのValidate
+	if !sourceID.Valid() {
+		return nil, errors.NewInvalidRequest(
+			errors.Layer_Domain,
+			errors.NewInformation(
+				errors.ID_InvalidParams,
+				"",
+				[]errors.InvalidParams{
+					errors.NewInvalidParams("id", sourceID),
+				},
+			),
+			"ID Valid error",
+		)
+	}
+	// TargetIDのValidate
+	if !targetID.Valid() {
+		return nil, errors.NewInvalidRequest(
+			errors.Layer_Domain,
+			errors.NewInformation(
+				errors.ID_InvalidParams,
+				"",
+				[]errors.InvalidParams{
+					errors.NewInvalidParams("target_id", targetID),
+				},
+			),
+			"target_id Valid error",
+		)
+	}
+	// 自己統合のValidate
+	if sourceID == targetID {
+		return nil, errors.NewInvalidRequest(
+			errors.Layer_Domain,
+			errors.NewInformation(
+				errors.ID_InvalidParams,
+				"a game cannot be merged into itself",
+				[]errors.InvalidParams{
+					errors.NewInvalidParams("target_id", targetID),
+				},
+			),
+			"target_id Valid error",
+		)
+	}
+
+	// NOTE: 統合元・統合先の読み込みは統合と同じトランザクションで行い、読み込み後の変更を失わないようにする
+	return s.repository.GameMerge(sourceID, targetID, mergeGame)
+}
+
+// 統合後のゲームの作成とValidate
+func mergeGame(target *Game, source *Game) (*Game, []platform.ID, []tag.ID, error) {
+	merged, platformIDs, tagIDs := target.Merge(source)
+	if err := validGame(merged); err != nil {
+		return nil, nil, nil, err
+	}
+	return merged, platformIDs, tagIDs, nil
+}
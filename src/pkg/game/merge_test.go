@@ -0,0 +1,291 @@
+package game
+
+import (
+	"mysrtafes-backend/pkg/game/platform"
+	"mysrtafes-backend/pkg/game/series"
+	"mysrtafes-backend/pkg/game/tag"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func newMergeTestLink(id LinkID, rawURL string, order DisplayOrder) *Link {
+	u, _ := NewURL(rawURL)
+	return NewLinkWithID(id, "link", u, "").SetDisplayOrder(order)
+}
+
+func TestGame_Merge(t *testing.T) {
+	releaseDate, _ := NewReleaseDate("2000-01-01")
+	tests := []struct {
+		name            string
+		target          *Game
+		source          *Game
+		want            *Game
+		wantPlatformIDs []platform.ID
+		wantTagIDs      []tag.ID
+	}{
+		{
+			name: "タグ・プラットフォーム・リンク・別名の和集合と旧題",
+			target: &Game{
+				ID:   1,
+				Name: "風来のシレン",
+				Links: []*Link{
+					newMergeTestLink(1, "http://example.com/a", 1),
+				},
+				Aliases: []*Alias{
+					NewAlias("シレン", "ja", AliasKind_Abbreviation),
+				},
+				Platforms: []*platform.Platform{{ID: 1}},
+				Tags:      []*tag.Tag{{ID: 1}, {ID: 2}},
+			},
+			source: &Game{
+				ID:   2,
+				Name: "不思議のダンジョン2 風来のシレン",
+				Links: []*Link{
+					newMergeTestLink(5, "http://example.com/a", 1),
+					newMergeTestLink(6, "http://example.com/b", 2),
+				},
+				Aliases: []*Alias{
+					NewAlias("シレン", "ja", AliasKind_Abbreviation),
+					NewAlias("Shiren", "en", AliasKind_Official),
+				},
+				Platforms: []*platform.Platform{{ID: 1}, {ID: 3}},
+				Tags:      []*tag.Tag{{ID: 2}, {ID: 4}},
+			},
+			want: &Game{
+				ID:   1,
+				Name: "風来のシレン",
+				Links: []*Link{
+					newMergeTestLink(1, "http://example.com/a", 1),
+					newMergeTestLink(0, "http://example.com/b", 2),
+				},
+				Aliases: []*Alias{
+					NewAlias("シレン", "ja", AliasKind_Abbreviation),
+					NewAlias("Shiren", "en", AliasKind_Official),
+					NewAlias("不思議のダンジョン2 風来のシレン", "", AliasKind_Former),
+				},
+				Platforms: []*platform.Platform{{ID: 1}},
+				Tags:      []*tag.Tag{{ID: 1}, {ID: 2}},
+			},
+			wantPlatformIDs: []platform.ID{1, 3},
+			wantTagIDs:      []tag.ID{1, 2, 4},
+		},
+		{
+			name: "同名は旧題にしない・未登録の項目は統合元で補う",
+			target: &Game{
+				ID:        1,
+				Name:      "トルネコ",
+				Publisher: "チュンソフト",
+			},
+			source: &Game{
+				ID:           2,
+				Name:         "トルネコ",
+				Description:  "説明",
+				Publisher:    "エニックス",
+				Developer:    "チュンソフト",
+				ReleaseDate:  releaseDate,
+				Series:       &series.Series{ID: 3},
+				SeriesNumber: 1,
+			},
+			want: &Game{
+				ID:           1,
+				Name:         "トルネコ",
+				Description:  "説明",
+				Publisher:    "チュンソフト",
+				Developer:    "チュンソフト",
+				ReleaseDate:  releaseDate,
+				Series:       &series.Series{ID: 3},
+				SeriesNumber: 1,
+				Links:        []*Link{},
+				Aliases:      []*Alias{},
+			},
+			wantPlatformIDs: []platform.ID{},
+			wantTagIDs:      []tag.ID{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, gotPlatformIDs, gotTagIDs := tt.target.Merge(tt.source)
+			assert.Equal(t, tt.want, got)
+			assert.Equal(t, tt.wantPlatformIDs, gotPlatformIDs)
+			assert.Equal(t, tt.wantTagIDs, gotTagIDs)
+		})
+	}
+}
+
+func Test_server_Merge(t *testing.T) {
+	games := map[ID]*Game{
+		1: {ID: 1, Name: "統合先"},
+		2: {ID: 2, Name: "統合元"},
+		4: {ID: 4, Name: ""},
+	}
+	type args struct {
+		sourceID ID
+		targetID ID
+	}
+	tests := []struct {
+		name       string
+		repository repository
+		args       args
+		want       *Game
+		wantErr    bool
+	}{
+		{
+			name: "OK",
+			repository: repository{
+				game:      &Game{ID: 1, Name: "統合先"},
+				gamesByID: games,
+				read:      true,
+				merge:     true,
+			},
+			args: args{
+				sourceID: 2,
+				targetID: 1,
+			},
+			want: &Game{ID: 1, Name: "統合先"},
+		},
+		{
+			name: "統合元のIDのバリデートエラー",
+			repository: repository{
+				gamesByID: games,
+				read:      true,
+				merge:     true,
+			},
+			args: args{
+				sourceID: 0,
+				targetID: 1,
+			},
+			wantErr: true,
+		},
+		{
+			name: "統合先のIDのバリデートエラー",
+			repository: repository{
+				gamesByID: games,
+				read:      true,
+				merge:     true,
+			},
+			args: args{
+				sourceID: 2,
+				targetID: 0,
+			},
+			wantErr: true,
+		},
+		{
+			name: "自己統合エラー",
+			repository: repository{
+				gamesByID: games,
+				read:      true,
+				merge:     true,
+			},
+			args: args{
+				sourceID: 1,
+				targetID: 1,
+			},
+			wantErr: true,
+		},
+		{
+			name: "統合先が存在しないエラー",
+			repository: repository{
+				gamesByID: games,
+				read:      true,
+				merge:     true,
+			},
+			args: args{
+				sourceID: 2,
+				targetID: 3,
+			},
+			wantErr: true,
+		},
+		{
+			name: "統合後のゲームのバリデートエラー",
+			repository: repository{
+				game:      &Game{ID: 4, Name: ""},
+				gamesByID: games,
+				read:      true,
+				merge:     true,
+			},
+			args: args{
+				sourceID: 2,
+				targetID: 4,
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := &server{
+				repository: tt.repository,
+			}
+			got, err := s.Merge(tt.args.sourceID, tt.args.targetID)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("server.Merge() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func Test_server_Read(t *testing.T) {
+	games := map[ID]*Game{
+		1: {ID: 1, Name: "統合先"},
+	}
+	tests := []struct {
+		name       string
+		repository repository
+		id         ID
+		want       *Game
+		wantErr    bool
+	}{
+		{
+			name: "OK",
+			repository: repository{
+				gamesByID: games,
+				read:      true,
+			},
+			id:   1,
+			want: &Game{ID: 1, Name: "統合先"},
+		},
+		{
+			name: "OK(統合済みは統合先を返却)",
+			repository: repository{
+				gamesByID: games,
+				redirect:  &Redirect{From: 2, To: 1},
+				read:      true,
+			},
+			id:   2,
+			want: &Game{ID: 1, Name: "統合先"},
+		},
+		{
+			name: "存在しないエラー",
+			repository: repository{
+				gamesByID: games,
+				read:      true,
+			},
+			id:      2,
+			wantErr: true,
+		},
+		{
+			name: "IDのバリデートエラー",
+			repository: repository{
+				gamesByID: games,
+				read:      true,
+			},
+			id:      0,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := &server{
+				repository: tt.repository,
+			}
+			got, err := s.Read(tt.id, nil)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("server.Read() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -1,5 +1,13 @@
 package game
 
+import (
+	"time"
+
+	"mysrtafes-backend/pkg/game/platform"
+	"mysrtafes-backend/pkg/game/series"
+	"mysrtafes-backend/pkg/game/tag"
+)
+
 type SearchMode uint8
 
 const (
@@ -13,6 +21,23 @@ type Count = int
 type Seek struct {
 	LastID LastID
 	Count  Count
+	// NOTE: 指定されたときはLastIDより優先する
+	Cursor *Cursor
+}
+
+// シーク法の続きの位置
+// NOTE: 最後に取得したものの並び替えの値とIDの組で位置を表す
+// 並び順ごとに使う値が異なる(名前: SortName・Name、発売日: ReleaseDate・Precision、更新日時: UpdatedAt、シリーズ番号: SeriesNumber)
+type Cursor struct {
+	Order        Order
+	Desc         Desc
+	ID           ID
+	SortName     string
+	Name         Name
+	ReleaseDate  time.Time
+	Precision    Precision
+	UpdatedAt    time.Time
+	SeriesNumber SeriesNumber
 }
 
 type Limit = int
@@ -23,11 +48,56 @@ type Pagination struct {
 	Offset Offset
 }
 
+type TotalCount = int64
+
+// 検索結果のメタ情報
+// NOTE: 次の取得位置は次ページがあるときのみ設定する
+type FindMeta struct {
+	TotalCount TotalCount
+	HasNext    bool
+	NextLastID LastID
+	NextOffset Offset
+	NextCursor *Cursor
+}
+
+// 次ページの位置の設定
+// NOTE: 次ページがあるときのみ設定する
+func (m *FindMeta) SetNextCursor(cursor *Cursor) *FindMeta {
+	if m.HasNext {
+		m.NextCursor = cursor
+	}
+	return m
+}
+
+// 検索結果のメタ情報の生成
+// NOTE: idsは取得した行のIDを並び順のまま指定する。次ページは並び順によらず最後の行の後ろから始まる
+func NewFindMeta(f *FindOption, totalCount TotalCount, hasNext bool, ids []ID) *FindMeta {
+	meta := &FindMeta{
+		TotalCount: totalCount,
+		HasNext:    hasNext,
+	}
+	if !hasNext {
+		return meta
+	}
+	switch f.SearchMode {
+	case SearchMode_Seek:
+		if len(ids) > 0 {
+			meta.NextLastID = ids[len(ids)-1]
+		}
+	case SearchMode_Pagination:
+		meta.NextOffset = f.Pagination.Offset + len(ids)
+	}
+	return meta
+}
+
 type Order uint8
 
 const (
 	Order_ID Order = iota
 	Order_Name
+	Order_ReleaseDate
+	Order_UpdatedAt
+	Order_SeriesNumber
 )
 
 type Desc = bool
@@ -37,12 +107,77 @@ type OrderOption struct {
 	Desc  Desc
 }
 
-// タグ検索オプション
+// タグ・プラットフォーム・リンク種別の絞り込み方法
+// NOTE: タグとプラットフォームとリンク種別の条件同士は常にAND
+type Match uint8
+
+const (
+	// 指定したものをすべて持つ
+	Match_All Match = iota
+	// 指定したもののいずれかを持つ
+	Match_Any
+)
+
+// 発売日の範囲
+// NOTE: nilのときは制限なし
+type ReleasePeriod struct {
+	From *ReleaseDate
+	To   *ReleaseDate
+}
+
+// From ≦ To
+// NOTE: 精度が異なる場合はFromの期間の初日とToの期間の最終日で比較する
+func (r ReleasePeriod) Valid() bool {
+	if r.From == nil || r.To == nil {
+		return true
+	}
+	return !r.From.Time().After(r.To.End())
+}
+
+// 読み込む関連
+// NOTE: 不要な関連を読み込まないよう指定する。nilのときはすべて読み込む
+// Relationsは単体取得のときのみ読み込む
+type Include struct {
+	Links     bool
+	Aliases   bool
+	Series    bool
+	Tags      bool
+	Platforms bool
+	Cover     bool
+	Relations bool
+}
+
+// すべての関連を読み込む
+func NewInclude() *Include {
+	return &Include{
+		Links:     true,
+		Aliases:   true,
+		Series:    true,
+		Tags:      true,
+		Platforms: true,
+		Cover:     true,
+		Relations: true,
+	}
+}
+
+// ゲーム検索オプション
 type FindOption struct {
 	SearchMode  SearchMode
 	Seek        Seek
 	Pagination  Pagination
 	OrderOption OrderOption
+	Keyword     Keyword
+	TagIDs      []tag.ID
+	// NOTE: trueのときはTagIDsのタグの子孫のタグを持つゲームも対象とする
+	TagDescendants bool
+	PlatformIDs    []platform.ID
+	// NOTE: ゲームは1つのシリーズにのみ属するため、Matchによらずいずれかに属するものを対象とする
+	SeriesIDs     []series.ID
+	LinkKinds     []LinkKind
+	Match         Match
+	ReleasePeriod ReleasePeriod
+	// NOTE: nilのときはすべての関連を読み込む
+	Include *Include
 }
 
 func NewFindOption() *FindOption {
@@ -72,6 +207,24 @@ func (f *FindOption) SetSeek(lastID LastID, count Count) *FindOption {
 	return f
 }
 
+// シーク法の続きの位置の設定
+func (f *FindOption) SetCursor(cursor *Cursor) *FindOption {
+	f.Seek.Cursor = cursor
+	return f
+}
+
+// シーク法の位置と並び順の整合性
+// NOTE: カーソルは発行時と同じ並び順でのみ使え、LastIDはIDの並び順でのみ使える
+func (f *FindOption) ValidSeek() bool {
+	if f.SearchMode != SearchMode_Seek {
+		return true
+	}
+	if cursor := f.Seek.Cursor; cursor != nil {
+		return cursor.Order == f.OrderOption.Order && cursor.Desc == f.OrderOption.Desc
+	}
+	return f.Seek.LastID == 0 || f.OrderOption.Order == Order_ID
+}
+
 func (f *FindOption) SetPagination(limit Limit, offset Offset) *FindOption {
 	f.SearchMode = SearchMode_Pagination
 	f.Pagination = Pagination{
@@ -88,3 +241,91 @@ func (f *FindOption) SetOrder(order Order, desc Desc) *FindOption {
 	}
 	return f
 }
+
+func (f *FindOption) SetKeyword(keyword Keyword) *FindOption {
+	f.Keyword = keyword
+	return f
+}
+
+func (f *FindOption) SetTagIDs(tagIDs []tag.ID) *FindOption {
+	// NOTE: Match_Allの件数比較のために重複を除く
+	ids := make([]tag.ID, 0, len(tagIDs))
+	exists := make(map[tag.ID]bool, len(tagIDs))
+	for _, id := range tagIDs {
+		if exists[id] {
+			continue
+		}
+		exists[id] = true
+		ids = append(ids, id)
+	}
+	f.TagIDs = ids
+	return f
+}
+
+// 子孫のタグを含めるかの設定
+func (f *FindOption) SetTagDescendants(tagDescendants bool) *FindOption {
+	f.TagDescendants = tagDescendants
+	return f
+}
+
+func (f *FindOption) SetPlatformIDs(platformIDs []platform.ID) *FindOption {
+	// NOTE: Match_Allの件数比較のために重複を除く
+	ids := make([]platform.ID, 0, len(platformIDs))
+	exists := make(map[platform.ID]bool, len(platformIDs))
+	for _, id := range platformIDs {
+		if exists[id] {
+			continue
+		}
+		exists[id] = true
+		ids = append(ids, id)
+	}
+	f.PlatformIDs = ids
+	return f
+}
+
+func (f *FindOption) SetSeriesIDs(seriesIDs []series.ID) *FindOption {
+	ids := make([]series.ID, 0, len(seriesIDs))
+	exists := make(map[series.ID]bool, len(seriesIDs))
+	for _, id := range seriesIDs {
+		if exists[id] {
+			continue
+		}
+		exists[id] = true
+		ids = append(ids, id)
+	}
+	f.SeriesIDs = ids
+	return f
+}
+
+func (f *FindOption) SetLinkKinds(linkKinds []LinkKind) *FindOption {
+	// NOTE: Match_Allの件数比較のために重複を除く
+	kinds := make([]LinkKind, 0, len(linkKinds))
+	exists := make(map[LinkKind]bool, len(linkKinds))
+	for _, kind := range linkKinds {
+		if exists[kind] {
+			continue
+		}
+		exists[kind] = true
+		kinds = append(kinds, kind)
+	}
+	f.LinkKinds = kinds
+	return f
+}
+
+func (f *FindOption) SetMatch(match Match) *FindOption {
+	f.Match = match
+	return f
+}
+
+func (f *FindOption) SetReleasePeriod(from, to *ReleaseDate) *FindOption {
+	f.ReleasePeriod = ReleasePeriod{
+		From: from,
+		To:   to,
+	}
+	return f
+}
+
+func (f *FindOption) SetInclude(include *Include) *FindOption {
+	f.Include = include
+	return f
+}
@@ -1,6 +1,9 @@
 package game
 
 import (
+	"mysrtafes-backend/pkg/game/platform"
+	"mysrtafes-backend/pkg/game/series"
+	"mysrtafes-backend/pkg/game/tag"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -243,3 +246,468 @@ func TestFindOption_SetOrder(t *testing.T) {
 		})
 	}
 }
+
+func TestFindOption_SetKeyword(t *testing.T) {
+	type args struct {
+		keyword Keyword
+	}
+	tests := []struct {
+		name string
+		args args
+		want *FindOption
+	}{
+		{
+			name: "set ok",
+			args: args{
+				keyword: "シレン",
+			},
+			want: &FindOption{
+				SearchMode: SearchMode_All,
+				Seek: Seek{
+					LastID: 0,
+					Count:  30,
+				},
+				Pagination: Pagination{
+					Limit:  30,
+					Offset: 0,
+				},
+				OrderOption: OrderOption{
+					Order: Order_ID,
+					Desc:  false,
+				},
+				Keyword: "シレン",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := NewFindOption().SetKeyword(tt.args.keyword)
+			assert.Equal(t, got, tt.want)
+		})
+	}
+}
+
+func TestFindOption_SetTagIDs(t *testing.T) {
+	type args struct {
+		tagIDs []tag.ID
+	}
+	tests := []struct {
+		name string
+		args args
+		want []tag.ID
+	}{
+		{
+			name: "set ok",
+			args: args{
+				tagIDs: []tag.ID{1, 2, 3},
+			},
+			want: []tag.ID{1, 2, 3},
+		},
+		{
+			name: "duplicate ids",
+			args: args{
+				tagIDs: []tag.ID{3, 1, 3, 2, 1},
+			},
+			want: []tag.ID{3, 1, 2},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := NewFindOption().SetTagIDs(tt.args.tagIDs)
+			assert.Equal(t, tt.want, got.TagIDs)
+		})
+	}
+}
+
+func TestFindOption_SetPlatformIDs(t *testing.T) {
+	type args struct {
+		platformIDs []platform.ID
+	}
+	tests := []struct {
+		name string
+		args args
+		want []platform.ID
+	}{
+		{
+			name: "set ok",
+			args: args{
+				platformIDs: []platform.ID{1, 2, 3},
+			},
+			want: []platform.ID{1, 2, 3},
+		},
+		{
+			name: "duplicate ids",
+			args: args{
+				platformIDs: []platform.ID{2, 2, 1},
+			},
+			want: []platform.ID{2, 1},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := NewFindOption().SetPlatformIDs(tt.args.platformIDs)
+			assert.Equal(t, tt.want, got.PlatformIDs)
+		})
+	}
+}
+
+func TestFindOption_SetSeriesIDs(t *testing.T) {
+	type args struct {
+		seriesIDs []series.ID
+	}
+	tests := []struct {
+		name string
+		args args
+		want []series.ID
+	}{
+		{
+			name: "set ok",
+			args: args{
+				seriesIDs: []series.ID{1, 2, 3},
+			},
+			want: []series.ID{1, 2, 3},
+		},
+		{
+			name: "duplicate ids",
+			args: args{
+				seriesIDs: []series.ID{2, 2, 1},
+			},
+			want: []series.ID{2, 1},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := NewFindOption().SetSeriesIDs(tt.args.seriesIDs)
+			assert.Equal(t, tt.want, got.SeriesIDs)
+		})
+	}
+}
+
+func TestFindOption_SetLinkKinds(t *testing.T) {
+	type args struct {
+		linkKinds []LinkKind
+	}
+	tests := []struct {
+		name string
+		args args
+		want []LinkKind
+	}{
+		{
+			name: "set ok",
+			args: args{
+				linkKinds: []LinkKind{LinkKind_Store, LinkKind_Wiki},
+			},
+			want: []LinkKind{LinkKind_Store, LinkKind_Wiki},
+		},
+		{
+			name: "duplicate kinds",
+			args: args{
+				linkKinds: []LinkKind{LinkKind_Video, LinkKind_Video, LinkKind_Official},
+			},
+			want: []LinkKind{LinkKind_Video, LinkKind_Official},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := NewFindOption().SetLinkKinds(tt.args.linkKinds)
+			assert.Equal(t, tt.want, got.LinkKinds)
+		})
+	}
+}
+
+func TestFindOption_SetMatch(t *testing.T) {
+	type args struct {
+		match Match
+	}
+	tests := []struct {
+		name string
+		args args
+		want Match
+	}{
+		{
+			name: "all",
+			args: args{
+				match: Match_All,
+			},
+			want: Match_All,
+		},
+		{
+			name: "any",
+			args: args{
+				match: Match_Any,
+			},
+			want: Match_Any,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := NewFindOption().SetMatch(tt.args.match)
+			assert.Equal(t, tt.want, got.Match)
+		})
+	}
+}
+
+func TestReleasePeriod_Valid(t *testing.T) {
+	from, _ := NewReleaseDate("2000-01-01")
+	to, _ := NewReleaseDate("2000-12-31")
+	tests := []struct {
+		name string
+		r    ReleasePeriod
+		want bool
+	}{
+		{
+			name: "OK",
+			r:    ReleasePeriod{From: &from, To: &to},
+			want: true,
+		},
+		{
+			name: "同日",
+			r:    ReleasePeriod{From: &from, To: &from},
+			want: true,
+		},
+		{
+			name: "Fromのみ",
+			r:    ReleasePeriod{From: &from},
+			want: true,
+		},
+		{
+			name: "Toのみ",
+			r:    ReleasePeriod{To: &to},
+			want: true,
+		},
+		{
+			name: "指定なし",
+			r:    ReleasePeriod{},
+			want: true,
+		},
+		{
+			name: "逆転",
+			r:    ReleasePeriod{From: &to, To: &from},
+			want: false,
+		},
+		{
+			name: "精度違いの同じ期間",
+			r: func() ReleasePeriod {
+				from, _ := NewReleaseDate("2000-12")
+				to, _ := NewReleaseDate("2000")
+				return ReleasePeriod{From: &from, To: &to}
+			}(),
+			want: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.r.Valid())
+		})
+	}
+}
+
+func TestFindOption_SetReleasePeriod(t *testing.T) {
+	from, _ := NewReleaseDate("2000-01-01")
+	to, _ := NewReleaseDate("2000-12-31")
+	type args struct {
+		from *ReleaseDate
+		to   *ReleaseDate
+	}
+	tests := []struct {
+		name string
+		args args
+		want ReleasePeriod
+	}{
+		{
+			name: "set ok",
+			args: args{
+				from: &from,
+				to:   &to,
+			},
+			want: ReleasePeriod{
+				From: &from,
+				To:   &to,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := NewFindOption().SetReleasePeriod(tt.args.from, tt.args.to)
+			assert.Equal(t, tt.want, got.ReleasePeriod)
+		})
+	}
+}
+
+func TestNewFindMeta(t *testing.T) {
+	type args struct {
+		f          *FindOption
+		totalCount TotalCount
+		hasNext    bool
+		ids        []ID
+	}
+	tests := []struct {
+		name string
+		args args
+		want *FindMeta
+	}{
+		{
+			name: "seekの次ページあり",
+			args: args{
+				f:          NewFindOption().SetSeek(10, 5),
+				totalCount: 30,
+				hasNext:    true,
+				ids:        []ID{16, 17, 18, 19, 20},
+			},
+			want: &FindMeta{
+				TotalCount: 30,
+				HasNext:    true,
+				NextLastID: 20,
+			},
+		},
+		{
+			name: "seek(降順)の次ページは最後の行から",
+			args: args{
+				f:          NewFindOption().SetSeek(25, 5).SetOrder(Order_ID, true),
+				totalCount: 30,
+				hasNext:    true,
+				ids:        []ID{24, 23, 22, 21, 20},
+			},
+			want: &FindMeta{
+				TotalCount: 30,
+				HasNext:    true,
+				NextLastID: 20,
+			},
+		},
+		{
+			name: "paginationの次ページあり",
+			args: args{
+				f:          NewFindOption().SetPagination(5, 10),
+				totalCount: 30,
+				hasNext:    true,
+				ids:        []ID{16, 17, 18, 19, 20},
+			},
+			want: &FindMeta{
+				TotalCount: 30,
+				HasNext:    true,
+				NextOffset: 15,
+			},
+		},
+		{
+			name: "次ページなし",
+			args: args{
+				f:          NewFindOption().SetPagination(5, 25),
+				totalCount: 30,
+				hasNext:    false,
+				ids:        []ID{26, 27, 28, 29, 30},
+			},
+			want: &FindMeta{
+				TotalCount: 30,
+			},
+		},
+		{
+			name: "全件取得",
+			args: args{
+				f:          NewFindOption(),
+				totalCount: 30,
+				ids:        []ID{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16, 17, 18, 19, 20, 21, 22, 23, 24, 25, 26, 27, 28, 29, 30},
+			},
+			want: &FindMeta{
+				TotalCount: 30,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, NewFindMeta(tt.args.f, tt.args.totalCount, tt.args.hasNext, tt.args.ids))
+		})
+	}
+}
+
+// 降順のシーク法で次ページが取得した行の後ろから始まる
+// NOTE: 降順のときはNextLastIDより小さいIDが次ページになる
+func TestNewFindMeta_SeekDesc(t *testing.T) {
+	all := []ID{30, 29, 28, 27, 26, 25, 24}
+	f := NewFindOption().SetSeek(0, 3).SetOrder(Order_ID, true)
+	page := all[:3]
+	meta := NewFindMeta(f, TotalCount(len(all)), true, page)
+
+	next := []ID{}
+	for _, id := range all {
+		if id < meta.NextLastID {
+			next = append(next, id)
+		}
+	}
+	assert.Equal(t, []ID{27, 26, 25, 24}, next)
+}
+func TestFindOption_ValidSeek(t *testing.T) {
+	tests := []struct {
+		name string
+		f    *FindOption
+		want bool
+	}{
+		{
+			name: "シーク法以外",
+			f:    NewFindOption().SetPagination(10, 0).SetOrder(Order_Name, false),
+			want: true,
+		},
+		{
+			name: "並び順が同じカーソル",
+			f: NewFindOption().SetSeek(0, 10).SetOrder(Order_Name, true).
+				SetCursor(&Cursor{Order: Order_Name, Desc: true, ID: 3}),
+			want: true,
+		},
+		{
+			name: "並び順が異なるカーソル",
+			f: NewFindOption().SetSeek(0, 10).SetOrder(Order_Name, false).
+				SetCursor(&Cursor{Order: Order_Name, Desc: true, ID: 3}),
+			want: false,
+		},
+		{
+			name: "IDの並び順でのlast_id",
+			f:    NewFindOption().SetSeek(3, 10).SetOrder(Order_ID, true),
+			want: true,
+		},
+		{
+			name: "IDの並び順以外でのlast_id",
+			f:    NewFindOption().SetSeek(3, 10).SetOrder(Order_UpdatedAt, false),
+			want: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.f.ValidSeek())
+		})
+	}
+}
+
+func TestFindMeta_SetNextCursor(t *testing.T) {
+	cursor := &Cursor{Order: Order_Name, ID: 3}
+	assert.Equal(t, cursor, NewFindMeta(NewFindOption().SetSeek(0, 1), 5, true, []ID{3}).SetNextCursor(cursor).NextCursor)
+	assert.Nil(t, NewFindMeta(NewFindOption().SetSeek(0, 1), 1, false, []ID{3}).SetNextCursor(cursor).NextCursor)
+}
+
+func TestFindOption_SetInclude(t *testing.T) {
+	tests := []struct {
+		name    string
+		include *Include
+		want    *Include
+	}{
+		{
+			name:    "タグのみ",
+			include: &Include{Tags: true},
+			want:    &Include{Tags: true},
+		},
+		{
+			name:    "nilはすべて読み込む",
+			include: nil,
+			want:    nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := NewFindOption().SetInclude(tt.include)
+			assert.Equal(t, tt.want, got.Include)
+		})
+	}
+}
+
+func TestFindOption_SetTagDescendants(t *testing.T) {
+	assert.True(t, NewFindOption().SetTagDescendants(true).TagDescendants)
+	assert.False(t, NewFindOption().SetTagDescendants(true).SetTagDescendants(false).TagDescendants)
+}
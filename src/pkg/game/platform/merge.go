@@ -0,0 +1,81 @@
+package platform
+
+import (
+	"mysrtafes-backend/pkg/errors"
+)
+
+// 統合後のプラットフォーム
+// NOTE: 統合先(p)の別名に統合元の名前・別名を重複なく加える。引数のプラットフォームは変更しない
+func (p *Platform) Merge(source *Platform) *Platform {
+	merged := *p
+
+	synonyms := make([]Name, 0, len(p.Synonyms)+len(source.Synonyms)+1)
+	exists := map[Name]bool{p.Name: true}
+	appendSynonym := func(name Name) {
+		if exists[name] {
+			return
+		}
+		exists[name] = true
+		synonyms = append(synonyms, name)
+	}
+	for _, synonym := range p.Synonyms {
+		appendSynonym(synonym)
+	}
+	appendSynonym(source.Name)
+	for _, synonym := range source.Synonyms {
+		appendSynonym(synonym)
+	}
+	merged.Synonyms = synonyms
+	return &merged
+}
+
+// GamePlatformの統合
+// NOTE: sourceIDのプラットフォーム(統合元)をtargetIDのプラットフォーム(統合先)に統合する。
+// ゲームとの関連は重複なく統合先に移し、統合元の名前は統合先の別名として残し、統合元は削除する
+func (s *server) Merge(sourceID ID, targetID ID) (*Platform, error) {
+	// SourceIDのValidate
+	if !sourceID.Valid() {
+		return nil, errors.NewInvalidRequest(
+			errors.Layer_Domain,
+			errors.NewInformation(
+				errors.ID_InvalidParams,
+				"",
+				[]errors.InvalidParams{
+					errors.NewInvalidParams("id", sourceID),
+				},
+			),
+			"ID Valid error",
+		)
+	}
+	// TargetIDのValidate
+	if !targetID.Valid() {
+		return nil, errors.NewInvalidRequest(
+			errors.Layer_Domain,
+			errors.NewInformation(
+				errors.ID_InvalidParams,
+				"",
+				[]errors.InvalidParams{
+					errors.NewInvalidParams("target_id", targetID),
+				},
+			),
+			"target_id Valid error",
+		)
+	}
+	// 自己統合のValidate
+	if sourceID == targetID {
+		return nil, errors.NewInvalidRequest(
+			errors.Layer_Domain,
+			errors.NewInformation(
+				errors.ID_InvalidParams,
+				"a platform cannot be merged into itself",
+				[]errors.InvalidParams{
+					errors.NewInvalidParams("target_id", targetID),
+				},
+			),
+			"target_id Valid error",
+		)
+	}
+
+	// NOTE: 統合元・統合先の読み込みは、統合と同じトランザクションで行う
+	return s.repository.PlatformMerge(sourceID, targetID, (*Platform).Merge)
+}
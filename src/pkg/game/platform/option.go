@@ -1,5 +1,7 @@
 package platform
 
+import "time"
+
 type SearchMode uint8
 
 const (
@@ -13,6 +15,25 @@ type Count = int
 type Seek struct {
 	LastID LastID
 	Count  Count
+	// NOTE: 指定されたときはLastIDより優先する
+	Cursor *Cursor
+}
+
+// シーク法の続きの位置
+// NOTE: 最後に取得したものの並び替えの値とIDの組で位置を表す
+// 並び順ごとに使う値が異なる(名前: Name、更新日時: UpdatedAt、ゲームの数: GameCount、
+// メーカー: Manufacturer、種類: Kind、世代: Generation、発売年: ReleaseYear)
+type Cursor struct {
+	Order        Order
+	Desc         Desc
+	ID           ID
+	Name         Name
+	UpdatedAt    time.Time
+	GameCount    GameCount
+	Manufacturer Manufacturer
+	Kind         Kind
+	Generation   Generation
+	ReleaseYear  ReleaseYear
 }
 
 type Limit = int
@@ -23,11 +44,59 @@ type Pagination struct {
 	Offset Offset
 }
 
+type TotalCount = int64
+
+// 検索結果のメタ情報
+// NOTE: 次の取得位置は次ページがあるときのみ設定する
+type FindMeta struct {
+	TotalCount TotalCount
+	HasNext    bool
+	NextLastID LastID
+	NextOffset Offset
+	NextCursor *Cursor
+}
+
+// 次ページの位置の設定
+// NOTE: 次ページがあるときのみ設定する
+func (m *FindMeta) SetNextCursor(cursor *Cursor) *FindMeta {
+	if m.HasNext {
+		m.NextCursor = cursor
+	}
+	return m
+}
+
+// 検索結果のメタ情報の生成
+// NOTE: idsは取得した行のIDを並び順のまま指定する。次ページは並び順によらず最後の行の後ろから始まる
+func NewFindMeta(f *FindOption, totalCount TotalCount, hasNext bool, ids []ID) *FindMeta {
+	meta := &FindMeta{
+		TotalCount: totalCount,
+		HasNext:    hasNext,
+	}
+	if !hasNext {
+		return meta
+	}
+	switch f.SearchMode {
+	case SearchMode_Seek:
+		if len(ids) > 0 {
+			meta.NextLastID = ids[len(ids)-1]
+		}
+	case SearchMode_Pagination:
+		meta.NextOffset = f.Pagination.Offset + len(ids)
+	}
+	return meta
+}
+
 type Order uint8
 
 const (
 	Order_ID Order = iota
 	Order_Name
+	Order_UpdatedAt
+	Order_GameCount
+	Order_Manufacturer
+	Order_Kind
+	Order_Generation
+	Order_ReleaseYear
 )
 
 type Desc = bool
@@ -37,12 +106,40 @@ type OrderOption struct {
 	Desc  Desc
 }
 
+// 発売年の範囲
+// NOTE: 0のときは制限なし
+type ReleaseYearRange struct {
+	From ReleaseYear
+	To   ReleaseYear
+}
+
+// From ≦ To
+func (r ReleaseYearRange) Valid() bool {
+	if !r.From.Valid() || !r.To.Valid() {
+		return false
+	}
+	if r.From == 0 || r.To == 0 {
+		return true
+	}
+	return r.From <= r.To
+}
+
 // プラットフォーム検索オプション
 type FindOption struct {
 	SearchMode  SearchMode
 	Seek        Seek
 	Pagination  Pagination
 	OrderOption OrderOption
+	// NOTE: trueのときはプラットフォームを使うゲームの数を返却する
+	WithGameCount bool
+	// NOTE: trueのときはどのゲームにも使われていないプラットフォームのみに絞り込む
+	Unused bool
+	// NOTE: 指定されたときはいずれかに一致するプラットフォームのみに絞り込む
+	Manufacturers []Manufacturer
+	Kinds         []Kind
+	Generations   []Generation
+	// NOTE: 指定されたときは発売年が範囲内のプラットフォームのみに絞り込む。発売年が未登録のものは除く
+	ReleaseYearRange ReleaseYearRange
 }
 
 func NewFindOption() *FindOption {
@@ -72,6 +169,24 @@ func (f *FindOption) SetSeek(lastID LastID, count Count) *FindOption {
 	return f
 }
 
+// シーク法の続きの位置の設定
+func (f *FindOption) SetCursor(cursor *Cursor) *FindOption {
+	f.Seek.Cursor = cursor
+	return f
+}
+
+// シーク法の位置と並び順の整合性
+// NOTE: カーソルは発行時と同じ並び順でのみ使え、LastIDはIDの並び順でのみ使える
+func (f *FindOption) ValidSeek() bool {
+	if f.SearchMode != SearchMode_Seek {
+		return true
+	}
+	if cursor := f.Seek.Cursor; cursor != nil {
+		return cursor.Order == f.OrderOption.Order && cursor.Desc == f.OrderOption.Desc
+	}
+	return f.Seek.LastID == 0 || f.OrderOption.Order == Order_ID
+}
+
 func (f *FindOption) SetPagination(limit Limit, offset Offset) *FindOption {
 	f.SearchMode = SearchMode_Pagination
 	f.Pagination = Pagination{
@@ -88,3 +203,70 @@ func (f *FindOption) SetOrder(order Order, desc Desc) *FindOption {
 	}
 	return f
 }
+
+// プラットフォームを使うゲームの数を返却するかの設定
+func (f *FindOption) SetWithGameCount(withGameCount bool) *FindOption {
+	f.WithGameCount = withGameCount
+	return f
+}
+
+// 未使用のプラットフォームの絞り込みの設定
+func (f *FindOption) SetUnused(unused bool) *FindOption {
+	f.Unused = unused
+	return f
+}
+
+// メーカーの絞り込みの設定
+// NOTE: 重複は除く
+func (f *FindOption) SetManufacturers(manufacturers []Manufacturer) *FindOption {
+	f.Manufacturers = make([]Manufacturer, 0, len(manufacturers))
+	exists := make(map[Manufacturer]bool, len(manufacturers))
+	for _, manufacturer := range manufacturers {
+		if exists[manufacturer] {
+			continue
+		}
+		exists[manufacturer] = true
+		f.Manufacturers = append(f.Manufacturers, manufacturer)
+	}
+	return f
+}
+
+// 種類の絞り込みの設定
+// NOTE: 重複は除く
+func (f *FindOption) SetKinds(kinds []Kind) *FindOption {
+	f.Kinds = make([]Kind, 0, len(kinds))
+	exists := make(map[Kind]bool, len(kinds))
+	for _, kind := range kinds {
+		if exists[kind] {
+			continue
+		}
+		exists[kind] = true
+		f.Kinds = append(f.Kinds, kind)
+	}
+	return f
+}
+
+// 世代の絞り込みの設定
+// NOTE: 重複は除く
+func (f *FindOption) SetGenerations(generations []Generation) *FindOption {
+	f.Generations = make([]Generation, 0, len(generations))
+	exists := make(map[Generation]bool, len(generations))
+	for _, generation := range generations {
+		if exists[generation] {
+			continue
+		}
+		exists[generation] = true
+		f.Generations = append(f.Generations, generation)
+	}
+	return f
+}
+
+// 発売年の範囲の設定
+// NOTE: 0のときは制限なし
+func (f *FindOption) SetReleaseYearRange(from, to ReleaseYear) *FindOption {
+	f.ReleaseYearRange = ReleaseYearRange{
+		From: from,
+		To:   to,
+	}
+	return f
+}
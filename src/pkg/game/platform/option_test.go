@@ -243,3 +243,211 @@ func TestFindOption_SetOrder(t *testing.T) {
 		})
 	}
 }
+
+func TestNewFindMeta(t *testing.T) {
+	type args struct {
+		f          *FindOption
+		totalCount TotalCount
+		hasNext    bool
+		ids        []ID
+	}
+	tests := []struct {
+		name string
+		args args
+		want *FindMeta
+	}{
+		{
+			name: "seekの次ページあり",
+			args: args{
+				f:          NewFindOption().SetSeek(10, 5),
+				totalCount: 30,
+				hasNext:    true,
+				ids:        []ID{16, 17, 18, 19, 20},
+			},
+			want: &FindMeta{
+				TotalCount: 30,
+				HasNext:    true,
+				NextLastID: 20,
+			},
+		},
+		{
+			name: "seek(降順)の次ページは最後の行から",
+			args: args{
+				f:          NewFindOption().SetSeek(25, 5).SetOrder(Order_ID, true),
+				totalCount: 30,
+				hasNext:    true,
+				ids:        []ID{24, 23, 22, 21, 20},
+			},
+			want: &FindMeta{
+				TotalCount: 30,
+				HasNext:    true,
+				NextLastID: 20,
+			},
+		},
+		{
+			name: "paginationの次ページあり",
+			args: args{
+				f:          NewFindOption().SetPagination(5, 10),
+				totalCount: 30,
+				hasNext:    true,
+				ids:        []ID{16, 17, 18, 19, 20},
+			},
+			want: &FindMeta{
+				TotalCount: 30,
+				HasNext:    true,
+				NextOffset: 15,
+			},
+		},
+		{
+			name: "次ページなし",
+			args: args{
+				f:          NewFindOption().SetPagination(5, 25),
+				totalCount: 30,
+				hasNext:    false,
+				ids:        []ID{26, 27, 28, 29, 30},
+			},
+			want: &FindMeta{
+				TotalCount: 30,
+			},
+		},
+		{
+			name: "全件取得",
+			args: args{
+				f:          NewFindOption(),
+				totalCount: 30,
+				ids:        []ID{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16, 17, 18, 19, 20, 21, 22, 23, 24, 25, 26, 27, 28, 29, 30},
+			},
+			want: &FindMeta{
+				TotalCount: 30,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, NewFindMeta(tt.args.f, tt.args.totalCount, tt.args.hasNext, tt.args.ids))
+		})
+	}
+}
+
+func TestFindOption_ValidSeek(t *testing.T) {
+	tests := []struct {
+		name string
+		f    *FindOption
+		want bool
+	}{
+		{
+			name: "シーク法以外",
+			f:    NewFindOption().SetPagination(10, 0).SetOrder(Order_Name, false),
+			want: true,
+		},
+		{
+			name: "並び順が同じカーソル",
+			f: NewFindOption().SetSeek(0, 10).SetOrder(Order_Name, true).
+				SetCursor(&Cursor{Order: Order_Name, Desc: true, ID: 3}),
+			want: true,
+		},
+		{
+			name: "並び順が異なるカーソル",
+			f: NewFindOption().SetSeek(0, 10).SetOrder(Order_Name, false).
+				SetCursor(&Cursor{Order: Order_Name, Desc: true, ID: 3}),
+			want: false,
+		},
+		{
+			name: "IDの並び順でのlast_id",
+			f:    NewFindOption().SetSeek(3, 10).SetOrder(Order_ID, true),
+			want: true,
+		},
+		{
+			name: "IDの並び順以外でのlast_id",
+			f:    NewFindOption().SetSeek(3, 10).SetOrder(Order_UpdatedAt, false),
+			want: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.f.ValidSeek())
+		})
+	}
+}
+
+func TestFindMeta_SetNextCursor(t *testing.T) {
+	cursor := &Cursor{Order: Order_Name, ID: 3}
+	assert.Equal(t, cursor, NewFindMeta(NewFindOption().SetSeek(0, 1), 5, true, []ID{3}).SetNextCursor(cursor).NextCursor)
+	assert.Nil(t, NewFindMeta(NewFindOption().SetSeek(0, 1), 1, false, []ID{3}).SetNextCursor(cursor).NextCursor)
+}
+
+func TestFindOption_SetWithGameCount(t *testing.T) {
+	assert.True(t, NewFindOption().SetWithGameCount(true).WithGameCount)
+	assert.False(t, NewFindOption().SetWithGameCount(true).SetWithGameCount(false).WithGameCount)
+}
+
+func TestFindOption_SetUnused(t *testing.T) {
+	assert.True(t, NewFindOption().SetUnused(true).Unused)
+	assert.False(t, NewFindOption().SetUnused(true).SetUnused(false).Unused)
+}
+
+func TestFindOption_SetManufacturers(t *testing.T) {
+	got := NewFindOption().SetManufacturers([]Manufacturer{"任天堂", "セガ", "任天堂"})
+	assert.Equal(t, []Manufacturer{"任天堂", "セガ"}, got.Manufacturers)
+}
+
+func TestFindOption_SetKinds(t *testing.T) {
+	got := NewFindOption().SetKinds([]Kind{Kind_Home, Kind_Handheld, Kind_Home})
+	assert.Equal(t, []Kind{Kind_Home, Kind_Handheld}, got.Kinds)
+}
+
+func TestFindOption_SetGenerations(t *testing.T) {
+	got := NewFindOption().SetGenerations([]Generation{3, 4, 3})
+	assert.Equal(t, []Generation{3, 4}, got.Generations)
+}
+
+func TestFindOption_SetReleaseYearRange(t *testing.T) {
+	got := NewFindOption().SetReleaseYearRange(1983, 1994)
+	assert.Equal(t, ReleaseYearRange{From: 1983, To: 1994}, got.ReleaseYearRange)
+}
+
+func TestReleaseYearRange_Valid(t *testing.T) {
+	tests := []struct {
+		name string
+		r    ReleaseYearRange
+		want bool
+	}{
+		{
+			name: "OK",
+			r:    ReleaseYearRange{From: 1983, To: 1994},
+			want: true,
+		},
+		{
+			name: "同じ年",
+			r:    ReleaseYearRange{From: 1983, To: 1983},
+			want: true,
+		},
+		{
+			name: "Fromのみ",
+			r:    ReleaseYearRange{From: 1983},
+			want: true,
+		},
+		{
+			name: "Toのみ",
+			r:    ReleaseYearRange{To: 1994},
+			want: true,
+		},
+		{
+			name: "FromがToより後",
+			r:    ReleaseYearRange{From: 1994, To: 1983},
+			want: false,
+		},
+		{
+			name: "不正な年",
+			r:    ReleaseYearRange{From: 1900},
+			want: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.r.Valid(); got != tt.want {
+				t.Errorf("ReleaseYearRange.Valid() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -1,6 +1,9 @@
 package platform
 
-import "time"
+import (
+	"fmt"
+	"time"
+)
 
 // PlatformID
 type ID uint64
@@ -26,13 +29,107 @@ func (d Description) Valid() bool {
 	return len(d) >= 0 && len(d) <= 2048
 }
 
+// メーカー
+type Manufacturer string
+
+// 0 ≦ manufacturer.length ≦ 256
+func (m Manufacturer) Valid() bool {
+	// NOTE: メーカーは空でもOK
+	return len(m) < 256
+}
+
+// プラットフォームの種類
+type Kind uint8
+
+// NOTE: 種類未登録のプラットフォームは未分類として扱う
+const (
+	Kind_None Kind = iota
+	Kind_Home
+	Kind_Handheld
+	Kind_Hybrid
+	Kind_PC
+	Kind_Mobile
+	Kind_Arcade
+	Kind_MAX
+)
+
+// home, handheld, hybrid, pc, mobile, arcade を受け付ける
+// NOTE: 空文字は未分類とする
+func NewKind(kind string) (Kind, error) {
+	if kind == "" {
+		return Kind_None, nil
+	}
+	for k := Kind_Home; k < Kind_MAX; k++ {
+		if k.String() == kind {
+			return k, nil
+		}
+	}
+	return Kind_None, fmt.Errorf("kind format error: %q", kind)
+}
+
+func (k Kind) Valid() bool {
+	return k < Kind_MAX
+}
+
+// NOTE: 未分類は空文字
+func (k Kind) String() string {
+	switch k {
+	case Kind_Home:
+		return "home"
+	case Kind_Handheld:
+		return "handheld"
+	case Kind_Hybrid:
+		return "hybrid"
+	case Kind_PC:
+		return "pc"
+	case Kind_Mobile:
+		return "mobile"
+	case Kind_Arcade:
+		return "arcade"
+	default:
+		return ""
+	}
+}
+
+// 世代
+// NOTE: 家庭用ゲーム機の世代(ファミコンは第3世代)。0は未登録、または世代で区切れないもの(PCなど)
+type Generation uint8
+
+// 0 ≦ generation ≦ 20
+func (g Generation) Valid() bool {
+	return g <= 20
+}
+
+// 発売年
+// NOTE: 0は未登録
+type ReleaseYear uint16
+
+// releaseYear = 0 または 1950 ≦ releaseYear ≦ 9999
+func (y ReleaseYear) Valid() bool {
+	return y == 0 || (y >= 1950 && y <= 9999)
+}
+
+// プラットフォームを使うゲームの数
+type GameCount = int64
+
 // プラットフォーム
 type Platform struct {
 	ID          ID
 	Name        Name
 	Description Description
-	CreatedAt   time.Time
-	UpdatedAt   time.Time
+	// NOTE: メーカー・種類・世代・発売年は未登録のときゼロ値
+	Manufacturer Manufacturer
+	Kind         Kind
+	Generation   Generation
+	ReleaseYear  ReleaseYear
+	CreatedAt    time.Time
+	UpdatedAt    time.Time
+	// NOTE: ゴミ箱にないときはゼロ値
+	DeletedAt time.Time
+	// NOTE: 検索オプションで数えるよう指定したときのみ設定する
+	GameCount GameCount
+	// NOTE: 統合したプラットフォームの旧名。名前・他の別名と重複しない
+	Synonyms []Name
 }
 
 func New(name Name, description Description) *Platform {
@@ -49,3 +146,63 @@ func NewWithID(id ID, name Name, description Description) *Platform {
 		Description: description,
 	}
 }
+
+// メーカーの設定
+func (p *Platform) SetManufacturer(manufacturer Manufacturer) *Platform {
+	p.Manufacturer = manufacturer
+	return p
+}
+
+// 種類の設定
+func (p *Platform) SetKind(kind Kind) *Platform {
+	p.Kind = kind
+	return p
+}
+
+// 世代の設定
+func (p *Platform) SetGeneration(generation Generation) *Platform {
+	p.Generation = generation
+	return p
+}
+
+// 発売年の設定
+func (p *Platform) SetReleaseYear(releaseYear ReleaseYear) *Platform {
+	p.ReleaseYear = releaseYear
+	return p
+}
+
+// 更新対象のフィールド
+type Field uint8
+
+const (
+	Field_Name Field = iota
+	Field_Description
+	Field_Manufacturer
+	Field_Kind
+	Field_Generation
+	Field_ReleaseYear
+)
+
+// 変更されたフィールド
+func (p *Platform) ChangedFields(after *Platform) []Field {
+	fields := []Field{}
+	if p.Name != after.Name {
+		fields = append(fields, Field_Name)
+	}
+	if p.Description != after.Description {
+		fields = append(fields, Field_Description)
+	}
+	if p.Manufacturer != after.Manufacturer {
+		fields = append(fields, Field_Manufacturer)
+	}
+	if p.Kind != after.Kind {
+		fields = append(fields, Field_Kind)
+	}
+	if p.Generation != after.Generation {
+		fields = append(fields, Field_Generation)
+	}
+	if p.ReleaseYear != after.ReleaseYear {
+		fields = append(fields, Field_ReleaseYear)
+	}
+	return fields
+}
@@ -3,6 +3,7 @@ package platform
 import (
 	"math/rand"
 	"reflect"
+	"strings"
 	"testing"
 )
 
@@ -110,6 +111,169 @@ func TestDescription_Valid(t *testing.T) {
 	}
 }
 
+func TestManufacturer_Valid(t *testing.T) {
+	tests := []struct {
+		name string
+		m    Manufacturer
+		want bool
+	}{
+		{
+			name: "OK",
+			m:    "任天堂",
+			want: true,
+		},
+		{
+			name: "空文字は未登録",
+			m:    "",
+			want: true,
+		},
+		{
+			name: "長すぎる文字列",
+			m:    Manufacturer(strings.Repeat("a", 256)),
+			want: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.m.Valid(); got != tt.want {
+				t.Errorf("Manufacturer.Valid() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNewKind(t *testing.T) {
+	tests := []struct {
+		name    string
+		kind    string
+		want    Kind
+		wantErr bool
+	}{
+		{
+			name: "据え置き",
+			kind: "home",
+			want: Kind_Home,
+		},
+		{
+			name: "携帯",
+			kind: "handheld",
+			want: Kind_Handheld,
+		},
+		{
+			name: "空文字は未分類",
+			kind: "",
+			want: Kind_None,
+		},
+		{
+			name:    "不正な種類",
+			kind:    "console",
+			want:    Kind_None,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := NewKind(tt.kind)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("NewKind() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("NewKind() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestKind_Valid(t *testing.T) {
+	if !Kind_Arcade.Valid() {
+		t.Errorf("Kind.Valid() = false, want true")
+	}
+	if Kind_MAX.Valid() {
+		t.Errorf("Kind.Valid() = true, want false")
+	}
+}
+
+func TestGeneration_Valid(t *testing.T) {
+	tests := []struct {
+		name string
+		g    Generation
+		want bool
+	}{
+		{
+			name: "OK",
+			g:    9,
+			want: true,
+		},
+		{
+			name: "0は未登録",
+			g:    0,
+			want: true,
+		},
+		{
+			name: "大きすぎる世代",
+			g:    21,
+			want: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.g.Valid(); got != tt.want {
+				t.Errorf("Generation.Valid() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestReleaseYear_Valid(t *testing.T) {
+	tests := []struct {
+		name string
+		y    ReleaseYear
+		want bool
+	}{
+		{
+			name: "OK",
+			y:    1983,
+			want: true,
+		},
+		{
+			name: "0は未登録",
+			y:    0,
+			want: true,
+		},
+		{
+			name: "古すぎる年",
+			y:    1949,
+			want: false,
+		},
+		{
+			name: "5桁の年",
+			y:    10000,
+			want: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.y.Valid(); got != tt.want {
+				t.Errorf("ReleaseYear.Valid() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPlatform_ChangedFields(t *testing.T) {
+	before := &Platform{ID: 1, Name: "ファミリーコンピュータ", Description: "説明", Manufacturer: "任天堂"}
+	after := NewWithID(1, "ファミリーコンピュータ", "説明").
+		SetManufacturer("任天堂").
+		SetKind(Kind_Home).
+		SetGeneration(3).
+		SetReleaseYear(1983)
+	want := []Field{Field_Kind, Field_Generation, Field_ReleaseYear}
+	if got := before.ChangedFields(after); !reflect.DeepEqual(got, want) {
+		t.Errorf("Platform.ChangedFields() = %v, want %v", got, want)
+	}
+}
+
 func TestNew(t *testing.T) {
 	type args struct {
 		name        Name
@@ -174,3 +338,16 @@ func TestNewWithID(t *testing.T) {
 		})
 	}
 }
+
+func TestPlatform_Merge(t *testing.T) {
+	target := &Platform{ID: 1, Name: "Nintendo Switch", Synonyms: []Name{"NSW"}}
+	source := &Platform{ID: 2, Name: "Switch", Synonyms: []Name{"NSW", "Nintendo Switch", "スイッチ"}}
+	want := &Platform{ID: 1, Name: "Nintendo Switch", Synonyms: []Name{"NSW", "Switch", "スイッチ"}}
+	if got := target.Merge(source); !reflect.DeepEqual(got, want) {
+		t.Errorf("Platform.Merge() = %v, want %v", got, want)
+	}
+	// NOTE: 統合先は変更しない
+	if !reflect.DeepEqual(target.Synonyms, []Name{"NSW"}) {
+		t.Errorf("Platform.Merge() changed target synonyms = %v", target.Synonyms)
+	}
+}
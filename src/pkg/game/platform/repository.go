@@ -1,23 +1,44 @@
 package platform
 
-import "mysrtafes-backend/pkg/errors"
+import (
+	"mysrtafes-backend/pkg/errors"
+	"mysrtafes-backend/pkg/version"
+)
 
 type Repository interface {
 	PlatformCreate(*Platform) (*Platform, error)
 	PlatformRead(ID) (*Platform, error)
-	PlatformFind(*FindOption) ([]*Platform, error)
-	PlatformUpdate(*Platform) (*Platform, error)
-	PlatformDelete(ID) error
+	PlatformFind(*FindOption) ([]*Platform, *FindMeta, error)
+	PlatformUpdate(*Platform, *version.Precondition) (*Platform, error)
+	PlatformPatch(*Platform, []Field, *version.Precondition) (*Platform, error)
+	PlatformDelete(ID, *version.Precondition) error
+	PlatformTrash(*FindOption) ([]*Platform, *FindMeta, error)
+	PlatformRestore(ID) (*Platform, error)
+	PlatformPurge(ID) error
+	PlatformMerge(ID, ID, Merger) (*Platform, error)
 }
 
 type Server interface {
 	Create(*Platform) (*Platform, error)
 	Read(ID) (*Platform, error)
-	Find(*FindOption) ([]*Platform, error)
-	Update(*Platform) (*Platform, error)
-	Delete(ID) error
+	Find(*FindOption) ([]*Platform, *FindMeta, error)
+	Update(*Platform, *version.Precondition) (*Platform, error)
+	Patch(ID, Patcher, *version.Precondition) (*Platform, error)
+	Delete(ID, *version.Precondition) error
+	Trash(*FindOption) ([]*Platform, *FindMeta, error)
+	Restore(ID) (*Platform, error)
+	Purge(ID) error
+	Merge(ID, ID) (*Platform, error)
 }
 
+// 部分更新の適用
+// NOTE: 保存済みのプラットフォームを受け取り、変更後のプラットフォームを返却する。引数のプラットフォームは変更しないこと
+type Patcher func(*Platform) (*Platform, error)
+
+// 統合の適用
+// NOTE: 保存と同じトランザクションで読み込んだ統合先・統合元を受け取り、統合後のプラットフォームを返却する
+type Merger func(target *Platform, source *Platform) *Platform
+
 type server struct {
 	repository Repository
 }
@@ -55,6 +76,10 @@ func (s *server) Create(p *Platform) (*Platform, error) {
 			"Description Valid error",
 		)
 	}
+	// メーカー・種類・世代・発売年のValidate
+	if err := validSpec(p); err != nil {
+		return nil, err
+	}
 	return s.repository.PlatformCreate(p)
 }
 
@@ -76,11 +101,81 @@ func (s *server) Read(id ID) (*Platform, error) {
 	return s.repository.PlatformRead(id)
 }
 
-func (s *server) Find(findOption *FindOption) ([]*Platform, error) {
+func (s *server) Find(findOption *FindOption) ([]*Platform, *FindMeta, error) {
+	if err := validFindOption(findOption); err != nil {
+		return nil, nil, err
+	}
 	return s.repository.PlatformFind(findOption)
 }
 
-func (s *server) Update(p *Platform) (*Platform, error) {
+// 検索オプションのValidate
+// NOTE: ゴミ箱の検索も同じ検索オプションを使う
+func validFindOption(findOption *FindOption) error {
+	// 種類のValidate
+	for _, kind := range findOption.Kinds {
+		if !kind.Valid() {
+			return errors.NewInvalidRequest(
+				errors.Layer_Domain,
+				errors.NewInformation(
+					errors.ID_InvalidParams,
+					"",
+					[]errors.InvalidParams{
+						errors.NewInvalidParams("kinds", kind),
+					},
+				),
+				"kinds Valid error",
+			)
+		}
+	}
+	// 世代のValidate
+	for _, generation := range findOption.Generations {
+		if !generation.Valid() {
+			return errors.NewInvalidRequest(
+				errors.Layer_Domain,
+				errors.NewInformation(
+					errors.ID_InvalidParams,
+					"",
+					[]errors.InvalidParams{
+						errors.NewInvalidParams("generations", generation),
+					},
+				),
+				"generations Valid error",
+			)
+		}
+	}
+	// 発売年の範囲のValidate
+	if !findOption.ReleaseYearRange.Valid() {
+		return errors.NewInvalidRequest(
+			errors.Layer_Domain,
+			errors.NewInformation(
+				errors.ID_InvalidParams,
+				"",
+				[]errors.InvalidParams{
+					errors.NewInvalidParams("release_year_from", findOption.ReleaseYearRange.From),
+					errors.NewInvalidParams("release_year_to", findOption.ReleaseYearRange.To),
+				},
+			),
+			"release year range Valid error",
+		)
+	}
+	// シーク法の位置のValidate
+	if !findOption.ValidSeek() {
+		return errors.NewInvalidRequest(
+			errors.Layer_Domain,
+			errors.NewInformation(
+				errors.ID_InvalidParams,
+				"cursor or last_id does not match order",
+				[]errors.InvalidParams{
+					errors.NewInvalidParams("order", findOption.OrderOption.Order),
+				},
+			),
+			"seek position Valid error",
+		)
+	}
+	return nil
+}
+
+func (s *server) Update(p *Platform, precondition *version.Precondition) (*Platform, error) {
 	// IDのValidate
 	if !p.ID.Valid() {
 		return nil, errors.NewInvalidRequest(
@@ -123,10 +218,61 @@ func (s *server) Update(p *Platform) (*Platform, error) {
 			"Description Valid error",
 		)
 	}
-	return s.repository.PlatformUpdate(p)
+	// メーカー・種類・世代・発売年のValidate
+	if err := validSpec(p); err != nil {
+		return nil, err
+	}
+	// NOTE: 前提条件(If-Match)は保存と同じトランザクションで保存済みの版と比べる
+	return s.repository.PlatformUpdate(p, precondition)
 }
 
-func (s *server) Delete(id ID) error {
+// GamePlatformの部分更新
+// NOTE: 保存済みのプラットフォームに変更を適用し、変更のあったフィールドのみ保存する
+func (s *server) Patch(id ID, patcher Patcher, precondition *version.Precondition) (*Platform, error) {
+	// IDのValidate
+	if !id.Valid() {
+		return nil, errors.NewInvalidRequest(
+			errors.Layer_Domain,
+			errors.NewInformation(
+				errors.ID_InvalidParams,
+				"",
+				[]errors.InvalidParams{
+					errors.NewInvalidParams("id", id),
+				},
+			),
+			"ID Valid error",
+		)
+	}
+	current, err := s.repository.PlatformRead(id)
+	if err != nil {
+		return nil, err
+	}
+	// 前提条件のチェック
+	if err := precondition.Check(current.UpdatedAt); err != nil {
+		return nil, err
+	}
+	p, err := patcher(current)
+	if err != nil {
+		return nil, err
+	}
+	// NOTE: IDは変更させない
+	p.ID = current.ID
+	if err := validPlatform(p); err != nil {
+		return nil, err
+	}
+
+	fields := current.ChangedFields(p)
+	if len(fields) == 0 {
+		return current, nil
+	}
+	// NOTE: If-Matchの指定があるときは、読み込んでから保存するまでに更新されていないことも保存時に確認する
+	if precondition != nil {
+		precondition = version.NewPreconditionAt(current.UpdatedAt)
+	}
+	return s.repository.PlatformPatch(p, fields, precondition)
+}
+
+func (s *server) Delete(id ID, precondition *version.Precondition) error {
 	if !id.Valid() {
 		return errors.NewInvalidRequest(
 			errors.Layer_Domain,
@@ -140,5 +286,149 @@ func (s *server) Delete(id ID) error {
 			"ID Valid error",
 		)
 	}
-	return s.repository.PlatformDelete(id)
+	// NOTE: 前提条件(If-Match)は保存と同じトランザクションで保存済みの版と比べる
+	return s.repository.PlatformDelete(id, precondition)
+}
+
+// ゴミ箱の検索
+func (s *server) Trash(f *FindOption) ([]*Platform, *FindMeta, error) {
+	if err := validFindOption(f); err != nil {
+		return nil, nil, err
+	}
+	return s.repository.PlatformTrash(f)
+}
+
+// ゴミ箱からの復元
+// NOTE: 削除前の関連も復元する
+func (s *server) Restore(id ID) (*Platform, error) {
+	// IDのValidate
+	if !id.Valid() {
+		return nil, errors.NewInvalidRequest(
+			errors.Layer_Domain,
+			errors.NewInformation(
+				errors.ID_InvalidParams,
+				"",
+				[]errors.InvalidParams{
+					errors.NewInvalidParams("id", id),
+				},
+			),
+			"ID Valid error",
+		)
+	}
+	return s.repository.PlatformRestore(id)
+}
+
+// 完全削除
+// NOTE: ゴミ箱にあるもののみ対象とする
+func (s *server) Purge(id ID) error {
+	// IDのValidate
+	if !id.Valid() {
+		return errors.NewInvalidRequest(
+			errors.Layer_Domain,
+			errors.NewInformation(
+				errors.ID_InvalidParams,
+				"",
+				[]errors.InvalidParams{
+					errors.NewInvalidParams("id", id),
+				},
+			),
+			"ID Valid error",
+		)
+	}
+	return s.repository.PlatformPurge(id)
+}
+
+// プラットフォームのValidate
+// NOTE: 部分更新で変更後のプラットフォームに対して使用する
+func validPlatform(p *Platform) error {
+	// 名前のValidate
+	if !p.Name.Valid() {
+		return errors.NewInvalidRequest(
+			errors.Layer_Domain,
+			errors.NewInformation(
+				errors.ID_InvalidParams,
+				"",
+				[]errors.InvalidParams{
+					errors.NewInvalidParams("name", p.Name),
+				},
+			),
+			"Name Valid error",
+		)
+	}
+	// DescriptionのValidate
+	if !p.Description.Valid() {
+		return errors.NewInvalidRequest(
+			errors.Layer_Domain,
+			errors.NewInformation(
+				errors.ID_InvalidParams,
+				"",
+				[]errors.InvalidParams{
+					errors.NewInvalidParams("description", p.Description),
+				},
+			),
+			"Description Valid error",
+		)
+	}
+	return validSpec(p)
+}
+
+// メーカー・種類・世代・発売年のValidate
+func validSpec(p *Platform) error {
+	// メーカーのValidate
+	if !p.Manufacturer.Valid() {
+		return errors.NewInvalidRequest(
+			errors.Layer_Domain,
+			errors.NewInformation(
+				errors.ID_InvalidParams,
+				"",
+				[]errors.InvalidParams{
+					errors.NewInvalidParams("manufacturer", p.Manufacturer),
+				},
+			),
+			"Manufacturer Valid error",
+		)
+	}
+	// 種類のValidate
+	if !p.Kind.Valid() {
+		return errors.NewInvalidRequest(
+			errors.Layer_Domain,
+			errors.NewInformation(
+				errors.ID_InvalidParams,
+				"",
+				[]errors.InvalidParams{
+					errors.NewInvalidParams("kind", p.Kind),
+				},
+			),
+			"Kind Valid error",
+		)
+	}
+	// 世代のValidate
+	if !p.Generation.Valid() {
+		return errors.NewInvalidRequest(
+			errors.Layer_Domain,
+			errors.NewInformation(
+				errors.ID_InvalidParams,
+				"",
+				[]errors.InvalidParams{
+					errors.NewInvalidParams("generation", p.Generation),
+				},
+			),
+			"Generation Valid error",
+		)
+	}
+	// 発売年のValidate
+	if !p.ReleaseYear.Valid() {
+		return errors.NewInvalidRequest(
+			errors.Layer_Domain,
+			errors.NewInformation(
+				errors.ID_InvalidParams,
+				"",
+				[]errors.InvalidParams{
+					errors.NewInvalidParams("release_year", p.ReleaseYear),
+				},
+			),
+			"ReleaseYear Valid error",
+		)
+	}
+	return nil
 }
@@ -0,0 +1,257 @@
+package game
+
+import (
+	"fmt"
+	"mysrtafes-backend/pkg/errors"
+	"time"
+)
+
+// RelationID
+type RelationID uint64
+
+// 1 ≦ id
+func (i RelationID) Valid() bool {
+	return i > 0
+}
+
+// 関連の種別
+// NOTE: 関連元から関連先への向きを持つ(リメイク作品 remake_of 原作)
+type RelationKind uint8
+
+const (
+	RelationKind_RemakeOf RelationKind = iota
+	RelationKind_PortOf
+	RelationKind_ExpansionOf
+	RelationKind_SequelOf
+	RelationKind_MAX
+)
+
+// remake_of, port_of, expansion_of, sequel_of を受け付ける
+// NOTE: 関連は種別が必須のため空文字はエラーとする
+func NewRelationKind(kind string) (RelationKind, error) {
+	for k := RelationKind_RemakeOf; k < RelationKind_MAX; k++ {
+		if k.String() == kind {
+			return k, nil
+		}
+	}
+	return RelationKind_MAX, fmt.Errorf("relation kind format error: %q", kind)
+}
+
+func (k RelationKind) Valid() bool {
+	return k < RelationKind_MAX
+}
+
+func (k RelationKind) String() string {
+	switch k {
+	case RelationKind_RemakeOf:
+		return "remake_of"
+	case RelationKind_PortOf:
+		return "port_of"
+	case RelationKind_ExpansionOf:
+		return "expansion_of"
+	case RelationKind_SequelOf:
+		return "sequel_of"
+	default:
+		return ""
+	}
+}
+
+// 関連するゲーム
+// NOTE: 関連の表示に必要な項目のみ持つ
+type RelatedGame struct {
+	ID   ID
+	Name Name
+}
+
+// ゲーム間の関連
+type Relation struct {
+	RelationID RelationID
+	Kind       RelationKind
+	Source     RelatedGame
+	Target     RelatedGame
+	CreatedAt  time.Time
+	UpdatedAt  time.Time
+}
+
+func NewRelation(kind RelationKind, targetID ID) *Relation {
+	return &Relation{
+		Kind:   kind,
+		Target: RelatedGame{ID: targetID},
+	}
+}
+
+// 関連の設定
+func (g *Game) SetRelations(relations []*Relation) *Game {
+	g.Relations = relations
+	return g
+}
+
+type RelationRepository interface {
+	RelationCreate(ID, *Relation) (*Relation, error)
+	RelationRead(ID, RelationID) (*Relation, error)
+	RelationFind(ID) ([]*Relation, error)
+	RelationDelete(ID, RelationID) error
+}
+
+type RelationServer interface {
+	Create(ID, *Relation) (*Relation, error)
+	Read(ID, RelationID) (*Relation, error)
+	Find(ID) ([]*Relation, error)
+	Delete(ID, RelationID) error
+}
+
+type relationServer struct {
+	repository RelationRepository
+}
+
+func NewRelationServer(repo RelationRepository) RelationServer {
+	return &relationServer{repo}
+}
+
+// GameRelationの作成
+// NOTE: gameIDのゲームが関連元になる。循環の検出はDBの関連をたどるためRepositoryで行う
+func (s *relationServer) Create(gameID ID, r *Relation) (*Relation, error) {
+	// GameIDのValidate
+	if !gameID.Valid() {
+		return nil, errors.NewInvalidRequest(
+			errors.Layer_Domain,
+			errors.NewInformation(
+				errors.ID_InvalidParams,
+				"",
+				[]errors.InvalidParams{
+					errors.NewInvalidParams("gameID", gameID),
+				},
+			),
+			"gameID Valid error",
+		)
+	}
+	// KindのValidate
+	if !r.Kind.Valid() {
+		return nil, errors.NewInvalidRequest(
+			errors.Layer_Domain,
+			errors.NewInformation(
+				errors.ID_InvalidParams,
+				"",
+				[]errors.InvalidParams{
+					errors.NewInvalidParams("kind", r.Kind),
+				},
+			),
+			"kind Valid error",
+		)
+	}
+	// TargetIDのValidate
+	if !r.Target.ID.Valid() {
+		return nil, errors.NewInvalidRequest(
+			errors.Layer_Domain,
+			errors.NewInformation(
+				errors.ID_InvalidParams,
+				"",
+				[]errors.InvalidParams{
+					errors.NewInvalidParams("target_id", r.Target.ID),
+				},
+			),
+			"target_id Valid error",
+		)
+	}
+	// 自己参照のValidate
+	if r.Target.ID == gameID {
+		return nil, errors.NewInvalidRequest(
+			errors.Layer_Domain,
+			errors.NewInformation(
+				errors.ID_InvalidParams,
+				"a game cannot be related to itself",
+				[]errors.InvalidParams{
+					errors.NewInvalidParams("target_id", r.Target.ID),
+				},
+			),
+			"target_id Valid error",
+		)
+	}
+	return s.repository.RelationCreate(gameID, r)
+}
+
+// GameRelationの取得
+func (s *relationServer) Read(gameID ID, relationID RelationID) (*Relation, error) {
+	// GameIDのValidate
+	if !gameID.Valid() {
+		return nil, errors.NewInvalidRequest(
+			errors.Layer_Domain,
+			errors.NewInformation(
+				errors.ID_InvalidParams,
+				"",
+				[]errors.InvalidParams{
+					errors.NewInvalidParams("gameID", gameID),
+				},
+			),
+			"gameID Valid error",
+		)
+	}
+	// RelationIDのValidate
+	if !relationID.Valid() {
+		return nil, errors.NewInvalidRequest(
+			errors.Layer_Domain,
+			errors.NewInformation(
+				errors.ID_InvalidParams,
+				"",
+				[]errors.InvalidParams{
+					errors.NewInvalidParams("relationID", relationID),
+				},
+			),
+			"relationID Valid error",
+		)
+	}
+	return s.repository.RelationRead(gameID, relationID)
+}
+
+// GameRelationの一覧取得
+// NOTE: gameIDのゲームが関連元・関連先のどちらの関連も返却する
+func (s *relationServer) Find(gameID ID) ([]*Relation, error) {
+	// GameIDのValidate
+	if !gameID.Valid() {
+		return nil, errors.NewInvalidRequest(
+			errors.Layer_Domain,
+			errors.NewInformation(
+				errors.ID_InvalidParams,
+				"",
+				[]errors.InvalidParams{
+					errors.NewInvalidParams("gameID", gameID),
+				},
+			),
+			"gameID Valid error",
+		)
+	}
+	return s.repository.RelationFind(gameID)
+}
+
+// GameRelationの削除
+func (s *relationServer) Delete(gameID ID, relationID RelationID) error {
+	// GameIDのValidate
+	if !gameID.Valid() {
+		return errors.NewInvalidRequest(
+			errors.Layer_Domain,
+			errors.NewInformation(
+				errors.ID_InvalidParams,
+				"",
+				[]errors.InvalidParams{
+					errors.NewInvalidParams("gameID", gameID),
+				},
+			),
+			"gameID Valid error",
+		)
+	}
+	// RelationIDのValidate
+	if !relationID.Valid() {
+		return errors.NewInvalidRequest(
+			errors.Layer_Domain,
+			errors.NewInformation(
+				errors.ID_InvalidParams,
+				"",
+				[]errors.InvalidParams{
+					errors.NewInvalidParams("relationID", relationID),
+				},
+			),
+			"relationID Valid error",
+		)
+	}
+	return s.repository.RelationDelete(gameID, relationID)
+}
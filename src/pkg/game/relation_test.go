@@ -0,0 +1,296 @@
+package game
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type relationRepository struct {
+	relation  *Relation
+	relations []*Relation
+	err       error
+	// flags
+	create, read, find, delete bool
+}
+
+func (r relationRepository) RelationCreate(ID, *Relation) (*Relation, error) {
+	if r.create {
+		return r.relation, r.err
+	}
+	return nil, fmt.Errorf("failed create")
+}
+func (r relationRepository) RelationRead(ID, RelationID) (*Relation, error) {
+	if r.read {
+		return r.relation, r.err
+	}
+	return nil, fmt.Errorf("failed read")
+}
+func (r relationRepository) RelationFind(ID) ([]*Relation, error) {
+	if r.find {
+		return r.relations, r.err
+	}
+	return nil, fmt.Errorf("failed find")
+}
+func (r relationRepository) RelationDelete(ID, RelationID) error {
+	if r.delete {
+		return r.err
+	}
+	return fmt.Errorf("failed delete")
+}
+
+func TestNewRelationKind(t *testing.T) {
+	tests := []struct {
+		name    string
+		kind    string
+		want    RelationKind
+		wantErr bool
+	}{
+		{
+			name: "リメイク",
+			kind: "remake_of",
+			want: RelationKind_RemakeOf,
+		},
+		{
+			name: "移植",
+			kind: "port_of",
+			want: RelationKind_PortOf,
+		},
+		{
+			name: "拡張",
+			kind: "expansion_of",
+			want: RelationKind_ExpansionOf,
+		},
+		{
+			name: "続編",
+			kind: "sequel_of",
+			want: RelationKind_SequelOf,
+		},
+		{
+			name:    "未指定はエラー",
+			kind:    "",
+			want:    RelationKind_MAX,
+			wantErr: true,
+		},
+		{
+			name:    "存在しない種別",
+			kind:    "prequel_of",
+			want:    RelationKind_MAX,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := NewRelationKind(tt.kind)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("NewRelationKind() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestNewRelationServer(t *testing.T) {
+	type args struct {
+		repo RelationRepository
+	}
+	tests := []struct {
+		name string
+		args args
+		want RelationServer
+	}{
+		{
+			name: "new",
+			args: args{
+				repo: relationRepository{},
+			},
+			want: &relationServer{
+				repository: relationRepository{},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := NewRelationServer(tt.args.repo); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("NewRelationServer() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_relationServer_Create(t *testing.T) {
+	type fields struct {
+		repository RelationRepository
+	}
+	type args struct {
+		gameID ID
+		r      *Relation
+	}
+	tests := []struct {
+		name    string
+		fields  fields
+		args    args
+		want    *Relation
+		wantErr bool
+	}{
+		{
+			name: "OK",
+			fields: fields{
+				repository: relationRepository{
+					relation: &Relation{
+						RelationID: 1,
+						Kind:       RelationKind_RemakeOf,
+						Source:     RelatedGame{ID: 2, Name: "リメイク"},
+						Target:     RelatedGame{ID: 1, Name: "原作"},
+					},
+					create: true,
+				},
+			},
+			args: args{
+				gameID: 2,
+				r:      NewRelation(RelationKind_RemakeOf, 1),
+			},
+			want: &Relation{
+				RelationID: 1,
+				Kind:       RelationKind_RemakeOf,
+				Source:     RelatedGame{ID: 2, Name: "リメイク"},
+				Target:     RelatedGame{ID: 1, Name: "原作"},
+			},
+		},
+		{
+			name: "gameIDのバリデートエラー",
+			fields: fields{
+				repository: relationRepository{
+					create: true,
+				},
+			},
+			args: args{
+				gameID: 0,
+				r:      NewRelation(RelationKind_RemakeOf, 1),
+			},
+			wantErr: true,
+		},
+		{
+			name: "種別のバリデートエラー",
+			fields: fields{
+				repository: relationRepository{
+					create: true,
+				},
+			},
+			args: args{
+				gameID: 2,
+				r:      NewRelation(RelationKind_MAX, 1),
+			},
+			wantErr: true,
+		},
+		{
+			name: "関連先のバリデートエラー",
+			fields: fields{
+				repository: relationRepository{
+					create: true,
+				},
+			},
+			args: args{
+				gameID: 2,
+				r:      NewRelation(RelationKind_SequelOf, 0),
+			},
+			wantErr: true,
+		},
+		{
+			name: "自己参照エラー",
+			fields: fields{
+				repository: relationRepository{
+					create: true,
+				},
+			},
+			args: args{
+				gameID: 2,
+				r:      NewRelation(RelationKind_PortOf, 2),
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := &relationServer{
+				repository: tt.fields.repository,
+			}
+			got, err := s.Create(tt.args.gameID, tt.args.r)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("relationServer.Create() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("relationServer.Create() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_relationServer_Delete(t *testing.T) {
+	type fields struct {
+		repository RelationRepository
+	}
+	type args struct {
+		gameID     ID
+		relationID RelationID
+	}
+	tests := []struct {
+		name    string
+		fields  fields
+		args    args
+		wantErr bool
+	}{
+		{
+			name: "OK",
+			fields: fields{
+				repository: relationRepository{
+					delete: true,
+				},
+			},
+			args: args{
+				gameID:     1,
+				relationID: 1,
+			},
+		},
+		{
+			name: "gameIDのバリデートエラー",
+			fields: fields{
+				repository: relationRepository{
+					delete: true,
+				},
+			},
+			args: args{
+				gameID:     0,
+				relationID: 1,
+			},
+			wantErr: true,
+		},
+		{
+			name: "relationIDのバリデートエラー",
+			fields: fields{
+				repository: relationRepository{
+					delete: true,
+				},
+			},
+			args: args{
+				gameID:     1,
+				relationID: 0,
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := &relationServer{
+				repository: tt.fields.repository,
+			}
+			if err := s.Delete(tt.args.gameID, tt.args.relationID); (err != nil) != tt.wantErr {
+				t.Errorf("relationServer.Delete() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
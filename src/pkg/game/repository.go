@@ -1,27 +1,58 @@
 package game
 
 import (
+	"fmt"
 	"mysrtafes-backend/pkg/errors"
 	"mysrtafes-backend/pkg/game/platform"
 	"mysrtafes-backend/pkg/game/tag"
+	"mysrtafes-backend/pkg/version"
 )
 
 type Repository interface {
 	GameCreate(*Game, []platform.ID, []tag.ID) (*Game, error)
-	GameRead(ID) (*Game, error)
-	GameFind(*FindOption) ([]*Game, error)
-	GameUpdate(*Game, []platform.ID, []tag.ID) (*Game, error)
-	GameDelete(ID) error
+	GameRead(ID, *Include) (*Game, error)
+	GameFind(*FindOption) ([]*Game, *FindMeta, error)
+	GameFindByTag(tag.ID, *FindOption) ([]*Game, *FindMeta, error)
+	GameFindByPlatform(platform.ID, *FindOption) ([]*Game, *FindMeta, error)
+	GameUpdate(*Game, []platform.ID, []tag.ID, *version.Precondition) (*Game, error)
+	GamePatch(*Game, []platform.ID, []tag.ID, []Field, *version.Precondition) (*Game, error)
+	GameDelete(ID, *version.Precondition) error
+	GameTrash(*FindOption) ([]*Game, *FindMeta, error)
+	GameRestore(ID) (*Game, error)
+	GamePurge(ID) error
+	GameImport([]*ImportRow, ImportMode) ([]*ImportResult, error)
+	GameBulkUpdate([]*UpdateRow) ([]*BulkResult, error)
+	GameBulkDelete([]ID) ([]*BulkResult, error)
+	GameMerge(ID, ID, Merger) (*Game, error)
+	GameRedirect(ID) (*Redirect, error)
 }
 
 type Server interface {
 	Create(*Game, []platform.ID, []tag.ID) (*Game, error)
-	Read(ID) (*Game, error)
-	Find(*FindOption) ([]*Game, error)
-	Update(*Game, []platform.ID, []tag.ID) (*Game, error)
-	Delete(ID) error
+	Read(ID, *Include) (*Game, error)
+	Find(*FindOption) ([]*Game, *FindMeta, error)
+	FindByTag(tag.ID, *FindOption) ([]*Game, *FindMeta, error)
+	FindByPlatform(platform.ID, *FindOption) ([]*Game, *FindMeta, error)
+	Update(*Game, []platform.ID, []tag.ID, *version.Precondition) (*Game, error)
+	Patch(ID, Patcher, *version.Precondition) (*Game, error)
+	Delete(ID, *version.Precondition) error
+	Trash(*FindOption) ([]*Game, *FindMeta, error)
+	Restore(ID) (*Game, error)
+	Purge(ID) error
+	Import([]*ImportRow, ImportMode) ([]*ImportResult, error)
+	BulkUpdate([]*UpdateRow) ([]*BulkResult, error)
+	BulkDelete([]ID) ([]*BulkResult, error)
+	Merge(ID, ID) (*Game, error)
 }
 
+// 部分更新の適用
+// NOTE: 保存済みのゲームを受け取り、変更後のゲームとプラットフォーム・タグを返却する。引数のゲームは変更しないこと
+type Patcher func(*Game) (*Game, []platform.ID, []tag.ID, error)
+
+// 統合の適用
+// NOTE: 保存と同じトランザクションで読み込んだ統合先・統合元を受け取り、統合後のゲームとプラットフォーム・タグを返却する
+type Merger func(target *Game, source *Game) (*Game, []platform.ID, []tag.ID, error)
+
 type server struct {
 	repository Repository
 }
@@ -31,99 +62,219 @@ func NewServer(repo Repository) Server {
 }
 
 func (s *server) Create(g *Game, platformIDs []platform.ID, tagIDs []tag.ID) (*Game, error) {
-	// NameのValidate
-	if !g.Name.Valid() {
-		return nil, errors.NewInvalidRequest(
-			errors.Layer_Domain,
-			errors.NewInformation(
-				errors.ID_InvalidParams,
-				"",
-				[]errors.InvalidParams{
-					errors.NewInvalidParams("name", g.Name),
-				},
-			),
-			"Name Valid error",
-		)
+	if err := validGame(g); err != nil {
+		return nil, err
 	}
-	// DescriptionのValidate
-	if !g.Description.Valid() {
+	return s.repository.GameCreate(g, platformIDs, tagIDs)
+}
+
+// NOTE: includeがnilのときはすべての関連を読み込む
+func (s *server) Read(id ID, include *Include) (*Game, error) {
+	// IDのValidate
+	if !id.Valid() {
 		return nil, errors.NewInvalidRequest(
 			errors.Layer_Domain,
 			errors.NewInformation(
 				errors.ID_InvalidParams,
 				"",
 				[]errors.InvalidParams{
-					errors.NewInvalidParams("description", g.Description),
+					errors.NewInvalidParams("id", id),
 				},
 			),
-			"Description Valid error",
+			"ID Valid error",
 		)
 	}
+	g, err := s.repository.GameRead(id, include)
+	if _, ok := err.(errors.NotFoundError); !ok {
+		return g, err
+	}
+	// NOTE: 統合済みのゲームは統合先を返却する
+	redirect, redirectErr := s.repository.GameRedirect(id)
+	if redirectErr != nil {
+		if _, ok := redirectErr.(errors.NotFoundError); ok {
+			return nil, err
+		}
+		return nil, redirectErr
+	}
+	return s.repository.GameRead(redirect.To, include)
+}
+
+func (s *server) Find(findOption *FindOption) ([]*Game, *FindMeta, error) {
+	if err := validFindOption(findOption); err != nil {
+		return nil, nil, err
+	}
+	return s.repository.GameFind(findOption)
+}
 
-	// PublisherのValidate
-	if !g.Publisher.Valid() {
-		return nil, errors.NewInvalidRequest(
+// 指定タグを持つゲームの検索
+// NOTE: タグが存在しないときはNotFound
+func (s *server) FindByTag(tagID tag.ID, findOption *FindOption) ([]*Game, *FindMeta, error) {
+	// TagIDのValidate
+	if !tagID.Valid() {
+		return nil, nil, errors.NewInvalidRequest(
 			errors.Layer_Domain,
 			errors.NewInformation(
 				errors.ID_InvalidParams,
 				"",
 				[]errors.InvalidParams{
-					errors.NewInvalidParams("publisher", g.Publisher),
+					errors.NewInvalidParams("tagID", tagID),
 				},
 			),
-			"Publisher Valid error",
+			"tagID Valid error",
 		)
 	}
+	if err := validFindOption(findOption); err != nil {
+		return nil, nil, err
+	}
+	return s.repository.GameFindByTag(tagID, findOption)
+}
 
-	// DeveloperのValidate
-	if !g.Developer.Valid() {
-		return nil, errors.NewInvalidRequest(
+// 指定プラットフォームを持つゲームの検索
+// NOTE: プラットフォームが存在しないときはNotFound
+func (s *server) FindByPlatform(platformID platform.ID, findOption *FindOption) ([]*Game, *FindMeta, error) {
+	// PlatformIDのValidate
+	if !platformID.Valid() {
+		return nil, nil, errors.NewInvalidRequest(
 			errors.Layer_Domain,
 			errors.NewInformation(
 				errors.ID_InvalidParams,
 				"",
 				[]errors.InvalidParams{
-					errors.NewInvalidParams("developer", g.Developer),
+					errors.NewInvalidParams("platformID", platformID),
 				},
 			),
-			"Developer Valid error",
+			"platformID Valid error",
 		)
 	}
+	if err := validFindOption(findOption); err != nil {
+		return nil, nil, err
+	}
+	return s.repository.GameFindByPlatform(platformID, findOption)
+}
 
-	for _, link := range g.Links {
-		// link.TitleのValidate
-		if !link.Title.Valid() {
-			return nil, errors.NewInvalidRequest(
+// 検索オプションのValidate
+func validFindOption(findOption *FindOption) error {
+	// TagIDsのValidate
+	for _, tagID := range findOption.TagIDs {
+		if !tagID.Valid() {
+			return errors.NewInvalidRequest(
 				errors.Layer_Domain,
 				errors.NewInformation(
 					errors.ID_InvalidParams,
 					"",
 					[]errors.InvalidParams{
-						errors.NewInvalidParams("links.title", link.Title),
+						errors.NewInvalidParams("tag_ids", tagID),
 					},
 				),
-				"links.title Valid error",
+				"tag_ids Valid error",
 			)
 		}
-		// link.DescriptionのValidate
-		if !link.LinkDescription.Valid() {
-			return nil, errors.NewInvalidRequest(
+	}
+	// PlatformIDsのValidate
+	for _, platformID := range findOption.PlatformIDs {
+		if !platformID.Valid() {
+			return errors.NewInvalidRequest(
 				errors.Layer_Domain,
 				errors.NewInformation(
 					errors.ID_InvalidParams,
 					"",
 					[]errors.InvalidParams{
-						errors.NewInvalidParams("links.description", link.LinkDescription),
+						errors.NewInvalidParams("platform_ids", platformID),
 					},
 				),
-				"links.description Valid error",
+				"platform_ids Valid error",
 			)
 		}
 	}
-	return s.repository.GameCreate(g, platformIDs, tagIDs)
+	// SeriesIDsのValidate
+	for _, seriesID := range findOption.SeriesIDs {
+		if !seriesID.Valid() {
+			return errors.NewInvalidRequest(
+				errors.Layer_Domain,
+				errors.NewInformation(
+					errors.ID_InvalidParams,
+					"",
+					[]errors.InvalidParams{
+						errors.NewInvalidParams("series_ids", seriesID),
+					},
+				),
+				"series_ids Valid error",
+			)
+		}
+	}
+	// LinkKindsのValidate
+	for _, linkKind := range findOption.LinkKinds {
+		if !linkKind.Valid() {
+			return errors.NewInvalidRequest(
+				errors.Layer_Domain,
+				errors.NewInformation(
+					errors.ID_InvalidParams,
+					"",
+					[]errors.InvalidParams{
+						errors.NewInvalidParams("link_kinds", linkKind),
+					},
+				),
+				"link_kinds Valid error",
+			)
+		}
+	}
+	// ReleasePeriodのValidate
+	if !findOption.ReleasePeriod.Valid() {
+		return errors.NewInvalidRequest(
+			errors.Layer_Domain,
+			errors.NewInformation(
+				errors.ID_InvalidParams,
+				"",
+				[]errors.InvalidParams{
+					errors.NewInvalidParams("released_from", findOption.ReleasePeriod.From.String()),
+					errors.NewInvalidParams("released_to", findOption.ReleasePeriod.To.String()),
+				},
+			),
+			"release period Valid error",
+		)
+	}
+	// シーク法の位置のValidate
+	if !findOption.ValidSeek() {
+		return errors.NewInvalidRequest(
+			errors.Layer_Domain,
+			errors.NewInformation(
+				errors.ID_InvalidParams,
+				"cursor or last_id does not match order",
+				[]errors.InvalidParams{
+					errors.NewInvalidParams("order", findOption.OrderOption.Order),
+				},
+			),
+			"seek position Valid error",
+		)
+	}
+	return nil
+}
+
+func (s *server) Update(g *Game, platformIDs []platform.ID, tagIDs []tag.ID, precondition *version.Precondition) (*Game, error) {
+	// IDのValidate
+	if !g.ID.Valid() {
+		return nil, errors.NewInvalidRequest(
+			errors.Layer_Domain,
+			errors.NewInformation(
+				errors.ID_InvalidParams,
+				"",
+				[]errors.InvalidParams{
+					errors.NewInvalidParams("id", g.ID),
+				},
+			),
+			"ID Valid error",
+		)
+	}
+	if err := validGame(g); err != nil {
+		return nil, err
+	}
+	// NOTE: 前提条件(If-Match)は保存と同じトランザクションで保存済みの版と比べる
+	return s.repository.GameUpdate(g, platformIDs, tagIDs, precondition)
 }
 
-func (s *server) Read(id ID) (*Game, error) {
+// 部分更新
+// NOTE: 保存済みのゲームに変更を適用してUpdateと同じ条件でValidateし、変更のあったフィールドのみ保存する
+func (s *server) Patch(id ID, patcher Patcher, precondition *version.Precondition) (*Game, error) {
 	// IDのValidate
 	if !id.Valid() {
 		return nil, errors.NewInvalidRequest(
@@ -138,31 +289,292 @@ func (s *server) Read(id ID) (*Game, error) {
 			"ID Valid error",
 		)
 	}
-	return s.repository.GameRead(id)
+	current, err := s.repository.GameRead(id, nil)
+	if err != nil {
+		return nil, err
+	}
+	// 前提条件のチェック
+	if err := precondition.Check(current.UpdatedAt); err != nil {
+		return nil, err
+	}
+	g, platformIDs, tagIDs, err := patcher(current)
+	if err != nil {
+		return nil, err
+	}
+	// NOTE: IDは変更させない
+	g.ID = current.ID
+	if err := validGame(g); err != nil {
+		return nil, err
+	}
+
+	fields := current.ChangedFields(g, platformIDs, tagIDs)
+	if len(fields) == 0 {
+		return current, nil
+	}
+	// NOTE: If-Matchの指定があるときは、読み込んでから保存するまでに更新されていないことも保存時に確認する
+	if precondition != nil {
+		precondition = version.NewPreconditionAt(current.UpdatedAt)
+	}
+	return s.repository.GamePatch(g, platformIDs, tagIDs, fields, precondition)
 }
 
-func (s *server) Find(findOption *FindOption) ([]*Game, error) {
-	return s.repository.GameFind(findOption)
+func (s *server) Delete(id ID, precondition *version.Precondition) error {
+	if !id.Valid() {
+		return errors.NewInvalidRequest(
+			errors.Layer_Domain,
+			errors.NewInformation(
+				errors.ID_InvalidParams,
+				"",
+				[]errors.InvalidParams{
+					errors.NewInvalidParams("id", id),
+				},
+			),
+			"ID Valid error",
+		)
+	}
+	// NOTE: 前提条件(If-Match)は保存と同じトランザクションで保存済みの版と比べる
+	return s.repository.GameDelete(id, precondition)
 }
 
-func (s *server) Update(g *Game, platformIDs []platform.ID, tagIDs []tag.ID) (*Game, error) {
+// ゴミ箱の検索
+func (s *server) Trash(f *FindOption) ([]*Game, *FindMeta, error) {
+	if err := validFindOption(f); err != nil {
+		return nil, nil, err
+	}
+	return s.repository.GameTrash(f)
+}
+
+// ゴミ箱からの復元
+// NOTE: 削除前の関連も復元する
+func (s *server) Restore(id ID) (*Game, error) {
 	// IDのValidate
-	if !g.ID.Valid() {
+	if !id.Valid() {
 		return nil, errors.NewInvalidRequest(
 			errors.Layer_Domain,
 			errors.NewInformation(
 				errors.ID_InvalidParams,
 				"",
 				[]errors.InvalidParams{
-					errors.NewInvalidParams("id", g.ID),
+					errors.NewInvalidParams("id", id),
+				},
+			),
+			"ID Valid error",
+		)
+	}
+	return s.repository.GameRestore(id)
+}
+
+// 完全削除
+// NOTE: ゴミ箱にあるもののみ対象とする
+func (s *server) Purge(id ID) error {
+	// IDのValidate
+	if !id.Valid() {
+		return errors.NewInvalidRequest(
+			errors.Layer_Domain,
+			errors.NewInformation(
+				errors.ID_InvalidParams,
+				"",
+				[]errors.InvalidParams{
+					errors.NewInvalidParams("id", id),
 				},
 			),
 			"ID Valid error",
 		)
 	}
+	return s.repository.GamePurge(id)
+}
+
+// 一括登録
+// NOTE: 全行をCreateと同じ条件でValidateし、結果は行の並び順で返却する
+func (s *server) Import(rows []*ImportRow, mode ImportMode) ([]*ImportResult, error) {
+	// ModeのValidate
+	if !mode.Valid() {
+		return nil, errors.NewInvalidRequest(
+			errors.Layer_Domain,
+			errors.NewInformation(
+				errors.ID_InvalidParams,
+				"",
+				[]errors.InvalidParams{
+					errors.NewInvalidParams("import_mode", mode),
+				},
+			),
+			"import_mode Valid error",
+		)
+	}
+	// 件数のValidate
+	if err := validBulkCount(len(rows)); err != nil {
+		return nil, err
+	}
+
+	results := make([]*ImportResult, len(rows))
+	validRows := make([]*ImportRow, 0, len(rows))
+	validIndexes := make([]int, 0, len(rows))
+	for i, row := range rows {
+		err := row.Err
+		if err == nil {
+			err = validGame(row.Game)
+		}
+		if err != nil {
+			results[i] = NewImportFailed(err)
+			continue
+		}
+		validRows = append(validRows, row)
+		validIndexes = append(validIndexes, i)
+	}
+
+	// トランザクションモードでは1行でも不正があれば登録しない
+	if mode == ImportMode_Transaction && len(validRows) != len(rows) {
+		for _, i := range validIndexes {
+			results[i] = NewImportCanceled()
+		}
+		return results, nil
+	}
+	if len(validRows) == 0 {
+		return results, nil
+	}
+
+	imported, err := s.repository.GameImport(validRows, mode)
+	if err != nil {
+		return nil, err
+	}
+	for j, i := range validIndexes {
+		results[i] = imported[j]
+	}
+	return results, nil
+}
+
+// 一括更新
+// NOTE: 不正な件は失敗として返却し、残りを1トランザクションで更新する
+func (s *server) BulkUpdate(rows []*UpdateRow) ([]*BulkResult, error) {
+	// 件数のValidate
+	if err := validBulkCount(len(rows)); err != nil {
+		return nil, err
+	}
+
+	results := make([]*BulkResult, len(rows))
+	validRows := make([]*UpdateRow, 0, len(rows))
+	validIndexes := make([]int, 0, len(rows))
+	exists := make(map[ID]bool, len(rows))
+	for i, row := range rows {
+		err := row.Err
+		if err == nil {
+			err = validBulkID(row.Game.ID, exists)
+		}
+		if err == nil {
+			err = validGame(row.Game)
+		}
+		if err != nil {
+			results[i] = NewBulkFailed(row.Game.ID, err)
+			continue
+		}
+		validRows = append(validRows, row)
+		validIndexes = append(validIndexes, i)
+	}
+	if len(validRows) == 0 {
+		return results, nil
+	}
+
+	updated, err := s.repository.GameBulkUpdate(validRows)
+	if err != nil {
+		return nil, err
+	}
+	for j, i := range validIndexes {
+		results[i] = updated[j]
+	}
+	return results, nil
+}
+
+// 一括削除
+// NOTE: 不正なIDは失敗として返却し、残りを1トランザクションで削除する
+func (s *server) BulkDelete(ids []ID) ([]*BulkResult, error) {
+	// 件数のValidate
+	if err := validBulkCount(len(ids)); err != nil {
+		return nil, err
+	}
+
+	results := make([]*BulkResult, len(ids))
+	validIDs := make([]ID, 0, len(ids))
+	validIndexes := make([]int, 0, len(ids))
+	exists := make(map[ID]bool, len(ids))
+	for i, id := range ids {
+		if err := validBulkID(id, exists); err != nil {
+			results[i] = NewBulkFailed(id, err)
+			continue
+		}
+		validIDs = append(validIDs, id)
+		validIndexes = append(validIndexes, i)
+	}
+	if len(validIDs) == 0 {
+		return results, nil
+	}
+
+	deleted, err := s.repository.GameBulkDelete(validIDs)
+	if err != nil {
+		return nil, err
+	}
+	for j, i := range validIndexes {
+		results[i] = deleted[j]
+	}
+	return results, nil
+}
+
+// 一括操作の件数のValidate
+func validBulkCount(count int) error {
+	if count == 0 || count > BulkLimit {
+		return errors.NewInvalidRequest(
+			errors.Layer_Domain,
+			errors.NewInformation(
+				errors.ID_InvalidParams,
+				fmt.Sprintf("rows must be 1 to %d", BulkLimit),
+				[]errors.InvalidParams{
+					errors.NewInvalidParams("rows", count),
+				},
+			),
+			"rows count Valid error",
+		)
+	}
+	return nil
+}
+
+// 一括操作のIDのValidate
+// NOTE: 同じIDの2件目以降は失敗とする
+func validBulkID(id ID, exists map[ID]bool) error {
+	if !id.Valid() {
+		return errors.NewInvalidRequest(
+			errors.Layer_Domain,
+			errors.NewInformation(
+				errors.ID_InvalidParams,
+				"",
+				[]errors.InvalidParams{
+					errors.NewInvalidParams("id", id),
+				},
+			),
+			"ID Valid error",
+		)
+	}
+	if exists[id] {
+		return errors.NewInvalidRequest(
+			errors.Layer_Domain,
+			errors.NewInformation(
+				errors.ID_InvalidParams,
+				"duplicate id",
+				[]errors.InvalidParams{
+					errors.NewInvalidParams("id", id),
+				},
+			),
+			"ID duplicate error",
+		)
+	}
+	exists[id] = true
+	return nil
+}
+
+// ゲームのValidate
+// NOTE: 登録・更新・一括操作で共通の条件
+func validGame(g *Game) error {
 	// NameのValidate
 	if !g.Name.Valid() {
-		return nil, errors.NewInvalidRequest(
+		return errors.NewInvalidRequest(
 			errors.Layer_Domain,
 			errors.NewInformation(
 				errors.ID_InvalidParams,
@@ -176,7 +588,7 @@ func (s *server) Update(g *Game, platformIDs []platform.ID, tagIDs []tag.ID) (*G
 	}
 	// DescriptionのValidate
 	if !g.Description.Valid() {
-		return nil, errors.NewInvalidRequest(
+		return errors.NewInvalidRequest(
 			errors.Layer_Domain,
 			errors.NewInformation(
 				errors.ID_InvalidParams,
@@ -191,7 +603,7 @@ func (s *server) Update(g *Game, platformIDs []platform.ID, tagIDs []tag.ID) (*G
 
 	// PublisherのValidate
 	if !g.Publisher.Valid() {
-		return nil, errors.NewInvalidRequest(
+		return errors.NewInvalidRequest(
 			errors.Layer_Domain,
 			errors.NewInformation(
 				errors.ID_InvalidParams,
@@ -206,7 +618,7 @@ func (s *server) Update(g *Game, platformIDs []platform.ID, tagIDs []tag.ID) (*G
 
 	// DeveloperのValidate
 	if !g.Developer.Valid() {
-		return nil, errors.NewInvalidRequest(
+		return errors.NewInvalidRequest(
 			errors.Layer_Domain,
 			errors.NewInformation(
 				errors.ID_InvalidParams,
@@ -222,7 +634,7 @@ func (s *server) Update(g *Game, platformIDs []platform.ID, tagIDs []tag.ID) (*G
 	for _, link := range g.Links {
 		// link.TitleのValidate
 		if !link.Title.Valid() {
-			return nil, errors.NewInvalidRequest(
+			return errors.NewInvalidRequest(
 				errors.Layer_Domain,
 				errors.NewInformation(
 					errors.ID_InvalidParams,
@@ -236,7 +648,7 @@ func (s *server) Update(g *Game, platformIDs []platform.ID, tagIDs []tag.ID) (*G
 		}
 		// link.DescriptionのValidate
 		if !link.LinkDescription.Valid() {
-			return nil, errors.NewInvalidRequest(
+			return errors.NewInvalidRequest(
 				errors.Layer_Domain,
 				errors.NewInformation(
 					errors.ID_InvalidParams,
@@ -248,23 +660,124 @@ func (s *server) Update(g *Game, platformIDs []platform.ID, tagIDs []tag.ID) (*G
 				"links.description Valid error",
 			)
 		}
+		// link.KindのValidate
+		if !link.Kind.Valid() {
+			return errors.NewInvalidRequest(
+				errors.Layer_Domain,
+				errors.NewInformation(
+					errors.ID_InvalidParams,
+					"",
+					[]errors.InvalidParams{
+						errors.NewInvalidParams("links.kind", link.Kind),
+					},
+				),
+				"links.kind Valid error",
+			)
+		}
+		// link.Store, link.RegionのValidate
+		if !link.Store.Valid() || !link.Region.Valid() || !link.ValidStore() {
+			return errors.NewInvalidRequest(
+				errors.Layer_Domain,
+				errors.NewInformation(
+					errors.ID_InvalidParams,
+					"store and region are only for store links",
+					[]errors.InvalidParams{
+						errors.NewInvalidParams("links.store", link.Store),
+						errors.NewInvalidParams("links.region", link.Region),
+					},
+				),
+				"links.store Valid error",
+			)
+		}
 	}
-	return s.repository.GameUpdate(g, platformIDs, tagIDs)
-}
 
-func (s *server) Delete(id ID) error {
-	if !id.Valid() {
+	// SeriesのValidate
+	if g.Series != nil && !g.Series.ID.Valid() {
 		return errors.NewInvalidRequest(
 			errors.Layer_Domain,
 			errors.NewInformation(
 				errors.ID_InvalidParams,
 				"",
 				[]errors.InvalidParams{
-					errors.NewInvalidParams("id", id),
+					errors.NewInvalidParams("series_id", g.Series.ID),
 				},
 			),
-			"ID Valid error",
+			"series_id Valid error",
 		)
 	}
-	return s.repository.GameDelete(id)
+	if !g.ValidSeriesNumber() {
+		return errors.NewInvalidRequest(
+			errors.Layer_Domain,
+			errors.NewInformation(
+				errors.ID_InvalidParams,
+				"series_number is only for games in a series",
+				[]errors.InvalidParams{
+					errors.NewInvalidParams("series_number", g.SeriesNumber),
+				},
+			),
+			"series_number Valid error",
+		)
+	}
+
+	exists := make(map[string]bool, len(g.Aliases))
+	for _, alias := range g.Aliases {
+		// alias.NameのValidate
+		if !alias.Name.Valid() {
+			return errors.NewInvalidRequest(
+				errors.Layer_Domain,
+				errors.NewInformation(
+					errors.ID_InvalidParams,
+					"",
+					[]errors.InvalidParams{
+						errors.NewInvalidParams("aliases.name", alias.Name),
+					},
+				),
+				"aliases.name Valid error",
+			)
+		}
+		// alias.LanguageのValidate
+		if !alias.Language.Valid() {
+			return errors.NewInvalidRequest(
+				errors.Layer_Domain,
+				errors.NewInformation(
+					errors.ID_InvalidParams,
+					"",
+					[]errors.InvalidParams{
+						errors.NewInvalidParams("aliases.language", alias.Language),
+					},
+				),
+				"aliases.language Valid error",
+			)
+		}
+		// alias.KindのValidate
+		if !alias.Kind.Valid() {
+			return errors.NewInvalidRequest(
+				errors.Layer_Domain,
+				errors.NewInformation(
+					errors.ID_InvalidParams,
+					"",
+					[]errors.InvalidParams{
+						errors.NewInvalidParams("aliases.kind", alias.Kind),
+					},
+				),
+				"aliases.kind Valid error",
+			)
+		}
+		// 名前・言語・種別の重複チェック
+		if exists[alias.key()] {
+			return errors.NewInvalidRequest(
+				errors.Layer_Domain,
+				errors.NewInformation(
+					errors.ID_InvalidParams,
+					"duplicate alias",
+					[]errors.InvalidParams{
+						errors.NewInvalidParams("aliases.name", alias.Name),
+					},
+				),
+				"aliases duplicate error",
+			)
+		}
+		exists[alias.key()] = true
+	}
+	return nil
 }
@@ -0,0 +1,360 @@
+package game
+
+import (
+	"fmt"
+	"mysrtafes-backend/pkg/game/platform"
+	"mysrtafes-backend/pkg/game/tag"
+	"mysrtafes-backend/pkg/version"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_server_Patch(t *testing.T) {
+	current := &Game{
+		ID:          1,
+		Name:        "TestGame",
+		Description: "desc",
+		Platforms:   []*platform.Platform{{ID: 2}},
+		Tags:        []*tag.Tag{{ID: 1}},
+	}
+	patched := &Game{
+		ID:          1,
+		Name:        "TestGame",
+		Description: "patched",
+		Platforms:   []*platform.Platform{{ID: 2}},
+		Tags:        []*tag.Tag{{ID: 1}},
+	}
+	// NOTE: 読み込んでから保存するまでに他から更新されたときの更新日時
+	storedAt := current.UpdatedAt.Add(time.Second)
+	type args struct {
+		id           ID
+		patcher      Patcher
+		precondition *version.Precondition
+	}
+	tests := []struct {
+		name       string
+		repository repository
+		args       args
+		want       *Game
+		wantFields []Field
+		wantErr    bool
+	}{
+		{
+			name: "OK",
+			repository: repository{
+				game:    current,
+				patched: patched,
+				read:    true,
+				patch:   true,
+			},
+			args: args{
+				id: 1,
+				patcher: func(g *Game) (*Game, []platform.ID, []tag.ID, error) {
+					return &Game{ID: 1, Name: g.Name, Description: "patched"}, []platform.ID{2}, []tag.ID{1}, nil
+				},
+			},
+			want:       patched,
+			wantFields: []Field{Field_Description},
+		},
+		{
+			name: "OK(IDは変更させない)",
+			repository: repository{
+				game:    current,
+				patched: patched,
+				read:    true,
+				patch:   true,
+			},
+			args: args{
+				id: 1,
+				patcher: func(g *Game) (*Game, []platform.ID, []tag.ID, error) {
+					return &Game{ID: 5, Name: g.Name, Description: "patched"}, []platform.ID{2}, []tag.ID{1}, nil
+				},
+			},
+			want:       patched,
+			wantFields: []Field{Field_Description},
+		},
+		{
+			name: "OK(変更なしは保存しない)",
+			repository: repository{
+				game: current,
+				read: true,
+			},
+			args: args{
+				id: 1,
+				patcher: func(g *Game) (*Game, []platform.ID, []tag.ID, error) {
+					return &Game{ID: 1, Name: g.Name, Description: g.Description}, []platform.ID{2}, []tag.ID{1}, nil
+				},
+			},
+			want: current,
+		},
+		{
+			name: "IDのバリデートエラー",
+			repository: repository{
+				game:  current,
+				read:  true,
+				patch: true,
+			},
+			args: args{
+				id: 0,
+				patcher: func(g *Game) (*Game, []platform.ID, []tag.ID, error) {
+					return g, nil, nil, nil
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "取得エラー",
+			repository: repository{
+				patch: true,
+			},
+			args: args{
+				id: 1,
+				patcher: func(g *Game) (*Game, []platform.ID, []tag.ID, error) {
+					return g, nil, nil, nil
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "パッチ適用エラー",
+			repository: repository{
+				game:  current,
+				read:  true,
+				patch: true,
+			},
+			args: args{
+				id: 1,
+				patcher: func(g *Game) (*Game, []platform.ID, []tag.ID, error) {
+					return nil, nil, nil, fmt.Errorf("patch error")
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "Nameのバリデートエラー",
+			repository: repository{
+				game:  current,
+				read:  true,
+				patch: true,
+			},
+			args: args{
+				id: 1,
+				patcher: func(g *Game) (*Game, []platform.ID, []tag.ID, error) {
+					return &Game{ID: 1, Name: "", Description: g.Description}, []platform.ID{2}, []tag.ID{1}, nil
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "前提条件が不一致",
+			repository: repository{
+				game:  current,
+				read:  true,
+				patch: true,
+			},
+			args: args{
+				id: 1,
+				patcher: func(g *Game) (*Game, []platform.ID, []tag.ID, error) {
+					return patched, []platform.ID{2}, []tag.ID{1}, nil
+				},
+				precondition: version.NewPrecondition(`"old"`),
+			},
+			wantErr: true,
+		},
+		{
+			name: "読み込み後に更新された",
+			repository: repository{
+				game:   current,
+				stored: &storedAt,
+				read:   true,
+				patch:  true,
+			},
+			args: args{
+				id: 1,
+				patcher: func(g *Game) (*Game, []platform.ID, []tag.ID, error) {
+					return patched, []platform.ID{2}, []tag.ID{1}, nil
+				},
+				precondition: version.NewPrecondition(version.New(current.UpdatedAt).ETag()),
+			},
+			wantFields: []Field{Field_Description},
+			wantErr:    true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var fields []Field
+			tt.repository.patchFields = &fields
+			s := &server{
+				repository: tt.repository,
+			}
+			got, err := s.Patch(tt.args.id, tt.args.patcher, tt.args.precondition)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("server.Patch() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			assert.Equal(t, tt.want, got)
+			assert.Equal(t, tt.wantFields, fields)
+		})
+	}
+}
+
+func Test_server_FindByTag(t *testing.T) {
+	games := []*Game{{ID: 1, Name: "TestGame"}}
+	tests := []struct {
+		name       string
+		repository repository
+		tagID      tag.ID
+		findOption *FindOption
+		want       []*Game
+		wantErr    bool
+	}{
+		{
+			name:       "OK",
+			repository: repository{games: games, find: true},
+			tagID:      1,
+			findOption: NewFindOption(),
+			want:       games,
+		},
+		{
+			name:       "タグIDのバリデートエラー",
+			repository: repository{games: games, find: true},
+			tagID:      0,
+			findOption: NewFindOption(),
+			wantErr:    true,
+		},
+		{
+			name:       "検索オプションのバリデートエラー",
+			repository: repository{games: games, find: true},
+			tagID:      1,
+			findOption: NewFindOption().SetTagIDs([]tag.ID{0}),
+			wantErr:    true,
+		},
+		{
+			name:       "タグが存在しないエラー",
+			repository: repository{err: fmt.Errorf("not found"), find: true},
+			tagID:      1,
+			findOption: NewFindOption(),
+			wantErr:    true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := &server{
+				repository: tt.repository,
+			}
+			got, _, err := s.FindByTag(tt.tagID, tt.findOption)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("server.FindByTag() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func Test_server_FindByPlatform(t *testing.T) {
+	games := []*Game{{ID: 1, Name: "TestGame"}}
+	tests := []struct {
+		name       string
+		repository repository
+		platformID platform.ID
+		findOption *FindOption
+		want       []*Game
+		wantErr    bool
+	}{
+		{
+			name:       "OK",
+			repository: repository{games: games, find: true},
+			platformID: 1,
+			findOption: NewFindOption(),
+			want:       games,
+		},
+		{
+			name:       "プラットフォームIDのバリデートエラー",
+			repository: repository{games: games, find: true},
+			platformID: 0,
+			findOption: NewFindOption(),
+			wantErr:    true,
+		},
+		{
+			name:       "検索オプションのバリデートエラー",
+			repository: repository{games: games, find: true},
+			platformID: 1,
+			findOption: NewFindOption().SetPlatformIDs([]platform.ID{0}),
+			wantErr:    true,
+		},
+		{
+			name:       "プラットフォームが存在しないエラー",
+			repository: repository{err: fmt.Errorf("not found"), find: true},
+			platformID: 1,
+			findOption: NewFindOption(),
+			wantErr:    true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := &server{
+				repository: tt.repository,
+			}
+			got, _, err := s.FindByPlatform(tt.platformID, tt.findOption)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("server.FindByPlatform() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func Test_server_Trash(t *testing.T) {
+	games := []*Game{{ID: 1, Name: "TestGame"}}
+	from := NewReleaseDateWithPrecision(time.Date(1996, 1, 1, 0, 0, 0, 0, time.UTC), Precision_Year)
+	to := NewReleaseDateWithPrecision(time.Date(1995, 1, 1, 0, 0, 0, 0, time.UTC), Precision_Year)
+	tests := []struct {
+		name       string
+		repository repository
+		findOption *FindOption
+		want       []*Game
+		wantErr    bool
+	}{
+		{
+			name:       "OK",
+			repository: repository{games: games, trash: true},
+			findOption: NewFindOption(),
+			want:       games,
+		},
+		{
+			name:       "並び順が異なるカーソル",
+			repository: repository{games: games, trash: true},
+			findOption: NewFindOption().SetSeek(0, 10).SetCursor(&Cursor{Order: Order_Name, ID: 3}),
+			wantErr:    true,
+		},
+		{
+			name:       "発売日の範囲のバリデートエラー",
+			repository: repository{games: games, trash: true},
+			findOption: NewFindOption().SetReleasePeriod(&from, &to),
+			wantErr:    true,
+		},
+		{
+			name:       "タグIDのバリデートエラー",
+			repository: repository{games: games, trash: true},
+			findOption: NewFindOption().SetTagIDs([]tag.ID{0}),
+			wantErr:    true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := &server{
+				repository: tt.repository,
+			}
+			got, _, err := s.Trash(tt.findOption)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("server.Trash() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -0,0 +1,166 @@
+package series
+
+import "time"
+
+type SearchMode uint8
+
+const (
+	SearchMode_All SearchMode = iota
+	SearchMode_Seek
+	SearchMode_Pagination
+)
+
+type LastID = ID
+type Count = int
+type Seek struct {
+	LastID LastID
+	Count  Count
+	// NOTE: 指定されたときはLastIDより優先する
+	Cursor *Cursor
+}
+
+// シーク法の続きの位置
+// NOTE: 最後に取得したものの並び替えの値とIDの組で位置を表す
+// 並び順ごとに使う値が異なる(名前: Name、更新日時: UpdatedAt)
+type Cursor struct {
+	Order     Order
+	Desc      Desc
+	ID        ID
+	Name      Name
+	UpdatedAt time.Time
+}
+
+type Limit = int
+type Offset = int
+
+type Pagination struct {
+	Limit  Limit
+	Offset Offset
+}
+
+type TotalCount = int64
+
+// 検索結果のメタ情報
+// NOTE: 次の取得位置は次ページがあるときのみ設定する
+type FindMeta struct {
+	TotalCount TotalCount
+	HasNext    bool
+	NextLastID LastID
+	NextOffset Offset
+	NextCursor *Cursor
+}
+
+// 次ページの位置の設定
+// NOTE: 次ページがあるときのみ設定する
+func (m *FindMeta) SetNextCursor(cursor *Cursor) *FindMeta {
+	if m.HasNext {
+		m.NextCursor = cursor
+	}
+	return m
+}
+
+// 検索結果のメタ情報の生成
+// NOTE: idsは取得した行のIDを並び順のまま指定する。次ページは並び順によらず最後の行の後ろから始まる
+func NewFindMeta(f *FindOption, totalCount TotalCount, hasNext bool, ids []ID) *FindMeta {
+	meta := &FindMeta{
+		TotalCount: totalCount,
+		HasNext:    hasNext,
+	}
+	if !hasNext {
+		return meta
+	}
+	switch f.SearchMode {
+	case SearchMode_Seek:
+		if len(ids) > 0 {
+			meta.NextLastID = ids[len(ids)-1]
+		}
+	case SearchMode_Pagination:
+		meta.NextOffset = f.Pagination.Offset + len(ids)
+	}
+	return meta
+}
+
+type Order uint8
+
+const (
+	Order_ID Order = iota
+	Order_Name
+	Order_UpdatedAt
+)
+
+type Desc = bool
+
+type OrderOption struct {
+	Order Order
+	Desc  Desc
+}
+
+// シリーズ検索オプション
+type FindOption struct {
+	SearchMode  SearchMode
+	Seek        Seek
+	Pagination  Pagination
+	OrderOption OrderOption
+}
+
+func NewFindOption() *FindOption {
+	return &FindOption{
+		SearchMode: SearchMode_All,
+		Seek: Seek{
+			LastID: 0,
+			Count:  30,
+		},
+		Pagination: Pagination{
+			Limit:  30,
+			Offset: 0,
+		},
+		OrderOption: OrderOption{
+			Order: Order_ID,
+			Desc:  false,
+		},
+	}
+}
+
+func (f *FindOption) SetSeek(lastID LastID, count Count) *FindOption {
+	f.SearchMode = SearchMode_Seek
+	f.Seek = Seek{
+		LastID: lastID,
+		Count:  count,
+	}
+	return f
+}
+
+// シーク法の続きの位置の設定
+func (f *FindOption) SetCursor(cursor *Cursor) *FindOption {
+	f.Seek.Cursor = cursor
+	return f
+}
+
+// シーク法の位置と並び順の整合性
+// NOTE: カーソルは発行時と同じ並び順でのみ使え、LastIDはIDの並び順でのみ使える
+func (f *FindOption) ValidSeek() bool {
+	if f.SearchMode != SearchMode_Seek {
+		return true
+	}
+	if cursor := f.Seek.Cursor; cursor != nil {
+		return cursor.Order == f.OrderOption.Order && cursor.Desc == f.OrderOption.Desc
+	}
+	return f.Seek.LastID == 0 || f.OrderOption.Order == Order_ID
+}
+
+func (f *FindOption) SetPagination(limit Limit, offset Offset) *FindOption {
+	f.SearchMode = SearchMode_Pagination
+	f.Pagination = Pagination{
+		Limit:  limit,
+		Offset: offset,
+	}
+	return f
+}
+
+func (f *FindOption) SetOrder(order Order, desc Desc) *FindOption {
+	f.OrderOption = OrderOption{
+		Order: order,
+		Desc:  desc,
+	}
+	return f
+}
@@ -0,0 +1,242 @@
+package series
+
+import "mysrtafes-backend/pkg/errors"
+
+type Repository interface {
+	SeriesCreate(*Series) (*Series, error)
+	SeriesRead(ID) (*Series, error)
+	SeriesFind(*FindOption) ([]*Series, *FindMeta, error)
+	SeriesUpdate(*Series) (*Series, error)
+	SeriesPatch(*Series, []Field) (*Series, error)
+	SeriesDelete(ID) error
+}
+
+type Server interface {
+	Create(*Series) (*Series, error)
+	Read(ID) (*Series, error)
+	Find(*FindOption) ([]*Series, *FindMeta, error)
+	Update(*Series) (*Series, error)
+	Patch(ID, Patcher) (*Series, error)
+	Delete(ID) error
+}
+
+// 部分更新の適用
+// NOTE: 保存済みのシリーズを受け取り、変更後のシリーズを返却する。引数のシリーズは変更しないこと
+type Patcher func(*Series) (*Series, error)
+
+type server struct {
+	repository Repository
+}
+
+func NewServer(repo Repository) Server {
+	return &server{repo}
+}
+
+// GameSeriesの作成
+func (s *server) Create(sr *Series) (*Series, error) {
+	// 名前のValidate
+	if !sr.Name.Valid() {
+		return nil, errors.NewInvalidRequest(
+			errors.Layer_Domain,
+			errors.NewInformation(
+				errors.ID_InvalidParams,
+				"",
+				[]errors.InvalidParams{
+					errors.NewInvalidParams("name", sr.Name),
+				},
+			),
+			"Name Valid error",
+		)
+	}
+	// DescriptionのValidate
+	if !sr.Description.Valid() {
+		return nil, errors.NewInvalidRequest(
+			errors.Layer_Domain,
+			errors.NewInformation(
+				errors.ID_InvalidParams,
+				"",
+				[]errors.InvalidParams{
+					errors.NewInvalidParams("description", sr.Description),
+				},
+			),
+			"Description Valid error",
+		)
+	}
+	return s.repository.SeriesCreate(sr)
+}
+
+// GameSeriesの検索
+func (s *server) Read(id ID) (*Series, error) {
+	// IDのValidate
+	if !id.Valid() {
+		return nil, errors.NewInvalidRequest(
+			errors.Layer_Domain,
+			errors.NewInformation(
+				errors.ID_InvalidParams,
+				"",
+				[]errors.InvalidParams{
+					errors.NewInvalidParams("id", id),
+				},
+			),
+			"ID Valid error",
+		)
+	}
+	return s.repository.SeriesRead(id)
+}
+
+// GameSeriesの複数検索
+func (s *server) Find(f *FindOption) ([]*Series, *FindMeta, error) {
+	// シーク法の位置のValidate
+	if !f.ValidSeek() {
+		return nil, nil, errors.NewInvalidRequest(
+			errors.Layer_Domain,
+			errors.NewInformation(
+				errors.ID_InvalidParams,
+				"cursor or last_id does not match order",
+				[]errors.InvalidParams{
+					errors.NewInvalidParams("order", f.OrderOption.Order),
+				},
+			),
+			"seek position Valid error",
+		)
+	}
+	return s.repository.SeriesFind(f)
+}
+
+// GameSeriesの更新
+func (s *server) Update(sr *Series) (*Series, error) {
+	// IDのValidate
+	if !sr.ID.Valid() {
+		return nil, errors.NewInvalidRequest(
+			errors.Layer_Domain,
+			errors.NewInformation(
+				errors.ID_InvalidParams,
+				"",
+				[]errors.InvalidParams{
+					errors.NewInvalidParams("id", sr.ID),
+				},
+			),
+			"ID Valid error",
+		)
+	}
+	// 名前のValidate
+	if !sr.Name.Valid() {
+		return nil, errors.NewInvalidRequest(
+			errors.Layer_Domain,
+			errors.NewInformation(
+				errors.ID_InvalidParams,
+				"",
+				[]errors.InvalidParams{
+					errors.NewInvalidParams("name", sr.Name),
+				},
+			),
+			"Name Valid error",
+		)
+	}
+	// DescriptionのValidate
+	if !sr.Description.Valid() {
+		return nil, errors.NewInvalidRequest(
+			errors.Layer_Domain,
+			errors.NewInformation(
+				errors.ID_InvalidParams,
+				"",
+				[]errors.InvalidParams{
+					errors.NewInvalidParams("description", sr.Description),
+				},
+			),
+			"Description Valid error",
+		)
+	}
+	return s.repository.SeriesUpdate(sr)
+}
+
+// GameSeriesの部分更新
+// NOTE: 保存済みのシリーズに変更を適用し、変更のあったフィールドのみ保存する
+func (s *server) Patch(id ID, patcher Patcher) (*Series, error) {
+	// IDのValidate
+	if !id.Valid() {
+		return nil, errors.NewInvalidRequest(
+			errors.Layer_Domain,
+			errors.NewInformation(
+				errors.ID_InvalidParams,
+				"",
+				[]errors.InvalidParams{
+					errors.NewInvalidParams("id", id),
+				},
+			),
+			"ID Valid error",
+		)
+	}
+	current, err := s.repository.SeriesRead(id)
+	if err != nil {
+		return nil, err
+	}
+	sr, err := patcher(current)
+	if err != nil {
+		return nil, err
+	}
+	// NOTE: IDは変更させない
+	sr.ID = current.ID
+	if err := validSeries(sr); err != nil {
+		return nil, err
+	}
+
+	fields := current.ChangedFields(sr)
+	if len(fields) == 0 {
+		return current, nil
+	}
+	return s.repository.SeriesPatch(sr, fields)
+}
+
+// GameSeriesの削除
+// NOTE: シリーズに属していたゲームは削除せず、シリーズの参照を解除する
+func (s *server) Delete(id ID) error {
+	if !id.Valid() {
+		return errors.NewInvalidRequest(
+			errors.Layer_Domain,
+			errors.NewInformation(
+				errors.ID_InvalidParams,
+				"",
+				[]errors.InvalidParams{
+					errors.NewInvalidParams("id", id),
+				},
+			),
+			"ID Valid error",
+		)
+	}
+	return s.repository.SeriesDelete(id)
+}
+
+// シリーズのValidate
+// NOTE: 部分更新で変更後のシリーズに対して使用する
+func validSeries(sr *Series) error {
+	// 名前のValidate
+	if !sr.Name.Valid() {
+		return errors.NewInvalidRequest(
+			errors.Layer_Domain,
+			errors.NewInformation(
+				errors.ID_InvalidParams,
+				"",
+				[]errors.InvalidParams{
+					errors.NewInvalidParams("name", sr.Name),
+				},
+			),
+			"Name Valid error",
+		)
+	}
+	// DescriptionのValidate
+	if !sr.Description.Valid() {
+		return errors.NewInvalidRequest(
+			errors.Layer_Domain,
+			errors.NewInformation(
+				errors.ID_InvalidParams,
+				"",
+				[]errors.InvalidParams{
+					errors.NewInvalidParams("description", sr.Description),
+				},
+			),
+			"Description Valid error",
+		)
+	}
+	return nil
+}
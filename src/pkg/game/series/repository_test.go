@@ -0,0 +1,724 @@
+package series
+
+import (
+	"fmt"
+	"math/rand"
+	"reflect"
+	"testing"
+)
+
+type repository struct {
+	series  *Series
+	list    []*Series
+	meta    *FindMeta
+	patched *Series
+	err     error
+	// flags
+	create, read, find, update, patch, delete bool
+}
+
+func (r repository) SeriesCreate(*Series) (*Series, error) {
+	if r.create {
+		return r.series, r.err
+	}
+	return nil, fmt.Errorf("failed create")
+}
+func (r repository) SeriesRead(ID) (*Series, error) {
+	if r.read {
+		return r.series, r.err
+	}
+	return nil, fmt.Errorf("failed read")
+}
+
+func (r repository) SeriesFind(*FindOption) ([]*Series, *FindMeta, error) {
+	if r.find {
+		return r.list, r.meta, r.err
+	}
+	return nil, nil, fmt.Errorf("failed find")
+}
+func (r repository) SeriesUpdate(*Series) (*Series, error) {
+	if r.update {
+		return r.series, r.err
+	}
+	return nil, fmt.Errorf("failed update")
+}
+func (r repository) SeriesPatch(*Series, []Field) (*Series, error) {
+	if r.patch {
+		return r.patched, r.err
+	}
+	return nil, fmt.Errorf("failed patch")
+}
+func (r repository) SeriesDelete(ID) error {
+	if r.delete {
+		return r.err
+	}
+	return fmt.Errorf("failed delete")
+}
+
+func TestNewServer(t *testing.T) {
+	type args struct {
+		repo Repository
+	}
+	tests := []struct {
+		name string
+		args args
+		want Server
+	}{
+		{
+			name: "new",
+			args: args{
+				repo: repository{},
+			},
+			want: &server{
+				repository: repository{},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := NewServer(tt.args.repo); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("NewServer() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_server_Create(t *testing.T) {
+	type fields struct {
+		repository Repository
+	}
+	type args struct {
+		t *Series
+	}
+	tests := []struct {
+		name    string
+		fields  fields
+		args    args
+		want    *Series
+		wantErr bool
+	}{
+		{
+			name: "OK",
+			fields: fields{
+				repository: repository{
+					series: &Series{
+						ID:          1,
+						Name:        "OK",
+						Description: "OKですよ",
+					},
+					create: true,
+				},
+			},
+			args: args{
+				t: &Series{
+					Name:        "OK",
+					Description: "OKですよ",
+				},
+			},
+			want: &Series{
+				ID:          1,
+				Name:        "OK",
+				Description: "OKですよ",
+			},
+		},
+		{
+			name: "名前のバリデートエラー",
+			fields: fields{
+				repository: repository{
+					series: nil,
+					err:    nil,
+					create: true,
+				},
+			},
+			args: args{
+				t: &Series{
+					Name:        "",
+					Description: "NGですよ",
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "説明のバリデートエラー",
+			fields: fields{
+				repository: repository{
+					series: nil,
+					err:    nil,
+					create: true,
+				},
+			},
+			args: args{
+				t: &Series{
+					Name: "NG",
+					Description: func() Description {
+						var letters = []rune("abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789")
+						s := make([]rune, 2049)
+						for i := range s {
+							s[i] = letters[rand.Intn(len(letters))]
+						}
+						return Description(s)
+					}(),
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "repositoryのエラー",
+			fields: fields{
+				repository: repository{
+					series: nil,
+					err:    fmt.Errorf("create error"),
+					create: true,
+				},
+			},
+			args: args{
+				t: &Series{
+					Name:        "NG",
+					Description: "NGですよ",
+				},
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := &server{
+				repository: tt.fields.repository,
+			}
+			got, err := s.Create(tt.args.t)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("server.Create() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("server.Create() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_server_Read(t *testing.T) {
+	type fields struct {
+		repository Repository
+	}
+	type args struct {
+		id ID
+	}
+	tests := []struct {
+		name    string
+		fields  fields
+		args    args
+		want    *Series
+		wantErr bool
+	}{
+		{
+			name: "OK",
+			fields: fields{
+				repository: repository{
+					series: &Series{
+						ID:          1,
+						Name:        "OK",
+						Description: "OKですよ",
+					},
+					read: true,
+				},
+			},
+			args: args{
+				id: 1,
+			},
+			want: &Series{
+				ID:          1,
+				Name:        "OK",
+				Description: "OKですよ",
+			},
+		},
+		{
+			name: "idのバリデートエラー",
+			fields: fields{
+				repository: repository{
+					series: &Series{
+						ID:          0,
+						Name:        "OK",
+						Description: "OKですよ",
+					},
+					read: true,
+				},
+			},
+			args: args{
+				id: 0,
+			},
+			wantErr: true,
+		},
+		{
+			name: "repositoryのエラー",
+			fields: fields{
+				repository: repository{
+					series: nil,
+					err:    fmt.Errorf("read error"),
+					read:   true,
+				},
+			},
+			args: args{
+				id: 1,
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := &server{
+				repository: tt.fields.repository,
+			}
+			got, err := s.Read(tt.args.id)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("server.Read() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("server.Read() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_server_Find(t *testing.T) {
+	type fields struct {
+		repository Repository
+	}
+	type args struct {
+		f *FindOption
+	}
+	tests := []struct {
+		name    string
+		fields  fields
+		args    args
+		want    []*Series
+		want1   *FindMeta
+		wantErr bool
+	}{
+		{
+			name: "OK",
+			fields: fields{
+				repository: repository{
+					list: []*Series{
+						{
+							ID:          1,
+							Name:        "OK",
+							Description: "OKですよ",
+						},
+						{
+							ID:          2,
+							Name:        "OK2",
+							Description: "OK2ですよ",
+						},
+					},
+					meta: &FindMeta{TotalCount: 2},
+					find: true,
+				},
+			},
+			args: args{
+				f: NewFindOption(),
+			},
+			want1: &FindMeta{TotalCount: 2},
+			want: []*Series{
+				{
+					ID:          1,
+					Name:        "OK",
+					Description: "OKですよ",
+				},
+				{
+					ID:          2,
+					Name:        "OK2",
+					Description: "OK2ですよ",
+				},
+			},
+		},
+		{
+			name: "並び順が異なるカーソル",
+			fields: fields{
+				repository: repository{
+					find: true,
+				},
+			},
+			args: args{
+				f: NewFindOption().SetSeek(0, 10).SetCursor(&Cursor{Order: Order_Name, ID: 3}),
+			},
+			wantErr: true,
+		},
+		{
+			name: "repositoryのエラー",
+			fields: fields{
+				repository: repository{
+					series: nil,
+					err:    fmt.Errorf("find error"),
+					find:   true,
+				},
+			},
+			args: args{
+				f: NewFindOption(),
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := &server{
+				repository: tt.fields.repository,
+			}
+			got, got1, err := s.Find(tt.args.f)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("server.Find() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("server.Find() = %v, want %v", got, tt.want)
+			}
+			if !reflect.DeepEqual(got1, tt.want1) {
+				t.Errorf("server.Find() got1 = %v, want %v", got1, tt.want1)
+			}
+		})
+	}
+}
+
+func Test_server_Update(t *testing.T) {
+	type fields struct {
+		repository Repository
+	}
+	type args struct {
+		t *Series
+	}
+	tests := []struct {
+		name    string
+		fields  fields
+		args    args
+		want    *Series
+		wantErr bool
+	}{
+		{
+			name: "OK",
+			fields: fields{
+				repository: repository{
+					series: &Series{
+						ID:          1,
+						Name:        "OK",
+						Description: "OKですよ",
+					},
+					update: true,
+				},
+			},
+			args: args{
+				t: &Series{
+					ID:          1,
+					Name:        "OK",
+					Description: "OKですよ",
+				},
+			},
+			want: &Series{
+				ID:          1,
+				Name:        "OK",
+				Description: "OKですよ",
+			},
+		},
+		{
+			name: "idのバリデートエラー",
+			fields: fields{
+				repository: repository{
+					series: &Series{
+						ID:          0,
+						Name:        "OK",
+						Description: "OKですよ",
+					},
+					update: true,
+				},
+			},
+			args: args{
+				t: &Series{
+					ID:          0,
+					Name:        "OK",
+					Description: "OKですよ",
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "Nameのバリデートエラー",
+			fields: fields{
+				repository: repository{
+					series: &Series{
+						ID:          1,
+						Name:        "NG",
+						Description: "NGですよ",
+					},
+					update: true,
+				},
+			},
+			args: args{
+				t: &Series{
+					ID:          1,
+					Name:        "",
+					Description: "NGですよ",
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "Descriptionのバリデートエラー",
+			fields: fields{
+				repository: repository{
+					series: &Series{
+						ID:          1,
+						Name:        "NG",
+						Description: "NGですよ",
+					},
+					update: true,
+				},
+			},
+			args: args{
+				t: &Series{
+					ID:   1,
+					Name: "NG",
+					Description: func() Description {
+						var letters = []rune("abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789")
+
+						s := make([]rune, 2049)
+						for i := range s {
+							s[i] = letters[rand.Intn(len(letters))]
+						}
+						return Description(s)
+					}(),
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "repositoryのエラー",
+			fields: fields{
+				repository: repository{
+					series: nil,
+					err:    fmt.Errorf("read error"),
+					update: true,
+				},
+			},
+			args: args{
+				t: &Series{
+					Name:        "OK",
+					Description: "OKですよ",
+				},
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := &server{
+				repository: tt.fields.repository,
+			}
+			got, err := s.Update(tt.args.t)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("server.Update() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("server.Update() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_server_Patch(t *testing.T) {
+	type fields struct {
+		repository Repository
+	}
+	type args struct {
+		id      ID
+		patcher Patcher
+	}
+	tests := []struct {
+		name    string
+		fields  fields
+		args    args
+		want    *Series
+		wantErr bool
+	}{
+		{
+			name: "OK",
+			fields: fields{
+				repository: repository{
+					series: &Series{
+						ID:          1,
+						Name:        "OK",
+						Description: "OKですよ",
+					},
+					patched: &Series{
+						ID:          1,
+						Name:        "OK",
+						Description: "変更しました",
+					},
+					read:  true,
+					patch: true,
+				},
+			},
+			args: args{
+				id: 1,
+				patcher: func(t *Series) (*Series, error) {
+					return NewWithID(t.ID, t.Name, "変更しました"), nil
+				},
+			},
+			want: &Series{
+				ID:          1,
+				Name:        "OK",
+				Description: "変更しました",
+			},
+		},
+		{
+			name: "変更なしは保存しない",
+			fields: fields{
+				repository: repository{
+					series: &Series{
+						ID:          1,
+						Name:        "OK",
+						Description: "OKですよ",
+					},
+					read: true,
+				},
+			},
+			args: args{
+				id: 1,
+				patcher: func(t *Series) (*Series, error) {
+					return NewWithID(t.ID, t.Name, t.Description), nil
+				},
+			},
+			want: &Series{
+				ID:          1,
+				Name:        "OK",
+				Description: "OKですよ",
+			},
+		},
+		{
+			name: "idのバリデートエラー",
+			fields: fields{
+				repository: repository{
+					read:  true,
+					patch: true,
+				},
+			},
+			args: args{
+				id: 0,
+				patcher: func(t *Series) (*Series, error) {
+					return t, nil
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "取得エラー",
+			fields: fields{
+				repository: repository{
+					patch: true,
+				},
+			},
+			args: args{
+				id: 1,
+				patcher: func(t *Series) (*Series, error) {
+					return t, nil
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "Nameのバリデートエラー",
+			fields: fields{
+				repository: repository{
+					series: &Series{
+						ID:          1,
+						Name:        "OK",
+						Description: "OKですよ",
+					},
+					read:  true,
+					patch: true,
+				},
+			},
+			args: args{
+				id: 1,
+				patcher: func(t *Series) (*Series, error) {
+					return NewWithID(t.ID, "", t.Description), nil
+				},
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := &server{
+				repository: tt.fields.repository,
+			}
+			got, err := s.Patch(tt.args.id, tt.args.patcher)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("server.Patch() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("server.Patch() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_server_Delete(t *testing.T) {
+	type fields struct {
+		repository Repository
+	}
+	type args struct {
+		id ID
+	}
+	tests := []struct {
+		name    string
+		fields  fields
+		args    args
+		wantErr bool
+	}{
+		{
+			name: "OK",
+			fields: fields{
+				repository: repository{
+					series: &Series{
+						ID:          1,
+						Name:        "OK",
+						Description: "OKですよ",
+					},
+					delete: true,
+				},
+			},
+			args: args{
+				id: 1,
+			},
+		},
+		{
+			name: "idのバリデートエラー",
+			fields: fields{
+				repository: repository{
+					series: &Series{
+						ID:          0,
+						Name:        "OK",
+						Description: "OKですよ",
+					},
+					delete: true,
+				},
+			},
+			args: args{
+				id: 0,
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := &server{
+				repository: tt.fields.repository,
+			}
+			if err := s.Delete(tt.args.id); (err != nil) != tt.wantErr {
+				t.Errorf("server.Delete() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
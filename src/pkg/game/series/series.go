@@ -0,0 +1,75 @@
+package series
+
+import (
+	"time"
+)
+
+// SeriesID
+type ID uint64
+
+// 1 ≦ id
+func (i ID) Valid() bool {
+	return i > 0
+}
+
+// シリーズ名
+type Name string
+
+// 1 ≦ name.length ≦ 255
+func (n Name) Valid() bool {
+	return len(n) > 0 && len(n) < 256
+}
+
+// シリーズ説明
+type Description string
+
+// 0 ≦ Description.length ≦ 2048
+func (d Description) Valid() bool {
+	// NOTE: 説明は空でもOK
+	return len(d) >= 0 && len(d) <= 2048
+}
+
+// シリーズ(風来のシレン、トルネコ、ポケダン、チョコボなど)
+// NOTE: ゲームはいずれか1つのシリーズに属する
+type Series struct {
+	ID          ID
+	Name        Name
+	Description Description
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+}
+
+func New(name Name, description Description) *Series {
+	return &Series{
+		Name:        name,
+		Description: description,
+	}
+}
+
+func NewWithID(id ID, name Name, description Description) *Series {
+	return &Series{
+		ID:          id,
+		Name:        name,
+		Description: description,
+	}
+}
+
+// 更新対象のフィールド
+type Field uint8
+
+const (
+	Field_Name Field = iota
+	Field_Description
+)
+
+// 変更されたフィールド
+func (s *Series) ChangedFields(after *Series) []Field {
+	fields := []Field{}
+	if s.Name != after.Name {
+		fields = append(fields, Field_Name)
+	}
+	if s.Description != after.Description {
+		fields = append(fields, Field_Description)
+	}
+	return fields
+}
@@ -0,0 +1,205 @@
+package series
+
+import (
+	"math/rand"
+	"reflect"
+	"testing"
+)
+
+func TestName_Valid(t *testing.T) {
+	tests := []struct {
+		name string
+		n    Name
+		want bool
+	}{
+		{
+			name: "OK",
+			n:    "風来のシレン",
+			want: true,
+		},
+		{
+			name: "空文字",
+			n:    "",
+			want: false,
+		},
+		{
+			name: "長すぎる文字列",
+			n: func() Name {
+				var letters = []rune("abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789")
+
+				s := make([]rune, 256)
+				for i := range s {
+					s[i] = letters[rand.Intn(len(letters))]
+				}
+				return Name(s)
+			}(),
+			want: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.n.Valid(); got != tt.want {
+				t.Errorf("Name.Valid() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDescription_Valid(t *testing.T) {
+	tests := []struct {
+		name string
+		d    Description
+		want bool
+	}{
+		{
+			name: "OK",
+			d:    "古き良きローグライクです",
+			want: true,
+		},
+		{
+			name: "空文字",
+			d:    "",
+			want: true,
+		},
+		{
+			name: "長すぎる文字列",
+			d: func() Description {
+				var letters = []rune("abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789")
+
+				s := make([]rune, 2049)
+				for i := range s {
+					s[i] = letters[rand.Intn(len(letters))]
+				}
+				return Description(s)
+			}(),
+			want: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.d.Valid(); got != tt.want {
+				t.Errorf("Description.Valid() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestID_Valid(t *testing.T) {
+	tests := []struct {
+		name string
+		i    ID
+		want bool
+	}{
+		{
+			name: "OK",
+			i:    1,
+			want: true,
+		},
+		{
+			name: "NG",
+			i:    0,
+			want: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.i.Valid(); got != tt.want {
+				t.Errorf("ID.Valid() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNew(t *testing.T) {
+	type args struct {
+		name        Name
+		description Description
+	}
+	tests := []struct {
+		name string
+		args args
+		want *Series
+	}{
+		{
+			name: "OK",
+			args: args{
+				name:        "風来のシレン",
+				description: "面白いゲームです",
+			},
+			want: &Series{
+				Name:        "風来のシレン",
+				Description: "面白いゲームです",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := New(tt.args.name, tt.args.description); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("New() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNewWithID(t *testing.T) {
+	type args struct {
+		id          ID
+		name        Name
+		description Description
+	}
+	tests := []struct {
+		name string
+		args args
+		want *Series
+	}{
+		{
+			name: "OK",
+			args: args{
+				id:          1000006,
+				name:        "トルネコの大冒険",
+				description: "面白いゲームです2",
+			},
+			want: &Series{
+				ID:          1000006,
+				Name:        "トルネコの大冒険",
+				Description: "面白いゲームです2",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := NewWithID(tt.args.id, tt.args.name, tt.args.description); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("NewWithID() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSeries_ChangedFields(t *testing.T) {
+	tests := []struct {
+		name   string
+		before *Series
+		after  *Series
+		want   []Field
+	}{
+		{
+			name:   "変更なし",
+			before: NewWithID(1, "風来のシレン", "ローグライク"),
+			after:  NewWithID(1, "風来のシレン", "ローグライク"),
+			want:   []Field{},
+		},
+		{
+			name:   "名前と説明の変更",
+			before: NewWithID(1, "風来のシレン", "ローグライク"),
+			after:  NewWithID(1, "シレン", "不思議のダンジョン"),
+			want:   []Field{Field_Name, Field_Description},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.before.ChangedFields(tt.after); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Series.ChangedFields() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
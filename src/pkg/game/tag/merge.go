@@ -0,0 +1,81 @@
+package tag
+
+import (
+	"mysrtafes-backend/pkg/errors"
+)
+
+// 統合後のタグ
+// NOTE: 統合先(t)の別名に統合元の名前・別名を重複なく加える。引数のタグは変更しない
+func (t *Tag) Merge(source *Tag) *Tag {
+	merged := *t
+
+	synonyms := make([]Name, 0, len(t.Synonyms)+len(source.Synonyms)+1)
+	exists := map[Name]bool{t.Name: true}
+	appendSynonym := func(name Name) {
+		if exists[name] {
+			return
+		}
+		exists[name] = true
+		synonyms = append(synonyms, name)
+	}
+	for _, synonym := range t.Synonyms {
+		appendSynonym(synonym)
+	}
+	appendSynonym(source.Name)
+	for _, synonym := range source.Synonyms {
+		appendSynonym(synonym)
+	}
+	merged.Synonyms = synonyms
+	return &merged
+}
+
+// GameTagの統合
+// NOTE: sourceIDのタグ(統合元)をtargetIDのタグ(統合先)に統合する。
+// ゲームとの関連と子タグは統合先に移し、統合元の名前は統合先の別名として残し、統合元は削除する
+func (s *server) Merge(sourceID ID, targetID ID) (*Tag, error) {
+	// SourceIDのValidate
+	if !sourceID.Valid() {
+		return nil, errors.NewInvalidRequest(
+			errors.Layer_Domain,
+			errors.NewInformation(
+				errors.ID_InvalidParams,
+				"",
+				[]errors.InvalidParams{
+					errors.NewInvalidParams("id", sourceID),
+				},
+			),
+			"ID Valid error",
+		)
+	}
+	// TargetIDのValidate
+	if !targetID.Valid() {
+		return nil, errors.NewInvalidRequest(
+			errors.Layer_Domain,
+			errors.NewInformation(
+				errors.ID_InvalidParams,
+				"",
+				[]errors.InvalidParams{
+					errors.NewInvalidParams("target_id", targetID),
+				},
+			),
+			"target_id Valid error",
+		)
+	}
+	// 自己統合のValidate
+	if sourceID == targetID {
+		return nil, errors.NewInvalidRequest(
+			errors.Layer_Domain,
+			errors.NewInformation(
+				errors.ID_InvalidParams,
+				"a tag cannot be merged into itself",
+				[]errors.InvalidParams{
+					errors.NewInvalidParams("target_id", targetID),
+				},
+			),
+			"target_id Valid error",
+		)
+	}
+
+	// NOTE: 統合元・統合先の読み込みと、統合先が統合元の子孫でないことの確認は、統合と同じトランザクションで行う
+	return s.repository.TagMerge(sourceID, targetID, (*Tag).Merge)
+}
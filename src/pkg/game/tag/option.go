@@ -1,5 +1,7 @@
 package tag
 
+import "time"
+
 type SearchMode uint8
 
 const (
@@ -13,6 +15,20 @@ type Count = int
 type Seek struct {
 	LastID LastID
 	Count  Count
+	// NOTE: 指定されたときはLastIDより優先する
+	Cursor *Cursor
+}
+
+// シーク法の続きの位置
+// NOTE: 最後に取得したものの並び替えの値とIDの組で位置を表す
+// 並び順ごとに使う値が異なる(名前: Name、更新日時: UpdatedAt、ゲームの数: GameCount)
+type Cursor struct {
+	Order     Order
+	Desc      Desc
+	ID        ID
+	Name      Name
+	UpdatedAt time.Time
+	GameCount GameCount
 }
 
 type Limit = int
@@ -23,11 +39,55 @@ type Pagination struct {
 	Offset Offset
 }
 
+type TotalCount = int64
+
+// 検索結果のメタ情報
+// NOTE: 次の取得位置は次ページがあるときのみ設定する
+type FindMeta struct {
+	TotalCount TotalCount
+	HasNext    bool
+	NextLastID LastID
+	NextOffset Offset
+	NextCursor *Cursor
+}
+
+// 次ページの位置の設定
+// NOTE: 次ページがあるときのみ設定する
+func (m *FindMeta) SetNextCursor(cursor *Cursor) *FindMeta {
+	if m.HasNext {
+		m.NextCursor = cursor
+	}
+	return m
+}
+
+// 検索結果のメタ情報の生成
+// NOTE: idsは取得した行のIDを並び順のまま指定する。次ページは並び順によらず最後の行の後ろから始まる
+func NewFindMeta(f *FindOption, totalCount TotalCount, hasNext bool, ids []ID) *FindMeta {
+	meta := &FindMeta{
+		TotalCount: totalCount,
+		HasNext:    hasNext,
+	}
+	if !hasNext {
+		return meta
+	}
+	switch f.SearchMode {
+	case SearchMode_Seek:
+		if len(ids) > 0 {
+			meta.NextLastID = ids[len(ids)-1]
+		}
+	case SearchMode_Pagination:
+		meta.NextOffset = f.Pagination.Offset + len(ids)
+	}
+	return meta
+}
+
 type Order uint8
 
 const (
 	Order_ID Order = iota
 	Order_Name
+	Order_UpdatedAt
+	Order_GameCount
 )
 
 type Desc = bool
@@ -43,6 +103,12 @@ type FindOption struct {
 	Seek        Seek
 	Pagination  Pagination
 	OrderOption OrderOption
+	// NOTE: trueのときはタグを使うゲームの数を返却する
+	WithGameCount bool
+	// NOTE: trueのときはどのゲームにも使われていないタグのみに絞り込む
+	Unused bool
+	// NOTE: 指定があるときはいずれかの分類のタグに絞り込む
+	Categories []Category
 }
 
 func NewFindOption() *FindOption {
@@ -72,6 +138,24 @@ func (f *FindOption) SetSeek(lastID LastID, count Count) *FindOption {
 	return f
 }
 
+// シーク法の続きの位置の設定
+func (f *FindOption) SetCursor(cursor *Cursor) *FindOption {
+	f.Seek.Cursor = cursor
+	return f
+}
+
+// シーク法の位置と並び順の整合性
+// NOTE: カーソルは発行時と同じ並び順でのみ使え、LastIDはIDの並び順でのみ使える
+func (f *FindOption) ValidSeek() bool {
+	if f.SearchMode != SearchMode_Seek {
+		return true
+	}
+	if cursor := f.Seek.Cursor; cursor != nil {
+		return cursor.Order == f.OrderOption.Order && cursor.Desc == f.OrderOption.Desc
+	}
+	return f.Seek.LastID == 0 || f.OrderOption.Order == Order_ID
+}
+
 func (f *FindOption) SetPagination(limit Limit, offset Offset) *FindOption {
 	f.SearchMode = SearchMode_Pagination
 	f.Pagination = Pagination{
@@ -88,3 +172,30 @@ func (f *FindOption) SetOrder(order Order, desc Desc) *FindOption {
 	}
 	return f
 }
+
+// タグを使うゲームの数を返却するかの設定
+func (f *FindOption) SetWithGameCount(withGameCount bool) *FindOption {
+	f.WithGameCount = withGameCount
+	return f
+}
+
+// 未使用のタグの絞り込みの設定
+func (f *FindOption) SetUnused(unused bool) *FindOption {
+	f.Unused = unused
+	return f
+}
+
+// 分類の絞り込みの設定
+// NOTE: 重複は除く
+func (f *FindOption) SetCategories(categories []Category) *FindOption {
+	f.Categories = make([]Category, 0, len(categories))
+	exists := make(map[Category]bool, len(categories))
+	for _, category := range categories {
+		if exists[category] {
+			continue
+		}
+		exists[category] = true
+		f.Categories = append(f.Categories, category)
+	}
+	return f
+}
@@ -243,3 +243,150 @@ func TestFindOption_SetOrder(t *testing.T) {
 		})
 	}
 }
+
+func TestNewFindMeta(t *testing.T) {
+	type args struct {
+		f          *FindOption
+		totalCount TotalCount
+		hasNext    bool
+		ids        []ID
+	}
+	tests := []struct {
+		name string
+		args args
+		want *FindMeta
+	}{
+		{
+			name: "seekの次ページあり",
+			args: args{
+				f:          NewFindOption().SetSeek(10, 5),
+				totalCount: 30,
+				hasNext:    true,
+				ids:        []ID{16, 17, 18, 19, 20},
+			},
+			want: &FindMeta{
+				TotalCount: 30,
+				HasNext:    true,
+				NextLastID: 20,
+			},
+		},
+		{
+			name: "seek(降順)の次ページは最後の行から",
+			args: args{
+				f:          NewFindOption().SetSeek(25, 5).SetOrder(Order_ID, true),
+				totalCount: 30,
+				hasNext:    true,
+				ids:        []ID{24, 23, 22, 21, 20},
+			},
+			want: &FindMeta{
+				TotalCount: 30,
+				HasNext:    true,
+				NextLastID: 20,
+			},
+		},
+		{
+			name: "paginationの次ページあり",
+			args: args{
+				f:          NewFindOption().SetPagination(5, 10),
+				totalCount: 30,
+				hasNext:    true,
+				ids:        []ID{16, 17, 18, 19, 20},
+			},
+			want: &FindMeta{
+				TotalCount: 30,
+				HasNext:    true,
+				NextOffset: 15,
+			},
+		},
+		{
+			name: "次ページなし",
+			args: args{
+				f:          NewFindOption().SetPagination(5, 25),
+				totalCount: 30,
+				hasNext:    false,
+				ids:        []ID{26, 27, 28, 29, 30},
+			},
+			want: &FindMeta{
+				TotalCount: 30,
+			},
+		},
+		{
+			name: "全件取得",
+			args: args{
+				f:          NewFindOption(),
+				totalCount: 30,
+				ids:        []ID{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16, 17, 18, 19, 20, 21, 22, 23, 24, 25, 26, 27, 28, 29, 30},
+			},
+			want: &FindMeta{
+				TotalCount: 30,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, NewFindMeta(tt.args.f, tt.args.totalCount, tt.args.hasNext, tt.args.ids))
+		})
+	}
+}
+
+func TestFindOption_ValidSeek(t *testing.T) {
+	tests := []struct {
+		name string
+		f    *FindOption
+		want bool
+	}{
+		{
+			name: "シーク法以外",
+			f:    NewFindOption().SetPagination(10, 0).SetOrder(Order_Name, false),
+			want: true,
+		},
+		{
+			name: "並び順が同じカーソル",
+			f: NewFindOption().SetSeek(0, 10).SetOrder(Order_Name, true).
+				SetCursor(&Cursor{Order: Order_Name, Desc: true, ID: 3}),
+			want: true,
+		},
+		{
+			name: "並び順が異なるカーソル",
+			f: NewFindOption().SetSeek(0, 10).SetOrder(Order_Name, false).
+				SetCursor(&Cursor{Order: Order_Name, Desc: true, ID: 3}),
+			want: false,
+		},
+		{
+			name: "IDの並び順でのlast_id",
+			f:    NewFindOption().SetSeek(3, 10).SetOrder(Order_ID, true),
+			want: true,
+		},
+		{
+			name: "IDの並び順以外でのlast_id",
+			f:    NewFindOption().SetSeek(3, 10).SetOrder(Order_UpdatedAt, false),
+			want: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.f.ValidSeek())
+		})
+	}
+}
+
+func TestFindMeta_SetNextCursor(t *testing.T) {
+	cursor := &Cursor{Order: Order_Name, ID: 3}
+	assert.Equal(t, cursor, NewFindMeta(NewFindOption().SetSeek(0, 1), 5, true, []ID{3}).SetNextCursor(cursor).NextCursor)
+	assert.Nil(t, NewFindMeta(NewFindOption().SetSeek(0, 1), 1, false, []ID{3}).SetNextCursor(cursor).NextCursor)
+}
+
+func TestFindOption_SetWithGameCount(t *testing.T) {
+	assert.True(t, NewFindOption().SetWithGameCount(true).WithGameCount)
+	assert.False(t, NewFindOption().SetWithGameCount(true).SetWithGameCount(false).WithGameCount)
+}
+
+func TestFindOption_SetUnused(t *testing.T) {
+	assert.True(t, NewFindOption().SetUnused(true).Unused)
+	assert.False(t, NewFindOption().SetUnused(true).SetUnused(false).Unused)
+}
+
+func TestFindOption_SetCategories(t *testing.T) {
+	got := NewFindOption().SetCategories([]Category{Category_Genre, Category_Mechanic, Category_Genre})
+	assert.Equal(t, []Category{Category_Genre, Category_Mechanic}, got.Categories)
+}
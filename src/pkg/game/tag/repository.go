@@ -1,23 +1,44 @@
 package tag
 
-import "mysrtafes-backend/pkg/errors"
+import (
+	"mysrtafes-backend/pkg/errors"
+	"mysrtafes-backend/pkg/version"
+)
 
 type Repository interface {
 	TagCreate(*Tag) (*Tag, error)
 	TagRead(ID) (*Tag, error)
-	TagFind(*FindOption) ([]*Tag, error)
-	TagUpdate(*Tag) (*Tag, error)
-	TagDelete(ID) error
+	TagFind(*FindOption) ([]*Tag, *FindMeta, error)
+	TagUpdate(*Tag, *version.Precondition) (*Tag, error)
+	TagPatch(*Tag, []Field, *version.Precondition) (*Tag, error)
+	TagDelete(ID, *version.Precondition) error
+	TagTrash(*FindOption) ([]*Tag, *FindMeta, error)
+	TagRestore(ID) (*Tag, error)
+	TagPurge(ID) error
+	TagMerge(ID, ID, Merger) (*Tag, error)
 }
 
 type Server interface {
 	Create(*Tag) (*Tag, error)
 	Read(ID) (*Tag, error)
-	Find(*FindOption) ([]*Tag, error)
-	Update(*Tag) (*Tag, error)
-	Delete(ID) error
+	Find(*FindOption) ([]*Tag, *FindMeta, error)
+	Update(*Tag, *version.Precondition) (*Tag, error)
+	Patch(ID, Patcher, *version.Precondition) (*Tag, error)
+	Delete(ID, *version.Precondition) error
+	Trash(*FindOption) ([]*Tag, *FindMeta, error)
+	Restore(ID) (*Tag, error)
+	Purge(ID) error
+	Merge(ID, ID) (*Tag, error)
 }
 
+// 部分更新の適用
+// NOTE: 保存済みのタグを受け取り、変更後のタグを返却する。引数のタグは変更しないこと
+type Patcher func(*Tag) (*Tag, error)
+
+// 統合の適用
+// NOTE: 保存と同じトランザクションで読み込んだ統合先・統合元を受け取り、統合後のタグを返却する
+type Merger func(target *Tag, source *Tag) *Tag
+
 type server struct {
 	repository Repository
 }
@@ -56,6 +77,24 @@ func (s *server) Create(t *Tag) (*Tag, error) {
 			"Description Valid error",
 		)
 	}
+	// 分類のValidate
+	if !t.Category.Valid() {
+		return nil, errors.NewInvalidRequest(
+			errors.Layer_Domain,
+			errors.NewInformation(
+				errors.ID_InvalidParams,
+				"",
+				[]errors.InvalidParams{
+					errors.NewInvalidParams("category", t.Category),
+				},
+			),
+			"Category Valid error",
+		)
+	}
+	// 親タグのValidate
+	if err := s.validParent(t); err != nil {
+		return nil, err
+	}
 	return s.repository.TagCreate(t)
 }
 
@@ -79,12 +118,51 @@ func (s *server) Read(id ID) (*Tag, error) {
 }
 
 // GameTagの複数検索
-func (s *server) Find(f *FindOption) ([]*Tag, error) {
+func (s *server) Find(f *FindOption) ([]*Tag, *FindMeta, error) {
+	if err := validFindOption(f); err != nil {
+		return nil, nil, err
+	}
 	return s.repository.TagFind(f)
 }
 
+// 検索オプションのValidate
+// NOTE: ゴミ箱の検索も同じ検索オプションを使う
+func validFindOption(f *FindOption) error {
+	// 分類のValidate
+	for _, category := range f.Categories {
+		if !category.Valid() {
+			return errors.NewInvalidRequest(
+				errors.Layer_Domain,
+				errors.NewInformation(
+					errors.ID_InvalidParams,
+					"",
+					[]errors.InvalidParams{
+						errors.NewInvalidParams("categories", category),
+					},
+				),
+				"categories Valid error",
+			)
+		}
+	}
+	// シーク法の位置のValidate
+	if !f.ValidSeek() {
+		return errors.NewInvalidRequest(
+			errors.Layer_Domain,
+			errors.NewInformation(
+				errors.ID_InvalidParams,
+				"cursor or last_id does not match order",
+				[]errors.InvalidParams{
+					errors.NewInvalidParams("order", f.OrderOption.Order),
+				},
+			),
+			"seek position Valid error",
+		)
+	}
+	return nil
+}
+
 // GameTagの更新
-func (s *server) Update(t *Tag) (*Tag, error) {
+func (s *server) Update(t *Tag, precondition *version.Precondition) (*Tag, error) {
 	// IDのValidate
 	if !t.ID.Valid() {
 		return nil, errors.NewInvalidRequest(
@@ -127,11 +205,92 @@ func (s *server) Update(t *Tag) (*Tag, error) {
 			"Description Valid error",
 		)
 	}
-	return s.repository.TagUpdate(t)
+	// 分類のValidate
+	if !t.Category.Valid() {
+		return nil, errors.NewInvalidRequest(
+			errors.Layer_Domain,
+			errors.NewInformation(
+				errors.ID_InvalidParams,
+				"",
+				[]errors.InvalidParams{
+					errors.NewInvalidParams("category", t.Category),
+				},
+			),
+			"Category Valid error",
+		)
+	}
+	// 親タグのValidate
+	// NOTE: 親タグを変更したときのみ確認する。ゴミ箱にある親タグの下にはそのまま残せる
+	if t.ParentID != 0 {
+		current, err := s.repository.TagRead(t.ID)
+		if err != nil {
+			return nil, err
+		}
+		if t.ParentID != current.ParentID {
+			if err := s.validParent(t); err != nil {
+				return nil, err
+			}
+		}
+	}
+	// NOTE: 前提条件(If-Match)は保存と同じトランザクションで保存済みの版と比べる
+	return s.repository.TagUpdate(t, precondition)
+}
+
+// GameTagの部分更新
+// NOTE: 保存済みのタグに変更を適用し、変更のあったフィールドのみ保存する
+func (s *server) Patch(id ID, patcher Patcher, precondition *version.Precondition) (*Tag, error) {
+	// IDのValidate
+	if !id.Valid() {
+		return nil, errors.NewInvalidRequest(
+			errors.Layer_Domain,
+			errors.NewInformation(
+				errors.ID_InvalidParams,
+				"",
+				[]errors.InvalidParams{
+					errors.NewInvalidParams("id", id),
+				},
+			),
+			"ID Valid error",
+		)
+	}
+	current, err := s.repository.TagRead(id)
+	if err != nil {
+		return nil, err
+	}
+	// 前提条件のチェック
+	if err := precondition.Check(current.UpdatedAt); err != nil {
+		return nil, err
+	}
+	t, err := patcher(current)
+	if err != nil {
+		return nil, err
+	}
+	// NOTE: IDは変更させない
+	t.ID = current.ID
+	if err := validTag(t); err != nil {
+		return nil, err
+	}
+
+	fields := current.ChangedFields(t)
+	if len(fields) == 0 {
+		return current, nil
+	}
+	// 親タグのValidate
+	// NOTE: 親タグを変更したときのみ確認する
+	if t.ParentID != current.ParentID {
+		if err := s.validParent(t); err != nil {
+			return nil, err
+		}
+	}
+	// NOTE: If-Matchの指定があるときは、読み込んでから保存するまでに更新されていないことも保存時に確認する
+	if precondition != nil {
+		precondition = version.NewPreconditionAt(current.UpdatedAt)
+	}
+	return s.repository.TagPatch(t, fields, precondition)
 }
 
 // GameTagの削除
-func (s *server) Delete(id ID) error {
+func (s *server) Delete(id ID, precondition *version.Precondition) error {
 	if !id.Valid() {
 		return errors.NewInvalidRequest(
 			errors.Layer_Domain,
@@ -145,5 +304,141 @@ func (s *server) Delete(id ID) error {
 			"ID Valid error",
 		)
 	}
-	return s.repository.TagDelete(id)
+	// NOTE: 前提条件(If-Match)は保存と同じトランザクションで保存済みの版と比べる
+	return s.repository.TagDelete(id, precondition)
+}
+
+// GameTagのゴミ箱の検索
+func (s *server) Trash(f *FindOption) ([]*Tag, *FindMeta, error) {
+	if err := validFindOption(f); err != nil {
+		return nil, nil, err
+	}
+	return s.repository.TagTrash(f)
+}
+
+// GameTagのゴミ箱からの復元
+// NOTE: 削除前の関連も復元する
+func (s *server) Restore(id ID) (*Tag, error) {
+	// IDのValidate
+	if !id.Valid() {
+		return nil, errors.NewInvalidRequest(
+			errors.Layer_Domain,
+			errors.NewInformation(
+				errors.ID_InvalidParams,
+				"",
+				[]errors.InvalidParams{
+					errors.NewInvalidParams("id", id),
+				},
+			),
+			"ID Valid error",
+		)
+	}
+	return s.repository.TagRestore(id)
+}
+
+// GameTagの完全削除
+// NOTE: ゴミ箱にあるもののみ対象とする
+func (s *server) Purge(id ID) error {
+	// IDのValidate
+	if !id.Valid() {
+		return errors.NewInvalidRequest(
+			errors.Layer_Domain,
+			errors.NewInformation(
+				errors.ID_InvalidParams,
+				"",
+				[]errors.InvalidParams{
+					errors.NewInvalidParams("id", id),
+				},
+			),
+			"ID Valid error",
+		)
+	}
+	return s.repository.TagPurge(id)
+}
+
+// タグのValidate
+// NOTE: 部分更新で変更後のタグに対して使用する
+func validTag(t *Tag) error {
+	// 名前のValidate
+	if !t.Name.Valid() {
+		return errors.NewInvalidRequest(
+			errors.Layer_Domain,
+			errors.NewInformation(
+				errors.ID_InvalidParams,
+				"",
+				[]errors.InvalidParams{
+					errors.NewInvalidParams("name", t.Name),
+				},
+			),
+			"Name Valid error",
+		)
+	}
+	// DescriptionのValidate
+	if !t.Description.Valid() {
+		return errors.NewInvalidRequest(
+			errors.Layer_Domain,
+			errors.NewInformation(
+				errors.ID_InvalidParams,
+				"",
+				[]errors.InvalidParams{
+					errors.NewInvalidParams("description", t.Description),
+				},
+			),
+			"Description Valid error",
+		)
+	}
+	// 分類のValidate
+	if !t.Category.Valid() {
+		return errors.NewInvalidRequest(
+			errors.Layer_Domain,
+			errors.NewInformation(
+				errors.ID_InvalidParams,
+				"",
+				[]errors.InvalidParams{
+					errors.NewInvalidParams("category", t.Category),
+				},
+			),
+			"Category Valid error",
+		)
+	}
+	return nil
+}
+
+// 親タグのValidate
+// NOTE: 新しい親タグが自身でなく、ゴミ箱にないことを確認する。
+// 親タグをたどって自身に戻らない(循環しない)ことは、ゴミ箱にある祖先も含めて保存と同じトランザクションで確認する
+func (s *server) validParent(t *Tag) error {
+	if t.ParentID == 0 {
+		return nil
+	}
+	if t.ParentID == t.ID {
+		return errors.NewInvalidRequest(
+			errors.Layer_Domain,
+			errors.NewInformation(
+				errors.ID_InvalidParams,
+				"parent tag is cyclic",
+				[]errors.InvalidParams{
+					errors.NewInvalidParams("parent_id", t.ParentID),
+				},
+			),
+			"Parent cycle error",
+		)
+	}
+	if _, err := s.repository.TagRead(t.ParentID); err != nil {
+		if _, ok := err.(errors.NotFoundError); ok {
+			return errors.NewInvalidRequest(
+				errors.Layer_Domain,
+				errors.NewInformation(
+					errors.ID_InvalidParams,
+					"parent tag is nothing",
+					[]errors.InvalidParams{
+						errors.NewInvalidParams("parent_id", t.ParentID),
+					},
+				),
+				"Parent Valid error",
+			)
+		}
+		return err
+	}
+	return nil
 }
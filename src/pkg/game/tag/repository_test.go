@@ -3,16 +3,25 @@ package tag
 import (
 	"fmt"
 	"math/rand"
+	"mysrtafes-backend/pkg/errors"
+	"mysrtafes-backend/pkg/version"
 	"reflect"
 	"testing"
+	"time"
 )
 
 type repository struct {
-	tag  *Tag
-	tags []*Tag
-	err  error
+	tag     *Tag
+	tags    []*Tag
+	meta    *FindMeta
+	patched *Tag
+	// NOTE: 指定があるときはTagReadでIDごとのタグを返却し、ないIDはNotFoundとする
+	tagsByID map[ID]*Tag
+	// NOTE: 指定があるときは保存時に読み込む更新日時とし、ないときはtagの更新日時とする
+	stored *time.Time
+	err    error
 	// flags
-	create, read, find, update, delete bool
+	create, read, find, update, patch, delete, trash, restore, purge, merge bool
 }
 
 func (r repository) TagCreate(*Tag) (*Tag, error) {
@@ -21,32 +30,103 @@ func (r repository) TagCreate(*Tag) (*Tag, error) {
 	}
 	return nil, fmt.Errorf("failed create")
 }
-func (r repository) TagRead(ID) (*Tag, error) {
+func (r repository) TagRead(id ID) (*Tag, error) {
+	if r.read && r.tagsByID != nil {
+		if t, ok := r.tagsByID[id]; ok {
+			return t, nil
+		}
+		return nil, errors.NewNotFound(errors.Layer_Model, nil, "not found")
+	}
 	if r.read {
 		return r.tag, r.err
 	}
 	return nil, fmt.Errorf("failed read")
 }
 
-func (r repository) TagFind(*FindOption) ([]*Tag, error) {
+func (r repository) TagFind(*FindOption) ([]*Tag, *FindMeta, error) {
 	if r.find {
-		return r.tags, r.err
+		return r.tags, r.meta, r.err
 	}
-	return nil, fmt.Errorf("failed find")
+	return nil, nil, fmt.Errorf("failed find")
 }
-func (r repository) TagUpdate(*Tag) (*Tag, error) {
+
+// NOTE: 保存時の前提条件のチェック
+func (r repository) checkPrecondition(precondition *version.Precondition) error {
+	if r.stored != nil {
+		return precondition.Check(*r.stored)
+	}
+	if r.tag != nil {
+		return precondition.Check(r.tag.UpdatedAt)
+	}
+	return nil
+}
+
+func (r repository) TagUpdate(_ *Tag, precondition *version.Precondition) (*Tag, error) {
 	if r.update {
+		if err := r.checkPrecondition(precondition); err != nil {
+			return nil, err
+		}
 		return r.tag, r.err
 	}
 	return nil, fmt.Errorf("failed update")
 }
-func (r repository) TagDelete(ID) error {
+func (r repository) TagPatch(_ *Tag, _ []Field, precondition *version.Precondition) (*Tag, error) {
+	if r.patch {
+		if err := r.checkPrecondition(precondition); err != nil {
+			return nil, err
+		}
+		return r.patched, r.err
+	}
+	return nil, fmt.Errorf("failed patch")
+}
+func (r repository) TagDelete(_ ID, precondition *version.Precondition) error {
 	if r.delete {
+		if err := r.checkPrecondition(precondition); err != nil {
+			return err
+		}
 		return r.err
 	}
 	return fmt.Errorf("failed delete")
 }
 
+func (r repository) TagTrash(*FindOption) ([]*Tag, *FindMeta, error) {
+	if r.trash {
+		return r.tags, r.meta, r.err
+	}
+	return nil, nil, fmt.Errorf("failed trash")
+}
+func (r repository) TagRestore(ID) (*Tag, error) {
+	if r.restore {
+		return r.tag, r.err
+	}
+	return nil, fmt.Errorf("failed restore")
+}
+func (r repository) TagPurge(ID) error {
+	if r.purge {
+		return r.err
+	}
+	return fmt.Errorf("failed purge")
+}
+
+// NOTE: 読み込んだ統合先・統合元から作った統合後のタグをそのまま返却する
+func (r repository) TagMerge(sourceID ID, targetID ID, merge Merger) (*Tag, error) {
+	if r.merge && r.err != nil {
+		return nil, r.err
+	}
+	if r.merge {
+		source, err := r.TagRead(sourceID)
+		if err != nil {
+			return nil, err
+		}
+		target, err := r.TagRead(targetID)
+		if err != nil {
+			return nil, err
+		}
+		return merge(target, source), nil
+	}
+	return nil, fmt.Errorf("failed merge")
+}
+
 func TestNewServer(t *testing.T) {
 	type args struct {
 		repo Repository
@@ -285,6 +365,7 @@ func Test_server_Find(t *testing.T) {
 		fields  fields
 		args    args
 		want    []*Tag
+		want1   *FindMeta
 		wantErr bool
 	}{
 		{
@@ -303,12 +384,14 @@ func Test_server_Find(t *testing.T) {
 							Description: "OK2ですよ",
 						},
 					},
+					meta: &FindMeta{TotalCount: 2},
 					find: true,
 				},
 			},
 			args: args{
-				f: nil,
+				f: NewFindOption(),
 			},
+			want1: &FindMeta{TotalCount: 2},
 			want: []*Tag{
 				{
 					ID:          1,
@@ -322,6 +405,18 @@ func Test_server_Find(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "並び順が異なるカーソル",
+			fields: fields{
+				repository: repository{
+					find: true,
+				},
+			},
+			args: args{
+				f: NewFindOption().SetSeek(0, 10).SetCursor(&Cursor{Order: Order_Name, ID: 3}),
+			},
+			wantErr: true,
+		},
 		{
 			name: "repositoryのエラー",
 			fields: fields{
@@ -332,7 +427,7 @@ func Test_server_Find(t *testing.T) {
 				},
 			},
 			args: args{
-				f: nil,
+				f: NewFindOption(),
 			},
 			wantErr: true,
 		},
@@ -342,7 +437,7 @@ func Test_server_Find(t *testing.T) {
 			s := &server{
 				repository: tt.fields.repository,
 			}
-			got, err := s.Find(tt.args.f)
+			got, got1, err := s.Find(tt.args.f)
 			if (err != nil) != tt.wantErr {
 				t.Errorf("server.Find() error = %v, wantErr %v", err, tt.wantErr)
 				return
@@ -350,16 +445,66 @@ func Test_server_Find(t *testing.T) {
 			if !reflect.DeepEqual(got, tt.want) {
 				t.Errorf("server.Find() = %v, want %v", got, tt.want)
 			}
+			if !reflect.DeepEqual(got1, tt.want1) {
+				t.Errorf("server.Find() got1 = %v, want %v", got1, tt.want1)
+			}
+		})
+	}
+}
+
+func Test_server_Trash(t *testing.T) {
+	tags := []*Tag{{ID: 1, Name: "OK"}}
+	tests := []struct {
+		name       string
+		repository repository
+		f          *FindOption
+		want       []*Tag
+		wantErr    bool
+	}{
+		{
+			name:       "OK",
+			repository: repository{tags: tags, trash: true},
+			f:          NewFindOption(),
+			want:       tags,
+		},
+		{
+			name:       "並び順が異なるカーソル",
+			repository: repository{tags: tags, trash: true},
+			f:          NewFindOption().SetSeek(0, 10).SetCursor(&Cursor{Order: Order_Name, ID: 3}),
+			wantErr:    true,
+		},
+		{
+			name:       "分類のバリデートエラー",
+			repository: repository{tags: tags, trash: true},
+			f:          NewFindOption().SetCategories([]Category{Category(99)}),
+			wantErr:    true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := &server{
+				repository: tt.repository,
+			}
+			got, _, err := s.Trash(tt.f)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("server.Trash() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("server.Trash() = %v, want %v", got, tt.want)
+			}
 		})
 	}
 }
 
 func Test_server_Update(t *testing.T) {
+	updatedAt := time.Date(2023, 4, 1, 12, 0, 0, 0, time.UTC)
 	type fields struct {
 		repository Repository
 	}
 	type args struct {
-		t *Tag
+		t            *Tag
+		precondition *version.Precondition
 	}
 	tests := []struct {
 		name    string
@@ -393,6 +538,60 @@ func Test_server_Update(t *testing.T) {
 				Description: "OKですよ",
 			},
 		},
+		{
+			name: "OK(ゴミ箱にある親タグのまま更新)",
+			fields: fields{
+				repository: repository{
+					tag: &Tag{
+						ID:       1,
+						Name:     "OK",
+						ParentID: 5,
+					},
+					// NOTE: 親タグ(5)はゴミ箱にあり読み込めない
+					tagsByID: map[ID]*Tag{
+						1: {ID: 1, Name: "OK", ParentID: 5},
+					},
+					read:   true,
+					update: true,
+				},
+			},
+			args: args{
+				t: &Tag{
+					ID:       1,
+					Name:     "OK",
+					ParentID: 5,
+				},
+			},
+			want: &Tag{
+				ID:       1,
+				Name:     "OK",
+				ParentID: 5,
+			},
+		},
+		{
+			name: "ゴミ箱にある親タグへ変更するエラー",
+			fields: fields{
+				repository: repository{
+					tag: &Tag{
+						ID:   1,
+						Name: "OK",
+					},
+					tagsByID: map[ID]*Tag{
+						1: {ID: 1, Name: "OK"},
+					},
+					read:   true,
+					update: true,
+				},
+			},
+			args: args{
+				t: &Tag{
+					ID:       1,
+					Name:     "OK",
+					ParentID: 5,
+				},
+			},
+			wantErr: true,
+		},
 		{
 			name: "idのバリデートエラー",
 			fields: fields{
@@ -481,13 +680,64 @@ func Test_server_Update(t *testing.T) {
 			},
 			wantErr: true,
 		},
+		{
+			name: "前提条件が一致",
+			fields: fields{
+				repository: repository{
+					tag: &Tag{
+						ID:          1,
+						Name:        "OK",
+						Description: "OKですよ",
+						UpdatedAt:   updatedAt,
+					},
+					update: true,
+				},
+			},
+			args: args{
+				t: &Tag{
+					ID:          1,
+					Name:        "OK",
+					Description: "OKですよ",
+				},
+				precondition: version.NewPrecondition(version.New(updatedAt).ETag()),
+			},
+			want: &Tag{
+				ID:          1,
+				Name:        "OK",
+				Description: "OKですよ",
+				UpdatedAt:   updatedAt,
+			},
+		},
+		{
+			name: "前提条件が不一致",
+			fields: fields{
+				repository: repository{
+					tag: &Tag{
+						ID:          1,
+						Name:        "OK",
+						Description: "OKですよ",
+						UpdatedAt:   updatedAt,
+					},
+					update: true,
+				},
+			},
+			args: args{
+				t: &Tag{
+					ID:          1,
+					Name:        "OK",
+					Description: "OKですよ",
+				},
+				precondition: version.NewPrecondition(`"old"`),
+			},
+			wantErr: true,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			s := &server{
 				repository: tt.fields.repository,
 			}
-			got, err := s.Update(tt.args.t)
+			got, err := s.Update(tt.args.t, tt.args.precondition)
 			if (err != nil) != tt.wantErr {
 				t.Errorf("server.Update() error = %v, wantErr %v", err, tt.wantErr)
 				return
@@ -499,12 +749,204 @@ func Test_server_Update(t *testing.T) {
 	}
 }
 
+func Test_server_Patch(t *testing.T) {
+	updatedAt := time.Date(2023, 4, 1, 12, 0, 0, 0, time.UTC)
+	// NOTE: 読み込んでから保存するまでに他から更新されたときの更新日時
+	storedAt := updatedAt.Add(time.Second)
+	type fields struct {
+		repository Repository
+	}
+	type args struct {
+		id           ID
+		patcher      Patcher
+		precondition *version.Precondition
+	}
+	tests := []struct {
+		name    string
+		fields  fields
+		args    args
+		want    *Tag
+		wantErr bool
+	}{
+		{
+			name: "OK",
+			fields: fields{
+				repository: repository{
+					tag: &Tag{
+						ID:          1,
+						Name:        "OK",
+						Description: "OKですよ",
+					},
+					patched: &Tag{
+						ID:          1,
+						Name:        "OK",
+						Description: "変更しました",
+					},
+					read:  true,
+					patch: true,
+				},
+			},
+			args: args{
+				id: 1,
+				patcher: func(t *Tag) (*Tag, error) {
+					return NewWithID(t.ID, t.Name, "変更しました"), nil
+				},
+			},
+			want: &Tag{
+				ID:          1,
+				Name:        "OK",
+				Description: "変更しました",
+			},
+		},
+		{
+			name: "変更なしは保存しない",
+			fields: fields{
+				repository: repository{
+					tag: &Tag{
+						ID:          1,
+						Name:        "OK",
+						Description: "OKですよ",
+					},
+					read: true,
+				},
+			},
+			args: args{
+				id: 1,
+				patcher: func(t *Tag) (*Tag, error) {
+					return NewWithID(t.ID, t.Name, t.Description), nil
+				},
+			},
+			want: &Tag{
+				ID:          1,
+				Name:        "OK",
+				Description: "OKですよ",
+			},
+		},
+		{
+			name: "idのバリデートエラー",
+			fields: fields{
+				repository: repository{
+					read:  true,
+					patch: true,
+				},
+			},
+			args: args{
+				id: 0,
+				patcher: func(t *Tag) (*Tag, error) {
+					return t, nil
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "取得エラー",
+			fields: fields{
+				repository: repository{
+					patch: true,
+				},
+			},
+			args: args{
+				id: 1,
+				patcher: func(t *Tag) (*Tag, error) {
+					return t, nil
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "Nameのバリデートエラー",
+			fields: fields{
+				repository: repository{
+					tag: &Tag{
+						ID:          1,
+						Name:        "OK",
+						Description: "OKですよ",
+					},
+					read:  true,
+					patch: true,
+				},
+			},
+			args: args{
+				id: 1,
+				patcher: func(t *Tag) (*Tag, error) {
+					return NewWithID(t.ID, "", t.Description), nil
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "前提条件が不一致",
+			fields: fields{
+				repository: repository{
+					tag: &Tag{
+						ID:          1,
+						Name:        "OK",
+						Description: "OKですよ",
+						UpdatedAt:   updatedAt,
+					},
+					read:  true,
+					patch: true,
+				},
+			},
+			args: args{
+				id: 1,
+				patcher: func(t *Tag) (*Tag, error) {
+					return NewWithID(t.ID, t.Name, "変更しました"), nil
+				},
+				precondition: version.NewPrecondition(`"old"`),
+			},
+			wantErr: true,
+		},
+		{
+			name: "読み込み後に更新された",
+			fields: fields{
+				repository: repository{
+					tag: &Tag{
+						ID:          1,
+						Name:        "OK",
+						Description: "OKですよ",
+						UpdatedAt:   updatedAt,
+					},
+					stored: &storedAt,
+					read:   true,
+					patch:  true,
+				},
+			},
+			args: args{
+				id: 1,
+				patcher: func(t *Tag) (*Tag, error) {
+					return NewWithID(t.ID, t.Name, "変更しました"), nil
+				},
+				precondition: version.NewPrecondition(version.New(updatedAt).ETag()),
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := &server{
+				repository: tt.fields.repository,
+			}
+			got, err := s.Patch(tt.args.id, tt.args.patcher, tt.args.precondition)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("server.Patch() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("server.Patch() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
 func Test_server_Delete(t *testing.T) {
+	updatedAt := time.Date(2023, 4, 1, 12, 0, 0, 0, time.UTC)
 	type fields struct {
 		repository Repository
 	}
 	type args struct {
-		id ID
+		id           ID
+		precondition *version.Precondition
 	}
 	tests := []struct {
 		name    string
@@ -545,15 +987,269 @@ func Test_server_Delete(t *testing.T) {
 			},
 			wantErr: true,
 		},
+		{
+			name: "前提条件が一致",
+			fields: fields{
+				repository: repository{
+					tag: &Tag{
+						ID:        1,
+						Name:      "OK",
+						UpdatedAt: updatedAt,
+					},
+					delete: true,
+				},
+			},
+			args: args{
+				id:           1,
+				precondition: version.NewPrecondition(version.New(updatedAt).ETag()),
+			},
+		},
+		{
+			name: "前提条件が不一致",
+			fields: fields{
+				repository: repository{
+					tag: &Tag{
+						ID:        1,
+						Name:      "OK",
+						UpdatedAt: updatedAt,
+					},
+					delete: true,
+				},
+			},
+			args: args{
+				id:           1,
+				precondition: version.NewPrecondition(`"old"`),
+			},
+			wantErr: true,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			s := &server{
 				repository: tt.fields.repository,
 			}
-			if err := s.Delete(tt.args.id); (err != nil) != tt.wantErr {
+			if err := s.Delete(tt.args.id, tt.args.precondition); (err != nil) != tt.wantErr {
 				t.Errorf("server.Delete() error = %v, wantErr %v", err, tt.wantErr)
 			}
 		})
 	}
 }
+
+func Test_server_Restore(t *testing.T) {
+	tests := []struct {
+		name       string
+		repository Repository
+		id         ID
+		want       *Tag
+		wantErr    bool
+	}{
+		{
+			name: "OK",
+			repository: repository{
+				tag: &Tag{
+					ID:   1,
+					Name: "OK",
+				},
+				restore: true,
+			},
+			id: 1,
+			want: &Tag{
+				ID:   1,
+				Name: "OK",
+			},
+		},
+		{
+			name: "idのバリデートエラー",
+			repository: repository{
+				restore: true,
+			},
+			id:      0,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := &server{
+				repository: tt.repository,
+			}
+			got, err := s.Restore(tt.id)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("server.Restore() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("server.Restore() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_server_Purge(t *testing.T) {
+	tests := []struct {
+		name       string
+		repository Repository
+		id         ID
+		wantErr    bool
+	}{
+		{
+			name: "OK",
+			repository: repository{
+				purge: true,
+			},
+			id: 1,
+		},
+		{
+			name: "idのバリデートエラー",
+			repository: repository{
+				purge: true,
+			},
+			id:      0,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := &server{
+				repository: tt.repository,
+			}
+			if err := s.Purge(tt.id); (err != nil) != tt.wantErr {
+				t.Errorf("server.Purge() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func Test_server_validParent(t *testing.T) {
+	// NOTE: 1 ← 2 ← 3 の親子関係
+	tags := map[ID]*Tag{
+		1: {ID: 1, Name: "ジャンル"},
+		2: {ID: 2, Name: "ローグライク", ParentID: 1},
+		3: {ID: 3, Name: "不思議のダンジョン", ParentID: 2},
+	}
+	tests := []struct {
+		name       string
+		repository repository
+		t          *Tag
+		wantErr    bool
+	}{
+		{
+			name:       "OK(親タグなし)",
+			repository: repository{tagsByID: tags, read: true},
+			t:          &Tag{ID: 2, Name: "ローグライク"},
+		},
+		{
+			name:       "OK(新規登録)",
+			repository: repository{tagsByID: tags, read: true},
+			t:          &Tag{Name: "風来のシレン", ParentID: 3},
+		},
+		{
+			name:       "OK(別の親タグへ移動)",
+			repository: repository{tagsByID: tags, read: true},
+			t:          &Tag{ID: 3, Name: "不思議のダンジョン", ParentID: 1},
+		},
+		{
+			name:       "自身を親タグにするエラー",
+			repository: repository{tagsByID: tags, read: true},
+			t:          &Tag{ID: 2, Name: "ローグライク", ParentID: 2},
+			wantErr:    true,
+		},
+		{
+			name:       "親タグが存在しないエラー",
+			repository: repository{tagsByID: tags, read: true},
+			t:          &Tag{ID: 3, Name: "不思議のダンジョン", ParentID: 99},
+			wantErr:    true,
+		},
+		{
+			name:       "repositoryのエラー",
+			repository: repository{err: fmt.Errorf("read error"), read: true},
+			t:          &Tag{ID: 3, Name: "不思議のダンジョン", ParentID: 1},
+			wantErr:    true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := &server{
+				repository: tt.repository,
+			}
+			if err := s.validParent(tt.t); (err != nil) != tt.wantErr {
+				t.Errorf("server.validParent() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func Test_server_Merge(t *testing.T) {
+	// NOTE: 1 ← 2 ← 3 の親子関係
+	tags := map[ID]*Tag{
+		1: {ID: 1, Name: "ジャンル"},
+		2: {ID: 2, Name: "ローグライク", ParentID: 1, Synonyms: []Name{"ローグライクゲーム"}},
+		3: {ID: 3, Name: "ローグライト", ParentID: 2},
+		4: {ID: 4, Name: "ローグライクゲーム"},
+	}
+	tests := []struct {
+		name       string
+		repository repository
+		sourceID   ID
+		targetID   ID
+		want       *Tag
+		wantErr    bool
+	}{
+		{
+			name:       "OK",
+			repository: repository{tagsByID: tags, read: true, merge: true},
+			sourceID:   4,
+			targetID:   2,
+			want:       &Tag{ID: 2, Name: "ローグライク", ParentID: 1, Synonyms: []Name{"ローグライクゲーム"}},
+		},
+		{
+			name:       "OK(子タグを親タグに統合)",
+			repository: repository{tagsByID: tags, read: true, merge: true},
+			sourceID:   3,
+			targetID:   2,
+			want:       &Tag{ID: 2, Name: "ローグライク", ParentID: 1, Synonyms: []Name{"ローグライクゲーム", "ローグライト"}},
+		},
+		{
+			name:       "自身に統合するエラー",
+			repository: repository{tagsByID: tags, read: true, merge: true},
+			sourceID:   2,
+			targetID:   2,
+			wantErr:    true,
+		},
+		{
+			name:       "統合元が存在しないエラー",
+			repository: repository{tagsByID: tags, read: true, merge: true},
+			sourceID:   99,
+			targetID:   2,
+			wantErr:    true,
+		},
+		{
+			name:       "統合先のIDのバリデートエラー",
+			repository: repository{tagsByID: tags, read: true, merge: true},
+			sourceID:   2,
+			targetID:   0,
+			wantErr:    true,
+		},
+		{
+			name:       "repositoryのエラー",
+			repository: repository{tagsByID: tags, err: fmt.Errorf("merge error"), read: true, merge: true},
+			sourceID:   4,
+			targetID:   2,
+			wantErr:    true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := &server{
+				repository: tt.repository,
+			}
+			got, err := s.Merge(tt.sourceID, tt.targetID)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("server.Merge() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("server.Merge() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -1,6 +1,7 @@
 package tag
 
 import (
+	"fmt"
 	"time"
 )
 
@@ -29,13 +30,69 @@ func (d Description) Valid() bool {
 	return len(d) >= 0 && len(d) < 2049
 }
 
+// タグの分類
+type Category uint8
+
+// NOTE: 分類未登録のタグは未分類として扱う
+const (
+	Category_None Category = iota
+	Category_Genre
+	Category_Mechanic
+	Category_Difficulty
+	Category_MAX
+)
+
+// genre, mechanic, difficulty を受け付ける
+// NOTE: 空文字は未分類とする
+func NewCategory(category string) (Category, error) {
+	if category == "" {
+		return Category_None, nil
+	}
+	for c := Category_Genre; c < Category_MAX; c++ {
+		if c.String() == category {
+			return c, nil
+		}
+	}
+	return Category_None, fmt.Errorf("category format error: %q", category)
+}
+
+func (c Category) Valid() bool {
+	return c < Category_MAX
+}
+
+// NOTE: 未分類は空文字
+func (c Category) String() string {
+	switch c {
+	case Category_Genre:
+		return "genre"
+	case Category_Mechanic:
+		return "mechanic"
+	case Category_Difficulty:
+		return "difficulty"
+	default:
+		return ""
+	}
+}
+
+// タグを使うゲームの数
+type GameCount = int64
+
 // タグ
 type Tag struct {
 	ID          ID
 	Name        Name
 	Description Description
-	CreatedAt   time.Time
-	UpdatedAt   time.Time
+	Category    Category
+	// NOTE: 親タグがないときはゼロ値
+	ParentID  ID
+	CreatedAt time.Time
+	UpdatedAt time.Time
+	// NOTE: ゴミ箱にないときはゼロ値
+	DeletedAt time.Time
+	// NOTE: 検索オプションで数えるよう指定したときのみ設定する
+	GameCount GameCount
+	// NOTE: 統合したタグの旧名。名前・他の別名と重複しない
+	Synonyms []Name
 }
 
 func New(name Name, description Description) *Tag {
@@ -52,3 +109,44 @@ func NewWithID(id ID, name Name, description Description) *Tag {
 		Description: description,
 	}
 }
+
+// 分類の設定
+func (t *Tag) SetCategory(category Category) *Tag {
+	t.Category = category
+	return t
+}
+
+// 親タグの設定
+// NOTE: 0のときは親タグなし
+func (t *Tag) SetParentID(parentID ID) *Tag {
+	t.ParentID = parentID
+	return t
+}
+
+// 更新対象のフィールド
+type Field uint8
+
+const (
+	Field_Name Field = iota
+	Field_Description
+	Field_Category
+	Field_Parent
+)
+
+// 変更されたフィールド
+func (t *Tag) ChangedFields(after *Tag) []Field {
+	fields := []Field{}
+	if t.Name != after.Name {
+		fields = append(fields, Field_Name)
+	}
+	if t.Description != after.Description {
+		fields = append(fields, Field_Description)
+	}
+	if t.Category != after.Category {
+		fields = append(fields, Field_Category)
+	}
+	if t.ParentID != after.ParentID {
+		fields = append(fields, Field_Parent)
+	}
+	return fields
+}
@@ -174,3 +174,77 @@ func TestNewWithID(t *testing.T) {
 		})
 	}
 }
+
+func TestNewCategory(t *testing.T) {
+	tests := []struct {
+		name     string
+		category string
+		want     Category
+		wantErr  bool
+	}{
+		{
+			name:     "ジャンル",
+			category: "genre",
+			want:     Category_Genre,
+		},
+		{
+			name:     "難易度",
+			category: "difficulty",
+			want:     Category_Difficulty,
+		},
+		{
+			name:     "空文字は未分類",
+			category: "",
+			want:     Category_None,
+		},
+		{
+			name:     "不正な分類",
+			category: "platform",
+			want:     Category_None,
+			wantErr:  true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := NewCategory(tt.category)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("NewCategory() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("NewCategory() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCategory_Valid(t *testing.T) {
+	if !Category_Mechanic.Valid() {
+		t.Errorf("Category.Valid() = false, want true")
+	}
+	if Category_MAX.Valid() {
+		t.Errorf("Category.Valid() = true, want false")
+	}
+}
+
+func TestTag_ChangedFields(t *testing.T) {
+	before := &Tag{ID: 1, Name: "ローグライク", Description: "説明"}
+	after := NewWithID(1, "ローグライク", "説明").SetCategory(Category_Genre).SetParentID(2)
+	want := []Field{Field_Category, Field_Parent}
+	if got := before.ChangedFields(after); !reflect.DeepEqual(got, want) {
+		t.Errorf("Tag.ChangedFields() = %v, want %v", got, want)
+	}
+}
+
+func TestTag_Merge(t *testing.T) {
+	target := &Tag{ID: 1, Name: "Nintendo Switch", Synonyms: []Name{"NSW"}}
+	source := &Tag{ID: 2, Name: "Switch", Synonyms: []Name{"NSW", "Nintendo Switch", "スイッチ"}}
+	want := &Tag{ID: 1, Name: "Nintendo Switch", Synonyms: []Name{"NSW", "Switch", "スイッチ"}}
+	if got := target.Merge(source); !reflect.DeepEqual(got, want) {
+		t.Errorf("Tag.Merge() = %v, want %v", got, want)
+	}
+	// NOTE: 統合先は変更しない
+	if !reflect.DeepEqual(target.Synonyms, []Name{"NSW"}) {
+		t.Errorf("Tag.Merge() changed target synonyms = %v", target.Synonyms)
+	}
+}
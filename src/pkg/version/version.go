@@ -0,0 +1,90 @@
+package version
+
+import (
+	"mysrtafes-backend/pkg/errors"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// エンティティの版
+// NOTE: 更新日時から作る。更新のたびに変わるため、ETagの値として使う
+type Version string
+
+func New(updatedAt time.Time) Version {
+	return Version(strconv.FormatInt(updatedAt.UnixNano(), 36))
+}
+
+// 強いETagの形式("版")
+func (v Version) ETag() string {
+	return `"` + string(v) + `"`
+}
+
+// 更新の前提条件
+// NOTE: If-Matchで指定された版。nilのときは無条件で更新する
+type Precondition struct {
+	// NOTE: *はいずれの版にも一致する
+	Any      bool
+	Versions []Version
+}
+
+// If-Matchの値から作る
+// NOTE: 未指定のときはnil。弱いETag(W/"...")は強い比較で一致しないため無視する
+func NewPrecondition(ifMatch string) *Precondition {
+	ifMatch = strings.TrimSpace(ifMatch)
+	if ifMatch == "" {
+		return nil
+	}
+	if ifMatch == "*" {
+		return &Precondition{Any: true}
+	}
+	p := &Precondition{Versions: []Version{}}
+	for _, tag := range strings.Split(ifMatch, ",") {
+		tag = strings.TrimSpace(tag)
+		if len(tag) < 2 || !strings.HasPrefix(tag, `"`) || !strings.HasSuffix(tag, `"`) {
+			continue
+		}
+		p.Versions = append(p.Versions, Version(tag[1:len(tag)-1]))
+	}
+	return p
+}
+
+// 保存済みの版が前提条件を満たすか
+func (p *Precondition) Match(updatedAt time.Time) bool {
+	if p == nil || p.Any {
+		return true
+	}
+	current := New(updatedAt)
+	for _, v := range p.Versions {
+		if v == current {
+			return true
+		}
+	}
+	return false
+}
+
+// 前提条件のチェック
+// NOTE: 満たさないときは現在の版をエラーに含める
+func (p *Precondition) Check(updatedAt time.Time) error {
+	if p.Match(updatedAt) {
+		return nil
+	}
+	return errors.NewPreconditionFailed(
+		errors.Layer_Domain,
+		errors.NewInformation(
+			errors.ID_VersionMismatch,
+			"resource has been modified",
+			[]errors.InvalidParams{
+				errors.NewInvalidParams("If-Match", p.Versions),
+				errors.NewInvalidParams("ETag", New(updatedAt).ETag()),
+			},
+		),
+		"version mismatch error",
+	)
+}
+
+// 読み込んだ版に固定した前提条件
+// NOTE: 読み込んでから保存するまでに他から更新されていないことを保存時に確認するために使う
+func NewPreconditionAt(updatedAt time.Time) *Precondition {
+	return &Precondition{Versions: []Version{New(updatedAt)}}
+}
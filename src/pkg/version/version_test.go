@@ -0,0 +1,107 @@
+package version
+
+import (
+	"mysrtafes-backend/pkg/errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestVersion_ETag(t *testing.T) {
+	updatedAt := time.Date(2023, 4, 1, 12, 0, 0, 0, time.UTC)
+	assert.Equal(t, `"`+string(New(updatedAt))+`"`, New(updatedAt).ETag())
+	// NOTE: 更新日時が変われば版も変わる
+	assert.NotEqual(t, New(updatedAt), New(updatedAt.Add(time.Nanosecond)))
+}
+
+func TestNewPrecondition(t *testing.T) {
+	tests := []struct {
+		name    string
+		ifMatch string
+		want    *Precondition
+	}{
+		{
+			name:    "OK",
+			ifMatch: `"abc"`,
+			want:    &Precondition{Versions: []Version{"abc"}},
+		},
+		{
+			name:    "複数指定",
+			ifMatch: ` "abc" , "def"`,
+			want:    &Precondition{Versions: []Version{"abc", "def"}},
+		},
+		{
+			name:    "*はいずれにも一致",
+			ifMatch: "*",
+			want:    &Precondition{Any: true},
+		},
+		{
+			name:    "弱いETagは無視する",
+			ifMatch: `W/"abc"`,
+			want:    &Precondition{Versions: []Version{}},
+		},
+		{
+			name:    "未指定はnil",
+			ifMatch: "",
+			want:    nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, NewPrecondition(tt.ifMatch))
+		})
+	}
+}
+
+func TestPrecondition_Check(t *testing.T) {
+	updatedAt := time.Date(2023, 4, 1, 12, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name         string
+		precondition *Precondition
+		wantErr      bool
+	}{
+		{
+			name:         "一致",
+			precondition: NewPrecondition(`"old", ` + New(updatedAt).ETag()),
+		},
+		{
+			name:         "nilは無条件",
+			precondition: nil,
+		},
+		{
+			name:         "*は無条件",
+			precondition: NewPrecondition("*"),
+		},
+		{
+			name:         "不一致はエラー",
+			precondition: NewPrecondition(`"old"`),
+			wantErr:      true,
+		},
+		{
+			name:         "読み込んだ版に固定",
+			precondition: NewPreconditionAt(updatedAt),
+		},
+		{
+			name:         "読み込んだ後に更新されたらエラー",
+			precondition: NewPreconditionAt(updatedAt.Add(-time.Second)),
+			wantErr:      true,
+		},
+		{
+			name:         "弱いETagのみはエラー",
+			precondition: NewPrecondition(`W/` + New(updatedAt).ETag()),
+			wantErr:      true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.precondition.Check(updatedAt)
+			if !tt.wantErr {
+				assert.NoError(t, err)
+				return
+			}
+			_, ok := err.(errors.PreconditionFailedError)
+			assert.True(t, ok)
+		})
+	}
+}
@@ -0,0 +1,114 @@
+package mysrtafes_backend
+
+import (
+	"fmt"
+	"mysrtafes-backend/pkg/errors"
+	"mysrtafes-backend/pkg/game"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type gameAliases struct {
+	ID           uint64 `gorm:"primaryKey;autoIncrement;"`
+	GameMasterID game.ID
+	Name         game.AliasName
+	Language     game.AliasLanguage
+	Kind         game.AliasKind
+	CreatedAt    time.Time
+	UpdatedAt    time.Time
+}
+
+func (gameAliases) TableName() string {
+	return "game_aliases"
+}
+
+func NewGameAliases(alias *game.Alias) gameAliases {
+	return gameAliases{
+		Name:     alias.Name,
+		Language: alias.Language,
+		Kind:     alias.Kind,
+	}
+}
+
+// 一意になる組
+func (a *gameAliases) key() string {
+	return fmt.Sprintf("%s\n%s\n%d", a.Name, a.Language, a.Kind)
+}
+
+func (a *gameAliases) NewEntity() *game.Alias {
+	return &game.Alias{
+		Name:      a.Name,
+		Language:  a.Language,
+		Kind:      a.Kind,
+		CreatedAt: a.CreatedAt,
+		UpdatedAt: a.UpdatedAt,
+	}
+}
+
+// 別名の差し替え
+// NOTE: 名前・言語・種別が同じ別名は作成日時を保持し、指定のない別名は削除する
+func (g *gameMaster) replaceAliases(db *gorm.DB) error {
+	current := []gameAliases{}
+	result := db.Where("game_master_id = ?", g.ID).Find(&current)
+	if result.Error != nil {
+		return errors.NewInternalServerError(
+			errors.Layer_Model,
+			errors.NewInformation(
+				errors.ID_DBReadError,
+				result.Error.Error(),
+				nil,
+			),
+			"read game_aliases error",
+		)
+	}
+	exists := make(map[string]gameAliases, len(current))
+	for _, alias := range current {
+		exists[alias.key()] = alias
+	}
+
+	keep := make(map[string]bool, len(g.GameAliases))
+	for i := range g.GameAliases {
+		alias := &g.GameAliases[i]
+		alias.GameMasterID = g.ID
+		if stored, ok := exists[alias.key()]; ok {
+			*alias = stored
+			keep[alias.key()] = true
+			continue
+		}
+		if result := db.Create(alias); result.Error != nil {
+			return errors.NewInternalServerError(
+				errors.Layer_Model,
+				errors.NewInformation(
+					errors.ID_DBCreateError,
+					result.Error.Error(),
+					nil,
+				),
+				"create game_aliases error",
+			)
+		}
+	}
+
+	// 指定されなかった別名の削除
+	deleteIDs := make([]uint64, 0, len(current))
+	for _, alias := range current {
+		if !keep[alias.key()] {
+			deleteIDs = append(deleteIDs, alias.ID)
+		}
+	}
+	if len(deleteIDs) == 0 {
+		return nil
+	}
+	if result := db.Where("id IN ?", deleteIDs).Delete(&gameAliases{}); result.Error != nil {
+		return errors.NewInternalServerError(
+			errors.Layer_Model,
+			errors.NewInformation(
+				errors.ID_DBDeleteError,
+				result.Error.Error(),
+				nil,
+			),
+			"delete game_aliases error",
+		)
+	}
+	return nil
+}
@@ -0,0 +1,71 @@
+package mysrtafes_backend
+
+import (
+	"mysrtafes-backend/pkg/errors"
+	"mysrtafes-backend/pkg/game"
+
+	"gorm.io/gorm"
+)
+
+// 埋め戻しの1回あたりの件数
+const backfillBatchSize = 100
+
+// ゲームの検索用文字列・並び替え用の名前の埋め戻し
+// NOTE: search_text・sort_nameの追加前に登録したゲームは空文字のままなので、名前・別名などから計算して保存する。
+// どちらも名前を含み空文字にならないため、空文字のゲームのみ対象とすれば何度実行してもよい。表現は変わらないため更新日時は変えない
+// カラム・テーブルの追加(documents/database/migration.sql)前のスキーマでは起動を止めないよう何もしない。追加後の起動で埋め戻す
+func BackfillGames(db *gorm.DB) error {
+	migrator := db.Migrator()
+	if !migrator.HasColumn(&gameMaster{}, "search_text") ||
+		!migrator.HasColumn(&gameMaster{}, "sort_name") ||
+		!migrator.HasTable(&gameAliases{}) {
+		return nil
+	}
+	models := []*gameMaster{}
+	result := db.Unscoped().
+		Preload("GameAliases").
+		Where("search_text = '' OR sort_name = ''").
+		FindInBatches(&models, backfillBatchSize, func(tx *gorm.DB, _ int) error {
+			for _, model := range models {
+				entity := model.backfillEntity()
+				result := tx.Unscoped().
+					Model(&gameMaster{ID: model.ID}).
+					UpdateColumns(map[string]interface{}{
+						"search_text": entity.SearchText(),
+						"sort_name":   entity.SortName(),
+					})
+				if result.Error != nil {
+					return result.Error
+				}
+			}
+			return nil
+		})
+	if result.Error != nil {
+		return errors.NewInternalServerError(
+			errors.Layer_Model,
+			errors.NewInformation(
+				errors.ID_DBUpdateError,
+				result.Error.Error(),
+				nil,
+			),
+			"backfill game_masters error",
+		)
+	}
+	return nil
+}
+
+// 埋め戻しの計算に使う値のみ設定したゲーム
+func (g *gameMaster) backfillEntity() *game.Game {
+	aliases := make([]*game.Alias, 0, len(g.GameAliases))
+	for _, alias := range g.GameAliases {
+		aliases = append(aliases, alias.NewEntity())
+	}
+	return &game.Game{
+		ID:          g.ID,
+		Name:        g.Name,
+		Description: g.Description,
+		Publisher:   g.Publisher,
+		Developer:   g.Developer,
+		Aliases:     aliases,
+	}
+}
@@ -0,0 +1,221 @@
+package mysrtafes_backend
+
+import (
+	stdErrors "errors"
+	"mysrtafes-backend/pkg/errors"
+	"mysrtafes-backend/pkg/game"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type GameCover interface {
+	Save(db *gorm.DB) error
+	Read(db *gorm.DB) error
+	Delete(db *gorm.DB) error
+	NewEntity() *game.Cover
+}
+
+// ゲームのカバー画像
+// NOTE: ゲームごとに1件のため、ゲームのIDを主キーにする
+type gameCovers struct {
+	GameMasterID game.ID `gorm:"primaryKey;autoIncrement:false"`
+	Key          game.CoverKey
+	URL          string
+	ContentType  game.CoverContentType
+	Size         game.CoverSize
+	Width        int
+	Height       int
+	ThumbnailKey game.CoverKey
+	ThumbnailURL string
+	CreatedAt    time.Time
+	UpdatedAt    time.Time
+}
+
+func (gameCovers) TableName() string {
+	return "game_covers"
+}
+
+func NewGameCover(gameID game.ID, cover *game.Cover) GameCover {
+	return &gameCovers{
+		GameMasterID: gameID,
+		Key:          cover.Key,
+		URL:          cover.URL,
+		ContentType:  cover.ContentType,
+		Size:         cover.Size,
+		Width:        cover.Width,
+		Height:       cover.Height,
+		ThumbnailKey: cover.ThumbnailKey,
+		ThumbnailURL: cover.ThumbnailURL,
+	}
+}
+
+func NewGameCoverFromID(gameID game.ID) GameCover {
+	return &gameCovers{
+		GameMasterID: gameID,
+	}
+}
+
+// 登録・差し替え
+// NOTE: 差し替えのときは作成日時を保持する
+func (g *gameCovers) Save(db *gorm.DB) error {
+	if err := existsGame(db, g.GameMasterID); err != nil {
+		return err
+	}
+	var count int64
+	result := db.Model(&gameCovers{}).Where("game_master_id = ?", g.GameMasterID).Count(&count)
+	if result.Error != nil {
+		return errors.NewInternalServerError(
+			errors.Layer_Model,
+			errors.NewInformation(
+				errors.ID_DBReadError,
+				result.Error.Error(),
+				nil,
+			),
+			"read game_covers error",
+		)
+	}
+	if count == 0 {
+		result = db.Create(g)
+		if result.Error != nil {
+			return errors.NewInternalServerError(
+				errors.Layer_Model,
+				errors.NewInformation(
+					errors.ID_DBCreateError,
+					result.Error.Error(),
+					nil,
+				),
+				"create game_covers error",
+			)
+		}
+		return touchGames(db, g.GameMasterID)
+	}
+	result = db.Model(g).
+		Select("Key", "URL", "ContentType", "Size", "Width", "Height", "ThumbnailKey", "ThumbnailURL").
+		Updates(g)
+	if result.Error != nil {
+		return errors.NewInternalServerError(
+			errors.Layer_Model,
+			errors.NewInformation(
+				errors.ID_DBUpdateError,
+				result.Error.Error(),
+				nil,
+			),
+			"update game_covers error",
+		)
+	}
+	if err := touchGames(db, g.GameMasterID); err != nil {
+		return err
+	}
+	// NOTE: 作成日時を返却するため再取得
+	return g.Read(db)
+}
+
+func (g *gameCovers) Read(db *gorm.DB) error {
+	result := db.Where("game_master_id = ?", g.GameMasterID).Take(g)
+	if stdErrors.Is(result.Error, gorm.ErrRecordNotFound) {
+		return errors.NewNotFound(
+			errors.Layer_Model,
+			errors.NewInformation(
+				errors.ID_DBReadError,
+				result.Error.Error(),
+				[]errors.InvalidParams{
+					errors.NewInvalidParams("gameID", g.GameMasterID),
+				},
+			),
+			"game_covers is nothing error",
+		)
+	}
+	if result.Error != nil {
+		return errors.NewInternalServerError(
+			errors.Layer_Model,
+			errors.NewInformation(
+				errors.ID_DBReadError,
+				result.Error.Error(),
+				nil,
+			),
+			"read game_covers error",
+		)
+	}
+	return nil
+}
+
+func (g *gameCovers) Delete(db *gorm.DB) error {
+	result := db.Where("game_master_id = ?", g.GameMasterID).Delete(&gameCovers{})
+	if result.Error != nil {
+		return errors.NewInternalServerError(
+			errors.Layer_Model,
+			errors.NewInformation(
+				errors.ID_DBDeleteError,
+				result.Error.Error(),
+				nil,
+			),
+			"delete game_covers error",
+		)
+	}
+	if result.RowsAffected == 0 {
+		return errors.NewNotFound(
+			errors.Layer_Model,
+			errors.NewInformation(
+				errors.ID_DBDeleteError,
+				"",
+				[]errors.InvalidParams{
+					errors.NewInvalidParams("gameID", g.GameMasterID),
+				},
+			),
+			"game_covers is nothing error",
+		)
+	}
+	return touchGames(db, g.GameMasterID)
+}
+
+func (g *gameCovers) NewEntity() *game.Cover {
+	return &game.Cover{
+		Key:          g.Key,
+		URL:          g.URL,
+		ContentType:  g.ContentType,
+		Size:         g.Size,
+		Width:        g.Width,
+		Height:       g.Height,
+		ThumbnailKey: g.ThumbnailKey,
+		ThumbnailURL: g.ThumbnailURL,
+		CreatedAt:    g.CreatedAt,
+		UpdatedAt:    g.UpdatedAt,
+	}
+}
+
+// カバー画像の付け替え
+// NOTE: ゲームの統合で使う。統合先にカバー画像がないときのみ統合元のものを引き継ぎ、それ以外は統合元とともに削除する
+func moveCover(db *gorm.DB, from, to game.ID) error {
+	var count int64
+	result := db.Model(&gameCovers{}).Where("game_master_id = ?", to).Count(&count)
+	if result.Error != nil {
+		return errors.NewInternalServerError(
+			errors.Layer_Model,
+			errors.NewInformation(
+				errors.ID_DBReadError,
+				result.Error.Error(),
+				nil,
+			),
+			"read game_covers error",
+		)
+	}
+	if count > 0 {
+		return nil
+	}
+	result = db.Model(&gameCovers{}).
+		Where("game_master_id = ?", from).
+		Update("game_master_id", to)
+	if result.Error != nil {
+		return errors.NewInternalServerError(
+			errors.Layer_Model,
+			errors.NewInformation(
+				errors.ID_DBUpdateError,
+				result.Error.Error(),
+				nil,
+			),
+			"update game_covers error",
+		)
+	}
+	return nil
+}
@@ -5,7 +5,9 @@ import (
 	"mysrtafes-backend/pkg/errors"
 	"mysrtafes-backend/pkg/game"
 	"mysrtafes-backend/pkg/game/platform"
+	"mysrtafes-backend/pkg/game/series"
 	"mysrtafes-backend/pkg/game/tag"
+	"mysrtafes-backend/pkg/version"
 	"time"
 
 	"gorm.io/gorm"
@@ -15,23 +17,47 @@ import (
 type GameMaster interface {
 	Create(*gorm.DB) error
 	Read(db *gorm.DB) error
+	ReadInclude(db *gorm.DB, include *game.Include) error
+	ReadForUpdate(db *gorm.DB) error
 	Update(db *gorm.DB) error
+	Patch(db *gorm.DB, fields []game.Field) error
 	Delete(db *gorm.DB) error
+	CheckPrecondition(db *gorm.DB, precondition *version.Precondition) error
+	Restore(db *gorm.DB) error
+	Purge(db *gorm.DB) error
+	Merge(db *gorm.DB, sourceID game.ID) error
+	Exists(db *gorm.DB) error
 	NewEntity() (*game.Game, error)
 }
 
 type gameMaster struct {
-	ID                game.ID `gorm:"primaryKey;autoIncrement"`
-	Name              game.Name
-	Description       game.Description
-	Publisher         game.Publisher
-	Developer         game.Developer
-	CreatedAt         time.Time
-	UpdatedAt         time.Time
+	ID          game.ID `gorm:"primaryKey;autoIncrement"`
+	Name        game.Name
+	Description game.Description
+	Publisher   game.Publisher
+	Developer   game.Developer
+	ReleaseDate *time.Time `gorm:"type:date"`
+	// NOTE: release_dateは精度の期間の初日を保存する
+	ReleaseDatePrecision game.Precision
+	SearchText           string
+	// NOTE: 読みを正規化した並び替え用の名前
+	SortName string
+	// NOTE: シリーズに属さないときはNULL
+	SeriesMasterID *series.ID
+	SeriesNumber   game.SeriesNumber
+	CreatedAt      time.Time
+	UpdatedAt      time.Time
+	// NOTE: 論理削除。リンク・中間テーブルは復元のために残す
+	DeletedAt         gorm.DeletedAt `gorm:"index"`
 	GameReferenceURLs []gameReferenceURLs
+	GameAliases       []gameAliases
+	SeriesMaster      *seriesMaster
 	Platforms         []*platformMaster `gorm:"many2many:game_platform_links;"`
 	Tags              []*tagMaster      `gorm:"many2many:game_tag_links;"`
-	// TODO: LaravelでなぜかReleaseDateを追加するの忘れてたのでどこかのタイミングでmigrateと一緒に追加する。
+	// NOTE: 関連は関連元・関連先の両方向から読み込むため、Readで別に取得する
+	GameRelations gameRelationList `gorm:"-"`
+	// NOTE: カバー画像未登録のときはnil
+	GameCover *gameCovers
 }
 
 func NewGameMaster(game *game.Game, platforms []*platformMaster, tags []*tagMaster) GameMaster {
@@ -39,15 +65,32 @@ func NewGameMaster(game *game.Game, platforms []*platformMaster, tags []*tagMast
 	for _, link := range game.Links {
 		links = append(links, NewGameReferenceURLs(link))
 	}
+	aliases := make([]gameAliases, 0, len(game.Aliases))
+	for _, alias := range game.Aliases {
+		aliases = append(aliases, NewGameAliases(alias))
+	}
+	// NOTE: 発売日未登録はNULLで保存
+	var releaseDate *time.Time
+	if !game.ReleaseDate.Time().IsZero() {
+		date := game.ReleaseDate.Time()
+		releaseDate = &date
+	}
 	return &gameMaster{
-		ID:                game.ID,
-		Name:              game.Name,
-		Description:       game.Description,
-		Publisher:         game.Publisher,
-		Developer:         game.Developer,
-		GameReferenceURLs: links,
-		Platforms:         platforms,
-		Tags:              tags,
+		ID:                   game.ID,
+		Name:                 game.Name,
+		Description:          game.Description,
+		Publisher:            game.Publisher,
+		Developer:            game.Developer,
+		ReleaseDate:          releaseDate,
+		ReleaseDatePrecision: game.ReleaseDate.Precision,
+		SearchText:           game.SearchText(),
+		SortName:             game.SortName(),
+		SeriesMasterID:       game.SeriesID(),
+		SeriesNumber:         game.SeriesNumber,
+		GameReferenceURLs:    links,
+		GameAliases:          aliases,
+		Platforms:            platforms,
+		Tags:                 tags,
 	}
 }
 
@@ -65,8 +108,11 @@ func (g *gameMaster) Create(db *gorm.DB) error {
 	if err := g.joinTable(db); err != nil {
 		return err
 	}
+	if err := g.existsSeries(db); err != nil {
+		return err
+	}
 	// NOTE: 中間テーブルのみ作成するためのOmit
-	result := db.Omit("Tags.*", "Platforms.*").Create(g)
+	result := db.Omit("Tags.*", "Platforms.*", "SeriesMaster").Create(g)
 	if result.Error != nil {
 		// FIXME: ここ、ModelのBeforeCreateのエラーが伝播できてない。
 		return errors.NewInternalServerError(
@@ -83,10 +129,14 @@ func (g *gameMaster) Create(db *gorm.DB) error {
 }
 
 func (g *gameMaster) Read(db *gorm.DB) error {
+	return g.ReadInclude(db, nil)
+}
+
+// 読み込む関連を指定した取得
+// NOTE: includeがnilのときはすべて読み込む
+func (g *gameMaster) ReadInclude(db *gorm.DB, include *game.Include) error {
 	result := db.
-		Preload("GameReferenceURLs").
-		Preload("Platforms").
-		Preload("Tags").
+		Scopes(preloadGame(include)).
 		Where("id = ?", g.ID).
 		Find(&g)
 
@@ -101,14 +151,73 @@ func (g *gameMaster) Read(db *gorm.DB) error {
 			"read game_masters error",
 		)
 	}
-	return nil
+	if result.RowsAffected == 0 {
+		return errors.NewNotFound(
+			errors.Layer_Model,
+			errors.NewInformation(
+				errors.ID_DBReadError,
+				"",
+				[]errors.InvalidParams{
+					errors.NewInvalidParams("gameID", g.ID),
+				},
+			),
+			"game_masters is nothing error",
+		)
+	}
+	if include != nil && !include.Relations {
+		return nil
+	}
+	return g.GameRelations.find(db, g.ID)
+}
+
+// ロックして取得
+// NOTE: 読み込んだ値をもとに保存するときに、保存と同じトランザクションで呼ぶ
+func (g *gameMaster) ReadForUpdate(db *gorm.DB) error {
+	return g.Read(db.Clauses(clause.Locking{Strength: "UPDATE"}))
+}
+
+// 関連の読み込み
+// NOTE: includeがnilのときはすべて読み込む。指定のない関連は空のまま返却する
+func preloadGame(include *game.Include) func(db *gorm.DB) *gorm.DB {
+	if include == nil {
+		include = game.NewInclude()
+	}
+	return func(db *gorm.DB) *gorm.DB {
+		if include.Links {
+			db = db.Preload("GameReferenceURLs", orderDisplayOrder)
+		}
+		if include.Aliases {
+			db = db.Preload("GameAliases")
+		}
+		if include.Series {
+			db = db.Preload("SeriesMaster")
+		}
+		if include.Platforms {
+			db = db.Preload("Platforms")
+		}
+		if include.Tags {
+			db = db.Preload("Tags")
+		}
+		if include.Cover {
+			db = db.Preload("GameCover")
+		}
+		return db
+	}
 }
 
 func (g *gameMaster) Update(db *gorm.DB) error {
+	if err := g.existsSeries(db); err != nil {
+		return err
+	}
+	if err := g.replaceLinks(db); err != nil {
+		return err
+	}
+	if err := g.replaceAliases(db); err != nil {
+		return err
+	}
 	err := stdErrors.Join(
 		db.Model(&g).Association("Tags").Replace(g.Tags),
 		db.Model(&g).Association("Platforms").Replace(g.Platforms),
-		db.Model(&g).Association("GameReferenceURLs").Delete(g.GameReferenceURLs),
 	)
 	if err != nil {
 		return errors.NewInternalServerError(
@@ -121,7 +230,100 @@ func (g *gameMaster) Update(db *gorm.DB) error {
 			"update game_masters error",
 		)
 	}
-	result := db.Omit("Tags", "Platforms").Updates(g)
+	result := db.Omit("Tags", "Platforms", "GameReferenceURLs", "GameAliases", "SeriesMaster").Updates(g)
+	if result.Error != nil {
+		return errors.NewInternalServerError(
+			errors.Layer_Model,
+			errors.NewInformation(
+				errors.ID_DBUpdateError,
+				result.Error.Error(),
+				nil,
+			),
+			"update game_masters error",
+		)
+	}
+	// NOTE: Updatesはゼロ値を更新しないため、シリーズの解除(NULL・0)はSelectで指定して更新する
+	result = db.Model(g).Select("SeriesMasterID", "SeriesNumber").Updates(g)
+	if result.Error != nil {
+		return errors.NewInternalServerError(
+			errors.Layer_Model,
+			errors.NewInformation(
+				errors.ID_DBUpdateError,
+				result.Error.Error(),
+				nil,
+			),
+			"update game_masters error",
+		)
+	}
+	return nil
+}
+
+// 部分更新
+// NOTE: 指定されたフィールドのみ更新する。リンク・タグ・プラットフォームは指定されたときのみ差し替える
+func (g *gameMaster) Patch(db *gorm.DB, fields []game.Field) error {
+	columns := make([]string, 0, len(fields)+3)
+	searchText := false
+	sortName := false
+	var err error
+	for _, field := range fields {
+		switch field {
+		case game.Field_Name:
+			columns = append(columns, "Name")
+			searchText = true
+			sortName = true
+		case game.Field_Description:
+			columns = append(columns, "Description")
+			searchText = true
+		case game.Field_Publisher:
+			columns = append(columns, "Publisher")
+			searchText = true
+		case game.Field_Developer:
+			columns = append(columns, "Developer")
+			searchText = true
+		case game.Field_ReleaseDate:
+			columns = append(columns, "ReleaseDate", "ReleaseDatePrecision")
+		case game.Field_Links:
+			if err := g.replaceLinks(db); err != nil {
+				return err
+			}
+		case game.Field_Aliases:
+			if err := g.replaceAliases(db); err != nil {
+				return err
+			}
+			searchText = true
+			sortName = true
+		case game.Field_Platforms:
+			err = stdErrors.Join(err, db.Model(&g).Association("Platforms").Replace(g.Platforms))
+		case game.Field_Tags:
+			err = stdErrors.Join(err, db.Model(&g).Association("Tags").Replace(g.Tags))
+		case game.Field_Series:
+			if err := g.existsSeries(db); err != nil {
+				return err
+			}
+			columns = append(columns, "SeriesMasterID", "SeriesNumber")
+		}
+	}
+	if err != nil {
+		return errors.NewInternalServerError(
+			errors.Layer_Model,
+			errors.NewInformation(
+				errors.ID_DBUpdateError,
+				err.Error(),
+				nil,
+			),
+			"update game_masters error",
+		)
+	}
+	// NOTE: 検索用文字列は元になるフィールドの変更時のみ更新する
+	if searchText {
+		columns = append(columns, "SearchText")
+	}
+	if sortName {
+		columns = append(columns, "SortName")
+	}
+	columns = append(columns, "UpdatedAt")
+
+	result := db.Select(columns).Updates(g)
 	if result.Error != nil {
 		return errors.NewInternalServerError(
 			errors.Layer_Model,
@@ -137,10 +339,60 @@ func (g *gameMaster) Update(db *gorm.DB) error {
 }
 
 func (g *gameMaster) Delete(db *gorm.DB) error {
-	result := db.Select(
+	result := db.Delete(g)
+	if result.Error != nil {
+		return errors.NewInternalServerError(
+			errors.Layer_Model,
+			errors.NewInformation(
+				errors.ID_DBDeleteError,
+				result.Error.Error(),
+				nil,
+			),
+			"delete game_masters error",
+		)
+	}
+	return nil
+}
+
+// 前提条件(If-Match)のチェック
+// NOTE: 保存と同じトランザクションで呼ぶ
+func (g *gameMaster) CheckPrecondition(db *gorm.DB, precondition *version.Precondition) error {
+	return checkPrecondition(db, &gameMaster{}, "game_masters", "gameID", g.ID, precondition)
+}
+
+// ゴミ箱から復元
+func (g *gameMaster) Restore(db *gorm.DB) error {
+	return restoreTrash(db, &gameMaster{}, "game_masters", "gameID", g.ID)
+}
+
+// 完全削除
+// NOTE: ゴミ箱にあるもののみ対象とし、リンク・中間テーブル・カバー画像の登録も削除する。
+// カバー画像のファイルはストレージに残る
+func (g *gameMaster) Purge(db *gorm.DB) error {
+	if err := existsTrash(db, &gameMaster{}, "game_masters", "gameID", g.ID); err != nil {
+		return err
+	}
+	// NOTE: 関連は関連元・関連先のどちらからも削除する
+	result := db.
+		Where("source_game_master_id = ? OR target_game_master_id = ?", g.ID, g.ID).
+		Delete(&gameRelations{})
+	if result.Error != nil {
+		return errors.NewInternalServerError(
+			errors.Layer_Model,
+			errors.NewInformation(
+				errors.ID_DBDeleteError,
+				result.Error.Error(),
+				nil,
+			),
+			"purge game_relations error",
+		)
+	}
+	result = db.Unscoped().Select(
 		"GameReferenceURLs",
+		"GameAliases",
 		"Platforms",
 		"Tags",
+		"GameCover",
 	).Delete(g)
 	if result.Error != nil {
 		return errors.NewInternalServerError(
@@ -150,12 +402,158 @@ func (g *gameMaster) Delete(db *gorm.DB) error {
 				result.Error.Error(),
 				nil,
 			),
-			"delete game_masters error",
+			"purge game_masters error",
 		)
 	}
 	return nil
 }
 
+// 統合
+// NOTE: gは統合後の統合先。統合元の関連・転送を統合先に付け替えてから統合元を削除する
+// 統合元・統合先は呼び出し元で同じトランザクション内にロックして読み込み済みとする
+func (g *gameMaster) Merge(db *gorm.DB, sourceID game.ID) error {
+	if err := g.Update(db); err != nil {
+		return err
+	}
+	if err := repointRelations(db, sourceID, g.ID); err != nil {
+		return err
+	}
+	if err := recordRedirect(db, sourceID, g.ID); err != nil {
+		return err
+	}
+	if err := moveCover(db, sourceID, g.ID); err != nil {
+		return err
+	}
+	// NOTE: 統合元はゴミ箱に残さず、リンク・別名・中間テーブルごと削除する
+	result := db.Unscoped().Select(
+		"GameReferenceURLs",
+		"GameAliases",
+		"Platforms",
+		"Tags",
+		"GameCover",
+	).Delete(&gameMaster{ID: sourceID})
+	if result.Error != nil {
+		return errors.NewInternalServerError(
+			errors.Layer_Model,
+			errors.NewInformation(
+				errors.ID_DBDeleteError,
+				result.Error.Error(),
+				nil,
+			),
+			"merge game_masters error",
+		)
+	}
+	return nil
+}
+
+// 存在チェック
+func (g *gameMaster) Exists(db *gorm.DB) error {
+	return existsGame(db, g.ID)
+}
+
+// リンクの差し替え
+// NOTE: IDを指定したリンクは更新してIDと作成日時を保持し、指定のないリンクは削除する
+func (g *gameMaster) replaceLinks(db *gorm.DB) error {
+	linkIDs := make([]game.LinkID, 0, len(g.GameReferenceURLs))
+	for _, link := range g.GameReferenceURLs {
+		if link.ID.Valid() {
+			linkIDs = append(linkIDs, link.ID)
+		}
+	}
+
+	// 指定されたIDがゲームのリンクであるかチェック
+	if len(linkIDs) > 0 {
+		var count int64
+		result := db.Model(&gameReferenceURLs{}).
+			Where("game_master_id = ? AND id IN ?", g.ID, linkIDs).
+			Count(&count)
+		if result.Error != nil {
+			return errors.NewInternalServerError(
+				errors.Layer_Model,
+				errors.NewInformation(
+					errors.ID_DBReadError,
+					result.Error.Error(),
+					nil,
+				),
+				"read game_reference_urls error",
+			)
+		}
+		if int(count) != len(linkIDs) {
+			return errors.NewInvalidValidate(
+				errors.Layer_Model,
+				errors.NewInformation(
+					errors.ID_InvalidParams,
+					"link is not of the game",
+					[]errors.InvalidParams{
+						errors.NewInvalidParams("links.id", linkIDs),
+					},
+				),
+				"links.id model is nothing error",
+			)
+		}
+	}
+
+	// 指定されなかったリンクの削除
+	query := db.Where("game_master_id = ?", g.ID)
+	if len(linkIDs) > 0 {
+		query = query.Where("id NOT IN ?", linkIDs)
+	}
+	if result := query.Delete(&gameReferenceURLs{}); result.Error != nil {
+		return errors.NewInternalServerError(
+			errors.Layer_Model,
+			errors.NewInformation(
+				errors.ID_DBDeleteError,
+				result.Error.Error(),
+				nil,
+			),
+			"delete game_reference_urls error",
+		)
+	}
+
+	for i := range g.GameReferenceURLs {
+		link := &g.GameReferenceURLs[i]
+		link.GameMasterID = g.ID
+		if link.ID.Valid() {
+			result := db.Model(link).
+				Select("Title", "URL", "Description", "DisplayOrder", "Kind", "Store", "Region").
+				Updates(link)
+			if result.Error != nil {
+				return errors.NewInternalServerError(
+					errors.Layer_Model,
+					errors.NewInformation(
+						errors.ID_DBUpdateError,
+						result.Error.Error(),
+						nil,
+					),
+					"update game_reference_urls error",
+				)
+			}
+			continue
+		}
+		if result := db.Create(link); result.Error != nil {
+			return errors.NewInternalServerError(
+				errors.Layer_Model,
+				errors.NewInformation(
+					errors.ID_DBCreateError,
+					result.Error.Error(),
+					nil,
+				),
+				"create game_reference_urls error",
+			)
+		}
+	}
+	return nil
+}
+
+// 指定されたシリーズの存在チェック
+// NOTE: シリーズに属さないときはチェックしない
+func (g *gameMaster) existsSeries(db *gorm.DB) error {
+	if g.SeriesMasterID == nil {
+		return nil
+	}
+	return existsSeries(db, *g.SeriesMasterID)
+}
+
 func (*gameMaster) joinTable(db *gorm.DB) error {
 	// 中間テーブルの設定
 	err := stdErrors.Join(
@@ -176,6 +574,31 @@ func (*gameMaster) joinTable(db *gorm.DB) error {
 	return nil
 }
 
+// シーク法の次の位置
+// NOTE: 並び順で使う値のみ設定する
+func (g *gameMaster) cursor(orderOption game.OrderOption) *game.Cursor {
+	cursor := &game.Cursor{
+		Order: orderOption.Order,
+		Desc:  orderOption.Desc,
+		ID:    g.ID,
+	}
+	switch orderOption.Order {
+	case game.Order_Name:
+		cursor.SortName = g.SortName
+		cursor.Name = g.Name
+	case game.Order_ReleaseDate:
+		if g.ReleaseDate != nil {
+			cursor.ReleaseDate = *g.ReleaseDate
+		}
+		cursor.Precision = g.ReleaseDatePrecision
+	case game.Order_UpdatedAt:
+		cursor.UpdatedAt = g.UpdatedAt
+	case game.Order_SeriesNumber:
+		cursor.SeriesNumber = g.SeriesNumber
+	}
+	return cursor
+}
+
 func (g *gameMaster) NewEntity() (*game.Game, error) {
 	links := make([]*game.Link, 0, len(g.GameReferenceURLs))
 	for _, rawLink := range g.GameReferenceURLs {
@@ -186,6 +609,11 @@ func (g *gameMaster) NewEntity() (*game.Game, error) {
 		links = append(links, link)
 	}
 
+	aliases := make([]*game.Alias, 0, len(g.GameAliases))
+	for _, rawAlias := range g.GameAliases {
+		aliases = append(aliases, rawAlias.NewEntity())
+	}
+
 	tags := make([]*tag.Tag, 0, len(g.Tags))
 	for _, rawTag := range g.Tags {
 		tags = append(tags, rawTag.NewEntity())
@@ -196,18 +624,44 @@ func (g *gameMaster) NewEntity() (*game.Game, error) {
 		platforms = append(platforms, rawPlatform.NewEntity())
 	}
 
+	// NOTE: 登録直後などシリーズを読み込んでいないときはIDのみ設定する
+	var s *series.Series
+	switch {
+	case g.SeriesMaster != nil:
+		s = g.SeriesMaster.NewEntity()
+	case g.SeriesMasterID != nil:
+		s = &series.Series{ID: *g.SeriesMasterID}
+	}
+
+	var releaseDate game.ReleaseDate
+	if g.ReleaseDate != nil {
+		releaseDate = game.NewReleaseDateWithPrecision(*g.ReleaseDate, g.ReleaseDatePrecision)
+	}
+
+	var cover *game.Cover
+	if g.GameCover != nil {
+		cover = g.GameCover.NewEntity()
+	}
+
 	// TODO: Createの時もここでTagとPlatformsをできれば入れるようにする実装を追加
 	return &game.Game{
-		ID:          g.ID,
-		Name:        g.Name,
-		Description: g.Description,
-		Publisher:   g.Publisher,
-		Developer:   g.Developer,
-		Links:       links,
-		Tags:        tags,
-		Platforms:   platforms,
-		CreatedAt:   g.CreatedAt,
-		UpdatedAt:   g.UpdatedAt,
+		ID:           g.ID,
+		Name:         g.Name,
+		Description:  g.Description,
+		Publisher:    g.Publisher,
+		Developer:    g.Developer,
+		ReleaseDate:  releaseDate,
+		Links:        links,
+		Aliases:      aliases,
+		Series:       s,
+		SeriesNumber: g.SeriesNumber,
+		Tags:         tags,
+		Platforms:    platforms,
+		Relations:    g.GameRelations.NewEntities(),
+		Cover:        cover,
+		CreatedAt:    g.CreatedAt,
+		UpdatedAt:    g.UpdatedAt,
+		DeletedAt:    g.DeletedAt.Time,
 	}, nil
 }
 
@@ -217,32 +671,147 @@ func NewGameMasters() gameMasters {
 	return []*gameMaster{}
 }
 
-func (g *gameMasters) Find(db *gorm.DB, findOption *game.FindOption) error {
+func (g *gameMasters) Find(db *gorm.DB, findOption *game.FindOption) (*game.FindMeta, error) {
+	// キーワード検索
+	// NOTE: search_textは正規化済みなので、すべての検索語を含むものに絞り込む
+	for _, term := range findOption.Keyword.Terms() {
+		db = db.Where("search_text LIKE ?", "%"+escapeLike(term)+"%")
+	}
+
+	// タグ・プラットフォーム・リンク種別での絞り込み
+	// NOTE: 中間テーブルのサブクエリで絞り込み、1クエリで実行する
+	if len(findOption.TagIDs) > 0 && findOption.TagDescendants {
+		// NOTE: 指定したタグごとに、自身か子孫のいずれかのタグを持つかで判定する
+		descendantIDs, err := tagDescendantIDs(db, findOption.TagIDs)
+		if err != nil {
+			return nil, err
+		}
+		switch findOption.Match {
+		case game.Match_Any:
+			tagIDs := []tag.ID{}
+			for _, ids := range descendantIDs {
+				tagIDs = append(tagIDs, ids...)
+			}
+			db = db.Where(
+				"game_masters.id IN (SELECT game_master_id FROM game_tag_links WHERE tag_master_id IN ?)",
+				tagIDs,
+			)
+		default:
+			for _, ids := range descendantIDs {
+				db = db.Where(
+					"game_masters.id IN (SELECT game_master_id FROM game_tag_links WHERE tag_master_id IN ?)",
+					ids,
+				)
+			}
+		}
+	} else if len(findOption.TagIDs) > 0 {
+		switch findOption.Match {
+		case game.Match_Any:
+			db = db.Where(
+				"game_masters.id IN (SELECT game_master_id FROM game_tag_links WHERE tag_master_id IN ?)",
+				findOption.TagIDs,
+			)
+		default:
+			db = db.Where(
+				"game_masters.id IN (SELECT game_master_id FROM game_tag_links WHERE tag_master_id IN ? GROUP BY game_master_id HAVING COUNT(DISTINCT tag_master_id) = ?)",
+				findOption.TagIDs,
+				len(findOption.TagIDs),
+			)
+		}
+	}
+	if len(findOption.PlatformIDs) > 0 {
+		switch findOption.Match {
+		case game.Match_Any:
+			db = db.Where(
+				"game_masters.id IN (SELECT game_master_id FROM game_platform_links WHERE platform_master_id IN ?)",
+				findOption.PlatformIDs,
+			)
+		default:
+			db = db.Where(
+				"game_masters.id IN (SELECT game_master_id FROM game_platform_links WHERE platform_master_id IN ? GROUP BY game_master_id HAVING COUNT(DISTINCT platform_master_id) = ?)",
+				findOption.PlatformIDs,
+				len(findOption.PlatformIDs),
+			)
+		}
+	}
+
+	// シリーズでの絞り込み
+	if len(findOption.SeriesIDs) > 0 {
+		db = db.Where("game_masters.series_master_id IN ?", findOption.SeriesIDs)
+	}
+
+	if len(findOption.LinkKinds) > 0 {
+		switch findOption.Match {
+		case game.Match_Any:
+			db = db.Where(
+				"game_masters.id IN (SELECT game_master_id FROM game_reference_urls WHERE kind IN ?)",
+				findOption.LinkKinds,
+			)
+		default:
+			db = db.Where(
+				"game_masters.id IN (SELECT game_master_id FROM game_reference_urls WHERE kind IN ? GROUP BY game_master_id HAVING COUNT(DISTINCT kind) = ?)",
+				findOption.LinkKinds,
+				len(findOption.LinkKinds),
+			)
+		}
+	}
+
+	// 発売日での絞り込み
+	// NOTE: 精度の異なる発売日は、発売日の期間が指定範囲と重なるものを対象とする
+	if from := findOption.ReleasePeriod.From; from != nil {
+		// 期間の最終日 ≧ From の初日
+		db = db.Where(
+			"((release_date_precision = ? AND release_date >= ?) OR (release_date_precision = ? AND release_date >= ?) OR (release_date_precision = ? AND release_date >= ?))",
+			game.Precision_Day, from.Time(),
+			game.Precision_Month, game.NewReleaseDateWithPrecision(from.Time(), game.Precision_Month).Time(),
+			game.Precision_Year, game.NewReleaseDateWithPrecision(from.Time(), game.Precision_Year).Time(),
+		)
+	}
+	if to := findOption.ReleasePeriod.To; to != nil {
+		// 期間の初日 ≦ To の最終日
+		db = db.Where("release_date <= ?", to.End())
+	}
+
+	// 絞り込み条件での総件数
+	// NOTE: 検索モードの条件を含めずに数える
+	db = db.Session(&gorm.Session{})
+	var totalCount int64
+	if result := db.Model(&gameMaster{}).Count(&totalCount); result.Error != nil {
+		return nil, errors.NewInternalServerError(
+			errors.Layer_Model,
+			errors.NewInformation(
+				errors.ID_DBReadError,
+				result.Error.Error(),
+				nil,
+			),
+			"count game_masters error",
+		)
+	}
+
 	// 検索モードで調整
+	// NOTE: 次ページの有無を判定するために1件多く取得する
+	limit := -1
 	switch findOption.SearchMode {
 	case game.SearchMode_Pagination:
-		db = db.Limit(findOption.Pagination.Limit).Offset(findOption.Pagination.Offset)
+		limit = findOption.Pagination.Limit
+		db = db.Limit(limit + 1).Offset(findOption.Pagination.Offset)
 	case game.SearchMode_Seek:
-		db = db.Where("id > ?", findOption.Seek.LastID).Limit(findOption.Seek.Count)
+		limit = findOption.Seek.Count
+		db = db.Limit(limit + 1)
+		if cursor := findOption.Seek.Cursor; cursor != nil {
+			db = seekAfter(db, gameOrderColumns(cursor.Order), gameSeekValues(cursor), cursor.Desc)
+		} else if findOption.Seek.LastID > 0 {
+			db = seekAfter(db, []string{"id"}, []interface{}{findOption.Seek.LastID}, findOption.OrderOption.Desc)
+		}
 	}
 
-	switch findOption.OrderOption.Order {
-	case game.Order_Name:
-		db = db.Order(
-			clause.OrderByColumn{
-				Column: clause.Column{Name: "name"},
-				Desc:   findOption.OrderOption.Desc,
-			},
-		)
-	}
+	db = orderBy(db, gameOrderColumns(findOption.OrderOption.Order), findOption.OrderOption.Desc)
 
 	result := db.
-		Preload("GameReferenceURLs").
-		Preload("Platforms").
-		Preload("Tags").
+		Scopes(preloadGame(findOption.Include)).
 		Find(&g)
 	if result.Error != nil {
-		return errors.NewInternalServerError(
+		return nil, errors.NewInternalServerError(
 			errors.Layer_Model,
 			errors.NewInformation(
 				errors.ID_DBReadError,
@@ -252,5 +821,79 @@ func (g *gameMasters) Find(db *gorm.DB, findOption *game.FindOption) error {
 			"find game_masters error",
 		)
 	}
-	return nil
+
+	hasNext := limit >= 0 && len(*g) > limit
+	if hasNext {
+		*g = (*g)[:limit]
+	}
+	ids := make([]game.ID, 0, len(*g))
+	for _, m := range *g {
+		ids = append(ids, m.ID)
+	}
+	meta := game.NewFindMeta(findOption, totalCount, hasNext, ids)
+	if findOption.SearchMode == game.SearchMode_Seek && len(*g) > 0 {
+		meta.SetNextCursor((*g)[len(*g)-1].cursor(findOption.OrderOption))
+	}
+	return meta, nil
+}
+
+// 発売日未登録を並び替えるときの値
+// NOTE: 未登録は昇順で先頭になるようにする
+const nullReleaseDate = "0001-01-01"
+
+// 並び順ごとの並び替えの列
+// NOTE: シーク法の位置が一意になるよう末尾にIDを加える
+func gameOrderColumns(order game.Order) []string {
+	switch order {
+	case game.Order_Name:
+		// NOTE: 読みで並べて五十音順にし、読みが同じ場合は名前で並べる
+		return []string{"sort_name", "name", "id"}
+	case game.Order_ReleaseDate:
+		// NOTE: 期間の初日が同じ場合は精度で並べる(昇順では精度の細かいものが先)
+		return []string{"COALESCE(release_date, '" + nullReleaseDate + "')", "release_date_precision", "id"}
+	case game.Order_UpdatedAt:
+		return []string{"updated_at", "id"}
+	case game.Order_SeriesNumber:
+		// NOTE: シリーズ内の番号順。番号なし(0)は昇順で先頭になる
+		return []string{"series_number", "id"}
+	default:
+		return []string{"id"}
+	}
+}
+
+// カーソルから並び替えの列の値を取り出す
+func gameSeekValues(cursor *game.Cursor) []interface{} {
+	switch cursor.Order {
+	case game.Order_Name:
+		return []interface{}{cursor.SortName, cursor.Name, cursor.ID}
+	case game.Order_ReleaseDate:
+		releaseDate := nullReleaseDate
+		if !cursor.ReleaseDate.IsZero() {
+			releaseDate = cursor.ReleaseDate.Format("2006-01-02")
+		}
+		return []interface{}{releaseDate, cursor.Precision, cursor.ID}
+	case game.Order_UpdatedAt:
+		return []interface{}{cursor.UpdatedAt, cursor.ID}
+	case game.Order_SeriesNumber:
+		return []interface{}{cursor.SeriesNumber, cursor.ID}
+	default:
+		return []interface{}{cursor.ID}
+	}
+}
+
+// ゴミ箱の検索
+func (g *gameMasters) FindTrash(db *gorm.DB, findOption *game.FindOption) (*game.FindMeta, error) {
+	return g.Find(db.Scopes(onlyTrash("game_masters")), findOption)
+}
+
+// 指定タグを持つゲームの検索
+// NOTE: 検索オプションのタグの絞り込みとは別の条件として加える
+func (g *gameMasters) FindByTag(db *gorm.DB, tagID tag.ID, findOption *game.FindOption) (*game.FindMeta, error) {
+	return g.Find(db.Where("game_masters.id IN (SELECT game_master_id FROM game_tag_links WHERE tag_master_id = ?)", tagID), findOption)
+}
+
+// 指定プラットフォームを持つゲームの検索
+// NOTE: 検索オプションのプラットフォームの絞り込みとは別の条件として加える
+func (g *gameMasters) FindByPlatform(db *gorm.DB, platformID platform.ID, findOption *game.FindOption) (*game.FindMeta, error) {
+	return g.Find(db.Where("game_masters.id IN (SELECT game_master_id FROM game_platform_links WHERE platform_master_id = ?)", platformID), findOption)
 }
@@ -1,17 +1,32 @@
 package mysrtafes_backend
 
 import (
+	stdErrors "errors"
 	"mysrtafes-backend/pkg/errors"
 	"mysrtafes-backend/pkg/game"
 	"time"
+
+	"gorm.io/gorm"
 )
 
+type GameReferenceURL interface {
+	Create(*gorm.DB) error
+	Read(db *gorm.DB) error
+	Update(db *gorm.DB) error
+	Delete(db *gorm.DB) error
+	NewEntity() (*game.Link, error)
+}
+
 type gameReferenceURLs struct {
 	ID           game.LinkID `gorm:"primaryKey;autoIncrement;"`
 	GameMasterID game.ID
 	Title        game.Title
 	URL          string
 	Description  game.LinkDescription
+	DisplayOrder game.DisplayOrder
+	Kind         game.LinkKind
+	Store        game.LinkStore
+	Region       game.LinkRegion
 	CreatedAt    time.Time
 	UpdatedAt    time.Time
 }
@@ -22,11 +37,160 @@ func (gameReferenceURLs) TableName() string {
 
 func NewGameReferenceURLs(link *game.Link) gameReferenceURLs {
 	return gameReferenceURLs{
-		ID:          link.LinkID,
-		Title:       link.Title,
-		URL:         link.URL.URL().String(),
-		Description: link.LinkDescription,
+		ID:           link.LinkID,
+		Title:        link.Title,
+		URL:          link.URL.URL().String(),
+		Description:  link.LinkDescription,
+		DisplayOrder: link.DisplayOrder,
+		Kind:         link.Kind,
+		Store:        link.Store,
+		Region:       link.Region,
+	}
+}
+
+func NewGameReferenceURL(gameID game.ID, link *game.Link) GameReferenceURL {
+	model := NewGameReferenceURLs(link)
+	model.GameMasterID = gameID
+	return &model
+}
+
+func NewGameReferenceURLFromID(gameID game.ID, linkID game.LinkID) GameReferenceURL {
+	return &gameReferenceURLs{
+		ID:           linkID,
+		GameMasterID: gameID,
+	}
+}
+
+func (g *gameReferenceURLs) Create(db *gorm.DB) error {
+	if err := existsGame(db, g.GameMasterID); err != nil {
+		return err
+	}
+	// NOTE: 表示順は末尾に追加
+	var maxOrder game.DisplayOrder
+	result := db.Model(&gameReferenceURLs{}).
+		Where("game_master_id = ?", g.GameMasterID).
+		Select("COALESCE(MAX(display_order), 0)").
+		Scan(&maxOrder)
+	if result.Error != nil {
+		return errors.NewInternalServerError(
+			errors.Layer_Model,
+			errors.NewInformation(
+				errors.ID_DBReadError,
+				result.Error.Error(),
+				nil,
+			),
+			"read game_reference_urls display_order error",
+		)
+	}
+	g.DisplayOrder = maxOrder + 1
+
+	result = db.Create(g)
+	if result.Error != nil {
+		return errors.NewInternalServerError(
+			errors.Layer_Model,
+			errors.NewInformation(
+				errors.ID_DBCreateError,
+				result.Error.Error(),
+				nil,
+			),
+			"create game_reference_urls error",
+		)
+	}
+	return touchGames(db, g.GameMasterID)
+}
+
+func (g *gameReferenceURLs) Read(db *gorm.DB) error {
+	result := db.
+		Where("id = ? AND game_master_id = ?", g.ID, g.GameMasterID).
+		Take(g)
+	if stdErrors.Is(result.Error, gorm.ErrRecordNotFound) {
+		return errors.NewNotFound(
+			errors.Layer_Model,
+			errors.NewInformation(
+				errors.ID_DBReadError,
+				result.Error.Error(),
+				[]errors.InvalidParams{
+					errors.NewInvalidParams("linkID", g.ID),
+				},
+			),
+			"game_reference_urls is nothing error",
+		)
+	}
+	if result.Error != nil {
+		return errors.NewInternalServerError(
+			errors.Layer_Model,
+			errors.NewInformation(
+				errors.ID_DBReadError,
+				result.Error.Error(),
+				nil,
+			),
+			"read game_reference_urls error",
+		)
+	}
+	return nil
+}
+
+func (g *gameReferenceURLs) Update(db *gorm.DB) error {
+	// 存在チェック
+	current := &gameReferenceURLs{ID: g.ID, GameMasterID: g.GameMasterID}
+	if err := current.Read(db); err != nil {
+		return err
+	}
+	// NOTE: 表示順は並び替えでのみ変更する
+	result := db.Model(current).
+		Select("Title", "URL", "Description", "Kind", "Store", "Region").
+		Updates(g)
+	if result.Error != nil {
+		return errors.NewInternalServerError(
+			errors.Layer_Model,
+			errors.NewInformation(
+				errors.ID_DBUpdateError,
+				result.Error.Error(),
+				nil,
+			),
+			"update game_reference_urls error",
+		)
+	}
+	if err := touchGames(db, g.GameMasterID); err != nil {
+		return err
+	}
+	// 更新後の値を取得
+	if err := current.Read(db); err != nil {
+		return err
 	}
+	*g = *current
+	return nil
+}
+
+func (g *gameReferenceURLs) Delete(db *gorm.DB) error {
+	result := db.
+		Where("game_master_id = ?", g.GameMasterID).
+		Delete(g)
+	if result.Error != nil {
+		return errors.NewInternalServerError(
+			errors.Layer_Model,
+			errors.NewInformation(
+				errors.ID_DBDeleteError,
+				result.Error.Error(),
+				nil,
+			),
+			"delete game_reference_urls error",
+		)
+	}
+	if result.RowsAffected == 0 {
+		return errors.NewNotFound(
+			errors.Layer_Model,
+			errors.NewInformation(
+				errors.ID_DBDeleteError,
+				"",
+				[]errors.InvalidParams{
+					errors.NewInvalidParams("linkID", g.ID),
+				},
+			),
+			"game_reference_urls is nothing error",
+		)
+	}
+	return touchGames(db, g.GameMasterID)
 }
 
 func (g *gameReferenceURLs) NewEntity() (*game.Link, error) {
@@ -43,9 +207,170 @@ func (g *gameReferenceURLs) NewEntity() (*game.Link, error) {
 		)
 	}
 	return &game.Link{
-		Title:     g.Title,
-		URL:       url,
-		CreatedAt: g.CreatedAt,
-		UpdatedAt: g.UpdatedAt,
+		LinkID:          g.ID,
+		Title:           g.Title,
+		URL:             url,
+		LinkDescription: g.Description,
+		DisplayOrder:    g.DisplayOrder,
+		Kind:            g.Kind,
+		Store:           g.Store,
+		Region:          g.Region,
+		CreatedAt:       g.CreatedAt,
+		UpdatedAt:       g.UpdatedAt,
 	}, nil
 }
+
+type gameReferenceURLList []*gameReferenceURLs
+
+func NewGameReferenceURLList() gameReferenceURLList {
+	return []*gameReferenceURLs{}
+}
+
+// 表示順
+func orderDisplayOrder(db *gorm.DB) *gorm.DB {
+	return db.Order("display_order").Order("id")
+}
+
+func (g *gameReferenceURLList) Find(db *gorm.DB, gameID game.ID) error {
+	if err := existsGame(db, gameID); err != nil {
+		return err
+	}
+	result := db.
+		Scopes(orderDisplayOrder).
+		Where("game_master_id = ?", gameID).
+		Find(g)
+	if result.Error != nil {
+		return errors.NewInternalServerError(
+			errors.Layer_Model,
+			errors.NewInformation(
+				errors.ID_DBReadError,
+				result.Error.Error(),
+				nil,
+			),
+			"find game_reference_urls error",
+		)
+	}
+	return nil
+}
+
+// 並び替え
+// NOTE: linkIDsはゲームの全リンクを過不足なく指定する必要がある
+func (g *gameReferenceURLList) Reorder(db *gorm.DB, gameID game.ID, linkIDs []game.LinkID) error {
+	if err := g.Find(db, gameID); err != nil {
+		return err
+	}
+	if len(*g) != len(linkIDs) {
+		return errors.NewInvalidValidate(
+			errors.Layer_Model,
+			errors.NewInformation(
+				errors.ID_InvalidParams,
+				"link_ids count mismatch",
+				[]errors.InvalidParams{
+					errors.NewInvalidParams("link_ids", linkIDs),
+				},
+			),
+			"link_ids must contain all links of the game",
+		)
+	}
+
+	links := make(map[game.LinkID]*gameReferenceURLs, len(*g))
+	for _, link := range *g {
+		links[link.ID] = link
+	}
+	sorted := make([]*gameReferenceURLs, 0, len(linkIDs))
+	for i, linkID := range linkIDs {
+		link, ok := links[linkID]
+		if !ok {
+			return errors.NewInvalidValidate(
+				errors.Layer_Model,
+				errors.NewInformation(
+					errors.ID_InvalidParams,
+					"link is not of the game",
+					[]errors.InvalidParams{
+						errors.NewInvalidParams("link_ids", linkID),
+					},
+				),
+				"link_ids model is nothing error",
+			)
+		}
+		link.DisplayOrder = game.DisplayOrder(i + 1)
+		result := db.Model(link).Update("display_order", link.DisplayOrder)
+		if result.Error != nil {
+			return errors.NewInternalServerError(
+				errors.Layer_Model,
+				errors.NewInformation(
+					errors.ID_DBUpdateError,
+					result.Error.Error(),
+					nil,
+				),
+				"update game_reference_urls display_order error",
+			)
+		}
+		sorted = append(sorted, link)
+	}
+	*g = sorted
+	return touchGames(db, gameID)
+}
+
+func (g gameReferenceURLList) NewEntities() ([]*game.Link, error) {
+	entities := make([]*game.Link, 0, len(g))
+	for _, model := range g {
+		entity, err := model.NewEntity()
+		if err != nil {
+			return nil, err
+		}
+		entities = append(entities, entity)
+	}
+	return entities, nil
+}
+
+// ゲームの存在チェック
+func existsGame(db *gorm.DB, gameID game.ID) error {
+	var count int64
+	result := db.Model(&gameMaster{}).Where("id = ?", gameID).Count(&count)
+	if result.Error != nil {
+		return errors.NewInternalServerError(
+			errors.Layer_Model,
+			errors.NewInformation(
+				errors.ID_DBReadError,
+				result.Error.Error(),
+				nil,
+			),
+			"read game_masters error",
+		)
+	}
+	if count == 0 {
+		return errors.NewNotFound(
+			errors.Layer_Model,
+			errors.NewInformation(
+				errors.ID_DBReadError,
+				"",
+				[]errors.InvalidParams{
+					errors.NewInvalidParams("gameID", gameID),
+				},
+			),
+			"game_masters is nothing error",
+		)
+	}
+	return nil
+}
+
+// ゲームの更新日時の更新
+// NOTE: リンク・関連・カバー画像などゲームの表現に含まれる行を変更したとき、ETagが変わるように呼ぶ
+func touchGames(db *gorm.DB, gameIDs ...game.ID) error {
+	result := db.Model(&gameMaster{}).
+		Where("id IN ?", gameIDs).
+		UpdateColumn("updated_at", time.Now())
+	if result.Error != nil {
+		return errors.NewInternalServerError(
+			errors.Layer_Model,
+			errors.NewInformation(
+				errors.ID_DBUpdateError,
+				result.Error.Error(),
+				nil,
+			),
+			"update game_masters updated_at error",
+		)
+	}
+	return nil
+}
@@ -3,6 +3,7 @@ package mysrtafes_backend
 import (
 	"mysrtafes-backend/pkg/errors"
 	"mysrtafes-backend/pkg/game/platform"
+	"mysrtafes-backend/pkg/version"
 	"time"
 
 	"gorm.io/gorm"
@@ -12,8 +13,14 @@ import (
 type PlatformMaster interface {
 	Create(*gorm.DB) error
 	Read(db *gorm.DB) error
+	ReadForUpdate(db *gorm.DB) error
 	Update(db *gorm.DB) error
+	Patch(db *gorm.DB, fields []platform.Field) error
 	Delete(db *gorm.DB) error
+	CheckPrecondition(db *gorm.DB, precondition *version.Precondition) error
+	Restore(db *gorm.DB) error
+	Purge(db *gorm.DB) error
+	Merge(db *gorm.DB, sourceID platform.ID) error
 	NewEntity() *platform.Platform
 }
 
@@ -21,15 +28,30 @@ type platformMaster struct {
 	ID          platform.ID `gorm:"primaryKey;autoIncrement"`
 	Name        platform.Name
 	Description platform.Description
-	CreatedAt   time.Time
-	UpdatedAt   time.Time
+	// NOTE: 未登録のときは空文字・0
+	Manufacturer platform.Manufacturer
+	Kind         platform.Kind
+	Generation   platform.Generation
+	ReleaseYear  platform.ReleaseYear
+	Synonyms     []*platformSynonym `gorm:"foreignKey:PlatformMasterID"`
+	CreatedAt    time.Time
+	UpdatedAt    time.Time
+	// NOTE: 論理削除
+	DeletedAt gorm.DeletedAt `gorm:"index"`
+	// NOTE: 検索時に集計した値を読み込むのみで、列としては持たない
+	GameCount int64 `gorm:"->;-:migration"`
 }
 
 func NewPlatformMaster(platform *platform.Platform) PlatformMaster {
 	return &platformMaster{
-		ID:          platform.ID,
-		Name:        platform.Name,
-		Description: platform.Description,
+		ID:           platform.ID,
+		Name:         platform.Name,
+		Description:  platform.Description,
+		Manufacturer: platform.Manufacturer,
+		Kind:         platform.Kind,
+		Generation:   platform.Generation,
+		ReleaseYear:  platform.ReleaseYear,
+		Synonyms:     newPlatformSynonyms(platform.Synonyms),
 	}
 }
 
@@ -70,7 +92,7 @@ func (t *platformMaster) Create(db *gorm.DB) error {
 }
 
 func (t *platformMaster) Read(db *gorm.DB) error {
-	result := db.Where("id = ?", t.ID).Find(&t)
+	result := preloadSynonyms(db).Where("id = ?", t.ID).Find(&t)
 	if result.Error != nil {
 		return errors.NewInternalServerError(
 			errors.Layer_Model,
@@ -82,12 +104,69 @@ func (t *platformMaster) Read(db *gorm.DB) error {
 			"read platform_masters error",
 		)
 	}
+	if result.RowsAffected == 0 {
+		return errors.NewNotFound(
+			errors.Layer_Model,
+			errors.NewInformation(
+				errors.ID_DBReadError,
+				"",
+				[]errors.InvalidParams{
+					errors.NewInvalidParams("platformID", t.ID),
+				},
+			),
+			"platform_masters is nothing error",
+		)
+	}
 	return nil
 }
 
+// ロックして取得
+// NOTE: 読み込んだ値をもとに保存するときに、保存と同じトランザクションで呼ぶ
+func (t *platformMaster) ReadForUpdate(db *gorm.DB) error {
+	return t.Read(db.Clauses(clause.Locking{Strength: "UPDATE"}))
+}
+
 func (t *platformMaster) Update(db *gorm.DB) error {
 	// TODO: 更新の時だけCreatedAtが入ってこない問題があるっぽい。
-	result := db.Updates(t)
+	// NOTE: メーカーなどを未登録(ゼロ値)に戻せるよう、更新する列を指定する。別名は統合でのみ変更する
+	result := db.Select("Name", "Description", "Manufacturer", "Kind", "Generation", "ReleaseYear", "UpdatedAt").Updates(t)
+	if result.Error != nil {
+		return errors.NewInternalServerError(
+			errors.Layer_Model,
+			errors.NewInformation(
+				errors.ID_DBUpdateError,
+				result.Error.Error(),
+				nil,
+			),
+			"update platform_masters error",
+		)
+	}
+	return nil
+}
+
+// 部分更新
+// NOTE: 指定されたフィールドのみ更新する
+func (t *platformMaster) Patch(db *gorm.DB, fields []platform.Field) error {
+	columns := make([]string, 0, len(fields)+1)
+	for _, field := range fields {
+		switch field {
+		case platform.Field_Name:
+			columns = append(columns, "Name")
+		case platform.Field_Description:
+			columns = append(columns, "Description")
+		case platform.Field_Manufacturer:
+			columns = append(columns, "Manufacturer")
+		case platform.Field_Kind:
+			columns = append(columns, "Kind")
+		case platform.Field_Generation:
+			columns = append(columns, "Generation")
+		case platform.Field_ReleaseYear:
+			columns = append(columns, "ReleaseYear")
+		}
+	}
+	columns = append(columns, "UpdatedAt")
+
+	result := db.Select(columns).Updates(t)
 	if result.Error != nil {
 		return errors.NewInternalServerError(
 			errors.Layer_Model,
@@ -118,39 +197,228 @@ func (t *platformMaster) Delete(db *gorm.DB) error {
 	return nil
 }
 
+// 前提条件(If-Match)のチェック
+// NOTE: 保存と同じトランザクションで呼ぶ
+func (t *platformMaster) CheckPrecondition(db *gorm.DB, precondition *version.Precondition) error {
+	return checkPrecondition(db, &platformMaster{}, "platform_masters", "platformID", t.ID, precondition)
+}
+
+// ゴミ箱から復元
+func (t *platformMaster) Restore(db *gorm.DB) error {
+	return restoreTrash(db, &platformMaster{}, "platform_masters", "platformID", t.ID)
+}
+
+// 完全削除
+// NOTE: ゴミ箱にあるもののみ対象とし、ゲームとの中間テーブルも削除する
+func (t *platformMaster) Purge(db *gorm.DB) error {
+	if err := existsTrash(db, &platformMaster{}, "platform_masters", "platformID", t.ID); err != nil {
+		return err
+	}
+	result := db.Where("platform_master_id = ?", t.ID).Delete(&gamePlatformLink{})
+	if result.Error != nil {
+		return errors.NewInternalServerError(
+			errors.Layer_Model,
+			errors.NewInformation(
+				errors.ID_DBDeleteError,
+				result.Error.Error(),
+				nil,
+			),
+			"delete game_platform_links error",
+		)
+	}
+	result = db.Where("platform_master_id = ?", t.ID).Delete(&platformSynonym{})
+	if result.Error != nil {
+		return errors.NewInternalServerError(
+			errors.Layer_Model,
+			errors.NewInformation(
+				errors.ID_DBDeleteError,
+				result.Error.Error(),
+				nil,
+			),
+			"delete platform_synonyms error",
+		)
+	}
+	result = db.Unscoped().Delete(t)
+	if result.Error != nil {
+		return errors.NewInternalServerError(
+			errors.Layer_Model,
+			errors.NewInformation(
+				errors.ID_DBDeleteError,
+				result.Error.Error(),
+				nil,
+			),
+			"purge platform_masters error",
+		)
+	}
+	return nil
+}
+
+// 統合
+// NOTE: 統合元のゲームとの関連を自身に移して別名を加え、統合元はゴミ箱に残さず削除する。
+// 統合元・統合先は同じトランザクションでReadForUpdateしておく
+func (t *platformMaster) Merge(db *gorm.DB, sourceID platform.ID) error {
+	if err := moveGameLinks(db, "game_platform_links", "platform_master_id", sourceID, t.ID); err != nil {
+		return err
+	}
+	if err := t.addSynonyms(db); err != nil {
+		return err
+	}
+	result := db.Where("platform_master_id = ?", sourceID).Delete(&platformSynonym{})
+	if result.Error != nil {
+		return errors.NewInternalServerError(
+			errors.Layer_Model,
+			errors.NewInformation(
+				errors.ID_DBDeleteError,
+				result.Error.Error(),
+				nil,
+			),
+			"delete platform_synonyms error",
+		)
+	}
+	result = db.Unscoped().Delete(&platformMaster{ID: sourceID})
+	if result.Error != nil {
+		return errors.NewInternalServerError(
+			errors.Layer_Model,
+			errors.NewInformation(
+				errors.ID_DBDeleteError,
+				result.Error.Error(),
+				nil,
+			),
+			"purge platform_masters error",
+		)
+	}
+	// NOTE: 別名が変わるため更新日時を更新する
+	result = db.Model(&platformMaster{ID: t.ID}).Update("updated_at", time.Now())
+	if result.Error != nil {
+		return errors.NewInternalServerError(
+			errors.Layer_Model,
+			errors.NewInformation(
+				errors.ID_DBUpdateError,
+				result.Error.Error(),
+				nil,
+			),
+			"update platform_masters error",
+		)
+	}
+	return nil
+}
+
+// シーク法の次の位置
+// NOTE: 並び順で使う値のみ設定する
+func (t *platformMaster) cursor(orderOption platform.OrderOption) *platform.Cursor {
+	cursor := &platform.Cursor{
+		Order: orderOption.Order,
+		Desc:  orderOption.Desc,
+		ID:    t.ID,
+	}
+	switch orderOption.Order {
+	case platform.Order_Name:
+		cursor.Name = t.Name
+	case platform.Order_UpdatedAt:
+		cursor.UpdatedAt = t.UpdatedAt
+	case platform.Order_GameCount:
+		cursor.GameCount = t.GameCount
+	case platform.Order_Manufacturer:
+		cursor.Manufacturer = t.Manufacturer
+	case platform.Order_Kind:
+		cursor.Kind = t.Kind
+	case platform.Order_Generation:
+		cursor.Generation = t.Generation
+	case platform.Order_ReleaseYear:
+		cursor.ReleaseYear = t.ReleaseYear
+	}
+	return cursor
+}
+
 func (t *platformMaster) NewEntity() *platform.Platform {
 	return &platform.Platform{
-		ID:          t.ID,
-		Name:        t.Name,
-		Description: t.Description,
-		CreatedAt:   t.CreatedAt,
-		UpdatedAt:   t.UpdatedAt,
+		ID:           t.ID,
+		Name:         t.Name,
+		Description:  t.Description,
+		Manufacturer: t.Manufacturer,
+		Kind:         t.Kind,
+		Generation:   t.Generation,
+		ReleaseYear:  t.ReleaseYear,
+		CreatedAt:    t.CreatedAt,
+		UpdatedAt:    t.UpdatedAt,
+		DeletedAt:    t.DeletedAt.Time,
+		GameCount:    t.GameCount,
+		Synonyms:     t.synonyms(),
 	}
 }
 
+func (t *platformMaster) synonyms() []platform.Name {
+	if len(t.Synonyms) == 0 {
+		return nil
+	}
+	names := make([]platform.Name, 0, len(t.Synonyms))
+	for _, synonym := range t.Synonyms {
+		names = append(names, synonym.Name)
+	}
+	return names
+}
+
 type platformMasters []*platformMaster
 
 func NewPlatformMasters() platformMasters {
 	return []*platformMaster{}
 }
 
-func (t *platformMasters) Find(db *gorm.DB, findOption *platform.FindOption) error {
+func (t *platformMasters) Find(db *gorm.DB, findOption *platform.FindOption) (*platform.FindMeta, error) {
+	// 総件数
+	// NOTE: 検索モードの条件を含めずに数える
+	db = db.Session(&gorm.Session{})
+	if findOption.Unused {
+		db = db.Where(platformGameCountColumn + " = 0")
+	}
+	if len(findOption.Manufacturers) > 0 {
+		db = db.Where("manufacturer IN ?", findOption.Manufacturers)
+	}
+	if len(findOption.Kinds) > 0 {
+		db = db.Where("kind IN ?", findOption.Kinds)
+	}
+	if len(findOption.Generations) > 0 {
+		db = db.Where("generation IN ?", findOption.Generations)
+	}
+	db = whereReleaseYear(db, findOption.ReleaseYearRange)
+	var totalCount int64
+	if result := db.Model(&platformMaster{}).Count(&totalCount); result.Error != nil {
+		return nil, errors.NewInternalServerError(
+			errors.Layer_Model,
+			errors.NewInformation(
+				errors.ID_DBReadError,
+				result.Error.Error(),
+				nil,
+			),
+			"count platform_masters error",
+		)
+	}
+
 	// 検索モードで調整
+	// NOTE: 次ページの有無を判定するために1件多く取得する
+	limit := -1
 	switch findOption.SearchMode {
 	case platform.SearchMode_Pagination:
-		db = db.Limit(findOption.Pagination.Limit).Offset(findOption.Pagination.Offset)
+		limit = findOption.Pagination.Limit
+		db = db.Limit(limit + 1).Offset(findOption.Pagination.Offset)
 	case platform.SearchMode_Seek:
-		db = db.Where("id > ?", findOption.Seek.LastID).Limit(findOption.Seek.Count)
+		limit = findOption.Seek.Count
+		db = db.Limit(limit + 1)
+		if cursor := findOption.Seek.Cursor; cursor != nil {
+			db = seekAfter(db, platformOrderColumns(cursor.Order), platformSeekValues(cursor), cursor.Desc)
+		} else if findOption.Seek.LastID > 0 {
+			db = seekAfter(db, []string{"id"}, []interface{}{findOption.Seek.LastID}, findOption.OrderOption.Desc)
+		}
 	}
 
-	switch findOption.OrderOption.Order {
-	case platform.Order_Name:
-		db.Order(clause.OrderByColumn{Column: clause.Column{Name: "name"}, Desc: findOption.OrderOption.Desc})
+	db = orderBy(db, platformOrderColumns(findOption.OrderOption.Order), findOption.OrderOption.Desc)
+	if findOption.WithGameCount || findOption.OrderOption.Order == platform.Order_GameCount {
+		db = db.Select("platform_masters.*, " + platformGameCountColumn + " AS game_count")
 	}
 
-	result := db.Find(&t)
+	result := preloadSynonyms(db).Find(&t)
 	if result.Error != nil {
-		return errors.NewInternalServerError(
+		return nil, errors.NewInternalServerError(
 			errors.Layer_Model,
 			errors.NewInformation(
 				errors.ID_DBReadError,
@@ -160,5 +428,90 @@ func (t *platformMasters) Find(db *gorm.DB, findOption *platform.FindOption) err
 			"find platform_masters error",
 		)
 	}
-	return nil
+
+	hasNext := limit >= 0 && len(*t) > limit
+	if hasNext {
+		*t = (*t)[:limit]
+	}
+	ids := make([]platform.ID, 0, len(*t))
+	for _, m := range *t {
+		ids = append(ids, m.ID)
+	}
+	meta := platform.NewFindMeta(findOption, totalCount, hasNext, ids)
+	if findOption.SearchMode == platform.SearchMode_Seek && len(*t) > 0 {
+		meta.SetNextCursor((*t)[len(*t)-1].cursor(findOption.OrderOption))
+	}
+	return meta, nil
+}
+
+// プラットフォームを使うゲームの数
+// NOTE: ゴミ箱にあるゲームは数えない。絞り込み・並び替えでも使うため相関サブクエリで集計する
+const platformGameCountColumn = "(SELECT COUNT(*) FROM game_platform_links" +
+	" INNER JOIN game_masters ON game_masters.id = game_platform_links.game_master_id" +
+	" WHERE game_platform_links.platform_master_id = platform_masters.id AND game_masters.deleted_at IS NULL)"
+
+// 並び順ごとの並び替えの列
+// NOTE: シーク法の位置が一意になるよう末尾にIDを加える
+func platformOrderColumns(order platform.Order) []string {
+	switch order {
+	case platform.Order_Name:
+		return []string{"name", "id"}
+	case platform.Order_UpdatedAt:
+		return []string{"updated_at", "id"}
+	case platform.Order_GameCount:
+		return []string{platformGameCountColumn, "id"}
+	case platform.Order_Manufacturer:
+		return []string{"manufacturer", "id"}
+	case platform.Order_Kind:
+		return []string{"kind", "id"}
+	case platform.Order_Generation:
+		return []string{"generation", "id"}
+	case platform.Order_ReleaseYear:
+		return []string{"release_year", "id"}
+	default:
+		return []string{"id"}
+	}
+}
+
+// カーソルから並び替えの列の値を取り出す
+func platformSeekValues(cursor *platform.Cursor) []interface{} {
+	switch cursor.Order {
+	case platform.Order_Name:
+		return []interface{}{cursor.Name, cursor.ID}
+	case platform.Order_UpdatedAt:
+		return []interface{}{cursor.UpdatedAt, cursor.ID}
+	case platform.Order_GameCount:
+		return []interface{}{cursor.GameCount, cursor.ID}
+	case platform.Order_Manufacturer:
+		return []interface{}{cursor.Manufacturer, cursor.ID}
+	case platform.Order_Kind:
+		return []interface{}{cursor.Kind, cursor.ID}
+	case platform.Order_Generation:
+		return []interface{}{cursor.Generation, cursor.ID}
+	case platform.Order_ReleaseYear:
+		return []interface{}{cursor.ReleaseYear, cursor.ID}
+	default:
+		return []interface{}{cursor.ID}
+	}
+}
+
+// 発売年の範囲の絞り込み
+// NOTE: 範囲を指定したときは発売年が未登録(0)のものを除く
+func whereReleaseYear(db *gorm.DB, releaseYearRange platform.ReleaseYearRange) *gorm.DB {
+	if releaseYearRange.From == 0 && releaseYearRange.To == 0 {
+		return db
+	}
+	db = db.Where("release_year <> 0")
+	if releaseYearRange.From != 0 {
+		db = db.Where("release_year >= ?", releaseYearRange.From)
+	}
+	if releaseYearRange.To != 0 {
+		db = db.Where("release_year <= ?", releaseYearRange.To)
+	}
+	return db
+}
+
+// ゴミ箱の検索
+func (t *platformMasters) FindTrash(db *gorm.DB, findOption *platform.FindOption) (*platform.FindMeta, error) {
+	return t.Find(db.Scopes(onlyTrash("platform_masters")), findOption)
 }
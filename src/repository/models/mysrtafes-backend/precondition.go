@@ -0,0 +1,53 @@
+package mysrtafes_backend
+
+import (
+	"mysrtafes-backend/pkg/errors"
+	"mysrtafes-backend/pkg/version"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// 前提条件(If-Match)のチェック
+// NOTE: 行をロック(SELECT ... FOR UPDATE)して保存済みの版と比べるため、保存と同じトランザクションで呼ぶ。
+// 前提条件がnilのときは何もしない
+func checkPrecondition(db *gorm.DB, model interface{}, table, param string, id interface{}, precondition *version.Precondition) error {
+	if precondition == nil {
+		return nil
+	}
+	var current struct {
+		UpdatedAt time.Time
+	}
+	result := db.
+		Model(model).
+		Clauses(clause.Locking{Strength: "UPDATE"}).
+		Select("updated_at").
+		Where("id = ?", id).
+		Find(&current)
+	if result.Error != nil {
+		return errors.NewInternalServerError(
+			errors.Layer_Model,
+			errors.NewInformation(
+				errors.ID_DBReadError,
+				result.Error.Error(),
+				nil,
+			),
+			"read "+table+" error",
+		)
+	}
+	if result.RowsAffected == 0 {
+		return errors.NewNotFound(
+			errors.Layer_Model,
+			errors.NewInformation(
+				errors.ID_DBReadError,
+				"",
+				[]errors.InvalidParams{
+					errors.NewInvalidParams(param, id),
+				},
+			),
+			table+" is nothing error",
+		)
+	}
+	return precondition.Check(current.UpdatedAt)
+}
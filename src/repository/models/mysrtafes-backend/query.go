@@ -0,0 +1,52 @@
+package mysrtafes_backend
+
+import (
+	"strings"
+
+	"gorm.io/gorm"
+)
+
+// LIKE検索用のエスケープ
+var likeReplacer = strings.NewReplacer(
+	`\`, `\\`,
+	`%`, `\%`,
+	`_`, `\_`,
+)
+
+func escapeLike(s string) string {
+	return likeReplacer.Replace(s)
+}
+
+// 並び替え
+// NOTE: 末尾の列は一意になるもの(ID)を指定し、シーク法で位置が一意に決まるようにする
+func orderBy(db *gorm.DB, columns []string, desc bool) *gorm.DB {
+	for _, column := range columns {
+		if desc {
+			column += " DESC"
+		}
+		db = db.Order(column)
+	}
+	return db
+}
+
+// シーク法の位置より後ろのものに絞り込む
+// NOTE: (c1, c2, ..., id) の組の辞書順で比較する。columnsとvaluesは同じ並びで指定すること
+func seekAfter(db *gorm.DB, columns []string, values []interface{}, desc bool) *gorm.DB {
+	op := " > ?"
+	if desc {
+		op = " < ?"
+	}
+	conditions := make([]string, 0, len(columns))
+	vars := make([]interface{}, 0, len(columns)*(len(columns)+1)/2)
+	for i := range columns {
+		parts := make([]string, 0, i+1)
+		for j := 0; j < i; j++ {
+			parts = append(parts, columns[j]+" = ?")
+			vars = append(vars, values[j])
+		}
+		parts = append(parts, columns[i]+op)
+		vars = append(vars, values[i])
+		conditions = append(conditions, "("+strings.Join(parts, " AND ")+")")
+	}
+	return db.Where("("+strings.Join(conditions, " OR ")+")", vars...)
+}
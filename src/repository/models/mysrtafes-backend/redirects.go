@@ -0,0 +1,102 @@
+package mysrtafes_backend
+
+import (
+	stdErrors "errors"
+	"mysrtafes-backend/pkg/errors"
+	"mysrtafes-backend/pkg/game"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type GameRedirect interface {
+	Read(db *gorm.DB) error
+	NewEntity() *game.Redirect
+}
+
+// 統合したゲームの転送先
+// NOTE: 統合元のゲームは削除するため、IDは自動採番しない
+type gameRedirects struct {
+	GameMasterID       game.ID `gorm:"primaryKey;autoIncrement:false"`
+	TargetGameMasterID game.ID
+	CreatedAt          time.Time
+}
+
+func (gameRedirects) TableName() string {
+	return "game_redirects"
+}
+
+func NewGameRedirectFromID(gameID game.ID) GameRedirect {
+	return &gameRedirects{
+		GameMasterID: gameID,
+	}
+}
+
+func (g *gameRedirects) Read(db *gorm.DB) error {
+	result := db.Where("game_master_id = ?", g.GameMasterID).Take(g)
+	if stdErrors.Is(result.Error, gorm.ErrRecordNotFound) {
+		return errors.NewNotFound(
+			errors.Layer_Model,
+			errors.NewInformation(
+				errors.ID_DBReadError,
+				result.Error.Error(),
+				[]errors.InvalidParams{
+					errors.NewInvalidParams("gameID", g.GameMasterID),
+				},
+			),
+			"game_redirects is nothing error",
+		)
+	}
+	if result.Error != nil {
+		return errors.NewInternalServerError(
+			errors.Layer_Model,
+			errors.NewInformation(
+				errors.ID_DBReadError,
+				result.Error.Error(),
+				nil,
+			),
+			"read game_redirects error",
+		)
+	}
+	return nil
+}
+
+func (g *gameRedirects) NewEntity() *game.Redirect {
+	return &game.Redirect{
+		From:      g.GameMasterID,
+		To:        g.TargetGameMasterID,
+		CreatedAt: g.CreatedAt,
+	}
+}
+
+// 転送の記録
+// NOTE: 統合元に向いていた転送も統合先に付け替え、転送が連鎖しないようにする
+func recordRedirect(db *gorm.DB, from, to game.ID) error {
+	result := db.Model(&gameRedirects{}).
+		Where("target_game_master_id = ?", from).
+		Update("target_game_master_id", to)
+	if result.Error != nil {
+		return errors.NewInternalServerError(
+			errors.Layer_Model,
+			errors.NewInformation(
+				errors.ID_DBUpdateError,
+				result.Error.Error(),
+				nil,
+			),
+			"update game_redirects error",
+		)
+	}
+	result = db.Create(&gameRedirects{GameMasterID: from, TargetGameMasterID: to})
+	if result.Error != nil {
+		return errors.NewInternalServerError(
+			errors.Layer_Model,
+			errors.NewInformation(
+				errors.ID_DBCreateError,
+				result.Error.Error(),
+				nil,
+			),
+			"create game_redirects error",
+		)
+	}
+	return nil
+}
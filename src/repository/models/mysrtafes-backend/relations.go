@@ -0,0 +1,429 @@
+package mysrtafes_backend
+
+import (
+	stdErrors "errors"
+	"mysrtafes-backend/pkg/errors"
+	"mysrtafes-backend/pkg/game"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type GameRelation interface {
+	Create(*gorm.DB) error
+	Read(db *gorm.DB) error
+	Delete(db *gorm.DB) error
+	NewEntity() *game.Relation
+}
+
+type gameRelations struct {
+	ID                 game.RelationID `gorm:"primaryKey;autoIncrement;"`
+	SourceGameMasterID game.ID
+	TargetGameMasterID game.ID
+	Kind               game.RelationKind
+	CreatedAt          time.Time
+	UpdatedAt          time.Time
+	SourceGameMaster   *gameMaster `gorm:"foreignKey:SourceGameMasterID"`
+	TargetGameMaster   *gameMaster `gorm:"foreignKey:TargetGameMasterID"`
+}
+
+func (gameRelations) TableName() string {
+	return "game_relations"
+}
+
+func NewGameRelation(gameID game.ID, relation *game.Relation) GameRelation {
+	return &gameRelations{
+		ID:                 relation.RelationID,
+		SourceGameMasterID: gameID,
+		TargetGameMasterID: relation.Target.ID,
+		Kind:               relation.Kind,
+	}
+}
+
+// NOTE: gameIDは関連元・関連先のどちらでもよい
+func NewGameRelationFromID(gameID game.ID, relationID game.RelationID) GameRelation {
+	return &gameRelations{
+		ID:                 relationID,
+		SourceGameMasterID: gameID,
+	}
+}
+
+// 関連先のゲームを読み込む
+// NOTE: ゴミ箱にあるゲームはnilになるため、NewEntityではIDのみ設定する
+func preloadRelatedGames(db *gorm.DB) *gorm.DB {
+	return db.Preload("SourceGameMaster").Preload("TargetGameMaster")
+}
+
+// ゴミ箱にないゲーム同士の関連に絞り込む
+func activeRelations(db *gorm.DB) *gorm.DB {
+	games := db.Session(&gorm.Session{NewDB: true}).Model(&gameMaster{}).Select("id")
+	return db.Where(
+		"source_game_master_id IN (?) AND target_game_master_id IN (?)",
+		games, games,
+	)
+}
+
+func (g *gameRelations) Create(db *gorm.DB) error {
+	if err := existsGame(db, g.SourceGameMasterID); err != nil {
+		return err
+	}
+	if err := g.existsTarget(db); err != nil {
+		return err
+	}
+	if err := g.duplicated(db); err != nil {
+		return err
+	}
+	if err := g.cycled(db); err != nil {
+		return err
+	}
+
+	result := db.Omit("SourceGameMaster", "TargetGameMaster").Create(g)
+	if result.Error != nil {
+		return errors.NewInternalServerError(
+			errors.Layer_Model,
+			errors.NewInformation(
+				errors.ID_DBCreateError,
+				result.Error.Error(),
+				nil,
+			),
+			"create game_relations error",
+		)
+	}
+	// NOTE: 関連は関連元・関連先のどちらのゲームにも含まれる
+	if err := touchGames(db, g.SourceGameMasterID, g.TargetGameMasterID); err != nil {
+		return err
+	}
+	// NOTE: 関連するゲームの名前を返却するため再取得
+	return g.Read(db)
+}
+
+func (g *gameRelations) Read(db *gorm.DB) error {
+	gameID := g.SourceGameMasterID
+	result := db.
+		Scopes(preloadRelatedGames).
+		Where("id = ?", g.ID).
+		Where("(source_game_master_id = ? OR target_game_master_id = ?)", gameID, gameID).
+		Take(g)
+	if stdErrors.Is(result.Error, gorm.ErrRecordNotFound) {
+		return errors.NewNotFound(
+			errors.Layer_Model,
+			errors.NewInformation(
+				errors.ID_DBReadError,
+				result.Error.Error(),
+				[]errors.InvalidParams{
+					errors.NewInvalidParams("relationID", g.ID),
+				},
+			),
+			"game_relations is nothing error",
+		)
+	}
+	if result.Error != nil {
+		return errors.NewInternalServerError(
+			errors.Layer_Model,
+			errors.NewInformation(
+				errors.ID_DBReadError,
+				result.Error.Error(),
+				nil,
+			),
+			"read game_relations error",
+		)
+	}
+	return nil
+}
+
+func (g *gameRelations) Delete(db *gorm.DB) error {
+	gameID := g.SourceGameMasterID
+	// NOTE: 関連先のゲームの更新日時も更新するため、削除前に読み込む
+	current := &gameRelations{ID: g.ID, SourceGameMasterID: gameID}
+	if err := current.Read(db); err != nil {
+		return err
+	}
+	result := db.
+		Where("(source_game_master_id = ? OR target_game_master_id = ?)", gameID, gameID).
+		Delete(&gameRelations{ID: g.ID})
+	if result.Error != nil {
+		return errors.NewInternalServerError(
+			errors.Layer_Model,
+			errors.NewInformation(
+				errors.ID_DBDeleteError,
+				result.Error.Error(),
+				nil,
+			),
+			"delete game_relations error",
+		)
+	}
+	if result.RowsAffected == 0 {
+		return errors.NewNotFound(
+			errors.Layer_Model,
+			errors.NewInformation(
+				errors.ID_DBDeleteError,
+				"",
+				[]errors.InvalidParams{
+					errors.NewInvalidParams("relationID", g.ID),
+				},
+			),
+			"game_relations is nothing error",
+		)
+	}
+	return touchGames(db, current.SourceGameMasterID, current.TargetGameMasterID)
+}
+
+// 関連先のゲームの存在チェック
+// NOTE: 関連先はリクエストの値のためNotFoundではなくValidateエラーとする
+func (g *gameRelations) existsTarget(db *gorm.DB) error {
+	var count int64
+	result := db.Model(&gameMaster{}).Where("id = ?", g.TargetGameMasterID).Count(&count)
+	if result.Error != nil {
+		return errors.NewInternalServerError(
+			errors.Layer_Model,
+			errors.NewInformation(
+				errors.ID_DBReadError,
+				result.Error.Error(),
+				nil,
+			),
+			"read game_masters error",
+		)
+	}
+	if count == 0 {
+		return errors.NewInvalidValidate(
+			errors.Layer_Model,
+			errors.NewInformation(
+				errors.ID_InvalidParams,
+				"target game is nothing",
+				[]errors.InvalidParams{
+					errors.NewInvalidParams("target_id", g.TargetGameMasterID),
+				},
+			),
+			"target game model is nothing error",
+		)
+	}
+	return nil
+}
+
+// 同じ種別・向きの関連の重複チェック
+func (g *gameRelations) duplicated(db *gorm.DB) error {
+	var count int64
+	result := db.Model(&gameRelations{}).
+		Where(
+			"source_game_master_id = ? AND target_game_master_id = ? AND kind = ?",
+			g.SourceGameMasterID, g.TargetGameMasterID, g.Kind,
+		).
+		Count(&count)
+	if result.Error != nil {
+		return errors.NewInternalServerError(
+			errors.Layer_Model,
+			errors.NewInformation(
+				errors.ID_DBReadError,
+				result.Error.Error(),
+				nil,
+			),
+			"read game_relations error",
+		)
+	}
+	if count > 0 {
+		return errors.NewInvalidValidate(
+			errors.Layer_Model,
+			errors.NewInformation(
+				errors.ID_InvalidParams,
+				"relation already exists",
+				[]errors.InvalidParams{
+					errors.NewInvalidParams("target_id", g.TargetGameMasterID),
+					errors.NewInvalidParams("kind", g.Kind.String()),
+				},
+			),
+			"game_relations duplicate error",
+		)
+	}
+	return nil
+}
+
+// 循環チェック
+// NOTE: いずれの種別も関連元が関連先の後に出たものを表すため、
+// 関連先から種別を問わず関連をたどって関連元に戻れるときは循環になる
+func (g *gameRelations) cycled(db *gorm.DB) error {
+	visited := map[game.ID]bool{g.TargetGameMasterID: true}
+	frontier := []game.ID{g.TargetGameMasterID}
+	for len(frontier) > 0 {
+		var targets []game.ID
+		result := db.Model(&gameRelations{}).
+			Where("source_game_master_id IN ?", frontier).
+			Pluck("target_game_master_id", &targets)
+		if result.Error != nil {
+			return errors.NewInternalServerError(
+				errors.Layer_Model,
+				errors.NewInformation(
+					errors.ID_DBReadError,
+					result.Error.Error(),
+					nil,
+				),
+				"read game_relations error",
+			)
+		}
+		frontier = frontier[:0]
+		for _, target := range targets {
+			if target == g.SourceGameMasterID {
+				return errors.NewInvalidValidate(
+					errors.Layer_Model,
+					errors.NewInformation(
+						errors.ID_InvalidParams,
+						"relation makes a cycle",
+						[]errors.InvalidParams{
+							errors.NewInvalidParams("target_id", g.TargetGameMasterID),
+							errors.NewInvalidParams("kind", g.Kind.String()),
+						},
+					),
+					"game_relations cycle error",
+				)
+			}
+			if !visited[target] {
+				visited[target] = true
+				frontier = append(frontier, target)
+			}
+		}
+	}
+	return nil
+}
+
+func (g *gameRelations) NewEntity() *game.Relation {
+	return &game.Relation{
+		RelationID: g.ID,
+		Kind:       g.Kind,
+		Source:     newRelatedGame(g.SourceGameMasterID, g.SourceGameMaster),
+		Target:     newRelatedGame(g.TargetGameMasterID, g.TargetGameMaster),
+		CreatedAt:  g.CreatedAt,
+		UpdatedAt:  g.UpdatedAt,
+	}
+}
+
+func newRelatedGame(gameID game.ID, model *gameMaster) game.RelatedGame {
+	if model == nil {
+		return game.RelatedGame{ID: gameID}
+	}
+	return game.RelatedGame{ID: model.ID, Name: model.Name}
+}
+
+type gameRelationList []*gameRelations
+
+func NewGameRelationList() gameRelationList {
+	return []*gameRelations{}
+}
+
+func (g *gameRelationList) Find(db *gorm.DB, gameID game.ID) error {
+	if err := existsGame(db, gameID); err != nil {
+		return err
+	}
+	return g.find(db, gameID)
+}
+
+// NOTE: ゲームの存在チェック済みのときに使う
+func (g *gameRelationList) find(db *gorm.DB, gameID game.ID) error {
+	result := db.
+		Scopes(preloadRelatedGames, activeRelations).
+		Where("(source_game_master_id = ? OR target_game_master_id = ?)", gameID, gameID).
+		Order("id").
+		Find(g)
+	if result.Error != nil {
+		return errors.NewInternalServerError(
+			errors.Layer_Model,
+			errors.NewInformation(
+				errors.ID_DBReadError,
+				result.Error.Error(),
+				nil,
+			),
+			"find game_relations error",
+		)
+	}
+	return nil
+}
+
+func (g gameRelationList) NewEntities() []*game.Relation {
+	entities := make([]*game.Relation, 0, len(g))
+	for _, model := range g {
+		entities = append(entities, model.NewEntity())
+	}
+	return entities
+}
+
+// 関連の付け替え
+// NOTE: ゲームの統合で使う。付け替えで自己参照・重複になる関連は削除し、循環になるときはエラーとする
+func repointRelations(db *gorm.DB, from, to game.ID) error {
+	relations := NewGameRelationList()
+	result := db.
+		Where("source_game_master_id = ? OR target_game_master_id = ?", from, from).
+		Find(&relations)
+	if result.Error != nil {
+		return errors.NewInternalServerError(
+			errors.Layer_Model,
+			errors.NewInformation(
+				errors.ID_DBReadError,
+				result.Error.Error(),
+				nil,
+			),
+			"read game_relations error",
+		)
+	}
+
+	repointed := NewGameRelationList()
+	// NOTE: 関連の相手のゲームは関連するゲームのIDが変わるため、更新日時を更新する
+	touched := make([]game.ID, 0, len(relations)+1)
+	touched = append(touched, to)
+	for _, relation := range relations {
+		if relation.SourceGameMasterID == from {
+			touched = append(touched, relation.TargetGameMasterID)
+		} else {
+			touched = append(touched, relation.SourceGameMasterID)
+		}
+		if relation.SourceGameMasterID == from {
+			relation.SourceGameMasterID = to
+		}
+		if relation.TargetGameMasterID == from {
+			relation.TargetGameMasterID = to
+		}
+		drop := relation.SourceGameMasterID == relation.TargetGameMasterID
+		if !drop {
+			err := relation.duplicated(db)
+			if _, ok := err.(errors.InvalidValidateError); ok {
+				drop = true
+			} else if err != nil {
+				return err
+			}
+		}
+		if drop {
+			if result := db.Delete(&gameRelations{ID: relation.ID}); result.Error != nil {
+				return errors.NewInternalServerError(
+					errors.Layer_Model,
+					errors.NewInformation(
+						errors.ID_DBDeleteError,
+						result.Error.Error(),
+						nil,
+					),
+					"delete game_relations error",
+				)
+			}
+			continue
+		}
+		result := db.Model(relation).
+			Select("SourceGameMasterID", "TargetGameMasterID").
+			Updates(relation)
+		if result.Error != nil {
+			return errors.NewInternalServerError(
+				errors.Layer_Model,
+				errors.NewInformation(
+					errors.ID_DBUpdateError,
+					result.Error.Error(),
+					nil,
+				),
+				"update game_relations error",
+			)
+		}
+		repointed = append(repointed, relation)
+	}
+
+	// NOTE: すべて付け替えてから循環をチェックする
+	for _, relation := range repointed {
+		if err := relation.cycled(db); err != nil {
+			return err
+		}
+	}
+	return touchGames(db, touched...)
+}
@@ -0,0 +1,325 @@
+package mysrtafes_backend
+
+import (
+	"mysrtafes-backend/pkg/errors"
+	"mysrtafes-backend/pkg/game/series"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type SeriesMaster interface {
+	Create(*gorm.DB) error
+	Read(db *gorm.DB) error
+	Update(db *gorm.DB) error
+	Patch(db *gorm.DB, fields []series.Field) error
+	Delete(db *gorm.DB) error
+	NewEntity() *series.Series
+}
+
+type seriesMaster struct {
+	ID          series.ID `gorm:"primaryKey;autoIncrement"`
+	Name        series.Name
+	Description series.Description
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+}
+
+func NewSeriesMaster(s *series.Series) SeriesMaster {
+	return &seriesMaster{
+		ID:          s.ID,
+		Name:        s.Name,
+		Description: s.Description,
+	}
+}
+
+func NewSeriesMasterFromID(seriesID series.ID) SeriesMaster {
+	return &seriesMaster{
+		ID: seriesID,
+	}
+}
+
+func (seriesMaster) TableName() string {
+	return "series_masters"
+}
+
+func (s *seriesMaster) Create(db *gorm.DB) error {
+	result := db.Create(s)
+	if result.Error != nil {
+		return errors.NewInternalServerError(
+			errors.Layer_Model,
+			errors.NewInformation(
+				errors.ID_DBCreateError,
+				result.Error.Error(),
+				nil,
+			),
+			"create series_masters error",
+		)
+	}
+	return nil
+}
+
+func (s *seriesMaster) Read(db *gorm.DB) error {
+	result := db.Where("id = ?", s.ID).Find(&s)
+	if result.Error != nil {
+		return errors.NewInternalServerError(
+			errors.Layer_Model,
+			errors.NewInformation(
+				errors.ID_DBReadError,
+				result.Error.Error(),
+				nil,
+			),
+			"read series_masters error",
+		)
+	}
+	if result.RowsAffected == 0 {
+		return errors.NewNotFound(
+			errors.Layer_Model,
+			errors.NewInformation(
+				errors.ID_DBReadError,
+				"",
+				[]errors.InvalidParams{
+					errors.NewInvalidParams("seriesID", s.ID),
+				},
+			),
+			"series_masters is nothing error",
+		)
+	}
+	return nil
+}
+
+func (s *seriesMaster) Update(db *gorm.DB) error {
+	// TODO: 更新の時だけCreatedAtがなぜか入ってこない問題があるっぽい。
+	result := db.Updates(s)
+	if result.Error != nil {
+		return errors.NewInternalServerError(
+			errors.Layer_Model,
+			errors.NewInformation(
+				errors.ID_DBUpdateError,
+				result.Error.Error(),
+				nil,
+			),
+			"update series_masters error",
+		)
+	}
+	return nil
+}
+
+// 部分更新
+// NOTE: 指定されたフィールドのみ更新する
+func (s *seriesMaster) Patch(db *gorm.DB, fields []series.Field) error {
+	columns := make([]string, 0, len(fields)+1)
+	for _, field := range fields {
+		switch field {
+		case series.Field_Name:
+			columns = append(columns, "Name")
+		case series.Field_Description:
+			columns = append(columns, "Description")
+		}
+	}
+	columns = append(columns, "UpdatedAt")
+
+	result := db.Select(columns).Updates(s)
+	if result.Error != nil {
+		return errors.NewInternalServerError(
+			errors.Layer_Model,
+			errors.NewInformation(
+				errors.ID_DBUpdateError,
+				result.Error.Error(),
+				nil,
+			),
+			"update series_masters error",
+		)
+	}
+	return nil
+}
+
+// 削除
+// NOTE: シリーズに属していたゲーム(ゴミ箱のものを含む)はシリーズの参照と番号を解除する
+func (s *seriesMaster) Delete(db *gorm.DB) error {
+	result := db.Unscoped().
+		Model(&gameMaster{}).
+		Where("series_master_id = ?", s.ID).
+		Updates(map[string]interface{}{
+			"series_master_id": nil,
+			"series_number":    0,
+		})
+	if result.Error != nil {
+		return errors.NewInternalServerError(
+			errors.Layer_Model,
+			errors.NewInformation(
+				errors.ID_DBUpdateError,
+				result.Error.Error(),
+				nil,
+			),
+			"update game_masters error",
+		)
+	}
+	result = db.Delete(s)
+	if result.Error != nil {
+		return errors.NewInternalServerError(
+			errors.Layer_Model,
+			errors.NewInformation(
+				errors.ID_DBDeleteError,
+				result.Error.Error(),
+				nil,
+			),
+			"delete series_masters error",
+		)
+	}
+	return nil
+}
+
+// シーク法の次の位置
+// NOTE: 並び順で使う値のみ設定する
+func (s *seriesMaster) cursor(orderOption series.OrderOption) *series.Cursor {
+	cursor := &series.Cursor{
+		Order: orderOption.Order,
+		Desc:  orderOption.Desc,
+		ID:    s.ID,
+	}
+	switch orderOption.Order {
+	case series.Order_Name:
+		cursor.Name = s.Name
+	case series.Order_UpdatedAt:
+		cursor.UpdatedAt = s.UpdatedAt
+	}
+	return cursor
+}
+
+func (s *seriesMaster) NewEntity() *series.Series {
+	return &series.Series{
+		ID:          s.ID,
+		Name:        s.Name,
+		Description: s.Description,
+		CreatedAt:   s.CreatedAt,
+		UpdatedAt:   s.UpdatedAt,
+	}
+}
+
+type seriesMasters []*seriesMaster
+
+func NewSeriesMasters() seriesMasters {
+	return []*seriesMaster{}
+}
+
+func (s *seriesMasters) Find(db *gorm.DB, findOption *series.FindOption) (*series.FindMeta, error) {
+	// 総件数
+	// NOTE: 検索モードの条件を含めずに数える
+	db = db.Session(&gorm.Session{})
+	var totalCount int64
+	if result := db.Model(&seriesMaster{}).Count(&totalCount); result.Error != nil {
+		return nil, errors.NewInternalServerError(
+			errors.Layer_Model,
+			errors.NewInformation(
+				errors.ID_DBReadError,
+				result.Error.Error(),
+				nil,
+			),
+			"count series_masters error",
+		)
+	}
+
+	// 検索モードで調整
+	// NOTE: 次ページの有無を判定するために1件多く取得する
+	limit := -1
+	switch findOption.SearchMode {
+	case series.SearchMode_Pagination:
+		limit = findOption.Pagination.Limit
+		db = db.Limit(limit + 1).Offset(findOption.Pagination.Offset)
+	case series.SearchMode_Seek:
+		limit = findOption.Seek.Count
+		db = db.Limit(limit + 1)
+		if cursor := findOption.Seek.Cursor; cursor != nil {
+			db = seekAfter(db, seriesOrderColumns(cursor.Order), seriesSeekValues(cursor), cursor.Desc)
+		} else if findOption.Seek.LastID > 0 {
+			db = seekAfter(db, []string{"id"}, []interface{}{findOption.Seek.LastID}, findOption.OrderOption.Desc)
+		}
+	}
+
+	db = orderBy(db, seriesOrderColumns(findOption.OrderOption.Order), findOption.OrderOption.Desc)
+
+	result := db.Find(&s)
+	if result.Error != nil {
+		return nil, errors.NewInternalServerError(
+			errors.Layer_Model,
+			errors.NewInformation(
+				errors.ID_DBReadError,
+				result.Error.Error(),
+				nil,
+			),
+			"find series_masters error",
+		)
+	}
+
+	hasNext := limit >= 0 && len(*s) > limit
+	if hasNext {
+		*s = (*s)[:limit]
+	}
+	ids := make([]series.ID, 0, len(*s))
+	for _, m := range *s {
+		ids = append(ids, m.ID)
+	}
+	meta := series.NewFindMeta(findOption, totalCount, hasNext, ids)
+	if findOption.SearchMode == series.SearchMode_Seek && len(*s) > 0 {
+		meta.SetNextCursor((*s)[len(*s)-1].cursor(findOption.OrderOption))
+	}
+	return meta, nil
+}
+
+// 並び順ごとの並び替えの列
+// NOTE: シーク法の位置が一意になるよう末尾にIDを加える
+func seriesOrderColumns(order series.Order) []string {
+	switch order {
+	case series.Order_Name:
+		return []string{"name", "id"}
+	case series.Order_UpdatedAt:
+		return []string{"updated_at", "id"}
+	default:
+		return []string{"id"}
+	}
+}
+
+// カーソルから並び替えの列の値を取り出す
+func seriesSeekValues(cursor *series.Cursor) []interface{} {
+	switch cursor.Order {
+	case series.Order_Name:
+		return []interface{}{cursor.Name, cursor.ID}
+	case series.Order_UpdatedAt:
+		return []interface{}{cursor.UpdatedAt, cursor.ID}
+	default:
+		return []interface{}{cursor.ID}
+	}
+}
+
+// シリーズの存在チェック
+// NOTE: ゲームの登録・更新で指定されたシリーズが存在しないときは不正なリクエストとする
+func existsSeries(db *gorm.DB, seriesID series.ID) error {
+	var count int64
+	result := db.Model(&seriesMaster{}).Where("id = ?", seriesID).Count(&count)
+	if result.Error != nil {
+		return errors.NewInternalServerError(
+			errors.Layer_Model,
+			errors.NewInformation(
+				errors.ID_DBReadError,
+				result.Error.Error(),
+				nil,
+			),
+			"read series_masters error",
+		)
+	}
+	if count == 0 {
+		return errors.NewInvalidValidate(
+			errors.Layer_Model,
+			errors.NewInformation(
+				errors.ID_InvalidParams,
+				"series is nothing",
+				[]errors.InvalidParams{
+					errors.NewInvalidParams("series_id", seriesID),
+				},
+			),
+			"series_id model is nothing error",
+		)
+	}
+	return nil
+}
@@ -0,0 +1,202 @@
+package mysrtafes_backend
+
+import (
+	"mysrtafes-backend/pkg/errors"
+	"mysrtafes-backend/pkg/game/platform"
+	"mysrtafes-backend/pkg/game/tag"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// 統合したタグの旧名
+type tagSynonym struct {
+	ID          uint64 `gorm:"primaryKey;autoIncrement"`
+	TagMasterID tag.ID `gorm:"index"`
+	Name        tag.Name
+	CreatedAt   time.Time
+}
+
+func (tagSynonym) TableName() string {
+	return "tag_synonyms"
+}
+
+func newTagSynonyms(names []tag.Name) []*tagSynonym {
+	synonyms := make([]*tagSynonym, 0, len(names))
+	for _, name := range names {
+		synonyms = append(synonyms, &tagSynonym{
+			Name: name,
+		})
+	}
+	return synonyms
+}
+
+// 統合したプラットフォームの旧名
+type platformSynonym struct {
+	ID               uint64      `gorm:"primaryKey;autoIncrement"`
+	PlatformMasterID platform.ID `gorm:"index"`
+	Name             platform.Name
+	CreatedAt        time.Time
+}
+
+func (platformSynonym) TableName() string {
+	return "platform_synonyms"
+}
+
+func newPlatformSynonyms(names []platform.Name) []*platformSynonym {
+	synonyms := make([]*platformSynonym, 0, len(names))
+	for _, name := range names {
+		synonyms = append(synonyms, &platformSynonym{
+			Name: name,
+		})
+	}
+	return synonyms
+}
+
+// 別名の読み込み
+// NOTE: 統合した順に並べる
+func preloadSynonyms(db *gorm.DB) *gorm.DB {
+	return db.Preload("Synonyms", func(db *gorm.DB) *gorm.DB {
+		return db.Order("id")
+	})
+}
+
+// 別名の追加
+// NOTE: 登録済みの別名は作成日時を保持するため、未登録のもののみ追加する
+func (t *tagMaster) addSynonyms(db *gorm.DB) error {
+	current := []tagSynonym{}
+	result := db.Where("tag_master_id = ?", t.ID).Find(&current)
+	if result.Error != nil {
+		return errors.NewInternalServerError(
+			errors.Layer_Model,
+			errors.NewInformation(
+				errors.ID_DBReadError,
+				result.Error.Error(),
+				nil,
+			),
+			"read tag_synonyms error",
+		)
+	}
+	exists := make(map[tag.Name]bool, len(current))
+	for _, synonym := range current {
+		exists[synonym.Name] = true
+	}
+	for _, synonym := range t.Synonyms {
+		if exists[synonym.Name] {
+			continue
+		}
+		exists[synonym.Name] = true
+		result := db.Create(&tagSynonym{
+			TagMasterID: t.ID,
+			Name:        synonym.Name,
+		})
+		if result.Error != nil {
+			return errors.NewInternalServerError(
+				errors.Layer_Model,
+				errors.NewInformation(
+					errors.ID_DBCreateError,
+					result.Error.Error(),
+					nil,
+				),
+				"create tag_synonyms error",
+			)
+		}
+	}
+	return nil
+}
+
+// 別名の追加
+// NOTE: 登録済みの別名は作成日時を保持するため、未登録のもののみ追加する
+func (t *platformMaster) addSynonyms(db *gorm.DB) error {
+	current := []platformSynonym{}
+	result := db.Where("platform_master_id = ?", t.ID).Find(&current)
+	if result.Error != nil {
+		return errors.NewInternalServerError(
+			errors.Layer_Model,
+			errors.NewInformation(
+				errors.ID_DBReadError,
+				result.Error.Error(),
+				nil,
+			),
+			"read platform_synonyms error",
+		)
+	}
+	exists := make(map[platform.Name]bool, len(current))
+	for _, synonym := range current {
+		exists[synonym.Name] = true
+	}
+	for _, synonym := range t.Synonyms {
+		if exists[synonym.Name] {
+			continue
+		}
+		exists[synonym.Name] = true
+		result := db.Create(&platformSynonym{
+			PlatformMasterID: t.ID,
+			Name:             synonym.Name,
+		})
+		if result.Error != nil {
+			return errors.NewInternalServerError(
+				errors.Layer_Model,
+				errors.NewInformation(
+					errors.ID_DBCreateError,
+					result.Error.Error(),
+					nil,
+				),
+				"create platform_synonyms error",
+			)
+		}
+	}
+	return nil
+}
+
+// 中間テーブルのゲームとの関連の付け替え
+// NOTE: 統合先と同じゲームとの関連は重複になるため、付け替えずに削除する
+func moveGameLinks(db *gorm.DB, table string, column string, sourceID interface{}, targetID interface{}) error {
+	// NOTE: 統合元と関連するゲームは表現が変わるため、ETagが変わるように更新日時を更新する
+	gameIDs := db.Session(&gorm.Session{NewDB: true}).Table(table).Select("game_master_id").Where(column+" = ?", sourceID)
+	result := db.Model(&gameMaster{}).
+		Where("id IN (?)", gameIDs).
+		UpdateColumn("updated_at", time.Now())
+	if result.Error != nil {
+		return errors.NewInternalServerError(
+			errors.Layer_Model,
+			errors.NewInformation(
+				errors.ID_DBUpdateError,
+				result.Error.Error(),
+				nil,
+			),
+			"update game_masters updated_at error",
+		)
+	}
+	// NOTE: MySQLは更新対象のテーブルをサブクエリで直接参照できないため、導出表を挟む
+	result = db.Exec(
+		"UPDATE "+table+" SET "+column+" = ? WHERE "+column+" = ? AND game_master_id NOT IN (SELECT game_master_id FROM (SELECT game_master_id FROM "+table+" WHERE "+column+" = ?) AS target_links)",
+		targetID,
+		sourceID,
+		targetID,
+	)
+	if result.Error != nil {
+		return errors.NewInternalServerError(
+			errors.Layer_Model,
+			errors.NewInformation(
+				errors.ID_DBUpdateError,
+				result.Error.Error(),
+				nil,
+			),
+			"update "+table+" error",
+		)
+	}
+	result = db.Exec("DELETE FROM "+table+" WHERE "+column+" = ?", sourceID)
+	if result.Error != nil {
+		return errors.NewInternalServerError(
+			errors.Layer_Model,
+			errors.NewInformation(
+				errors.ID_DBDeleteError,
+				result.Error.Error(),
+				nil,
+			),
+			"delete "+table+" error",
+		)
+	}
+	return nil
+}
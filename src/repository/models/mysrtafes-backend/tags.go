@@ -3,6 +3,7 @@ package mysrtafes_backend
 import (
 	"mysrtafes-backend/pkg/errors"
 	"mysrtafes-backend/pkg/game/tag"
+	"mysrtafes-backend/pkg/version"
 	"time"
 
 	"gorm.io/gorm"
@@ -12,8 +13,14 @@ import (
 type TagMaster interface {
 	Create(*gorm.DB) error
 	Read(db *gorm.DB) error
+	ReadForUpdate(db *gorm.DB) error
 	Update(db *gorm.DB) error
+	Patch(db *gorm.DB, fields []tag.Field) error
 	Delete(db *gorm.DB) error
+	CheckPrecondition(db *gorm.DB, precondition *version.Precondition) error
+	Restore(db *gorm.DB) error
+	Purge(db *gorm.DB) error
+	Merge(db *gorm.DB, sourceID tag.ID) error
 	NewEntity() *tag.Tag
 }
 
@@ -21,9 +28,17 @@ type tagMaster struct {
 	ID          tag.ID `gorm:"primaryKey;autoIncrement"`
 	Name        tag.Name
 	Description tag.Description
-	Game        []*gameMaster `gorm:"many2many:game_tag_links;"`
-	CreatedAt   time.Time
-	UpdatedAt   time.Time
+	Category    tag.Category
+	// NOTE: 親タグがないときはNULL
+	ParentID  *tag.ID       `gorm:"index"`
+	Game      []*gameMaster `gorm:"many2many:game_tag_links;"`
+	Synonyms  []*tagSynonym `gorm:"foreignKey:TagMasterID"`
+	CreatedAt time.Time
+	UpdatedAt time.Time
+	// NOTE: 論理削除
+	DeletedAt gorm.DeletedAt `gorm:"index"`
+	// NOTE: 検索時に集計した値を読み込むのみで、列としては持たない
+	GameCount int64 `gorm:"->;-:migration"`
 }
 
 func NewTagMaster(tag *tag.Tag) TagMaster {
@@ -31,9 +46,21 @@ func NewTagMaster(tag *tag.Tag) TagMaster {
 		ID:          tag.ID,
 		Name:        tag.Name,
 		Description: tag.Description,
+		Category:    tag.Category,
+		ParentID:    newTagParentID(tag.ParentID),
+		Synonyms:    newTagSynonyms(tag.Synonyms),
 	}
 }
 
+// 親タグID
+// NOTE: 0のときは親タグなし(NULL)
+func newTagParentID(parentID tag.ID) *tag.ID {
+	if parentID == 0 {
+		return nil
+	}
+	return &parentID
+}
+
 func NewTagMasterFromID(tagID tag.ID) TagMaster {
 	return &tagMaster{
 		ID: tagID,
@@ -71,7 +98,7 @@ func (t *tagMaster) Create(db *gorm.DB) error {
 }
 
 func (t *tagMaster) Read(db *gorm.DB) error {
-	result := db.Where("id = ?", t.ID).Find(&t)
+	result := preloadSynonyms(db).Where("id = ?", t.ID).Find(&t)
 	if result.Error != nil {
 		return errors.NewInternalServerError(
 			errors.Layer_Model,
@@ -83,12 +110,71 @@ func (t *tagMaster) Read(db *gorm.DB) error {
 			"read tag_masters error",
 		)
 	}
+	if result.RowsAffected == 0 {
+		return errors.NewNotFound(
+			errors.Layer_Model,
+			errors.NewInformation(
+				errors.ID_DBReadError,
+				"",
+				[]errors.InvalidParams{
+					errors.NewInvalidParams("tagID", t.ID),
+				},
+			),
+			"tag_masters is nothing error",
+		)
+	}
 	return nil
 }
 
+// ロックして取得
+// NOTE: 読み込んだ値をもとに保存するときに、保存と同じトランザクションで呼ぶ
+func (t *tagMaster) ReadForUpdate(db *gorm.DB) error {
+	return t.Read(db.Clauses(clause.Locking{Strength: "UPDATE"}))
+}
+
 func (t *tagMaster) Update(db *gorm.DB) error {
+	if err := t.cycled(db); err != nil {
+		return err
+	}
 	// TODO: 更新の時だけCreatedAtがなぜか入ってこない問題があるっぽい。
-	result := db.Updates(t)
+	// NOTE: 親タグをなし(NULL)にできるよう、更新する列を指定する
+	result := db.Select("Name", "Description", "Category", "ParentID", "UpdatedAt").Updates(t)
+	if result.Error != nil {
+		return errors.NewInternalServerError(
+			errors.Layer_Model,
+			errors.NewInformation(
+				errors.ID_DBUpdateError,
+				result.Error.Error(),
+				nil,
+			),
+			"update tag_masters error",
+		)
+	}
+	return nil
+}
+
+// 部分更新
+// NOTE: 指定されたフィールドのみ更新する
+func (t *tagMaster) Patch(db *gorm.DB, fields []tag.Field) error {
+	columns := make([]string, 0, len(fields)+1)
+	for _, field := range fields {
+		switch field {
+		case tag.Field_Name:
+			columns = append(columns, "Name")
+		case tag.Field_Description:
+			columns = append(columns, "Description")
+		case tag.Field_Category:
+			columns = append(columns, "Category")
+		case tag.Field_Parent:
+			if err := t.cycled(db); err != nil {
+				return err
+			}
+			columns = append(columns, "ParentID")
+		}
+	}
+	columns = append(columns, "UpdatedAt")
+
+	result := db.Select(columns).Updates(t)
 	if result.Error != nil {
 		return errors.NewInternalServerError(
 			errors.Layer_Model,
@@ -119,14 +205,207 @@ func (t *tagMaster) Delete(db *gorm.DB) error {
 	return nil
 }
 
+// 前提条件(If-Match)のチェック
+// NOTE: 保存と同じトランザクションで呼ぶ
+func (t *tagMaster) CheckPrecondition(db *gorm.DB, precondition *version.Precondition) error {
+	return checkPrecondition(db, &tagMaster{}, "tag_masters", "tagID", t.ID, precondition)
+}
+
+// ゴミ箱から復元
+func (t *tagMaster) Restore(db *gorm.DB) error {
+	return restoreTrash(db, &tagMaster{}, "tag_masters", "tagID", t.ID)
+}
+
+// 完全削除
+// NOTE: ゴミ箱にあるもののみ対象とし、ゲームとの中間テーブルも削除する。子タグは親タグなしにする
+func (t *tagMaster) Purge(db *gorm.DB) error {
+	if err := existsTrash(db, &tagMaster{}, "tag_masters", "tagID", t.ID); err != nil {
+		return err
+	}
+	result := db.Unscoped().Model(&tagMaster{}).Where("parent_id = ?", t.ID).Update("parent_id", nil)
+	if result.Error != nil {
+		return errors.NewInternalServerError(
+			errors.Layer_Model,
+			errors.NewInformation(
+				errors.ID_DBUpdateError,
+				result.Error.Error(),
+				nil,
+			),
+			"update tag_masters parent error",
+		)
+	}
+	result = db.Where("tag_master_id = ?", t.ID).Delete(&gameTagLink{})
+	if result.Error != nil {
+		return errors.NewInternalServerError(
+			errors.Layer_Model,
+			errors.NewInformation(
+				errors.ID_DBDeleteError,
+				result.Error.Error(),
+				nil,
+			),
+			"delete game_tag_links error",
+		)
+	}
+	result = db.Where("tag_master_id = ?", t.ID).Delete(&tagSynonym{})
+	if result.Error != nil {
+		return errors.NewInternalServerError(
+			errors.Layer_Model,
+			errors.NewInformation(
+				errors.ID_DBDeleteError,
+				result.Error.Error(),
+				nil,
+			),
+			"delete tag_synonyms error",
+		)
+	}
+	result = db.Unscoped().Delete(t)
+	if result.Error != nil {
+		return errors.NewInternalServerError(
+			errors.Layer_Model,
+			errors.NewInformation(
+				errors.ID_DBDeleteError,
+				result.Error.Error(),
+				nil,
+			),
+			"purge tag_masters error",
+		)
+	}
+	return nil
+}
+
+// 統合
+// NOTE: 統合元のゲームとの関連・子タグを自身に移して別名を加え、統合元はゴミ箱に残さず削除する。
+// 統合元・統合先は同じトランザクションでReadForUpdateしておく
+func (t *tagMaster) Merge(db *gorm.DB, sourceID tag.ID) error {
+	// 子孫への統合のチェック
+	// NOTE: 統合元の子タグは統合先に付け替えるため、統合先が統合元の子孫のときは循環する
+	reached, err := reachesTag(db, t.ID, sourceID)
+	if err != nil {
+		return err
+	}
+	if reached {
+		return errors.NewInvalidValidate(
+			errors.Layer_Model,
+			errors.NewInformation(
+				errors.ID_InvalidParams,
+				"a tag cannot be merged into its descendant",
+				[]errors.InvalidParams{
+					errors.NewInvalidParams("target_id", t.ID),
+				},
+			),
+			"tag_masters merge cycle error",
+		)
+	}
+	if err := moveGameLinks(db, "game_tag_links", "tag_master_id", sourceID, t.ID); err != nil {
+		return err
+	}
+	// NOTE: ゴミ箱にある子タグも付け替える
+	result := db.Unscoped().Model(&tagMaster{}).Where("parent_id = ?", sourceID).Update("parent_id", t.ID)
+	if result.Error != nil {
+		return errors.NewInternalServerError(
+			errors.Layer_Model,
+			errors.NewInformation(
+				errors.ID_DBUpdateError,
+				result.Error.Error(),
+				nil,
+			),
+			"update tag_masters parent error",
+		)
+	}
+	if err := t.addSynonyms(db); err != nil {
+		return err
+	}
+	result = db.Where("tag_master_id = ?", sourceID).Delete(&tagSynonym{})
+	if result.Error != nil {
+		return errors.NewInternalServerError(
+			errors.Layer_Model,
+			errors.NewInformation(
+				errors.ID_DBDeleteError,
+				result.Error.Error(),
+				nil,
+			),
+			"delete tag_synonyms error",
+		)
+	}
+	result = db.Unscoped().Delete(&tagMaster{ID: sourceID})
+	if result.Error != nil {
+		return errors.NewInternalServerError(
+			errors.Layer_Model,
+			errors.NewInformation(
+				errors.ID_DBDeleteError,
+				result.Error.Error(),
+				nil,
+			),
+			"purge tag_masters error",
+		)
+	}
+	// NOTE: 別名が変わるため更新日時を更新する
+	result = db.Model(&tagMaster{ID: t.ID}).Update("updated_at", time.Now())
+	if result.Error != nil {
+		return errors.NewInternalServerError(
+			errors.Layer_Model,
+			errors.NewInformation(
+				errors.ID_DBUpdateError,
+				result.Error.Error(),
+				nil,
+			),
+			"update tag_masters error",
+		)
+	}
+	return nil
+}
+
+// シーク法の次の位置
+// NOTE: 並び順で使う値のみ設定する
+func (t *tagMaster) cursor(orderOption tag.OrderOption) *tag.Cursor {
+	cursor := &tag.Cursor{
+		Order: orderOption.Order,
+		Desc:  orderOption.Desc,
+		ID:    t.ID,
+	}
+	switch orderOption.Order {
+	case tag.Order_Name:
+		cursor.Name = t.Name
+	case tag.Order_UpdatedAt:
+		cursor.UpdatedAt = t.UpdatedAt
+	case tag.Order_GameCount:
+		cursor.GameCount = t.GameCount
+	}
+	return cursor
+}
+
 func (t *tagMaster) NewEntity() *tag.Tag {
 	return &tag.Tag{
 		ID:          t.ID,
 		Name:        t.Name,
 		Description: t.Description,
+		Category:    t.Category,
+		ParentID:    t.parentID(),
 		CreatedAt:   t.CreatedAt,
 		UpdatedAt:   t.UpdatedAt,
+		DeletedAt:   t.DeletedAt.Time,
+		GameCount:   t.GameCount,
+		Synonyms:    t.synonyms(),
+	}
+}
+
+func (t *tagMaster) synonyms() []tag.Name {
+	if len(t.Synonyms) == 0 {
+		return nil
+	}
+	names := make([]tag.Name, 0, len(t.Synonyms))
+	for _, synonym := range t.Synonyms {
+		names = append(names, synonym.Name)
+	}
+	return names
+}
+
+// NOTE: 親タグがない(NULL)ときは0
+func (t *tagMaster) parentID() tag.ID {
+	if t.ParentID == nil {
+		return 0
 	}
+	return *t.ParentID
 }
 
 type tagMasters []*tagMaster
@@ -135,23 +414,54 @@ func NewTagMasters() tagMasters {
 	return []*tagMaster{}
 }
 
-func (t *tagMasters) Find(db *gorm.DB, findOption *tag.FindOption) error {
+func (t *tagMasters) Find(db *gorm.DB, findOption *tag.FindOption) (*tag.FindMeta, error) {
+	// 総件数
+	// NOTE: 検索モードの条件を含めずに数える
+	db = db.Session(&gorm.Session{})
+	if findOption.Unused {
+		db = db.Where(tagGameCountColumn + " = 0")
+	}
+	if len(findOption.Categories) > 0 {
+		db = db.Where("category IN ?", findOption.Categories)
+	}
+	var totalCount int64
+	if result := db.Model(&tagMaster{}).Count(&totalCount); result.Error != nil {
+		return nil, errors.NewInternalServerError(
+			errors.Layer_Model,
+			errors.NewInformation(
+				errors.ID_DBReadError,
+				result.Error.Error(),
+				nil,
+			),
+			"count tag_masters error",
+		)
+	}
+
 	// 検索モードで調整
+	// NOTE: 次ページの有無を判定するために1件多く取得する
+	limit := -1
 	switch findOption.SearchMode {
 	case tag.SearchMode_Pagination:
-		db = db.Limit(findOption.Pagination.Limit).Offset(findOption.Pagination.Offset)
+		limit = findOption.Pagination.Limit
+		db = db.Limit(limit + 1).Offset(findOption.Pagination.Offset)
 	case tag.SearchMode_Seek:
-		db = db.Where("id > ?", findOption.Seek.LastID).Limit(findOption.Seek.Count)
+		limit = findOption.Seek.Count
+		db = db.Limit(limit + 1)
+		if cursor := findOption.Seek.Cursor; cursor != nil {
+			db = seekAfter(db, tagOrderColumns(cursor.Order), tagSeekValues(cursor), cursor.Desc)
+		} else if findOption.Seek.LastID > 0 {
+			db = seekAfter(db, []string{"id"}, []interface{}{findOption.Seek.LastID}, findOption.OrderOption.Desc)
+		}
 	}
 
-	switch findOption.OrderOption.Order {
-	case tag.Order_Name:
-		db.Order(clause.OrderByColumn{Column: clause.Column{Name: "name"}, Desc: findOption.OrderOption.Desc})
+	db = orderBy(db, tagOrderColumns(findOption.OrderOption.Order), findOption.OrderOption.Desc)
+	if findOption.WithGameCount || findOption.OrderOption.Order == tag.Order_GameCount {
+		db = db.Select("tag_masters.*, " + tagGameCountColumn + " AS game_count")
 	}
 
-	result := db.Find(&t)
+	result := preloadSynonyms(db).Find(&t)
 	if result.Error != nil {
-		return errors.NewInternalServerError(
+		return nil, errors.NewInternalServerError(
 			errors.Layer_Model,
 			errors.NewInformation(
 				errors.ID_DBReadError,
@@ -161,5 +471,161 @@ func (t *tagMasters) Find(db *gorm.DB, findOption *tag.FindOption) error {
 			"find tag_masters error",
 		)
 	}
+
+	hasNext := limit >= 0 && len(*t) > limit
+	if hasNext {
+		*t = (*t)[:limit]
+	}
+	ids := make([]tag.ID, 0, len(*t))
+	for _, m := range *t {
+		ids = append(ids, m.ID)
+	}
+	meta := tag.NewFindMeta(findOption, totalCount, hasNext, ids)
+	if findOption.SearchMode == tag.SearchMode_Seek && len(*t) > 0 {
+		meta.SetNextCursor((*t)[len(*t)-1].cursor(findOption.OrderOption))
+	}
+	return meta, nil
+}
+
+// タグを使うゲームの数
+// NOTE: ゴミ箱にあるゲームは数えない。絞り込み・並び替えでも使うため相関サブクエリで集計する
+const tagGameCountColumn = "(SELECT COUNT(*) FROM game_tag_links" +
+	" INNER JOIN game_masters ON game_masters.id = game_tag_links.game_master_id" +
+	" WHERE game_tag_links.tag_master_id = tag_masters.id AND game_masters.deleted_at IS NULL)"
+
+// 並び順ごとの並び替えの列
+// NOTE: シーク法の位置が一意になるよう末尾にIDを加える
+func tagOrderColumns(order tag.Order) []string {
+	switch order {
+	case tag.Order_Name:
+		return []string{"name", "id"}
+	case tag.Order_UpdatedAt:
+		return []string{"updated_at", "id"}
+	case tag.Order_GameCount:
+		return []string{tagGameCountColumn, "id"}
+	default:
+		return []string{"id"}
+	}
+}
+
+// カーソルから並び替えの列の値を取り出す
+func tagSeekValues(cursor *tag.Cursor) []interface{} {
+	switch cursor.Order {
+	case tag.Order_Name:
+		return []interface{}{cursor.Name, cursor.ID}
+	case tag.Order_UpdatedAt:
+		return []interface{}{cursor.UpdatedAt, cursor.ID}
+	case tag.Order_GameCount:
+		return []interface{}{cursor.GameCount, cursor.ID}
+	default:
+		return []interface{}{cursor.ID}
+	}
+}
+
+// ゴミ箱の検索
+func (t *tagMasters) FindTrash(db *gorm.DB, findOption *tag.FindOption) (*tag.FindMeta, error) {
+	return t.Find(db.Scopes(onlyTrash("tag_masters")), findOption)
+}
+
+// 自身と子孫のタグID
+// NOTE: 指定したタグごとに返却する。ゴミ箱にあるタグより先はたどらない
+func tagDescendantIDs(db *gorm.DB, tagIDs []tag.ID) ([][]tag.ID, error) {
+	var rows []struct {
+		RootID tag.ID
+		ID     tag.ID
+	}
+	// NOTE: 指定したタグを起点に子タグを再帰的にたどる。循環は登録時に防いでいるが、念のためUNIONで重複を除く
+	result := db.Session(&gorm.Session{NewDB: true}).Raw(
+		"WITH RECURSIVE descendants (root_id, id) AS ("+
+			"SELECT parent_id, id FROM tag_masters WHERE parent_id IN ? AND deleted_at IS NULL "+
+			"UNION "+
+			"SELECT descendants.root_id, tag_masters.id FROM tag_masters JOIN descendants ON tag_masters.parent_id = descendants.id WHERE tag_masters.deleted_at IS NULL"+
+			") SELECT root_id, id FROM descendants",
+		tagIDs,
+	).Scan(&rows)
+	if result.Error != nil {
+		return nil, errors.NewInternalServerError(
+			errors.Layer_Model,
+			errors.NewInformation(
+				errors.ID_DBReadError,
+				result.Error.Error(),
+				nil,
+			),
+			"find tag_masters descendants error",
+		)
+	}
+	descendants := make(map[tag.ID][]tag.ID, len(tagIDs))
+	for _, row := range rows {
+		if row.ID == row.RootID {
+			continue
+		}
+		descendants[row.RootID] = append(descendants[row.RootID], row.ID)
+	}
+
+	ids := make([][]tag.ID, 0, len(tagIDs))
+	for _, tagID := range tagIDs {
+		ids = append(ids, append([]tag.ID{tagID}, descendants[tagID]...))
+	}
+	return ids, nil
+}
+
+// 親タグをたどってtoIDのタグに着くか
+// NOTE: fromID自身も含む。ゴミ箱にあるタグも親子関係を残しているため、たどる対象とする。
+// 同時に親タグを変更しても循環しないよう、たどった行をロックする。保存と同じトランザクションで呼ぶ
+func reachesTag(db *gorm.DB, fromID, toID tag.ID) (bool, error) {
+	visited := map[tag.ID]bool{}
+	for id := fromID; id != 0 && !visited[id]; {
+		if id == toID {
+			return true, nil
+		}
+		visited[id] = true
+		current := &tagMaster{}
+		result := db.Unscoped().
+			Clauses(clause.Locking{Strength: "UPDATE"}).
+			Select("id", "parent_id").
+			Where("id = ?", id).
+			Find(current)
+		if result.Error != nil {
+			return false, errors.NewInternalServerError(
+				errors.Layer_Model,
+				errors.NewInformation(
+					errors.ID_DBReadError,
+					result.Error.Error(),
+					nil,
+				),
+				"read tag_masters parent error",
+			)
+		}
+		if result.RowsAffected == 0 || current.ParentID == nil {
+			break
+		}
+		id = *current.ParentID
+	}
+	return false, nil
+}
+
+// 親タグの循環チェック
+// NOTE: 親タグをたどって自身に戻るときは循環になる
+func (t *tagMaster) cycled(db *gorm.DB) error {
+	if t.ParentID == nil {
+		return nil
+	}
+	reached, err := reachesTag(db, *t.ParentID, t.ID)
+	if err != nil {
+		return err
+	}
+	if reached {
+		return errors.NewInvalidValidate(
+			errors.Layer_Model,
+			errors.NewInformation(
+				errors.ID_InvalidParams,
+				"parent tag is cyclic",
+				[]errors.InvalidParams{
+					errors.NewInvalidParams("parent_id", *t.ParentID),
+				},
+			),
+			"tag_masters parent cycle error",
+		)
+	}
 	return nil
 }
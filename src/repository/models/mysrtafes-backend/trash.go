@@ -0,0 +1,72 @@
+package mysrtafes_backend
+
+import (
+	"mysrtafes-backend/pkg/errors"
+
+	"gorm.io/gorm"
+)
+
+// ゴミ箱(論理削除済み)にあるかチェック
+func existsTrash(db *gorm.DB, model interface{}, table, param string, id interface{}) error {
+	var count int64
+	result := db.Unscoped().
+		Model(model).
+		Where("id = ? AND deleted_at IS NOT NULL", id).
+		Count(&count)
+	if result.Error != nil {
+		return errors.NewInternalServerError(
+			errors.Layer_Model,
+			errors.NewInformation(
+				errors.ID_DBReadError,
+				result.Error.Error(),
+				nil,
+			),
+			"read "+table+" error",
+		)
+	}
+	if count == 0 {
+		return errors.NewNotFound(
+			errors.Layer_Model,
+			errors.NewInformation(
+				errors.ID_DBReadError,
+				"",
+				[]errors.InvalidParams{
+					errors.NewInvalidParams(param, id),
+				},
+			),
+			table+" is nothing in trash error",
+		)
+	}
+	return nil
+}
+
+// ゴミ箱から復元
+// NOTE: 論理削除では関連(リンク・中間テーブル)を残しているので、deleted_atを戻すだけで関連も復元される
+func restoreTrash(db *gorm.DB, model interface{}, table, param string, id interface{}) error {
+	if err := existsTrash(db, model, table, param, id); err != nil {
+		return err
+	}
+	result := db.Unscoped().
+		Model(model).
+		Where("id = ?", id).
+		Update("deleted_at", nil)
+	if result.Error != nil {
+		return errors.NewInternalServerError(
+			errors.Layer_Model,
+			errors.NewInformation(
+				errors.ID_DBUpdateError,
+				result.Error.Error(),
+				nil,
+			),
+			"restore "+table+" error",
+		)
+	}
+	return nil
+}
+
+// ゴミ箱の絞り込み
+func onlyTrash(table string) func(db *gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		return db.Unscoped().Where(table + ".deleted_at IS NOT NULL")
+	}
+}
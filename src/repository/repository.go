@@ -5,7 +5,9 @@ import (
 	"mysrtafes-backend/pkg/challenge"
 	"mysrtafes-backend/pkg/game"
 	"mysrtafes-backend/pkg/game/platform"
+	"mysrtafes-backend/pkg/game/series"
 	"mysrtafes-backend/pkg/game/tag"
+	"mysrtafes-backend/pkg/version"
 	mysrtafes_backend "mysrtafes-backend/repository/models/mysrtafes-backend"
 
 	"gorm.io/gorm"
@@ -22,9 +24,13 @@ type Repository interface {
 	// goal.Repository
 	// result.Repository
 	game.Repository
-	// link.Repository
+	game.LinkRepository
+	game.RelationRepository
+	game.CoverRepository
 	platform.Repository
+	series.Repository
 	tag.Repository
+	Backfill() error
 	Close() error
 }
 
@@ -66,31 +72,177 @@ func (r *repository) TagRead(tagID tag.ID) (*tag.Tag, error) {
 	return model.NewEntity(), err
 }
 
-func (r *repository) TagFind(f *tag.FindOption) ([]*tag.Tag, error) {
+func (r *repository) TagFind(f *tag.FindOption) ([]*tag.Tag, *tag.FindMeta, error) {
 	models := mysrtafes_backend.NewTagMasters()
-	err := models.Find(r.DB, f)
+	meta, err := models.Find(r.DB, f)
 	entities := make([]*tag.Tag, 0, len(models))
 	for _, model := range models {
 		entities = append(entities, model.NewEntity())
 	}
-	return entities, err
+	return entities, meta, err
 }
 
-func (r *repository) TagUpdate(tag *tag.Tag) (*tag.Tag, error) {
+func (r *repository) TagUpdate(tag *tag.Tag, precondition *version.Precondition) (*tag.Tag, error) {
 	model := mysrtafes_backend.NewTagMaster(tag)
 	err := r.DB.Transaction(func(tx *gorm.DB) error {
+		if err := model.CheckPrecondition(tx, precondition); err != nil {
+			return err
+		}
 		err := model.Update(tx)
 		if err != nil {
 			return err
 		}
 		return nil
 	})
-	return model.NewEntity(), err
+	if err != nil {
+		return nil, err
+	}
+	// NOTE: 作成日時やETagに使う更新日時を保存済みの値で返却するため再取得
+	model = mysrtafes_backend.NewTagMasterFromID(tag.ID)
+	if err := model.Read(r.DB); err != nil {
+		return nil, err
+	}
+	return model.NewEntity(), nil
+}
+
+func (r *repository) TagPatch(tag *tag.Tag, fields []tag.Field, precondition *version.Precondition) (*tag.Tag, error) {
+	model := mysrtafes_backend.NewTagMaster(tag)
+	err := r.DB.Transaction(func(tx *gorm.DB) error {
+		if err := model.CheckPrecondition(tx, precondition); err != nil {
+			return err
+		}
+		return model.Patch(tx, fields)
+	})
+	if err != nil {
+		return nil, err
+	}
+	// NOTE: 変更していないフィールドを返却するため再取得
+	model = mysrtafes_backend.NewTagMasterFromID(tag.ID)
+	if err := model.Read(r.DB); err != nil {
+		return nil, err
+	}
+	return model.NewEntity(), nil
 }
 
-func (r *repository) TagDelete(tagID tag.ID) error {
+func (r *repository) TagDelete(tagID tag.ID, precondition *version.Precondition) error {
 	model := mysrtafes_backend.NewTagMasterFromID(tagID)
-	return model.Delete(r.DB)
+	return r.DB.Transaction(func(tx *gorm.DB) error {
+		if err := model.CheckPrecondition(tx, precondition); err != nil {
+			return err
+		}
+		return model.Delete(tx)
+	})
+}
+
+func (r *repository) TagTrash(f *tag.FindOption) ([]*tag.Tag, *tag.FindMeta, error) {
+	models := mysrtafes_backend.NewTagMasters()
+	meta, err := models.FindTrash(r.DB, f)
+	entities := make([]*tag.Tag, 0, len(models))
+	for _, model := range models {
+		entities = append(entities, model.NewEntity())
+	}
+	return entities, meta, err
+}
+
+func (r *repository) TagRestore(tagID tag.ID) (*tag.Tag, error) {
+	model := mysrtafes_backend.NewTagMasterFromID(tagID)
+	err := r.DB.Transaction(func(tx *gorm.DB) error {
+		return model.Restore(tx)
+	})
+	if err != nil {
+		return nil, err
+	}
+	if err := model.Read(r.DB); err != nil {
+		return nil, err
+	}
+	return model.NewEntity(), nil
+}
+
+func (r *repository) TagPurge(tagID tag.ID) error {
+	model := mysrtafes_backend.NewTagMasterFromID(tagID)
+	return r.DB.Transaction(func(tx *gorm.DB) error {
+		return model.Purge(tx)
+	})
+}
+
+func (r *repository) TagMerge(sourceID tag.ID, targetID tag.ID, merge tag.Merger) (*tag.Tag, error) {
+	err := r.DB.Transaction(func(tx *gorm.DB) error {
+		source := mysrtafes_backend.NewTagMasterFromID(sourceID)
+		if err := source.ReadForUpdate(tx); err != nil {
+			return err
+		}
+		target := mysrtafes_backend.NewTagMasterFromID(targetID)
+		if err := target.ReadForUpdate(tx); err != nil {
+			return err
+		}
+		model := mysrtafes_backend.NewTagMaster(merge(target.NewEntity(), source.NewEntity()))
+		return model.Merge(tx, sourceID)
+	})
+	if err != nil {
+		return nil, err
+	}
+	// NOTE: 統合元から移した別名や更新日時を返却するため再取得
+	model := mysrtafes_backend.NewTagMasterFromID(targetID)
+	if err := model.Read(r.DB); err != nil {
+		return nil, err
+	}
+	return model.NewEntity(), nil
+}
+
+func (r *repository) SeriesCreate(series *series.Series) (*series.Series, error) {
+	model := mysrtafes_backend.NewSeriesMaster(series)
+	err := r.DB.Transaction(func(tx *gorm.DB) error {
+		return model.Create(tx)
+	})
+	return model.NewEntity(), err
+}
+
+func (r *repository) SeriesRead(seriesID series.ID) (*series.Series, error) {
+	model := mysrtafes_backend.NewSeriesMasterFromID(seriesID)
+	err := model.Read(r.DB)
+	return model.NewEntity(), err
+}
+
+func (r *repository) SeriesFind(f *series.FindOption) ([]*series.Series, *series.FindMeta, error) {
+	models := mysrtafes_backend.NewSeriesMasters()
+	meta, err := models.Find(r.DB, f)
+	entities := make([]*series.Series, 0, len(models))
+	for _, model := range models {
+		entities = append(entities, model.NewEntity())
+	}
+	return entities, meta, err
+}
+
+func (r *repository) SeriesUpdate(series *series.Series) (*series.Series, error) {
+	model := mysrtafes_backend.NewSeriesMaster(series)
+	err := r.DB.Transaction(func(tx *gorm.DB) error {
+		return model.Update(tx)
+	})
+	return model.NewEntity(), err
+}
+
+func (r *repository) SeriesPatch(series *series.Series, fields []series.Field) (*series.Series, error) {
+	model := mysrtafes_backend.NewSeriesMaster(series)
+	err := r.DB.Transaction(func(tx *gorm.DB) error {
+		return model.Patch(tx, fields)
+	})
+	if err != nil {
+		return nil, err
+	}
+	// NOTE: 変更していないフィールドを返却するため再取得
+	model = mysrtafes_backend.NewSeriesMasterFromID(series.ID)
+	if err := model.Read(r.DB); err != nil {
+		return nil, err
+	}
+	return model.NewEntity(), nil
+}
+
+// NOTE: ゲームのシリーズの参照の解除と同時に行う
+func (r *repository) SeriesDelete(seriesID series.ID) error {
+	model := mysrtafes_backend.NewSeriesMasterFromID(seriesID)
+	return r.DB.Transaction(func(tx *gorm.DB) error {
+		return model.Delete(tx)
+	})
 }
 
 func (r *repository) PlatformCreate(platform *platform.Platform) (*platform.Platform, error) {
@@ -111,31 +263,121 @@ func (r *repository) PlatformRead(platformID platform.ID) (*platform.Platform, e
 	return model.NewEntity(), err
 }
 
-func (r *repository) PlatformFind(p *platform.FindOption) ([]*platform.Platform, error) {
+func (r *repository) PlatformFind(p *platform.FindOption) ([]*platform.Platform, *platform.FindMeta, error) {
 	models := mysrtafes_backend.NewPlatformMasters()
-	err := models.Find(r.DB, p)
+	meta, err := models.Find(r.DB, p)
 	entities := make([]*platform.Platform, 0, len(models))
 	for _, model := range models {
 		entities = append(entities, model.NewEntity())
 	}
-	return entities, err
+	return entities, meta, err
 }
 
-func (r *repository) PlatformUpdate(platform *platform.Platform) (*platform.Platform, error) {
+func (r *repository) PlatformUpdate(platform *platform.Platform, precondition *version.Precondition) (*platform.Platform, error) {
 	model := mysrtafes_backend.NewPlatformMaster(platform)
 	err := r.DB.Transaction(func(tx *gorm.DB) error {
+		if err := model.CheckPrecondition(tx, precondition); err != nil {
+			return err
+		}
 		err := model.Update(tx)
 		if err != nil {
 			return err
 		}
 		return nil
 	})
-	return model.NewEntity(), err
+	if err != nil {
+		return nil, err
+	}
+	// NOTE: 作成日時やETagに使う更新日時を保存済みの値で返却するため再取得
+	model = mysrtafes_backend.NewPlatformMasterFromID(platform.ID)
+	if err := model.Read(r.DB); err != nil {
+		return nil, err
+	}
+	return model.NewEntity(), nil
+}
+
+func (r *repository) PlatformPatch(platform *platform.Platform, fields []platform.Field, precondition *version.Precondition) (*platform.Platform, error) {
+	model := mysrtafes_backend.NewPlatformMaster(platform)
+	err := r.DB.Transaction(func(tx *gorm.DB) error {
+		if err := model.CheckPrecondition(tx, precondition); err != nil {
+			return err
+		}
+		return model.Patch(tx, fields)
+	})
+	if err != nil {
+		return nil, err
+	}
+	// NOTE: 変更していないフィールドを返却するため再取得
+	model = mysrtafes_backend.NewPlatformMasterFromID(platform.ID)
+	if err := model.Read(r.DB); err != nil {
+		return nil, err
+	}
+	return model.NewEntity(), nil
 }
 
-func (r *repository) PlatformDelete(platformID platform.ID) error {
+func (r *repository) PlatformDelete(platformID platform.ID, precondition *version.Precondition) error {
 	model := mysrtafes_backend.NewPlatformMasterFromID(platformID)
-	return model.Delete(r.DB)
+	return r.DB.Transaction(func(tx *gorm.DB) error {
+		if err := model.CheckPrecondition(tx, precondition); err != nil {
+			return err
+		}
+		return model.Delete(tx)
+	})
+}
+
+func (r *repository) PlatformTrash(f *platform.FindOption) ([]*platform.Platform, *platform.FindMeta, error) {
+	models := mysrtafes_backend.NewPlatformMasters()
+	meta, err := models.FindTrash(r.DB, f)
+	entities := make([]*platform.Platform, 0, len(models))
+	for _, model := range models {
+		entities = append(entities, model.NewEntity())
+	}
+	return entities, meta, err
+}
+
+func (r *repository) PlatformRestore(platformID platform.ID) (*platform.Platform, error) {
+	model := mysrtafes_backend.NewPlatformMasterFromID(platformID)
+	err := r.DB.Transaction(func(tx *gorm.DB) error {
+		return model.Restore(tx)
+	})
+	if err != nil {
+		return nil, err
+	}
+	if err := model.Read(r.DB); err != nil {
+		return nil, err
+	}
+	return model.NewEntity(), nil
+}
+
+func (r *repository) PlatformPurge(platformID platform.ID) error {
+	model := mysrtafes_backend.NewPlatformMasterFromID(platformID)
+	return r.DB.Transaction(func(tx *gorm.DB) error {
+		return model.Purge(tx)
+	})
+}
+
+func (r *repository) PlatformMerge(sourceID platform.ID, targetID platform.ID, merge platform.Merger) (*platform.Platform, error) {
+	err := r.DB.Transaction(func(tx *gorm.DB) error {
+		source := mysrtafes_backend.NewPlatformMasterFromID(sourceID)
+		if err := source.ReadForUpdate(tx); err != nil {
+			return err
+		}
+		target := mysrtafes_backend.NewPlatformMasterFromID(targetID)
+		if err := target.ReadForUpdate(tx); err != nil {
+			return err
+		}
+		model := mysrtafes_backend.NewPlatformMaster(merge(target.NewEntity(), source.NewEntity()))
+		return model.Merge(tx, sourceID)
+	})
+	if err != nil {
+		return nil, err
+	}
+	// NOTE: 統合元から移した別名や更新日時を返却するため再取得
+	model := mysrtafes_backend.NewPlatformMasterFromID(targetID)
+	if err := model.Read(r.DB); err != nil {
+		return nil, err
+	}
+	return model.NewEntity(), nil
 }
 
 func (r *repository) GameCreate(game *game.Game, platformIDs []platform.ID, tagIDs []tag.ID) (*game.Game, error) {
@@ -155,37 +397,82 @@ func (r *repository) GameCreate(game *game.Game, platformIDs []platform.ID, tagI
 	return model.NewEntity()
 }
 
-func (r *repository) GameRead(gameID game.ID) (*game.Game, error) {
+func (r *repository) GameRead(gameID game.ID, include *game.Include) (*game.Game, error) {
 	model := mysrtafes_backend.NewGameMasterFromID(gameID)
-	err := model.Read(r.DB)
+	err := model.ReadInclude(r.DB, include)
 	if err != nil {
 		return nil, err
 	}
 	return model.NewEntity()
 }
 
-func (r *repository) GameFind(f *game.FindOption) ([]*game.Game, error) {
+func (r *repository) GameFind(f *game.FindOption) ([]*game.Game, *game.FindMeta, error) {
 	models := mysrtafes_backend.NewGameMasters()
-	err := models.Find(r.DB, f)
+	meta, err := models.Find(r.DB, f)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
+	}
+	entities := make([]*game.Game, 0, len(models))
+	for _, model := range models {
+		game, err := model.NewEntity()
+		if err != nil {
+			return nil, nil, err
+		}
+		entities = append(entities, game)
+	}
+	return entities, meta, nil
+}
+
+func (r *repository) GameFindByTag(tagID tag.ID, f *game.FindOption) ([]*game.Game, *game.FindMeta, error) {
+	// NOTE: タグが存在しない(ゴミ箱にある)ときはNotFound
+	if err := mysrtafes_backend.NewTagMasterFromID(tagID).Read(r.DB); err != nil {
+		return nil, nil, err
+	}
+	models := mysrtafes_backend.NewGameMasters()
+	meta, err := models.FindByTag(r.DB, tagID, f)
+	if err != nil {
+		return nil, nil, err
+	}
+	entities := make([]*game.Game, 0, len(models))
+	for _, model := range models {
+		game, err := model.NewEntity()
+		if err != nil {
+			return nil, nil, err
+		}
+		entities = append(entities, game)
+	}
+	return entities, meta, nil
+}
+
+func (r *repository) GameFindByPlatform(platformID platform.ID, f *game.FindOption) ([]*game.Game, *game.FindMeta, error) {
+	// NOTE: プラットフォームが存在しない(ゴミ箱にある)ときはNotFound
+	if err := mysrtafes_backend.NewPlatformMasterFromID(platformID).Read(r.DB); err != nil {
+		return nil, nil, err
+	}
+	models := mysrtafes_backend.NewGameMasters()
+	meta, err := models.FindByPlatform(r.DB, platformID, f)
+	if err != nil {
+		return nil, nil, err
 	}
 	entities := make([]*game.Game, 0, len(models))
 	for _, model := range models {
 		game, err := model.NewEntity()
 		if err != nil {
-			return nil, err
+			return nil, nil, err
 		}
 		entities = append(entities, game)
 	}
-	return entities, nil
+	return entities, meta, nil
 }
 
-func (r *repository) GameUpdate(game *game.Game, platformIDs []platform.ID, tagIDs []tag.ID) (*game.Game, error) {
+func (r *repository) GameUpdate(game *game.Game, platformIDs []platform.ID, tagIDs []tag.ID, precondition *version.Precondition) (*game.Game, error) {
 	tags := mysrtafes_backend.NewTagMasterListFromIDs(tagIDs)
 	platforms := mysrtafes_backend.NewPlatformListFromIDs(platformIDs)
 	model := mysrtafes_backend.NewGameMaster(game, platforms, tags)
 	err := r.DB.Transaction(func(tx *gorm.DB) error {
+		if err := model.CheckPrecondition(tx, precondition); err != nil {
+			return err
+		}
 		err := model.Update(tx)
 		if err != nil {
 			return err
@@ -195,12 +482,368 @@ func (r *repository) GameUpdate(game *game.Game, platformIDs []platform.ID, tagI
 	if err != nil {
 		return nil, err
 	}
+	// NOTE: リンクの作成日時などを返却するため再取得
+	model = mysrtafes_backend.NewGameMasterFromID(game.ID)
+	if err := model.Read(r.DB); err != nil {
+		return nil, err
+	}
 	return model.NewEntity()
 }
 
-func (r *repository) GameDelete(id game.ID) error {
+func (r *repository) GamePatch(game *game.Game, platformIDs []platform.ID, tagIDs []tag.ID, fields []game.Field, precondition *version.Precondition) (*game.Game, error) {
+	tags := mysrtafes_backend.NewTagMasterListFromIDs(tagIDs)
+	platforms := mysrtafes_backend.NewPlatformListFromIDs(platformIDs)
+	model := mysrtafes_backend.NewGameMaster(game, platforms, tags)
+	err := r.DB.Transaction(func(tx *gorm.DB) error {
+		if err := model.CheckPrecondition(tx, precondition); err != nil {
+			return err
+		}
+		return model.Patch(tx, fields)
+	})
+	if err != nil {
+		return nil, err
+	}
+	// NOTE: 変更していないフィールドやリンクの作成日時などを返却するため再取得
+	model = mysrtafes_backend.NewGameMasterFromID(game.ID)
+	if err := model.Read(r.DB); err != nil {
+		return nil, err
+	}
+	return model.NewEntity()
+}
+
+func (r *repository) GameDelete(id game.ID, precondition *version.Precondition) error {
+	model := mysrtafes_backend.NewGameMasterFromID(id)
+	return r.DB.Transaction(func(tx *gorm.DB) error {
+		if err := model.CheckPrecondition(tx, precondition); err != nil {
+			return err
+		}
+		return model.Delete(tx)
+	})
+}
+
+func (r *repository) GameTrash(f *game.FindOption) ([]*game.Game, *game.FindMeta, error) {
+	models := mysrtafes_backend.NewGameMasters()
+	meta, err := models.FindTrash(r.DB, f)
+	if err != nil {
+		return nil, nil, err
+	}
+	entities := make([]*game.Game, 0, len(models))
+	for _, model := range models {
+		entity, err := model.NewEntity()
+		if err != nil {
+			return nil, nil, err
+		}
+		entities = append(entities, entity)
+	}
+	return entities, meta, nil
+}
+
+func (r *repository) GameRestore(id game.ID) (*game.Game, error) {
 	model := mysrtafes_backend.NewGameMasterFromID(id)
-	return model.Delete(r.DB)
+	err := r.DB.Transaction(func(tx *gorm.DB) error {
+		return model.Restore(tx)
+	})
+	if err != nil {
+		return nil, err
+	}
+	// NOTE: 残しておいたリンク・タグ・プラットフォームごと返却する
+	if err := model.Read(r.DB); err != nil {
+		return nil, err
+	}
+	return model.NewEntity()
+}
+
+func (r *repository) GamePurge(id game.ID) error {
+	model := mysrtafes_backend.NewGameMasterFromID(id)
+	return r.DB.Transaction(func(tx *gorm.DB) error {
+		return model.Purge(tx)
+	})
+}
+
+func (r *repository) GameImport(rows []*game.ImportRow, mode game.ImportMode) ([]*game.ImportResult, error) {
+	results := make([]*game.ImportResult, 0, len(rows))
+	if mode == game.ImportMode_SkipInvalid {
+		// NOTE: 1行ずつのトランザクションで登録し、失敗した行は飛ばす
+		for _, row := range rows {
+			entity, err := r.GameCreate(row.Game, row.PlatformIDs, row.TagIDs)
+			if err != nil {
+				results = append(results, game.NewImportFailed(err))
+				continue
+			}
+			results = append(results, game.NewImportSucceeded(entity))
+		}
+		return results, nil
+	}
+
+	err := r.DB.Transaction(func(tx *gorm.DB) error {
+		for _, row := range rows {
+			tags := mysrtafes_backend.NewTagMasterListFromIDs(row.TagIDs)
+			platforms := mysrtafes_backend.NewPlatformListFromIDs(row.PlatformIDs)
+			model := mysrtafes_backend.NewGameMaster(row.Game, platforms, tags)
+			if err := model.Create(tx); err != nil {
+				results = append(results, game.NewImportFailed(err))
+				return err
+			}
+			entity, err := model.NewEntity()
+			if err != nil {
+				results = append(results, game.NewImportFailed(err))
+				return err
+			}
+			results = append(results, game.NewImportSucceeded(entity))
+		}
+		return nil
+	})
+	if err != nil {
+		// NOTE: ロールバックされたので、失敗した行以外は取り消しとする
+		for i, result := range results {
+			if result.Status == game.BulkStatus_Succeeded {
+				results[i] = game.NewImportCanceled()
+			}
+		}
+		for len(results) < len(rows) {
+			results = append(results, game.NewImportCanceled())
+		}
+	}
+	return results, nil
+}
+
+func (r *repository) GameBulkUpdate(rows []*game.UpdateRow) ([]*game.BulkResult, error) {
+	results := make([]*game.BulkResult, 0, len(rows))
+	err := r.DB.Transaction(func(tx *gorm.DB) error {
+		for _, row := range rows {
+			// NOTE: 1件ごとにセーブポイントを作り、失敗した件のみロールバックする
+			err := tx.Transaction(func(tx *gorm.DB) error {
+				if err := mysrtafes_backend.NewGameMasterFromID(row.Game.ID).Exists(tx); err != nil {
+					return err
+				}
+				tags := mysrtafes_backend.NewTagMasterListFromIDs(row.TagIDs)
+				platforms := mysrtafes_backend.NewPlatformListFromIDs(row.PlatformIDs)
+				return mysrtafes_backend.NewGameMaster(row.Game, platforms, tags).Update(tx)
+			})
+			if err != nil {
+				results = append(results, game.NewBulkFailed(row.Game.ID, err))
+				continue
+			}
+			results = append(results, game.NewBulkSucceeded(row.Game.ID))
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return results, nil
+}
+
+func (r *repository) GameBulkDelete(ids []game.ID) ([]*game.BulkResult, error) {
+	results := make([]*game.BulkResult, 0, len(ids))
+	err := r.DB.Transaction(func(tx *gorm.DB) error {
+		for _, id := range ids {
+			// NOTE: 1件ごとにセーブポイントを作り、失敗した件のみロールバックする
+			err := tx.Transaction(func(tx *gorm.DB) error {
+				model := mysrtafes_backend.NewGameMasterFromID(id)
+				if err := model.Exists(tx); err != nil {
+					return err
+				}
+				return model.Delete(tx)
+			})
+			if err != nil {
+				results = append(results, game.NewBulkFailed(id, err))
+				continue
+			}
+			results = append(results, game.NewBulkSucceeded(id))
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return results, nil
+}
+
+func (r *repository) GameMerge(sourceID game.ID, targetID game.ID, merge game.Merger) (*game.Game, error) {
+	err := r.DB.Transaction(func(tx *gorm.DB) error {
+		source := mysrtafes_backend.NewGameMasterFromID(sourceID)
+		if err := source.ReadForUpdate(tx); err != nil {
+			return err
+		}
+		target := mysrtafes_backend.NewGameMasterFromID(targetID)
+		if err := target.ReadForUpdate(tx); err != nil {
+			return err
+		}
+		sourceEntity, err := source.NewEntity()
+		if err != nil {
+			return err
+		}
+		targetEntity, err := target.NewEntity()
+		if err != nil {
+			return err
+		}
+		merged, platformIDs, tagIDs, err := merge(targetEntity, sourceEntity)
+		if err != nil {
+			return err
+		}
+		tags := mysrtafes_backend.NewTagMasterListFromIDs(tagIDs)
+		platforms := mysrtafes_backend.NewPlatformListFromIDs(platformIDs)
+		model := mysrtafes_backend.NewGameMaster(merged, platforms, tags)
+		return model.Merge(tx, sourceID)
+	})
+	if err != nil {
+		return nil, err
+	}
+	// NOTE: 統合元から移したリンク・別名などを返却するため再取得
+	model := mysrtafes_backend.NewGameMasterFromID(targetID)
+	if err := model.Read(r.DB); err != nil {
+		return nil, err
+	}
+	return model.NewEntity()
+}
+
+func (r *repository) GameRedirect(gameID game.ID) (*game.Redirect, error) {
+	model := mysrtafes_backend.NewGameRedirectFromID(gameID)
+	err := model.Read(r.DB)
+	if err != nil {
+		return nil, err
+	}
+	return model.NewEntity(), nil
+}
+
+func (r *repository) LinkCreate(gameID game.ID, link *game.Link) (*game.Link, error) {
+	model := mysrtafes_backend.NewGameReferenceURL(gameID, link)
+	err := r.DB.Transaction(func(tx *gorm.DB) error {
+		err := model.Create(tx)
+		if err != nil {
+			return err
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return model.NewEntity()
+}
+
+func (r *repository) LinkRead(gameID game.ID, linkID game.LinkID) (*game.Link, error) {
+	model := mysrtafes_backend.NewGameReferenceURLFromID(gameID, linkID)
+	err := model.Read(r.DB)
+	if err != nil {
+		return nil, err
+	}
+	return model.NewEntity()
+}
+
+func (r *repository) LinkFind(gameID game.ID) ([]*game.Link, error) {
+	models := mysrtafes_backend.NewGameReferenceURLList()
+	err := models.Find(r.DB, gameID)
+	if err != nil {
+		return nil, err
+	}
+	return models.NewEntities()
+}
+
+func (r *repository) LinkUpdate(gameID game.ID, link *game.Link) (*game.Link, error) {
+	model := mysrtafes_backend.NewGameReferenceURL(gameID, link)
+	err := r.DB.Transaction(func(tx *gorm.DB) error {
+		err := model.Update(tx)
+		if err != nil {
+			return err
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return model.NewEntity()
+}
+
+func (r *repository) LinkDelete(gameID game.ID, linkID game.LinkID) error {
+	model := mysrtafes_backend.NewGameReferenceURLFromID(gameID, linkID)
+	return r.DB.Transaction(func(tx *gorm.DB) error {
+		return model.Delete(tx)
+	})
+}
+
+func (r *repository) LinkReorder(gameID game.ID, linkIDs []game.LinkID) ([]*game.Link, error) {
+	models := mysrtafes_backend.NewGameReferenceURLList()
+	err := r.DB.Transaction(func(tx *gorm.DB) error {
+		err := models.Reorder(tx, gameID, linkIDs)
+		if err != nil {
+			return err
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return models.NewEntities()
+}
+
+func (r *repository) RelationCreate(gameID game.ID, relation *game.Relation) (*game.Relation, error) {
+	model := mysrtafes_backend.NewGameRelation(gameID, relation)
+	err := r.DB.Transaction(func(tx *gorm.DB) error {
+		return model.Create(tx)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return model.NewEntity(), nil
+}
+
+func (r *repository) RelationRead(gameID game.ID, relationID game.RelationID) (*game.Relation, error) {
+	model := mysrtafes_backend.NewGameRelationFromID(gameID, relationID)
+	err := model.Read(r.DB)
+	if err != nil {
+		return nil, err
+	}
+	return model.NewEntity(), nil
+}
+
+func (r *repository) RelationFind(gameID game.ID) ([]*game.Relation, error) {
+	models := mysrtafes_backend.NewGameRelationList()
+	err := models.Find(r.DB, gameID)
+	if err != nil {
+		return nil, err
+	}
+	return models.NewEntities(), nil
+}
+
+func (r *repository) RelationDelete(gameID game.ID, relationID game.RelationID) error {
+	model := mysrtafes_backend.NewGameRelationFromID(gameID, relationID)
+	return r.DB.Transaction(func(tx *gorm.DB) error {
+		return model.Delete(tx)
+	})
+}
+
+func (r *repository) CoverRead(gameID game.ID) (*game.Cover, error) {
+	model := mysrtafes_backend.NewGameCoverFromID(gameID)
+	err := model.Read(r.DB)
+	if err != nil {
+		return nil, err
+	}
+	return model.NewEntity(), nil
+}
+
+func (r *repository) CoverSave(gameID game.ID, cover *game.Cover) (*game.Cover, error) {
+	model := mysrtafes_backend.NewGameCover(gameID, cover)
+	err := r.DB.Transaction(func(tx *gorm.DB) error {
+		return model.Save(tx)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return model.NewEntity(), nil
+}
+
+func (r *repository) CoverDelete(gameID game.ID) error {
+	model := mysrtafes_backend.NewGameCoverFromID(gameID)
+	return r.DB.Transaction(func(tx *gorm.DB) error {
+		return model.Delete(tx)
+	})
+}
+
+// 追加したカラムの埋め戻し
+// NOTE: 起動時に呼ぶ。埋め戻し済みの行は対象外のため、何度実行してもよい
+func (r *repository) Backfill() error {
+	return mysrtafes_backend.BackfillGames(r.DB)
 }
 
 func (r *repository) Close() error {
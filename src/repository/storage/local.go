@@ -0,0 +1,127 @@
+package storage
+
+import (
+	stdErrors "errors"
+	"mysrtafes-backend/pkg/errors"
+	"mysrtafes-backend/pkg/game"
+	"net/http"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+)
+
+// ローカルのファイルシステムへの保存
+// NOTE: dir以下にキーのパスで保存し、baseURL以下のURLで公開する。公開はHandlerで行う
+type local struct {
+	dir     string
+	baseURL string
+}
+
+func NewLocal(dir string, baseURL string) *local {
+	return &local{
+		dir:     dir,
+		baseURL: strings.TrimSuffix(baseURL, "/"),
+	}
+}
+
+// NOTE: 配信時の形式は拡張子から判定されるため、contentTypeは使わない
+func (l *local) Save(key game.CoverKey, contentType game.CoverContentType, data []byte) (string, error) {
+	name, err := l.path(key)
+	if err != nil {
+		return "", err
+	}
+	if err := os.MkdirAll(filepath.Dir(name), 0o755); err != nil {
+		return "", saveError(key, err)
+	}
+	// NOTE: 書き込み途中のファイルを公開しないよう、一時ファイルに書いてから置き換える
+	tmp, err := os.CreateTemp(filepath.Dir(name), ".tmp-*")
+	if err != nil {
+		return "", saveError(key, err)
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return "", saveError(key, err)
+	}
+	if err := tmp.Close(); err != nil {
+		return "", saveError(key, err)
+	}
+	if err := os.Chmod(tmp.Name(), 0o644); err != nil {
+		return "", saveError(key, err)
+	}
+	if err := os.Rename(tmp.Name(), name); err != nil {
+		return "", saveError(key, err)
+	}
+	return l.baseURL + "/" + string(key), nil
+}
+
+// NOTE: 存在しないファイルの削除はエラーにしない
+func (l *local) Delete(key game.CoverKey) error {
+	name, err := l.path(key)
+	if err != nil {
+		return err
+	}
+	if err := os.Remove(name); err != nil && !stdErrors.Is(err, os.ErrNotExist) {
+		return errors.NewInternalServerError(
+			errors.Layer_Storage,
+			errors.NewInformation(
+				errors.ID_StorageDeleteError,
+				err.Error(),
+				[]errors.InvalidParams{
+					errors.NewInvalidParams("key", key),
+				},
+			),
+			"delete local file error",
+		)
+	}
+	return nil
+}
+
+// 保存したファイルの公開
+// NOTE: キーには登録日時を含み内容が変わらないため、長期間キャッシュさせる。ディレクトリの一覧は返却しない
+func (l *local) Handler() http.Handler {
+	files := http.FileServer(http.Dir(l.dir))
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasSuffix(r.URL.Path, "/") {
+			http.NotFound(w, r)
+			return
+		}
+		w.Header().Set("Cache-Control", "public, max-age=31536000, immutable")
+		files.ServeHTTP(w, r)
+	})
+}
+
+// キーに対応するファイルのパス
+// NOTE: dirの外を指すキーは受け付けない
+func (l *local) path(key game.CoverKey) (string, error) {
+	cleaned := path.Clean("/" + string(key))
+	if key == "" || cleaned != "/"+string(key) {
+		return "", errors.NewInternalServerError(
+			errors.Layer_Storage,
+			errors.NewInformation(
+				errors.ID_InvalidParams,
+				"key must be a clean relative path",
+				[]errors.InvalidParams{
+					errors.NewInvalidParams("key", key),
+				},
+			),
+			"local file key error",
+		)
+	}
+	return filepath.Join(l.dir, filepath.FromSlash(cleaned)), nil
+}
+
+func saveError(key game.CoverKey, err error) error {
+	return errors.NewInternalServerError(
+		errors.Layer_Storage,
+		errors.NewInformation(
+			errors.ID_StorageSaveError,
+			err.Error(),
+			[]errors.InvalidParams{
+				errors.NewInvalidParams("key", key),
+			},
+		),
+		"save local file error",
+	)
+}